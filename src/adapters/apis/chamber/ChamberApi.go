@@ -1,40 +1,76 @@
 package chamber
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"github.com/labstack/gommon/log"
-	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
+	"time"
+	"vnc-summarizer/core/domains/chamberresponse"
+	"vnc-summarizer/core/interfaces/cache"
+	"vnc-summarizer/core/interfaces/chamber"
+	"vnc-summarizer/utils/contexts"
 	"vnc-summarizer/utils/datetime"
 	"vnc-summarizer/utils/requesters"
 )
 
-type Chamber struct{}
+const (
+	defaultChamberApiAddress       = "https://dadosabertos.camara.leg.br/api/v2"
+	defaultChamberWebsiteAddress   = "https://www.camara.leg.br"
+	defaultChamberResponseCacheTtl = 24 * time.Hour
+)
 
-func NewChamberApi() *Chamber {
-	return &Chamber{}
+type Chamber struct {
+	apiAddress     string
+	websiteAddress string
+	responseCache  cache.ChamberResponse
 }
 
-func (instance Chamber) GetMostRecentPropositions() ([]map[string]interface{}, error) {
+// NewChamberApi creates the client of the Chamber of Deputies API. The reference data, such as the types of
+// propositions and events, is kept in the cache configured in CHAMBER_API_CACHE_BACKEND, which is disabled when nil.
+// The addresses of the API and of the website can be replaced in CHAMBER_API_ADDRESS and CHAMBER_WEBSITE_ADDRESS,
+// which allows the client to be used with a fake of the API.
+func NewChamberApi(responseCache cache.ChamberResponse) *Chamber {
+	return &Chamber{
+		apiAddress:     getAddress("CHAMBER_API_ADDRESS", defaultChamberApiAddress),
+		websiteAddress: getAddress("CHAMBER_WEBSITE_ADDRESS", defaultChamberWebsiteAddress),
+		responseCache:  responseCache,
+	}
+}
+
+func getAddress(environmentVariable, defaultAddress string) string {
+	address := strings.TrimSuffix(os.Getenv(environmentVariable), "/")
+	if address == "" {
+		return defaultAddress
+	}
+
+	return address
+}
+
+func (instance Chamber) GetMostRecentPropositions(ctx context.Context) ([]chamber.Proposition, error) {
 	currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
 	if err != nil {
 		log.Error("datetime.GetCurrentDateTimeInBrazil(): ", err)
 		return nil, err
 	}
 
-	var mostRecentPropositionsReturned []map[string]interface{}
+	var mostRecentPropositionsReturned []chamber.Proposition
 	for page := 1; ; page++ {
 		chunkSize := 100
 		urlOfTheMostRecentPropositions := fmt.Sprintf(
-			"https://dadosabertos.camara.leg.br/api/v2/proposicoes?pagina=%d&itens=%d&dataApresentacaoInicio=%s&ordenarPor=id&ordem=asc",
-			page, chunkSize, currentDateTime.AddDate(0, 0, -1).Format("2006-01-02"),
+			"%s/proposicoes?pagina=%d&itens=%d&dataApresentacaoInicio=%s&ordenarPor=id&ordem=asc",
+			instance.apiAddress, page, chunkSize, currentDateTime.AddDate(0, 0, -1).Format("2006-01-02"),
 		)
-		mostRecentPropositions, err := requesters.GetDataSliceFromUrl(urlOfTheMostRecentPropositions)
+		mostRecentPropositions, err := getDataSlice(ctx, urlOfTheMostRecentPropositions,
+			(*responseValidator).proposition)
 		if err != nil {
-			log.Error("requests.GetDataSliceFromUrl(): ", err.Error())
+			log.Error("getDataSlice(): ", err.Error())
 			return nil, err
 		}
 
@@ -48,18 +84,55 @@ func (instance Chamber) GetMostRecentPropositions() ([]map[string]interface{}, e
 	return mostRecentPropositionsReturned, nil
 }
 
-func (instance Chamber) GetPropositionByCode(code int) (map[string]interface{}, error) {
-	propositionUrl := fmt.Sprint("https://dadosabertos.camara.leg.br/api/v2/proposicoes/", code)
-	proposition, err := requesters.GetDataObjectFromUrl(propositionUrl)
+func (instance Chamber) GetPropositionsByDateRange(ctx context.Context, startDate, endDate time.Time) (
+	[]chamber.Proposition, error) {
+	var propositionsReturned []chamber.Proposition
+	for page := 1; ; page++ {
+		chunkSize := 100
+		urlOfThePropositions := fmt.Sprintf(
+			"%s/proposicoes?pagina=%d&itens=%d&dataApresentacaoInicio=%s&dataApresentacaoFim=%s&ordenarPor=id&ordem=asc",
+			instance.apiAddress, page, chunkSize, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"),
+		)
+		propositions, err := getDataSlice(ctx, urlOfThePropositions, (*responseValidator).proposition)
+		if err != nil {
+			log.Error("getDataSlice(): ", err.Error())
+			return nil, err
+		}
+
+		propositionsReturned = append(propositionsReturned, propositions...)
+
+		if len(propositions) < chunkSize {
+			break
+		}
+	}
+
+	return propositionsReturned, nil
+}
+
+func (instance Chamber) GetPropositionByCode(ctx context.Context, code int) (*chamber.Proposition, error) {
+	propositionUrl := fmt.Sprint(instance.apiAddress, "/proposicoes/", code)
+	proposition, err := getDataObject(ctx, propositionUrl, (*responseValidator).propositionDetails)
 	if err != nil {
-		log.Error("requests.GetDataObjectFromUrl(): ", err.Error())
+		log.Error("getDataObject(): ", err.Error())
 		return nil, err
 	}
 
 	return proposition, nil
 }
 
-func (instance Chamber) GetPropositionContentDirectly(propositionUrl string) (string, string, error) {
+func (instance Chamber) GetPropositionAuthors(ctx context.Context, propositionCode int) ([]chamber.Author, error) {
+	authorsUrl := fmt.Sprintf("%s/proposicoes/%d/autores", instance.apiAddress, propositionCode)
+	authors, err := getDataSlice(ctx, authorsUrl, (*responseValidator).author)
+	if err != nil {
+		log.Error("getDataSlice(): ", err.Error())
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+func (instance Chamber) GetPropositionContentDirectly(ctx context.Context, propositionUrl string) (string, string,
+	error) {
 	parsedPropositionUrl, err := url.Parse(propositionUrl)
 	if err != nil {
 		log.Errorf("Error parsing proposition URL %s: %s", propositionUrl, err.Error())
@@ -68,92 +141,111 @@ func (instance Chamber) GetPropositionContentDirectly(propositionUrl string) (st
 	queryParams := parsedPropositionUrl.Query()
 	propositionContentCode := queryParams.Get("codteor")
 
-	propositionUrl = fmt.Sprintf("https://www.camara.leg.br/internet/ordemdodia/integras/%s.htm",
+	propositionUrl = fmt.Sprintf("%s/internet/ordemdodia/integras/%s.htm", instance.websiteAddress,
 		propositionContentCode)
-	response, err := requesters.GetRequest(propositionUrl)
+	_, responseBody, err := requesters.SendChamberRequest(ctx, propositionUrl, nil)
 	if err != nil {
-		log.Error("requests.GetRequest(): ", err.Error())
+		log.Error("requesters.SendChamberRequest(): ", err.Error())
 		return "", "", err
 	}
 
-	responseBody, err := io.ReadAll(response.Body)
-	if err != nil {
-		log.Errorf("Error interpreting response to request %s: %s", propositionUrl, err.Error())
-		return "", "", err
-	}
-	responseBodyAsString := string(responseBody)
+	return propositionUrl, string(responseBody), nil
+}
 
-	if response.StatusCode != http.StatusOK {
-		errorMessage := fmt.Sprintf("Error making request to get original proposition content directly: "+
-			"[Status: %s; Body: %s]", response.Status, responseBodyAsString)
-		log.Error(errorMessage)
-		return "", "", errors.New(errorMessage)
+func (instance Chamber) GetPropositionTypes(ctx context.Context) ([]chamber.PropositionType, error) {
+	propositionTypesUrl := fmt.Sprint(instance.apiAddress, "/referencias/proposicoes/siglaTipo")
+	propositionTypes, err := getCachedDataSlice(ctx, instance.responseCache, propositionTypesUrl,
+		func(validator *responseValidator, field string, propositionType chamber.PropositionType) {
+			validator.reference(field, propositionType.Code, propositionType.Name)
+		})
+	if err != nil {
+		log.Error("getCachedDataSlice(): ", err.Error())
+		return nil, err
 	}
 
-	return propositionUrl, responseBodyAsString, err
+	return propositionTypes, nil
 }
 
-func (instance Chamber) GetPropositionTypes() ([]map[string]interface{}, error) {
-	propositionTypesUrl := "https://dadosabertos.camara.leg.br/api/v2/referencias/proposicoes/siglaTipo"
-	propositionTypes, err := requesters.GetDataSliceFromUrl(propositionTypesUrl)
+func (instance Chamber) GetDeputyByCode(ctx context.Context, code int) (*chamber.Deputy, error) {
+	deputyUrl := fmt.Sprint(instance.apiAddress, "/deputados/", code)
+	deputy, err := getDataObject(ctx, deputyUrl, (*responseValidator).deputy)
 	if err != nil {
-		log.Error("requests.GetDataSliceFromUrl(): ", err.Error())
+		log.Error("getDataObject(): ", err.Error())
 		return nil, err
 	}
 
-	return propositionTypes, nil
+	return deputy, nil
 }
 
-func (instance Chamber) GetPartyByAcronym(acronym string) (map[string]interface{}, error) {
-	partyUrlByAcronym := fmt.Sprint("https://dadosabertos.camara.leg.br/api/v2/partidos?sigla=", acronym)
-	parties, err := requesters.GetDataSliceFromUrl(partyUrlByAcronym)
+// GetPartyByAcronym searches the party by its acronym and then by its code, since the logo of the party is returned
+// only when the party is searched by its code
+func (instance Chamber) GetPartyByAcronym(ctx context.Context, acronym string) (*chamber.Party, error) {
+	partyUrlByAcronym := fmt.Sprint(instance.apiAddress, "/partidos?sigla=", acronym)
+	parties, err := getDataSlice(ctx, partyUrlByAcronym,
+		func(validator *responseValidator, field string, party chamber.Party) {
+			validator.requireInt(fmt.Sprint(field, ".id"), party.Id)
+		})
 	if err != nil {
-		log.Error("requests.GetDataSliceFromUrl(): ", err.Error())
+		log.Error("getDataSlice(): ", err.Error())
 		return nil, err
+	} else if len(parties) == 0 {
+		errorMessage := fmt.Sprintf("Party %s was not found", acronym)
+		log.Error(errorMessage)
+		return nil, errors.New(errorMessage)
 	}
 
-	return parties[0], nil
+	partyUrl := fmt.Sprint(instance.apiAddress, "/partidos/", parties[0].Id)
+	party, err := getDataObject(ctx, partyUrl, (*responseValidator).party)
+	if err != nil {
+		log.Error("getDataObject(): ", err.Error())
+		return nil, err
+	}
+
+	return party, nil
 }
 
-func (instance Chamber) GetLegislativeBodyByCode(code int) (map[string]interface{}, error) {
-	legislativeBodyUrl := fmt.Sprint("https://dadosabertos.camara.leg.br/api/v2/orgaos/", code)
-	legislativeBody, err := requesters.GetDataObjectFromUrl(legislativeBodyUrl)
+func (instance Chamber) GetLegislativeBodyByCode(ctx context.Context, code int) (*chamber.LegislativeBody, error) {
+	legislativeBodyUrl := fmt.Sprint(instance.apiAddress, "/orgaos/", code)
+	legislativeBody, err := getDataObject(ctx, legislativeBodyUrl, (*responseValidator).legislativeBody)
 	if err != nil {
-		log.Error("requests.GetDataObjectFromUrl(): ", err.Error())
+		log.Error("getDataObject(): ", err.Error())
 		return nil, err
 	}
 
 	return legislativeBody, nil
 }
 
-func (instance Chamber) GetLegislativeBodyTypes() ([]map[string]interface{}, error) {
-	urlOfLegislativeBodyTypes := "https://dadosabertos.camara.leg.br/api/v2/referencias/tiposOrgao"
-	legislativeBodyTypes, err := requesters.GetDataSliceFromUrl(urlOfLegislativeBodyTypes)
+func (instance Chamber) GetLegislativeBodyTypes(ctx context.Context) ([]chamber.LegislativeBodyType, error) {
+	urlOfLegislativeBodyTypes := fmt.Sprint(instance.apiAddress, "/referencias/tiposOrgao")
+	legislativeBodyTypes, err := getCachedDataSlice(ctx, instance.responseCache, urlOfLegislativeBodyTypes,
+		func(validator *responseValidator, field string, legislativeBodyType chamber.LegislativeBodyType) {
+			validator.reference(field, legislativeBodyType.Code, legislativeBodyType.Name)
+		})
 	if err != nil {
-		log.Error("requests.GetDataSliceFromUrl(): ", err.Error())
+		log.Error("getCachedDataSlice(): ", err.Error())
 		return nil, err
 	}
 
 	return legislativeBodyTypes, nil
 }
 
-func (instance Chamber) GetMostRecentVotes() ([]map[string]interface{}, error) {
+func (instance Chamber) GetMostRecentVotes(ctx context.Context) ([]chamber.Voting, error) {
 	currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
 	if err != nil {
 		log.Error("datetime.GetCurrentDateTimeInBrazil(): ", err)
 		return nil, err
 	}
 
-	var mostRecentVotesReturned []map[string]interface{}
+	var mostRecentVotesReturned []chamber.Voting
 	for page := 1; ; page++ {
 		chunkSize := 100
 		urlOfTheMostRecentVotes := fmt.Sprintf(
-			"https://dadosabertos.camara.leg.br/api/v2/votacoes?&pagina=%d&itens=%d&dataInicio=%s&ordenarPor=id&ordem=asc",
-			page, chunkSize, currentDateTime.AddDate(0, 0, -1).Format("2006-01-02"),
+			"%s/votacoes?&pagina=%d&itens=%d&dataInicio=%s&ordenarPor=id&ordem=asc",
+			instance.apiAddress, page, chunkSize, currentDateTime.AddDate(0, 0, -1).Format("2006-01-02"),
 		)
-		mostRecentVotes, err := requesters.GetDataSliceFromUrl(urlOfTheMostRecentVotes)
+		mostRecentVotes, err := getDataSlice(ctx, urlOfTheMostRecentVotes, (*responseValidator).voting)
 		if err != nil {
-			log.Error("requests.GetDataSliceFromUrl(): ", err.Error())
+			log.Error("getDataSlice(): ", err.Error())
 			return nil, err
 		}
 
@@ -167,34 +259,59 @@ func (instance Chamber) GetMostRecentVotes() ([]map[string]interface{}, error) {
 	return mostRecentVotesReturned, nil
 }
 
-func (instance Chamber) GetVotingByCode(code string) (map[string]interface{}, error) {
-	votingUrl := fmt.Sprint("https://dadosabertos.camara.leg.br/api/v2/votacoes/", code)
-	voting, err := requesters.GetDataObjectFromUrl(votingUrl)
+func (instance Chamber) GetVotesByDateRange(ctx context.Context, startDate, endDate time.Time) ([]chamber.Voting,
+	error) {
+	var votesReturned []chamber.Voting
+	for page := 1; ; page++ {
+		chunkSize := 100
+		urlOfTheVotes := fmt.Sprintf(
+			"%s/votacoes?pagina=%d&itens=%d&dataInicio=%s&dataFim=%s&ordenarPor=id&ordem=asc",
+			instance.apiAddress, page, chunkSize, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"),
+		)
+		votes, err := getDataSlice(ctx, urlOfTheVotes, (*responseValidator).voting)
+		if err != nil {
+			log.Error("getDataSlice(): ", err.Error())
+			return nil, err
+		}
+
+		votesReturned = append(votesReturned, votes...)
+
+		if len(votes) < chunkSize {
+			break
+		}
+	}
+
+	return votesReturned, nil
+}
+
+func (instance Chamber) GetVotingByCode(ctx context.Context, code string) (*chamber.Voting, error) {
+	votingUrl := fmt.Sprint(instance.apiAddress, "/votacoes/", code)
+	voting, err := getDataObject(ctx, votingUrl, (*responseValidator).votingDetails)
 	if err != nil {
-		log.Error("requests.GetDataObjectFromUrl(): ", err.Error())
+		log.Error("getDataObject(): ", err.Error())
 		return nil, err
 	}
 
 	return voting, nil
 }
 
-func (instance Chamber) GetMostRecentEvents() ([]map[string]interface{}, error) {
+func (instance Chamber) GetMostRecentEvents(ctx context.Context) ([]chamber.Event, error) {
 	currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
 	if err != nil {
 		log.Error("datetime.GetCurrentDatetimeInBrazil(): ", err)
 		return nil, err
 	}
 
-	var mostRecentEventsReturned []map[string]interface{}
+	var mostRecentEventsReturned []chamber.Event
 	for page := 1; ; page++ {
 		chunkSize := 100
 		urlOfTheMostRecentEvents := fmt.Sprintf(
-			"https://dadosabertos.camara.leg.br/api/v2/eventos?pagina=%d&itens=%d&dataInicio=%s&ordenarPor=id&ordem=asc",
-			page, chunkSize, currentDateTime.AddDate(0, 0, -1).Format("2006-01-02"),
+			"%s/eventos?pagina=%d&itens=%d&dataInicio=%s&ordenarPor=id&ordem=asc",
+			instance.apiAddress, page, chunkSize, currentDateTime.AddDate(0, 0, -1).Format("2006-01-02"),
 		)
-		mostRecentEvents, err := requesters.GetDataSliceFromUrl(urlOfTheMostRecentEvents)
+		mostRecentEvents, err := getDataSlice(ctx, urlOfTheMostRecentEvents, (*responseValidator).event)
 		if err != nil {
-			log.Error("requests.GetDataSliceFromUrl(): ", err.Error())
+			log.Error("getDataSlice(): ", err.Error())
 			return nil, err
 		}
 
@@ -208,47 +325,226 @@ func (instance Chamber) GetMostRecentEvents() ([]map[string]interface{}, error)
 	return mostRecentEventsReturned, nil
 }
 
-func (instance Chamber) GetEventByCode(code int) (map[string]interface{}, error) {
-	eventUrl := fmt.Sprint("https://dadosabertos.camara.leg.br/api/v2/eventos/", code)
-	event, err := requesters.GetDataObjectFromUrl(eventUrl)
+func (instance Chamber) GetEventsByDateRange(ctx context.Context, startDate, endDate time.Time) ([]chamber.Event,
+	error) {
+	var eventsReturned []chamber.Event
+	for page := 1; ; page++ {
+		chunkSize := 100
+		urlOfTheEvents := fmt.Sprintf(
+			"%s/eventos?pagina=%d&itens=%d&dataInicio=%s&dataFim=%s&ordenarPor=id&ordem=asc",
+			instance.apiAddress, page, chunkSize, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"),
+		)
+		events, err := getDataSlice(ctx, urlOfTheEvents, (*responseValidator).event)
+		if err != nil {
+			log.Error("getDataSlice(): ", err.Error())
+			return nil, err
+		}
+
+		eventsReturned = append(eventsReturned, events...)
+
+		if len(events) < chunkSize {
+			break
+		}
+	}
+
+	return eventsReturned, nil
+}
+
+func (instance Chamber) GetEventByCode(ctx context.Context, code int) (*chamber.Event, error) {
+	eventUrl := fmt.Sprint(instance.apiAddress, "/eventos/", code)
+	event, err := getDataObject(ctx, eventUrl, (*responseValidator).event)
 	if err != nil {
-		log.Error("requests.GetDataObjectFromUrl(): ", err.Error())
+		log.Error("getDataObject(): ", err.Error())
 		return nil, err
 	}
 
 	return event, nil
 }
 
-func (instance Chamber) GetEventsByCodes(eventCodes []string) ([]map[string]interface{}, error) {
-	eventsUrl := fmt.Sprintf("https://dadosabertos.camara.leg.br/api/v2/eventos?id=%s&itens=%d",
+func (instance Chamber) GetEventsByCodes(ctx context.Context, eventCodes []string) ([]chamber.Event, error) {
+	eventsUrl := fmt.Sprintf("%s/eventos?id=%s&itens=%d", instance.apiAddress,
 		strings.Join(eventCodes, ","), len(eventCodes))
-	events, err := requesters.GetDataSliceFromUrl(eventsUrl)
+	events, err := getDataSlice(ctx, eventsUrl, (*responseValidator).event)
 	if err != nil {
-		log.Error("requesters.GetDataSliceFromUrl(): ", err.Error())
+		log.Error("getDataSlice(): ", err.Error())
 		return nil, err
 	}
 
 	return events, nil
 }
 
-func (instance Chamber) GetEventTypes() ([]map[string]interface{}, error) {
-	eventTypesUrl := "https://dadosabertos.camara.leg.br/api/v2/referencias/eventos/codTipoEvento"
-	eventTypes, err := requesters.GetDataSliceFromUrl(eventTypesUrl)
+func (instance Chamber) GetEventAgendaItems(ctx context.Context, eventCode int) ([]chamber.EventAgendaItem, error) {
+	agendaItemsUrl := fmt.Sprintf("%s/eventos/%d/pauta", instance.apiAddress, eventCode)
+	agendaItems, err := getDataSlice(ctx, agendaItemsUrl, (*responseValidator).eventAgendaItem)
+	if err != nil {
+		log.Error("getDataSlice(): ", err.Error())
+		return nil, err
+	}
+
+	return agendaItems, nil
+}
+
+func (instance Chamber) GetEventTypes(ctx context.Context) ([]chamber.EventType, error) {
+	eventTypesUrl := fmt.Sprint(instance.apiAddress, "/referencias/eventos/codTipoEvento")
+	eventTypes, err := getCachedDataSlice(ctx, instance.responseCache, eventTypesUrl,
+		func(validator *responseValidator, field string, eventType chamber.EventType) {
+			validator.reference(field, eventType.Code, eventType.Name)
+		})
 	if err != nil {
-		log.Error("requests.GetDataSliceFromUrl(): ", err.Error())
+		log.Error("getCachedDataSlice(): ", err.Error())
 		return nil, err
 	}
 
 	return eventTypes, nil
 }
 
-func (instance Chamber) GetEventSituations() ([]map[string]interface{}, error) {
-	eventSituationsUrl := "https://dadosabertos.camara.leg.br/api/v2/referencias/situacoesEvento"
-	eventSituations, err := requesters.GetDataSliceFromUrl(eventSituationsUrl)
+func (instance Chamber) GetEventSituations(ctx context.Context) ([]chamber.EventSituation, error) {
+	eventSituationsUrl := fmt.Sprint(instance.apiAddress, "/referencias/situacoesEvento")
+	eventSituations, err := getCachedDataSlice(ctx, instance.responseCache, eventSituationsUrl,
+		func(validator *responseValidator, field string, eventSituation chamber.EventSituation) {
+			validator.reference(field, eventSituation.Code, eventSituation.Name)
+		})
 	if err != nil {
-		log.Error("requests.GetDataSliceFromUrl(): ", err.Error())
+		log.Error("getCachedDataSlice(): ", err.Error())
 		return nil, err
 	}
 
 	return eventSituations, nil
 }
+
+// getDataObject searches the object returned by the URL and checks the fields on which the summarizer depends, so
+// the objects returned by the client always have these fields
+func getDataObject[T any](ctx context.Context, url string,
+	validate func(validator *responseValidator, field string, data T)) (*T, error) {
+	var data T
+	err := requesters.GetDataFromUrl(ctx, url, &data)
+	if err != nil {
+		log.Error("requesters.GetDataFromUrl(): ", err.Error())
+		return nil, err
+	}
+
+	var validator responseValidator
+	validate(&validator, "dados", data)
+	err = validator.err(url)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// getDataSlice searches the list returned by the URL and checks the fields on which the summarizer depends in each
+// of its items
+func getDataSlice[T any](ctx context.Context, url string,
+	validate func(validator *responseValidator, field string, data T)) ([]T, error) {
+	_, responseBody, err := requesters.SendChamberRequest(ctx, url, nil)
+	if err != nil {
+		log.Error("requesters.SendChamberRequest(): ", err.Error())
+		return nil, err
+	}
+
+	return decodeDataSlice(url, responseBody, validate)
+}
+
+// getCachedDataSlice works as getDataSlice, but keeps the response in the cache, which is used for the reference data
+// that rarely changes and is searched for every item registered
+func getCachedDataSlice[T any](ctx context.Context, responseCache cache.ChamberResponse, url string,
+	validate func(validator *responseValidator, field string, data T)) ([]T, error) {
+	responseBody, err := getCachedResponseBody(ctx, responseCache, url)
+	if err != nil {
+		log.Error("getCachedResponseBody(): ", err.Error())
+		return nil, err
+	}
+
+	return decodeDataSlice(url, responseBody, validate)
+}
+
+func decodeDataSlice[T any](url string, responseBody []byte,
+	validate func(validator *responseValidator, field string, data T)) ([]T, error) {
+	var data []T
+	err := requesters.DecodeData(url, responseBody, &data)
+	if err != nil {
+		log.Error("requesters.DecodeData(): ", err.Error())
+		return nil, err
+	}
+
+	var validator responseValidator
+	for index, item := range data {
+		validate(&validator, fmt.Sprintf("dados[%d]", index), item)
+	}
+	err = validator.err(url)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// getCachedResponseBody returns the cached response while it has not expired. Expired responses are revalidated with
+// a conditional request when the API informed their ETag or Last-Modified, so their content is only downloaded again
+// when it was modified. When the API cannot be reached, the expired response is used.
+func getCachedResponseBody(ctx context.Context, responseCache cache.ChamberResponse, url string) ([]byte, error) {
+	if responseCache == nil {
+		_, responseBody, err := requesters.SendChamberRequest(ctx, url, nil)
+		return responseBody, err
+	}
+
+	keyHash := sha256.Sum256([]byte(url))
+	cacheKey := hex.EncodeToString(keyHash[:])
+	cachedResponse, err := responseCache.GetChamberResponse(ctx, cacheKey)
+	if err != nil {
+		log.Warn("responseCache.GetChamberResponse(): ", err.Error())
+	} else if cachedResponse != nil && !cachedResponse.IsExpired() {
+		return cachedResponse.Body(), nil
+	}
+
+	headers := map[string]string{}
+	if cachedResponse != nil && cachedResponse.ETag() != "" {
+		headers["If-None-Match"] = cachedResponse.ETag()
+	}
+	if cachedResponse != nil && cachedResponse.LastModified() != "" {
+		headers["If-Modified-Since"] = cachedResponse.LastModified()
+	}
+
+	response, responseBody, err := requesters.SendChamberRequest(ctx, url, headers)
+	if err != nil {
+		if cachedResponse != nil && ctx.Err() == nil {
+			log.Warnf("Using the expired response of %s, since the request failed: %s", url, err.Error())
+			return cachedResponse.Body(), nil
+		}
+
+		log.Error("requesters.SendChamberRequest(): ", err.Error())
+		return nil, err
+	}
+
+	eTag := response.Header.Get("ETag")
+	lastModified := response.Header.Get("Last-Modified")
+	if response.StatusCode == http.StatusNotModified && cachedResponse != nil {
+		log.Infof("The response of %s was not modified", url)
+		responseBody = cachedResponse.Body()
+		eTag = cachedResponse.ETag()
+		lastModified = cachedResponse.LastModified()
+	}
+
+	cacheTtl := contexts.GetTimeout("CHAMBER_API_CACHE_TTL", defaultChamberResponseCacheTtl)
+	updatedResponse, err := chamberresponse.NewBuilder().
+		Url(url).
+		Body(responseBody).
+		ETag(eTag).
+		LastModified(lastModified).
+		ExpiresAt(time.Now().Add(cacheTtl)).
+		Build()
+	if err != nil {
+		log.Warnf("Error validating the response of %s, which will not be cached: %s", url, err.Error())
+		return responseBody, nil
+	}
+
+	err = responseCache.SaveChamberResponse(ctx, cacheKey, *updatedResponse)
+	if err != nil {
+		log.Warn("responseCache.SaveChamberResponse(): ", err.Error())
+	}
+
+	return responseBody, nil
+}
@@ -0,0 +1,214 @@
+package chamber
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+	"vnc-summarizer/adapters/apis/chamber/chambertest"
+	"vnc-summarizer/adapters/databases/memory"
+	"vnc-summarizer/core/interfaces/cache"
+)
+
+// newFakeChamberApi creates a client pointed to a fake of the Chamber of Deputies API that replays the cassette. The
+// cassette can be recorded again against the real API with CHAMBER_CASSETTE_MODE=record.
+func newFakeChamberApi(t *testing.T, cassette string, responseCache cache.ChamberResponse) *Chamber {
+	t.Helper()
+
+	fakeServer, err := chambertest.NewServer(filepath.Join("testdata", "cassettes", cassette), chambertest.GetMode())
+	if err != nil {
+		t.Fatalf("chambertest.NewServer(): %s", err.Error())
+	}
+	t.Cleanup(func() {
+		err := fakeServer.Close()
+		if err != nil {
+			t.Errorf("fakeServer.Close(): %s", err.Error())
+		}
+		for _, requestUrl := range fakeServer.UnmatchedRequests() {
+			t.Errorf("The cassette does not have a response to %s", requestUrl)
+		}
+	})
+
+	t.Setenv("CHAMBER_API_ADDRESS", fakeServer.ApiAddress())
+	t.Setenv("CHAMBER_WEBSITE_ADDRESS", fakeServer.WebsiteAddress())
+	t.Setenv("CHAMBER_API_REQUESTS_PER_MINUTE", "")
+	t.Setenv("CHAMBER_API_MAXIMUM_ATTEMPTS", "1")
+
+	return NewChamberApi(responseCache)
+}
+
+func TestChamberApiPipeline(t *testing.T) {
+	ctx := context.Background()
+	chamberApi := newFakeChamberApi(t, "pipeline.json", nil)
+	day := time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC)
+
+	propositions, err := chamberApi.GetPropositionsByDateRange(ctx, day.AddDate(0, 0, -1), day)
+	if err != nil {
+		t.Fatalf("GetPropositionsByDateRange(): %s", err.Error())
+	}
+	if len(propositions) != 2 || propositions[0].Id != 2486190 || propositions[1].TypeAcronym != "REQ" {
+		t.Fatalf("GetPropositionsByDateRange() returned %+v", propositions)
+	}
+
+	proposition, err := chamberApi.GetPropositionByCode(ctx, propositions[0].Id)
+	if err != nil {
+		t.Fatalf("GetPropositionByCode(): %s", err.Error())
+	}
+	if proposition.SubmittedAt != "2025-03-10T16:12" || proposition.OriginalTextUrl == nil {
+		t.Fatalf("GetPropositionByCode() returned %+v", proposition)
+	}
+
+	contentUrl, content, err := chamberApi.GetPropositionContentDirectly(ctx, *proposition.OriginalTextUrl)
+	if err != nil {
+		t.Fatalf("GetPropositionContentDirectly(): %s", err.Error())
+	}
+	if path.Base(contentUrl) != "2871234.htm" || content == "" {
+		t.Fatalf("GetPropositionContentDirectly() returned %s: %s", contentUrl, content)
+	}
+
+	authors, err := chamberApi.GetPropositionAuthors(ctx, proposition.Id)
+	if err != nil {
+		t.Fatalf("GetPropositionAuthors(): %s", err.Error())
+	}
+	if len(authors) != 2 || authors[0].Uri == nil || authors[1].Uri != nil {
+		t.Fatalf("GetPropositionAuthors() returned %+v", authors)
+	}
+
+	deputy, err := chamberApi.GetDeputyByCode(ctx, 204379)
+	if err != nil {
+		t.Fatalf("GetDeputyByCode(): %s", err.Error())
+	}
+	party, err := chamberApi.GetPartyByAcronym(ctx, deputy.LastStatus.PartyAcronym)
+	if err != nil {
+		t.Fatalf("GetPartyByAcronym(): %s", err.Error())
+	}
+	if party.Id != 36844 || party.ImageUrl == "" {
+		t.Fatalf("GetPartyByAcronym() returned %+v", party)
+	}
+
+	votes, err := chamberApi.GetVotesByDateRange(ctx, day, day)
+	if err != nil {
+		t.Fatalf("GetVotesByDateRange(): %s", err.Error())
+	}
+	if len(votes) != 1 {
+		t.Fatalf("GetVotesByDateRange() returned %+v", votes)
+	}
+
+	voting, err := chamberApi.GetVotingByCode(ctx, votes[0].Id)
+	if err != nil {
+		t.Fatalf("GetVotingByCode(): %s", err.Error())
+	}
+	if voting.Approval == nil || *voting.Approval != 1 || len(voting.RelatedPropositions) != 1 {
+		t.Fatalf("GetVotingByCode() returned %+v", voting)
+	}
+
+	legislativeBody, err := chamberApi.GetLegislativeBodyByCode(ctx, voting.LegislativeBodyCode)
+	if err != nil {
+		t.Fatalf("GetLegislativeBodyByCode(): %s", err.Error())
+	}
+	if legislativeBody.Acronym != "PLEN" {
+		t.Fatalf("GetLegislativeBodyByCode() returned %+v", legislativeBody)
+	}
+
+	events, err := chamberApi.GetEventsByCodes(ctx, []string{"75801", "75800"})
+	if err != nil {
+		t.Fatalf("GetEventsByCodes(): %s", err.Error())
+	}
+	if len(events) != 2 || events[0].Id != 75801 || events[1].Id != 75800 {
+		t.Fatalf("GetEventsByCodes() returned %+v", events)
+	}
+
+	event, err := chamberApi.GetEventByCode(ctx, events[1].Id)
+	if err != nil {
+		t.Fatalf("GetEventByCode(): %s", err.Error())
+	}
+	if len(event.Requirements) != 1 || event.VideoUrl == nil {
+		t.Fatalf("GetEventByCode() returned %+v", event)
+	}
+
+	agendaItems, err := chamberApi.GetEventAgendaItems(ctx, event.Id)
+	if err != nil {
+		t.Fatalf("GetEventAgendaItems(): %s", err.Error())
+	}
+	if len(agendaItems) != 1 || agendaItems[0].Proposition.Id != proposition.Id {
+		t.Fatalf("GetEventAgendaItems() returned %+v", agendaItems)
+	}
+}
+
+func TestChamberApiReferenceData(t *testing.T) {
+	ctx := context.Background()
+	chamberApi := newFakeChamberApi(t, "pipeline.json", memory.NewChamberResponseRepository())
+
+	testCases := []struct {
+		name          string
+		getReferences func() (int, error)
+		expected      int
+	}{
+		{
+			name: "proposition types",
+			getReferences: func() (int, error) {
+				propositionTypes, err := chamberApi.GetPropositionTypes(ctx)
+				return len(propositionTypes), err
+			},
+			expected: 2,
+		},
+		{
+			name: "legislative body types",
+			getReferences: func() (int, error) {
+				legislativeBodyTypes, err := chamberApi.GetLegislativeBodyTypes(ctx)
+				return len(legislativeBodyTypes), err
+			},
+			expected: 2,
+		},
+		{
+			name: "event types",
+			getReferences: func() (int, error) {
+				eventTypes, err := chamberApi.GetEventTypes(ctx)
+				return len(eventTypes), err
+			},
+			expected: 2,
+		},
+		{
+			name: "event situations",
+			getReferences: func() (int, error) {
+				eventSituations, err := chamberApi.GetEventSituations(ctx)
+				return len(eventSituations), err
+			},
+			expected: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// The second search is answered by the cache
+			for attempt := 1; attempt <= 2; attempt++ {
+				numberOfReferences, err := testCase.getReferences()
+				if err != nil {
+					t.Fatalf("The %dth search returned an error: %s", attempt, err.Error())
+				}
+				if numberOfReferences != testCase.expected {
+					t.Fatalf("The %dth search returned %d references, expected %d", attempt, numberOfReferences,
+						testCase.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestChamberApiRevalidatesExpiredReferenceData(t *testing.T) {
+	ctx := context.Background()
+	chamberApi := newFakeChamberApi(t, "pipeline.json", memory.NewChamberResponseRepository())
+	t.Setenv("CHAMBER_API_CACHE_TTL", "1ns")
+
+	// The expired response is revalidated with its ETag, to which the fake answers 304
+	for attempt := 1; attempt <= 3; attempt++ {
+		propositionTypes, err := chamberApi.GetPropositionTypes(ctx)
+		if err != nil {
+			t.Fatalf("The %dth search returned an error: %s", attempt, err.Error())
+		}
+		if len(propositionTypes) != 2 || propositionTypes[0].Code != "139" {
+			t.Fatalf("The %dth search returned %+v", attempt, propositionTypes)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package chamber
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"vnc-summarizer/core/interfaces/chamber"
+)
+
+// responseValidator checks the fields of the data returned by the Chamber of Deputies API on which the summarizer
+// depends, naming the fields by their path in the response, so changes in the API are reported where they happen
+type responseValidator struct {
+	invalidFields []string
+}
+
+func (instance *responseValidator) requireInt(field string, value int) {
+	if value <= 0 {
+		instance.invalidFields = append(instance.invalidFields, fmt.Sprintf("The field %s is missing", field))
+	}
+}
+
+func (instance *responseValidator) requireString(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		instance.invalidFields = append(instance.invalidFields, fmt.Sprintf("The field %s is missing", field))
+	}
+}
+
+func (instance *responseValidator) requireDateTime(field, value, layout string) {
+	if strings.TrimSpace(value) == "" {
+		instance.invalidFields = append(instance.invalidFields, fmt.Sprintf("The field %s is missing", field))
+	} else if _, err := time.Parse(layout, value); err != nil {
+		instance.invalidFields = append(instance.invalidFields, fmt.Sprintf("The field %s is not in the %s format",
+			field, layout))
+	}
+}
+
+func (instance *responseValidator) optionalDateTime(field string, value *string, layout string) {
+	if value != nil {
+		instance.requireDateTime(field, *value, layout)
+	}
+}
+
+// requireCodeInUri checks that the URI ends with the code of the resource, since some resources are referenced only
+// by their URI
+func (instance *responseValidator) requireCodeInUri(field, uri string) {
+	if strings.TrimSpace(uri) == "" {
+		instance.invalidFields = append(instance.invalidFields, fmt.Sprintf("The field %s is missing", field))
+	} else if _, err := strconv.Atoi(path.Base(uri)); err != nil {
+		instance.invalidFields = append(instance.invalidFields, fmt.Sprintf("The field %s does not end with a code",
+			field))
+	}
+}
+
+func (instance *responseValidator) err(url string) error {
+	if len(instance.invalidFields) > 0 {
+		return errors.New(fmt.Sprintf("Invalid data in the response to request %s: %s", url,
+			strings.Join(instance.invalidFields, "; ")))
+	}
+
+	return nil
+}
+
+func (instance *responseValidator) proposition(field string, proposition chamber.Proposition) {
+	instance.requireInt(fmt.Sprint(field, ".id"), proposition.Id)
+	instance.requireInt(fmt.Sprint(field, ".codTipo"), proposition.TypeCode)
+	instance.requireString(fmt.Sprint(field, ".siglaTipo"), proposition.TypeAcronym)
+}
+
+// propositionDetails checks the fields returned only when the proposition is searched by its code
+func (instance *responseValidator) propositionDetails(field string, proposition chamber.Proposition) {
+	instance.proposition(field, proposition)
+	instance.requireDateTime(fmt.Sprint(field, ".dataApresentacao"), proposition.SubmittedAt,
+		chamber.PropositionDateTimeLayout)
+}
+
+func (instance *responseValidator) author(field string, author chamber.Author) {
+	instance.requireString(fmt.Sprint(field, ".nome"), author.Name)
+	instance.requireString(fmt.Sprint(field, ".tipo"), author.Type)
+	instance.requireInt(fmt.Sprint(field, ".codTipo"), author.TypeCode)
+	if author.TypeCode == chamber.DeputyAuthorTypeCode {
+		var uri string
+		if author.Uri != nil {
+			uri = *author.Uri
+		}
+		instance.requireCodeInUri(fmt.Sprint(field, ".uri"), uri)
+	}
+}
+
+func (instance *responseValidator) deputy(field string, deputy chamber.Deputy) {
+	instance.requireInt(fmt.Sprint(field, ".id"), deputy.Id)
+	instance.requireString(fmt.Sprint(field, ".cpf"), deputy.Cpf)
+	instance.requireString(fmt.Sprint(field, ".nomeCivil"), deputy.CivilName)
+	instance.requireString(fmt.Sprint(field, ".ultimoStatus.nomeEleitoral"), deputy.LastStatus.ElectoralName)
+	instance.requireString(fmt.Sprint(field, ".ultimoStatus.siglaPartido"), deputy.LastStatus.PartyAcronym)
+	instance.requireString(fmt.Sprint(field, ".ultimoStatus.siglaUf"), deputy.LastStatus.FederatedUnit)
+	instance.requireString(fmt.Sprint(field, ".ultimoStatus.urlFoto"), deputy.LastStatus.ImageUrl)
+}
+
+func (instance *responseValidator) party(field string, party chamber.Party) {
+	instance.requireInt(fmt.Sprint(field, ".id"), party.Id)
+	instance.requireString(fmt.Sprint(field, ".nome"), party.Name)
+	instance.requireString(fmt.Sprint(field, ".sigla"), party.Acronym)
+	instance.requireString(fmt.Sprint(field, ".urlLogo"), party.ImageUrl)
+}
+
+func (instance *responseValidator) legislativeBody(field string, legislativeBody chamber.LegislativeBody) {
+	instance.requireInt(fmt.Sprint(field, ".id"), legislativeBody.Id)
+	instance.requireInt(fmt.Sprint(field, ".codTipoOrgao"), legislativeBody.TypeCode)
+	instance.requireString(fmt.Sprint(field, ".nome"), legislativeBody.Name)
+	instance.requireString(fmt.Sprint(field, ".sigla"), legislativeBody.Acronym)
+}
+
+func (instance *responseValidator) reference(field, code, name string) {
+	instance.requireString(fmt.Sprint(field, ".cod"), code)
+	instance.requireString(fmt.Sprint(field, ".nome"), name)
+}
+
+func (instance *responseValidator) voting(field string, voting chamber.Voting) {
+	instance.requireString(fmt.Sprint(field, ".id"), voting.Id)
+	instance.requireString(fmt.Sprint(field, ".descricao"), voting.Result)
+	instance.requireDateTime(fmt.Sprint(field, ".data"), voting.Date, chamber.VotingDateLayout)
+	instance.optionalDateTime(fmt.Sprint(field, ".dataHoraRegistro"), voting.ResultAnnouncedAt,
+		chamber.VotingDateTimeLayout)
+}
+
+// votingDetails checks the fields returned only when the voting is searched by its code
+func (instance *responseValidator) votingDetails(field string, voting chamber.Voting) {
+	instance.voting(field, voting)
+	instance.requireInt(fmt.Sprint(field, ".idOrgao"), voting.LegislativeBodyCode)
+	for index, proposition := range voting.RelatedPropositions {
+		instance.requireInt(fmt.Sprintf("%s.objetosPossiveis[%d].id", field, index), proposition.Id)
+	}
+	for index, proposition := range voting.AffectedPropositions {
+		instance.requireInt(fmt.Sprintf("%s.proposicoesAfetadas[%d].id", field, index), proposition.Id)
+	}
+	if voting.LastPresentation != nil && voting.LastPresentation.CitedPropositionUri != nil {
+		instance.requireCodeInUri(fmt.Sprint(field, ".ultimaApresentacaoProposicao.uriProposicaoCitada"),
+			*voting.LastPresentation.CitedPropositionUri)
+	}
+}
+
+func (instance *responseValidator) event(field string, event chamber.Event) {
+	instance.requireInt(fmt.Sprint(field, ".id"), event.Id)
+	instance.requireDateTime(fmt.Sprint(field, ".dataHoraInicio"), event.StartsAt, chamber.EventDateTimeLayout)
+	instance.optionalDateTime(fmt.Sprint(field, ".dataHoraFim"), event.EndsAt, chamber.EventDateTimeLayout)
+	instance.requireString(fmt.Sprint(field, ".descricaoTipo"), event.TypeDescription)
+	instance.requireString(fmt.Sprint(field, ".situacao"), event.Situation)
+	for index, legislativeBody := range event.LegislativeBodies {
+		instance.requireInt(fmt.Sprintf("%s.orgaos[%d].id", field, index), legislativeBody.Id)
+	}
+	for index, requirement := range event.Requirements {
+		instance.requireCodeInUri(fmt.Sprintf("%s.requerimentos[%d].uri", field, index), requirement.Uri)
+	}
+}
+
+func (instance *responseValidator) eventAgendaItem(field string, agendaItem chamber.EventAgendaItem) {
+	instance.requireString(fmt.Sprint(field, ".titulo"), agendaItem.Title)
+	instance.requireInt(fmt.Sprint(field, ".codRegime"), agendaItem.RegimeCode)
+	instance.requireString(fmt.Sprint(field, ".regime"), agendaItem.Regime)
+	instance.requireInt(fmt.Sprint(field, ".proposicao_.id"), agendaItem.Proposition.Id)
+}
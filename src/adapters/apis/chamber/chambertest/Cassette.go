@@ -0,0 +1,83 @@
+package chambertest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Interaction is a response of the Chamber of Deputies API recorded in a cassette. JSON bodies are kept as they were
+// returned, so the fixtures can be read and edited, and the other bodies, such as the pages of the website, are kept as
+// text.
+type Interaction struct {
+	Url        string            `json:"url"`
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       json.RawMessage   `json:"body,omitempty"`
+	Text       string            `json:"text,omitempty"`
+}
+
+// Cassette is the set of responses replayed by the fake of the Chamber of Deputies API
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+func LoadCassette(path string) (*Cassette, error) {
+	cassetteFile, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Cassette{}, nil
+		}
+		log.Errorf("Error reading cassette %s: %s", path, err.Error())
+		return nil, err
+	}
+
+	var cassette Cassette
+	err = json.Unmarshal(cassetteFile, &cassette)
+	if err != nil {
+		log.Errorf("Error decoding cassette %s: %s", path, err.Error())
+		return nil, err
+	}
+
+	for index, interaction := range cassette.Interactions {
+		if interaction.Url == "" {
+			errorMessage := fmt.Sprintf("Interaction %d of cassette %s does not have a URL", index, path)
+			log.Error(errorMessage)
+			return nil, errors.New(errorMessage)
+		}
+	}
+
+	return &cassette, nil
+}
+
+// Save writes the cassette sorted by the URL of the interactions, so recording the same responses again does not
+// change the fixture
+func (instance Cassette) Save(path string) error {
+	sort.SliceStable(instance.Interactions, func(i, j int) bool {
+		return instance.Interactions[i].Url < instance.Interactions[j].Url
+	})
+
+	cassetteFile, err := json.MarshalIndent(instance, "", "  ")
+	if err != nil {
+		log.Errorf("Error encoding cassette %s: %s", path, err.Error())
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		log.Errorf("Error creating the directory of cassette %s: %s", path, err.Error())
+		return err
+	}
+
+	err = os.WriteFile(path, append(cassetteFile, '\n'), 0644)
+	if err != nil {
+		log.Errorf("Error writing cassette %s: %s", path, err.Error())
+		return err
+	}
+
+	return nil
+}
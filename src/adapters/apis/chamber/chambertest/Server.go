@@ -0,0 +1,374 @@
+package chambertest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Mode string
+
+const (
+	// Replay answers the requests with the responses of the cassette and never reaches the Chamber of Deputies API
+	Replay Mode = "replay"
+	// Record sends the requests to the Chamber of Deputies API and saves their responses in the cassette when the
+	// server is closed
+	Record Mode = "record"
+)
+
+const (
+	apiPath        = "/api/v2"
+	websitePath    = "/website"
+	apiAddress     = "https://dadosabertos.camara.leg.br/api/v2"
+	websiteAddress = "https://www.camara.leg.br"
+)
+
+// Server is a fake of the Chamber of Deputies API and of its website, which replays the responses recorded in a
+// cassette. The client is pointed to the fake with the addresses returned by ApiAddress and WebsiteAddress, which are
+// used in CHAMBER_API_ADDRESS and CHAMBER_WEBSITE_ADDRESS.
+type Server struct {
+	server            *httptest.Server
+	client            *http.Client
+	mode              Mode
+	cassettePath      string
+	cassette          *Cassette
+	interactions      map[string]int
+	unmatchedRequests []string
+	mutex             sync.Mutex
+}
+
+// GetMode returns the mode defined in CHAMBER_CASSETTE_MODE, so the cassettes of the tests can be recorded again with
+// CHAMBER_CASSETTE_MODE=record. Any other value replays the cassettes.
+func GetMode() Mode {
+	if Mode(os.Getenv("CHAMBER_CASSETTE_MODE")) == Record {
+		return Record
+	}
+
+	return Replay
+}
+
+func NewServer(cassettePath string, mode Mode) (*Server, error) {
+	cassette, err := LoadCassette(cassettePath)
+	if err != nil {
+		log.Error("LoadCassette(): ", err.Error())
+		return nil, err
+	}
+
+	fakeServer := &Server{
+		client:       &http.Client{Timeout: time.Minute},
+		mode:         mode,
+		cassettePath: cassettePath,
+		cassette:     cassette,
+		interactions: map[string]int{},
+	}
+	for index, interaction := range cassette.Interactions {
+		fakeServer.interactions[normalizeUrl(interaction.Url)] = index
+	}
+	fakeServer.server = httptest.NewServer(http.HandlerFunc(fakeServer.handleRequest))
+
+	return fakeServer, nil
+}
+
+func (instance *Server) ApiAddress() string {
+	return fmt.Sprint(instance.server.URL, apiPath)
+}
+
+func (instance *Server) WebsiteAddress() string {
+	return fmt.Sprint(instance.server.URL, websitePath)
+}
+
+// UnmatchedRequests returns the URLs of the Chamber of Deputies API requested by the client that were not found in the
+// cassette
+func (instance *Server) UnmatchedRequests() []string {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	return append([]string(nil), instance.unmatchedRequests...)
+}
+
+// Close stops the server and, in the Record mode, saves the recorded responses in the cassette
+func (instance *Server) Close() error {
+	instance.server.Close()
+	if instance.mode != Record {
+		return nil
+	}
+
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	err := instance.cassette.Save(instance.cassettePath)
+	if err != nil {
+		log.Error("cassette.Save(): ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (instance *Server) handleRequest(writer http.ResponseWriter, request *http.Request) {
+	var requestUrl string
+	switch {
+	case strings.HasPrefix(request.URL.Path, apiPath):
+		requestUrl = fmt.Sprint(apiAddress, strings.TrimPrefix(request.URL.Path, apiPath))
+	case strings.HasPrefix(request.URL.Path, websitePath):
+		requestUrl = fmt.Sprint(websiteAddress, strings.TrimPrefix(request.URL.Path, websitePath))
+	default:
+		http.NotFound(writer, request)
+		return
+	}
+	if request.URL.RawQuery != "" {
+		requestUrl = fmt.Sprint(requestUrl, "?", request.URL.RawQuery)
+	}
+
+	var interaction *Interaction
+	if instance.mode == Record {
+		recordedInteraction, err := instance.recordInteraction(request, requestUrl)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadGateway)
+			return
+		}
+		interaction = recordedInteraction
+	} else {
+		interaction = instance.findInteraction(requestUrl)
+	}
+
+	if interaction == nil {
+		instance.mutex.Lock()
+		instance.unmatchedRequests = append(instance.unmatchedRequests, requestUrl)
+		instance.mutex.Unlock()
+		http.Error(writer, fmt.Sprint("The cassette does not have a response to ", requestUrl), http.StatusNotFound)
+		return
+	}
+
+	writeInteraction(writer, request, *interaction)
+}
+
+// findInteraction searches the response to the request in the cassette. Besides the exact requests, which are
+// compared regardless of the order of the query parameters, the server answers the searches of several items by
+// their codes (?id=) with the items recorded in any list of the same resource and the pages (?pagina=&itens=) of a
+// list recorded without pagination, so the fixtures do not depend on how the client groups the requests.
+func (instance *Server) findInteraction(requestUrl string) *Interaction {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	index, found := instance.interactions[normalizeUrl(requestUrl)]
+	if found {
+		interaction := instance.cassette.Interactions[index]
+		return &interaction
+	}
+
+	parsedUrl, err := url.Parse(requestUrl)
+	if err != nil {
+		return nil
+	}
+	queryParams := parsedUrl.Query()
+
+	if codes := queryParams.Get("id"); codes != "" {
+		return instance.findItemsByCodes(parsedUrl, strings.Split(codes, ","))
+	}
+
+	page, pageErr := strconv.Atoi(queryParams.Get("pagina"))
+	pageSize, pageSizeErr := strconv.Atoi(queryParams.Get("itens"))
+	if pageErr != nil || pageSizeErr != nil || page < 1 || pageSize < 1 {
+		return nil
+	}
+	queryParams.Del("pagina")
+	queryParams.Del("itens")
+	parsedUrl.RawQuery = queryParams.Encode()
+	index, found = instance.interactions[normalizeUrl(parsedUrl.String())]
+	if !found {
+		return nil
+	}
+
+	items, err := getItems(instance.cassette.Interactions[index])
+	if err != nil {
+		return nil
+	}
+	firstItem := min((page-1)*pageSize, len(items))
+	lastItem := min(page*pageSize, len(items))
+
+	return newListInteraction(requestUrl, items[firstItem:lastItem])
+}
+
+func (instance *Server) findItemsByCodes(requestUrl *url.URL, codes []string) *Interaction {
+	itemsByCode := map[string]json.RawMessage{}
+	for _, interaction := range instance.cassette.Interactions {
+		interactionUrl, err := url.Parse(interaction.Url)
+		if err != nil || interactionUrl.Path != requestUrl.Path {
+			continue
+		}
+
+		items, err := getItems(interaction)
+		if err != nil {
+			continue
+		}
+		for _, item := range items {
+			var itemCode struct {
+				Id json.RawMessage `json:"id"`
+			}
+			if json.Unmarshal(item, &itemCode) == nil {
+				itemsByCode[strings.Trim(string(itemCode.Id), `"`)] = item
+			}
+		}
+	}
+
+	var items []json.RawMessage
+	for _, code := range codes {
+		item, found := itemsByCode[strings.TrimSpace(code)]
+		if !found {
+			return nil
+		}
+		items = append(items, item)
+	}
+
+	return newListInteraction(requestUrl.String(), items)
+}
+
+func (instance *Server) recordInteraction(request *http.Request, requestUrl string) (*Interaction, error) {
+	upstreamRequest, err := http.NewRequestWithContext(request.Context(), http.MethodGet, requestUrl, nil)
+	if err != nil {
+		log.Errorf("Error creating the request to %s: %s", requestUrl, err.Error())
+		return nil, err
+	}
+	upstreamRequest.Header.Set("Accept", request.Header.Get("Accept"))
+	upstreamRequest.Header.Set("User-Agent", request.Header.Get("User-Agent"))
+
+	response, err := instance.client.Do(upstreamRequest)
+	if err != nil {
+		log.Errorf("Error recording the response to %s: %s", requestUrl, err.Error())
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		log.Errorf("Error reading the response to %s: %s", requestUrl, err.Error())
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Url:        requestUrl,
+		StatusCode: response.StatusCode,
+		Headers:    map[string]string{},
+	}
+	for _, header := range []string{"Content-Type", "ETag", "Last-Modified"} {
+		if value := response.Header.Get(header); value != "" {
+			interaction.Headers[header] = value
+		}
+	}
+	if json.Valid(responseBody) {
+		interaction.Body = responseBody
+	} else {
+		interaction.Text = string(responseBody)
+	}
+
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	key := normalizeUrl(requestUrl)
+	if index, found := instance.interactions[key]; found {
+		instance.cassette.Interactions[index] = interaction
+	} else {
+		instance.interactions[key] = len(instance.cassette.Interactions)
+		instance.cassette.Interactions = append(instance.cassette.Interactions, interaction)
+	}
+
+	return &interaction, nil
+}
+
+func writeInteraction(writer http.ResponseWriter, request *http.Request, interaction Interaction) {
+	eTag := interaction.Headers["ETag"]
+	if eTag != "" && request.Header.Get("If-None-Match") == eTag {
+		writer.Header().Set("ETag", eTag)
+		writer.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	for header, value := range interaction.Headers {
+		writer.Header().Set(header, value)
+	}
+	if writer.Header().Get("Content-Type") == "" && interaction.Body != nil {
+		writer.Header().Set("Content-Type", "application/json")
+	}
+
+	statusCode := interaction.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	writer.WriteHeader(statusCode)
+
+	if interaction.Body != nil {
+		_, _ = writer.Write(interaction.Body)
+	} else {
+		_, _ = writer.Write([]byte(interaction.Text))
+	}
+}
+
+func getItems(interaction Interaction) ([]json.RawMessage, error) {
+	var list struct {
+		Data []json.RawMessage `json:"dados"`
+	}
+	err := json.Unmarshal(interaction.Body, &list)
+	if err != nil {
+		return nil, err
+	}
+	if list.Data == nil {
+		return nil, errors.New(fmt.Sprint("The response to ", interaction.Url, " is not a list"))
+	}
+
+	return list.Data, nil
+}
+
+func newListInteraction(requestUrl string, items []json.RawMessage) *Interaction {
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+
+	body, err := json.Marshal(map[string][]json.RawMessage{"dados": items})
+	if err != nil {
+		return nil
+	}
+
+	return &Interaction{
+		Url:        requestUrl,
+		StatusCode: http.StatusOK,
+		Body:       body,
+	}
+}
+
+// normalizeUrl removes the differences between requests that return the same response, which are the order and the
+// empty values of the query parameters and the order of the codes searched in the same request
+func normalizeUrl(requestUrl string) string {
+	parsedUrl, err := url.Parse(requestUrl)
+	if err != nil {
+		return requestUrl
+	}
+
+	queryParams := url.Values{}
+	for key, values := range parsedUrl.Query() {
+		if key == "" {
+			continue
+		}
+		for _, value := range values {
+			if key == "id" {
+				codes := strings.Split(value, ",")
+				sort.Strings(codes)
+				value = strings.Join(codes, ",")
+			}
+			queryParams.Add(key, value)
+		}
+	}
+	parsedUrl.RawQuery = queryParams.Encode()
+
+	return parsedUrl.String()
+}
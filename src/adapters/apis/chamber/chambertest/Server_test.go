@@ -0,0 +1,209 @@
+package chambertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+func newTestServer(t *testing.T, cassette Cassette) *Server {
+	t.Helper()
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	err := cassette.Save(cassettePath)
+	if err != nil {
+		t.Fatalf("cassette.Save(): %s", err.Error())
+	}
+
+	fakeServer, err := NewServer(cassettePath, Replay)
+	if err != nil {
+		t.Fatalf("NewServer(): %s", err.Error())
+	}
+	t.Cleanup(func() { _ = fakeServer.Close() })
+
+	return fakeServer
+}
+
+func newListBody(codes ...any) json.RawMessage {
+	var items []map[string]any
+	for _, code := range codes {
+		items = append(items, map[string]any{"id": code})
+	}
+	body, _ := json.Marshal(map[string]any{"dados": items})
+	return body
+}
+
+func getCodes(t *testing.T, requestUrl string) (int, string) {
+	t.Helper()
+
+	response, err := http.Get(requestUrl)
+	if err != nil {
+		t.Fatalf("http.Get(): %s", err.Error())
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("io.ReadAll(): %s", err.Error())
+	}
+	if response.StatusCode != http.StatusOK {
+		return response.StatusCode, ""
+	}
+
+	var list struct {
+		Data []struct {
+			Id json.RawMessage `json:"id"`
+		} `json:"dados"`
+	}
+	err = json.Unmarshal(responseBody, &list)
+	if err != nil {
+		t.Fatalf("json.Unmarshal(): %s", err.Error())
+	}
+
+	var codes string
+	for _, item := range list.Data {
+		codes = fmt.Sprint(codes, string(item.Id), ";")
+	}
+	return response.StatusCode, codes
+}
+
+func TestServerReplaysRequests(t *testing.T) {
+	fakeServer := newTestServer(t, Cassette{
+		Interactions: []Interaction{
+			{
+				Url:        "https://dadosabertos.camara.leg.br/api/v2/eventos?dataInicio=2025-03-11&ordem=asc",
+				StatusCode: http.StatusOK,
+				Body:       newListBody(1, 2, 3, 4, 5),
+			},
+			{
+				Url:        "https://dadosabertos.camara.leg.br/api/v2/eventos?id=7,6&itens=2",
+				StatusCode: http.StatusOK,
+				Body:       newListBody(6, 7),
+			},
+			{
+				Url:        "https://dadosabertos.camara.leg.br/api/v2/votacoes?pagina=1&itens=2",
+				StatusCode: http.StatusOK,
+				Body:       newListBody("10-1", "10-2"),
+			},
+		},
+	})
+
+	testCases := []struct {
+		name               string
+		request            string
+		expectedStatusCode int
+		expectedCodes      string
+	}{
+		{
+			name:               "exact request with the query parameters in another order",
+			request:            "/eventos?itens=2&id=6,7",
+			expectedStatusCode: http.StatusOK,
+			expectedCodes:      "6;7;",
+		},
+		{
+			name:               "empty query parameters are ignored",
+			request:            "/votacoes?&pagina=1&itens=2",
+			expectedStatusCode: http.StatusOK,
+			expectedCodes:      `"10-1";"10-2";`,
+		},
+		{
+			name:               "first page of a list recorded without pagination",
+			request:            "/eventos?pagina=1&itens=2&ordem=asc&dataInicio=2025-03-11",
+			expectedStatusCode: http.StatusOK,
+			expectedCodes:      "1;2;",
+		},
+		{
+			name:               "last page of a list recorded without pagination",
+			request:            "/eventos?pagina=3&itens=2&ordem=asc&dataInicio=2025-03-11",
+			expectedStatusCode: http.StatusOK,
+			expectedCodes:      "5;",
+		},
+		{
+			name:               "page after the end of a list recorded without pagination",
+			request:            "/eventos?pagina=4&itens=2&ordem=asc&dataInicio=2025-03-11",
+			expectedStatusCode: http.StatusOK,
+			expectedCodes:      "",
+		},
+		{
+			name:               "codes recorded in different lists",
+			request:            "/eventos?id=7,2,5&itens=3",
+			expectedStatusCode: http.StatusOK,
+			expectedCodes:      "7;2;5;",
+		},
+		{
+			name:               "code that was not recorded",
+			request:            "/eventos?id=2,8&itens=2",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "resource that was not recorded",
+			request:            "/eventos/1",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			statusCode, codes := getCodes(t, fmt.Sprint(fakeServer.ApiAddress(), testCase.request))
+			if statusCode != testCase.expectedStatusCode {
+				t.Fatalf("Expected status %d, got %d", testCase.expectedStatusCode, statusCode)
+			}
+			if codes != testCase.expectedCodes {
+				t.Fatalf("Expected codes %s, got %s", testCase.expectedCodes, codes)
+			}
+		})
+	}
+
+	if len(fakeServer.UnmatchedRequests()) != 2 {
+		t.Fatalf("Expected 2 unmatched requests, got %v", fakeServer.UnmatchedRequests())
+	}
+}
+
+func TestServerAnswersConditionalRequests(t *testing.T) {
+	fakeServer := newTestServer(t, Cassette{
+		Interactions: []Interaction{
+			{
+				Url:        "https://dadosabertos.camara.leg.br/api/v2/referencias/situacoesEvento",
+				StatusCode: http.StatusOK,
+				Headers:    map[string]string{"ETag": `"v1"`},
+				Body:       newListBody(1),
+			},
+		},
+	})
+
+	testCases := []struct {
+		name               string
+		eTag               string
+		expectedStatusCode int
+	}{
+		{name: "without ETag", eTag: "", expectedStatusCode: http.StatusOK},
+		{name: "same ETag", eTag: `"v1"`, expectedStatusCode: http.StatusNotModified},
+		{name: "other ETag", eTag: `"v0"`, expectedStatusCode: http.StatusOK},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodGet,
+				fmt.Sprint(fakeServer.ApiAddress(), "/referencias/situacoesEvento"), nil)
+			if err != nil {
+				t.Fatalf("http.NewRequest(): %s", err.Error())
+			}
+			if testCase.eTag != "" {
+				request.Header.Set("If-None-Match", testCase.eTag)
+			}
+
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatalf("http.DefaultClient.Do(): %s", err.Error())
+			}
+			_ = response.Body.Close()
+
+			if response.StatusCode != testCase.expectedStatusCode {
+				t.Fatalf("Expected status %d, got %d", testCase.expectedStatusCode, response.StatusCode)
+			}
+		})
+	}
+}
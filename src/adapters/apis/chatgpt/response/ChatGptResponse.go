@@ -1,9 +0,0 @@
-package response
-
-type ChatGptResponse struct {
-	Choices []struct {
-		Message struct {
-			Content string `json:"content"`
-		} `json:"message"`
-	} `json:"choices"`
-}
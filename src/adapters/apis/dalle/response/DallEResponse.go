@@ -1,7 +0,0 @@
-package response
-
-type DallEResponse struct {
-	Data []struct {
-		Url string `json:"url"`
-	} `json:"data"`
-}
@@ -0,0 +1,175 @@
+package imagegeneration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"vnc-summarizer/adapters/apis/imagegeneration/request"
+	"vnc-summarizer/adapters/apis/imagegeneration/response"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/utils/contexts"
+)
+
+const (
+	comfyUiProviderName        = "ComfyUI"
+	comfyUiPromptPlaceholder   = "{{prompt}}"
+	comfyUiHistoryPollInterval = 2 * time.Second
+)
+
+type ComfyUi struct {
+	address       string
+	workflowPath  string
+	registerUsage func(context.Context, generationusage.GenerationUsage) *generationusage.GenerationUsage
+}
+
+// NewComfyUiApi creates the client of ComfyUI, which executes the workflow saved in API format in the file configured
+// in COMFYUI_WORKFLOW_PATH, replacing the {{prompt}} text of the workflow with the prompt of the image
+func NewComfyUiApi(registerUsage func(context.Context,
+	generationusage.GenerationUsage) *generationusage.GenerationUsage) *ComfyUi {
+	return &ComfyUi{
+		address:       strings.TrimSuffix(os.Getenv("COMFYUI_API_ADDRESS"), "/"),
+		workflowPath:  os.Getenv("COMFYUI_WORKFLOW_PATH"),
+		registerUsage: registerUsage,
+	}
+}
+
+func (instance ComfyUi) GenerateImage(ctx context.Context, prompt, _, purpose string) ([]byte,
+	*generationusage.GenerationUsage, error) {
+	log.Infof("Starting communication with %s: %s", comfyUiProviderName, purpose)
+
+	ctx, cancel := contexts.WithTimeout(ctx, "IMAGE_GENERATION_TIMEOUT", 2*time.Minute)
+	defer cancel()
+
+	workflow, err := instance.getWorkflow(prompt)
+	if err != nil {
+		log.Error("getWorkflow(): ", err.Error())
+		return nil, nil, err
+	}
+
+	var comfyUiResponse response.ComfyUiResponse
+	err = sendRequest(ctx, comfyUiProviderName, "POST", fmt.Sprint(instance.address, "/prompt"), nil,
+		request.ComfyUiRequest{Prompt: workflow, ClientId: uuid.NewString()}, &comfyUiResponse)
+	if err != nil {
+		log.Error("sendRequest(): ", err.Error())
+		return nil, nil, err
+	}
+
+	generatedImage, err := instance.waitForImage(ctx, comfyUiResponse.PromptId)
+	if err != nil {
+		log.Error("waitForImage(): ", err.Error())
+		return nil, nil, err
+	}
+
+	imageUrl := fmt.Sprintf("%s/view?%s", instance.address, url.Values{
+		"filename":  {generatedImage.Filename},
+		"subfolder": {generatedImage.Subfolder},
+		"type":      {generatedImage.Type},
+	}.Encode())
+	image, err := downloadImage(ctx, comfyUiProviderName, imageUrl)
+	if err != nil {
+		log.Error("downloadImage(): ", err.Error())
+		return nil, nil, err
+	}
+
+	model := strings.TrimSuffix(filepath.Base(instance.workflowPath), filepath.Ext(instance.workflowPath))
+	generationUsage, err := getGenerationUsage(ctx, instance.registerUsage, comfyUiProviderName, model, 1)
+	if err != nil {
+		log.Error("getGenerationUsage(): ", err.Error())
+		return nil, nil, err
+	}
+
+	log.Infof("Successful communication with %s: %s", comfyUiProviderName, purpose)
+	return image, generationUsage, nil
+}
+
+func (instance ComfyUi) getWorkflow(prompt string) (map[string]interface{}, error) {
+	workflowAsJson, err := os.ReadFile(instance.workflowPath)
+	if err != nil {
+		log.Errorf("Error reading the %s workflow: %s", comfyUiProviderName, err.Error())
+		return nil, err
+	}
+
+	// The prompt is escaped as a JSON string, since it replaces the placeholder inside the strings of the workflow
+	escapedPrompt, err := json.Marshal(prompt)
+	if err != nil {
+		log.Error("Error escaping the prompt of the image: ", err.Error())
+		return nil, err
+	}
+
+	if !strings.Contains(string(workflowAsJson), comfyUiPromptPlaceholder) {
+		errorMessage := fmt.Sprintf("The %s workflow must contain the %s text to be replaced by the prompt",
+			comfyUiProviderName, comfyUiPromptPlaceholder)
+		log.Error(errorMessage)
+		return nil, errors.New(errorMessage)
+	}
+	workflowAsJson = []byte(strings.ReplaceAll(string(workflowAsJson), comfyUiPromptPlaceholder,
+		strings.Trim(string(escapedPrompt), `"`)))
+
+	var workflow map[string]interface{}
+	err = json.Unmarshal(workflowAsJson, &workflow)
+	if err != nil {
+		log.Errorf("Error interpreting the %s workflow: %s", comfyUiProviderName, err.Error())
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// waitForImage polls the history of the prompt until its execution finishes, since ComfyUI executes the workflows in
+// a queue, and returns the first image among the outputs of the workflow
+func (instance ComfyUi) waitForImage(ctx context.Context, promptId string) (*response.ComfyUiImage, error) {
+	historyUrl := fmt.Sprint(instance.address, "/history/", promptId)
+	for {
+		err := contexts.Sleep(ctx, comfyUiHistoryPollInterval)
+		if err != nil {
+			errorMessage := fmt.Sprintf("The execution of prompt %s by %s did not finish in time: %s", promptId,
+				comfyUiProviderName, err.Error())
+			log.Error(errorMessage)
+			return nil, errors.New(errorMessage)
+		}
+
+		var history response.ComfyUiHistoryResponse
+		err = sendRequest(ctx, comfyUiProviderName, "GET", historyUrl, nil, nil, &history)
+		if err != nil {
+			log.Error("sendRequest(): ", err.Error())
+			return nil, err
+		}
+
+		promptHistory, ok := history[promptId]
+		if !ok {
+			continue
+		} else if promptHistory.Status.StatusStr == "error" {
+			errorMessage := fmt.Sprintf("The execution of prompt %s by %s failed", promptId, comfyUiProviderName)
+			log.Error(errorMessage)
+			return nil, errors.New(errorMessage)
+		}
+
+		var nodeIds []string
+		for nodeId := range promptHistory.Outputs {
+			nodeIds = append(nodeIds, nodeId)
+		}
+		slices.Sort(nodeIds)
+
+		for _, nodeId := range nodeIds {
+			images := promptHistory.Outputs[nodeId].Images
+			if len(images) > 0 {
+				return &images[0], nil
+			}
+		}
+
+		if promptHistory.Status.Completed {
+			errorMessage := fmt.Sprintf("The %s workflow finished without generating images", comfyUiProviderName)
+			log.Error(errorMessage)
+			return nil, errors.New(errorMessage)
+		}
+	}
+}
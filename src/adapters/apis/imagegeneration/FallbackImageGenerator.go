@@ -0,0 +1,36 @@
+package imagegeneration
+
+import (
+	"context"
+	"github.com/labstack/gommon/log"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/core/interfaces/imagegeneration"
+)
+
+type Fallback struct {
+	imageGenerator         imagegeneration.ImageGenerator
+	fallbackImageGenerator imagegeneration.ImageGenerator
+}
+
+// NewFallbackImageGenerator creates the generator that uses the fallback generator whenever the main generator fails,
+// so the articles are not left without images due to unavailability or refusals of the main generator
+func NewFallbackImageGenerator(imageGenerator,
+	fallbackImageGenerator imagegeneration.ImageGenerator) *Fallback {
+	return &Fallback{
+		imageGenerator:         imageGenerator,
+		fallbackImageGenerator: fallbackImageGenerator,
+	}
+}
+
+func (instance Fallback) GenerateImage(ctx context.Context, prompt, theme, purpose string) ([]byte,
+	*generationusage.GenerationUsage, error) {
+	image, generationUsage, err := instance.imageGenerator.GenerateImage(ctx, prompt, theme, purpose)
+	if err == nil {
+		return image, generationUsage, nil
+	} else if ctx.Err() != nil {
+		return nil, nil, err
+	}
+
+	log.Warn("The image generation failed, using the fallback generator: ", err.Error())
+	return instance.fallbackImageGenerator.GenerateImage(ctx, prompt, theme, purpose)
+}
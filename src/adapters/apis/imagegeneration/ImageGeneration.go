@@ -0,0 +1,147 @@
+package imagegeneration
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"io"
+	"net/http"
+	"time"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/utils/contexts"
+	"vnc-summarizer/utils/converters"
+	"vnc-summarizer/utils/prices"
+	"vnc-summarizer/utils/requesters"
+)
+
+const (
+	imageWidth  = 1024
+	imageHeight = 1024
+)
+
+// sendRequest sends the request to the image generation provider and decodes its JSON response. The body is only sent
+// when it is informed.
+func sendRequest(ctx context.Context, providerName, method, url string, headers map[string]string, body interface{},
+	responseBody interface{}) error {
+	var requestBody io.Reader
+	if body != nil {
+		requestBodyAsJson, err := converters.ToJson(body)
+		if err != nil {
+			log.Error("converters.ToJson(): ", err.Error())
+			return err
+		}
+		requestBody = bytes.NewBuffer(requestBodyAsJson)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, requestBody)
+	if err != nil {
+		log.Errorf("Error building the request for communication with %s: %s", providerName, err.Error())
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for header, value := range headers {
+		request.Header.Set(header, value)
+	}
+
+	client := &http.Client{
+		Timeout: contexts.GetTimeout("IMAGE_GENERATION_TIMEOUT", 2*time.Minute),
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		log.Errorf("Error making request to %s: %s", providerName, err.Error())
+		return err
+	}
+	defer requesters.CloseResponseBody(request, response)
+
+	if response.StatusCode != http.StatusOK {
+		errorResponseBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			log.Errorf("Error interpreting %s response: %s", providerName, err.Error())
+			return err
+		}
+
+		errorMessage := fmt.Sprintf("Error making request to %s: [Status: %s; Body: %s]", providerName,
+			response.Status, string(errorResponseBody))
+		log.Error(errorMessage)
+		return errors.New(errorMessage)
+	}
+
+	err = json.NewDecoder(response.Body).Decode(responseBody)
+	if err != nil {
+		log.Errorf("Error reading the response body returned by %s: %s", providerName, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// downloadImage downloads the image generated by the providers that return the address of the image instead of its
+// content
+func downloadImage(ctx context.Context, providerName, imageUrl string) ([]byte, error) {
+	response, err := requesters.GetRequest(ctx, imageUrl)
+	if err != nil {
+		log.Error("requesters.GetRequest(): ", err.Error())
+		return nil, err
+	}
+	defer requesters.CloseResponseBody(response.Request, response)
+
+	if response.StatusCode != http.StatusOK {
+		errorMessage := fmt.Sprintf("Error downloading the image generated by %s: [Status: %s]", providerName,
+			response.Status)
+		log.Error(errorMessage)
+		return nil, errors.New(errorMessage)
+	}
+
+	image, err := io.ReadAll(response.Body)
+	if err != nil {
+		log.Errorf("Error interpreting the image generated by %s: %s", providerName, err.Error())
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func decodeBase64Image(providerName, encodedImage string) ([]byte, error) {
+	image, err := base64.StdEncoding.DecodeString(encodedImage)
+	if err != nil {
+		log.Errorf("Error decoding the image generated by %s: %s", providerName, err.Error())
+		return nil, err
+	}
+
+	return image, nil
+}
+
+// getGenerationUsage returns the number of generated images along with the estimated cost, which is calculated from
+// the price per image configured in IMAGE_MODEL_PRICES, after registering the usage
+func getGenerationUsage(ctx context.Context, registerUsage func(context.Context,
+	generationusage.GenerationUsage) *generationusage.GenerationUsage, providerName, model string,
+	numberOfImages int) (*generationusage.GenerationUsage, error) {
+	var estimatedCost float64
+	modelPrices, err := prices.GetModelPrices("IMAGE_MODEL_PRICES", model)
+	if err != nil {
+		log.Warn("prices.GetModelPrices(): ", err.Error())
+	} else if len(modelPrices) > 0 {
+		estimatedCost = float64(numberOfImages) * modelPrices[0]
+	}
+
+	generationUsage, err := generationusage.NewBuilder().
+		Provider(providerName).
+		Model(model).
+		NumberOfImages(numberOfImages).
+		EstimatedCost(estimatedCost).
+		Build()
+	if err != nil {
+		log.Errorf("Error validating usage data of %s: %s", providerName, err.Error())
+		return nil, err
+	}
+
+	if registerUsage != nil {
+		generationUsage = registerUsage(ctx, *generationUsage)
+	}
+
+	return generationUsage, nil
+}
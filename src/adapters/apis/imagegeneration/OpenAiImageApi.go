@@ -0,0 +1,94 @@
+package imagegeneration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"os"
+	"strings"
+	"vnc-summarizer/adapters/apis/imagegeneration/request"
+	"vnc-summarizer/adapters/apis/imagegeneration/response"
+	"vnc-summarizer/core/domains/generationusage"
+)
+
+const (
+	openAiProviderName      = "OpenAI Images"
+	defaultOpenAiApiAddress = "https://api.openai.com/v1"
+)
+
+type OpenAi struct {
+	address       string
+	apiKey        string
+	model         string
+	registerUsage func(context.Context, generationusage.GenerationUsage) *generationusage.GenerationUsage
+}
+
+// NewOpenAiImageApi creates the client of the image API of OpenAI, whose address can be replaced in
+// OPENAI_API_ADDRESS along with the address of the other OpenAI adapters
+func NewOpenAiImageApi(registerUsage func(context.Context,
+	generationusage.GenerationUsage) *generationusage.GenerationUsage) *OpenAi {
+	address := strings.TrimSuffix(os.Getenv("OPENAI_API_ADDRESS"), "/")
+	if address == "" {
+		address = defaultOpenAiApiAddress
+	}
+
+	return &OpenAi{
+		address:       address,
+		apiKey:        os.Getenv("OPENAI_API_KEY"),
+		model:         os.Getenv("OPENAI_IMAGE_API_MODEL"),
+		registerUsage: registerUsage,
+	}
+}
+
+func (instance OpenAi) GenerateImage(ctx context.Context, prompt, _, purpose string) ([]byte,
+	*generationusage.GenerationUsage, error) {
+	log.Infof("Starting communication with %s: %s", openAiProviderName, purpose)
+
+	body := request.OpenAiImageRequest{
+		Model:          instance.model,
+		NumberOfImages: 1,
+		Size:           fmt.Sprintf("%dx%d", imageWidth, imageHeight),
+		Prompt:         prompt,
+	}
+	// The GPT Image models always return the content of the image and reject the response format, while the DALL·E
+	// models return a temporary URL by default
+	if strings.HasPrefix(instance.model, "dall-e") {
+		body.ResponseFormat = "b64_json"
+	}
+
+	var openAiResponse response.OpenAiImageResponse
+	err := sendRequest(ctx, openAiProviderName, "POST", fmt.Sprint(instance.address, "/images/generations"),
+		map[string]string{"Authorization": fmt.Sprint("Bearer ", instance.apiKey)}, body, &openAiResponse)
+	if err != nil {
+		log.Error("sendRequest(): ", err.Error())
+		return nil, nil, err
+	}
+
+	if len(openAiResponse.Data) < 1 {
+		errorMessage := fmt.Sprintf("Could not get the result of the request to %s: %s", openAiProviderName, purpose)
+		log.Error(errorMessage)
+		return nil, nil, errors.New(errorMessage)
+	}
+
+	var image []byte
+	if openAiResponse.Data[0].B64Json != "" {
+		image, err = decodeBase64Image(openAiProviderName, openAiResponse.Data[0].B64Json)
+	} else {
+		image, err = downloadImage(ctx, openAiProviderName, openAiResponse.Data[0].Url)
+	}
+	if err != nil {
+		log.Error("Error getting the generated image: ", err.Error())
+		return nil, nil, err
+	}
+
+	generationUsage, err := getGenerationUsage(ctx, instance.registerUsage, openAiProviderName, instance.model,
+		len(openAiResponse.Data))
+	if err != nil {
+		log.Error("getGenerationUsage(): ", err.Error())
+		return nil, nil, err
+	}
+
+	log.Infof("Successful communication with %s: %s", openAiProviderName, purpose)
+	return image, generationUsage, nil
+}
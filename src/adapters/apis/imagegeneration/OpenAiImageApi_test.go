@@ -0,0 +1,58 @@
+package imagegeneration
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+	"vnc-summarizer/adapters/apis/openaitest"
+)
+
+func TestOpenAiImageApiWithStub(t *testing.T) {
+	stubServer := openaitest.NewServer(openaitest.Options{})
+	t.Cleanup(stubServer.Close)
+	t.Setenv("OPENAI_API_ADDRESS", stubServer.Address())
+	t.Setenv("OPENAI_API_KEY", "test")
+	t.Setenv("IMAGE_MODEL_PRICES", "")
+
+	testCases := []struct {
+		name  string
+		model string
+	}{
+		{name: "DALL·E model", model: "dall-e-3"},
+		{name: "GPT Image model", model: "gpt-image-1"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("OPENAI_IMAGE_API_MODEL", testCase.model)
+			imageApi := NewOpenAiImageApi(nil)
+
+			image, generationUsage, err := imageApi.GenerateImage(context.Background(), "Ilustração do Congresso",
+				"", "Proposition image")
+			if err != nil {
+				t.Fatalf("GenerateImage(): %s", err.Error())
+			}
+			if generationUsage.NumberOfImages() != 1 {
+				t.Fatalf("GenerateImage() returned the usage %+v", generationUsage)
+			}
+
+			decodedImage, err := png.Decode(bytes.NewReader(image))
+			if err != nil {
+				t.Fatalf("png.Decode(): %s", err.Error())
+			}
+			if decodedImage.Bounds().Dx() != imageWidth || decodedImage.Bounds().Dy() != imageHeight {
+				t.Fatalf("GenerateImage() returned an image of %s", decodedImage.Bounds())
+			}
+
+			sameImage, _, err := imageApi.GenerateImage(context.Background(), "Ilustração do Congresso", "",
+				"Proposition image")
+			if err != nil {
+				t.Fatalf("GenerateImage(): %s", err.Error())
+			}
+			if !bytes.Equal(image, sameImage) {
+				t.Fatal("The same prompt returned different images")
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package imagegeneration
+
+import (
+	"bytes"
+	"context"
+	"github.com/labstack/gommon/log"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/core/interfaces/imagegeneration"
+)
+
+const (
+	placeholderModel          = "placeholder"
+	placeholderNumberOfShapes = 6
+	placeholderLabelScale     = 12
+	maximumPlaceholderLabel   = 8
+)
+
+type Placeholder struct{}
+
+// NewPlaceholderImageGenerator creates the generator of placeholder images, which are drawn locally without any
+// external service, so it can be used during development and as a fallback when the image generation fails
+func NewPlaceholderImageGenerator() *Placeholder {
+	return &Placeholder{}
+}
+
+// GenerateImage draws an image whose colors are defined by the theme, which is the type of the proposition, and whose
+// shapes are defined by the prompt. The same theme and prompt always result in the same image.
+func (instance Placeholder) GenerateImage(ctx context.Context, prompt, theme, purpose string) ([]byte,
+	*generationusage.GenerationUsage, error) {
+	log.Info("Generating placeholder image: ", purpose)
+
+	hue := float64(getHash(theme) % 360)
+	firstColor := getColorFromHsv(hue, 0.65, 0.35)
+	secondColor := getColorFromHsv(math.Mod(hue+40, 360), 0.55, 0.75)
+
+	placeholderImage := image.NewNRGBA(image.Rect(0, 0, imageWidth, imageHeight))
+	for y := 0; y < imageHeight; y++ {
+		rowColor := mixColors(firstColor, secondColor, float64(y)/float64(imageHeight-1))
+		for x := 0; x < imageWidth; x++ {
+			placeholderImage.SetNRGBA(x, y, rowColor)
+		}
+	}
+
+	promptHash := getHash(prompt)
+	for shape := 0; shape < placeholderNumberOfShapes; shape++ {
+		centerX := int(promptHash >> (shape * 5) % imageWidth)
+		centerY := int(promptHash >> (shape*5 + 16) % imageHeight)
+		radius := 80 + int(promptHash>>(shape*3)%200)
+		drawCircle(placeholderImage, centerX, centerY, radius, color.NRGBA{R: 255, G: 255, B: 255, A: 40})
+	}
+
+	drawLabel(placeholderImage, theme)
+
+	var placeholderContent bytes.Buffer
+	err := png.Encode(&placeholderContent, placeholderImage)
+	if err != nil {
+		log.Error("Error encoding the placeholder image: ", err.Error())
+		return nil, nil, err
+	}
+
+	generationUsage, err := getGenerationUsage(ctx, nil, imagegeneration.PlaceholderProvider, placeholderModel, 1)
+	if err != nil {
+		log.Error("getGenerationUsage(): ", err.Error())
+		return nil, nil, err
+	}
+
+	return placeholderContent.Bytes(), generationUsage, nil
+}
+
+func getHash(text string) uint64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(text))
+	return hash.Sum64()
+}
+
+func getColorFromHsv(hue, saturation, value float64) color.NRGBA {
+	chroma := value * saturation
+	secondLargestComponent := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	var red, green, blue float64
+	switch {
+	case hue < 60:
+		red, green = chroma, secondLargestComponent
+	case hue < 120:
+		red, green = secondLargestComponent, chroma
+	case hue < 180:
+		green, blue = chroma, secondLargestComponent
+	case hue < 240:
+		green, blue = secondLargestComponent, chroma
+	case hue < 300:
+		red, blue = secondLargestComponent, chroma
+	default:
+		red, blue = chroma, secondLargestComponent
+	}
+
+	smallestComponent := value - chroma
+	return color.NRGBA{
+		R: uint8(math.Round((red + smallestComponent) * 255)),
+		G: uint8(math.Round((green + smallestComponent) * 255)),
+		B: uint8(math.Round((blue + smallestComponent) * 255)),
+		A: 255,
+	}
+}
+
+func mixColors(firstColor, secondColor color.NRGBA, proportion float64) color.NRGBA {
+	mixComponent := func(firstComponent, secondComponent uint8) uint8 {
+		return uint8(math.Round(float64(firstComponent)*(1-proportion) + float64(secondComponent)*proportion))
+	}
+
+	return color.NRGBA{
+		R: mixComponent(firstColor.R, secondColor.R),
+		G: mixComponent(firstColor.G, secondColor.G),
+		B: mixComponent(firstColor.B, secondColor.B),
+		A: 255,
+	}
+}
+
+func drawCircle(imageData *image.NRGBA, centerX, centerY, radius int, circleColor color.NRGBA) {
+	bounds := image.Rect(centerX-radius, centerY-radius, centerX+radius, centerY+radius).Intersect(imageData.Bounds())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if (x-centerX)*(x-centerX)+(y-centerY)*(y-centerY) > radius*radius {
+				continue
+			}
+
+			proportion := float64(circleColor.A) / 255
+			imageData.SetNRGBA(x, y, mixColors(imageData.NRGBAAt(x, y), circleColor, proportion))
+		}
+	}
+}
+
+// drawLabel writes the theme in the center of the image, drawing it with the bitmap font and enlarging it afterward,
+// since the font is too small to be read in the image
+func drawLabel(imageData *image.NRGBA, theme string) {
+	label := strings.ToUpper(strings.TrimSpace(theme))
+	if label == "" {
+		return
+	} else if len([]rune(label)) > maximumPlaceholderLabel {
+		label = string([]rune(label)[:maximumPlaceholderLabel])
+	}
+
+	face := basicfont.Face7x13
+	labelWidth := font.MeasureString(face, label).Ceil()
+	labelImage := image.NewNRGBA(image.Rect(0, 0, labelWidth, face.Height))
+	drawer := font.Drawer{
+		Dst:  labelImage,
+		Src:  image.NewUniform(color.White),
+		Face: face,
+		Dot:  fixed.P(0, face.Ascent),
+	}
+	drawer.DrawString(label)
+
+	scaledWidth := min(labelWidth*placeholderLabelScale, imageWidth*9/10)
+	scaledHeight := face.Height * scaledWidth / labelWidth
+	left := (imageWidth - scaledWidth) / 2
+	top := (imageHeight - scaledHeight) / 2
+	draw.NearestNeighbor.Scale(imageData, image.Rect(left, top, left+scaledWidth, top+scaledHeight), labelImage,
+		labelImage.Bounds(), draw.Over, nil)
+}
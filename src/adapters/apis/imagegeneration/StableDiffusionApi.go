@@ -0,0 +1,95 @@
+package imagegeneration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"os"
+	"strconv"
+	"strings"
+	"vnc-summarizer/adapters/apis/imagegeneration/request"
+	"vnc-summarizer/adapters/apis/imagegeneration/response"
+	"vnc-summarizer/core/domains/generationusage"
+)
+
+const (
+	stableDiffusionProviderName = "Stable Diffusion"
+	defaultStableDiffusionModel = "stable-diffusion"
+	defaultStableDiffusionSteps = 30
+)
+
+type StableDiffusion struct {
+	address        string
+	model          string
+	steps          int
+	negativePrompt string
+	registerUsage  func(context.Context, generationusage.GenerationUsage) *generationusage.GenerationUsage
+}
+
+// NewStableDiffusionApi creates the client of the servers that implement the txt2img API of the Automatic1111 web UI,
+// such as Automatic1111 itself, Forge and SD.Next
+func NewStableDiffusionApi(registerUsage func(context.Context,
+	generationusage.GenerationUsage) *generationusage.GenerationUsage) *StableDiffusion {
+	steps, err := strconv.Atoi(os.Getenv("STABLE_DIFFUSION_API_STEPS"))
+	if err != nil || steps < 1 {
+		steps = defaultStableDiffusionSteps
+	}
+
+	return &StableDiffusion{
+		address:        strings.TrimSuffix(os.Getenv("STABLE_DIFFUSION_API_ADDRESS"), "/"),
+		model:          os.Getenv("STABLE_DIFFUSION_API_MODEL"),
+		steps:          steps,
+		negativePrompt: os.Getenv("STABLE_DIFFUSION_API_NEGATIVE_PROMPT"),
+		registerUsage:  registerUsage,
+	}
+}
+
+func (instance StableDiffusion) GenerateImage(ctx context.Context, prompt, _, purpose string) ([]byte,
+	*generationusage.GenerationUsage, error) {
+	log.Infof("Starting communication with %s: %s", stableDiffusionProviderName, purpose)
+
+	body := request.StableDiffusionRequest{
+		Prompt:         prompt,
+		NegativePrompt: instance.negativePrompt,
+		Width:          imageWidth,
+		Height:         imageHeight,
+		Steps:          instance.steps,
+	}
+	// Without a configured model, the images are generated by the checkpoint currently loaded on the server
+	model := defaultStableDiffusionModel
+	if instance.model != "" {
+		body.OverrideSettings = map[string]interface{}{"sd_model_checkpoint": instance.model}
+		model = instance.model
+	}
+
+	var stableDiffusionResponse response.StableDiffusionResponse
+	err := sendRequest(ctx, stableDiffusionProviderName, "POST", fmt.Sprint(instance.address, "/sdapi/v1/txt2img"),
+		nil, body, &stableDiffusionResponse)
+	if err != nil {
+		log.Error("sendRequest(): ", err.Error())
+		return nil, nil, err
+	}
+
+	if len(stableDiffusionResponse.Images) < 1 {
+		errorMessage := fmt.Sprintf("Could not get the result of the request to %s: %s",
+			stableDiffusionProviderName, purpose)
+		log.Error(errorMessage)
+		return nil, nil, errors.New(errorMessage)
+	}
+
+	image, err := decodeBase64Image(stableDiffusionProviderName, stableDiffusionResponse.Images[0])
+	if err != nil {
+		log.Error("decodeBase64Image(): ", err.Error())
+		return nil, nil, err
+	}
+
+	generationUsage, err := getGenerationUsage(ctx, instance.registerUsage, stableDiffusionProviderName, model, 1)
+	if err != nil {
+		log.Error("getGenerationUsage(): ", err.Error())
+		return nil, nil, err
+	}
+
+	log.Infof("Successful communication with %s: %s", stableDiffusionProviderName, purpose)
+	return image, generationUsage, nil
+}
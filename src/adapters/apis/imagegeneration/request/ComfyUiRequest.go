@@ -0,0 +1,6 @@
+package request
+
+type ComfyUiRequest struct {
+	Prompt   map[string]interface{} `json:"prompt"`
+	ClientId string                 `json:"client_id"`
+}
@@ -1,8 +1,9 @@
 package request
 
-type DallERequest struct {
+type OpenAiImageRequest struct {
 	Model          string `json:"model"`
 	NumberOfImages int    `json:"n"`
 	Size           string `json:"size"`
 	Prompt         string `json:"prompt"`
+	ResponseFormat string `json:"response_format,omitempty"`
 }
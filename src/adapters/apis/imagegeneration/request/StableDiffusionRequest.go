@@ -0,0 +1,10 @@
+package request
+
+type StableDiffusionRequest struct {
+	Prompt           string                 `json:"prompt"`
+	NegativePrompt   string                 `json:"negative_prompt,omitempty"`
+	Width            int                    `json:"width"`
+	Height           int                    `json:"height"`
+	Steps            int                    `json:"steps"`
+	OverrideSettings map[string]interface{} `json:"override_settings,omitempty"`
+}
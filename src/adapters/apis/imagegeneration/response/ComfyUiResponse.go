@@ -0,0 +1,21 @@
+package response
+
+type ComfyUiResponse struct {
+	PromptId string `json:"prompt_id"`
+}
+
+type ComfyUiHistoryResponse map[string]struct {
+	Status struct {
+		StatusStr string `json:"status_str"`
+		Completed bool   `json:"completed"`
+	} `json:"status"`
+	Outputs map[string]struct {
+		Images []ComfyUiImage `json:"images"`
+	} `json:"outputs"`
+}
+
+type ComfyUiImage struct {
+	Filename  string `json:"filename"`
+	Subfolder string `json:"subfolder"`
+	Type      string `json:"type"`
+}
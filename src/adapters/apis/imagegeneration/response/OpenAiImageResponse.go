@@ -0,0 +1,8 @@
+package response
+
+type OpenAiImageResponse struct {
+	Data []struct {
+		Url     string `json:"url"`
+		B64Json string `json:"b64_json"`
+	} `json:"data"`
+}
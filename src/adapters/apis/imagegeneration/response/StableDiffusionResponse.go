@@ -0,0 +1,5 @@
+package response
+
+type StableDiffusionResponse struct {
+	Images []string `json:"images"`
+}
@@ -0,0 +1,171 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/labstack/gommon/log"
+	"strconv"
+	"strings"
+	"vnc-summarizer/adapters/apis/llm/request"
+	"vnc-summarizer/adapters/apis/llm/response"
+	"vnc-summarizer/utils/converters"
+	"vnc-summarizer/utils/tokenizers"
+)
+
+type anthropic struct {
+	apiKey    string
+	model     string
+	maxTokens string
+	client    rateLimitedClient
+}
+
+func (instance anthropic) name() string {
+	return "Claude"
+}
+
+func (instance anthropic) modelName() string {
+	return instance.model
+}
+
+// countTokens estimates the tokens of the text, since the tokenizer of Claude is not public
+func (instance anthropic) countTokens(text string) int {
+	return tokenizers.CountTokens(text)
+}
+
+func (instance anthropic) sendTextMessage(ctx context.Context, text string) (string, tokenUsage, error) {
+	return instance.sendMessage(ctx, text, "", nil)
+}
+
+// Claude does not have a JSON output mode, so the structured response is obtained by forcing the use of a tool whose
+// input schema is the requested schema
+func (instance anthropic) sendStructuredMessage(ctx context.Context, text, imageUrl, schemaName string,
+	schema map[string]interface{}) (string, tokenUsage, error) {
+	tool := request.AnthropicTool{
+		Name:        schemaName,
+		Description: "Registra a resposta no formato solicitado",
+		InputSchema: schema,
+	}
+
+	return instance.sendMessage(ctx, text, imageUrl, &tool)
+}
+
+func (instance anthropic) sendImageMessage(ctx context.Context, text, imageUrl string) (string, tokenUsage, error) {
+	return instance.sendMessage(ctx, text, imageUrl, nil)
+}
+
+// getAnthropicImageContent builds the content of the message with the image, which is sent in base64 when it is
+// informed as a data URL since the API does not accept this kind of URL
+func getAnthropicImageContent(text, imageUrl string) []map[string]interface{} {
+	imageSource := map[string]interface{}{
+		"type": "url",
+		"url":  imageUrl,
+	}
+	if mediaTypeAndData, isDataUrl := strings.CutPrefix(imageUrl, "data:"); isDataUrl {
+		mediaType, data, _ := strings.Cut(mediaTypeAndData, ";base64,")
+		imageSource = map[string]interface{}{
+			"type":       "base64",
+			"media_type": mediaType,
+			"data":       data,
+		}
+	}
+
+	return []map[string]interface{}{
+		{
+			"type":   "image",
+			"source": imageSource,
+		},
+		{
+			"type": "text",
+			"text": text,
+		},
+	}
+}
+
+func (instance anthropic) sendMessage(ctx context.Context, text, imageUrl string, tool *request.AnthropicTool) (string,
+	tokenUsage, error) {
+	maxTokens, err := strconv.Atoi(instance.maxTokens)
+	if err != nil {
+		log.Error("Error converting environment variable ANTHROPIC_API_MAX_TOKENS to integer: ", err.Error())
+		return "", tokenUsage{}, err
+	}
+
+	var schema map[string]interface{}
+	if tool != nil {
+		schema = tool.InputSchema
+	}
+	estimatedTokens, err := estimateRequestTokens(instance, text, imageUrl, schema)
+	if err != nil {
+		log.Error("estimateRequestTokens(): ", err.Error())
+		return "", tokenUsage{}, err
+	}
+
+	var content interface{} = text
+	if imageUrl != "" {
+		content = getAnthropicImageContent(text, imageUrl)
+	}
+
+	body := request.AnthropicRequest{
+		Model:     instance.model,
+		MaxTokens: maxTokens,
+		Messages: []request.AnthropicMessage{
+			{
+				Role:    "user",
+				Content: content,
+			},
+		},
+	}
+	if tool != nil {
+		body.Tools = []request.AnthropicTool{*tool}
+		body.ToolChoice = map[string]interface{}{
+			"type": "tool",
+			"name": tool.Name,
+		}
+	}
+	requestBody, err := converters.ToJson(body)
+	if err != nil {
+		log.Error("converters.ToJson(): ", err.Error())
+		return "", tokenUsage{}, err
+	}
+
+	headers := map[string]string{
+		"x-api-key":         instance.apiKey,
+		"anthropic-version": "2023-06-01",
+		"Content-Type":      "application/json",
+	}
+
+	responseBody, err := instance.client.post(ctx, "https://api.anthropic.com/v1/messages", headers, requestBody,
+		estimatedTokens)
+	if err != nil {
+		log.Error("client.post(): ", err.Error())
+		return "", tokenUsage{}, err
+	}
+
+	var anthropicResponse response.AnthropicResponse
+	err = json.Unmarshal(responseBody, &anthropicResponse)
+	if err != nil {
+		log.Error("Error reading the response body returned by Claude: ", err.Error())
+		return "", tokenUsage{}, err
+	}
+
+	var requestResult []string
+	for _, contentBlock := range anthropicResponse.Content {
+		if tool == nil && contentBlock.Type == "text" {
+			requestResult = append(requestResult, contentBlock.Text)
+		} else if tool != nil && contentBlock.Type == "tool_use" && contentBlock.Name == tool.Name {
+			requestResult = append(requestResult, string(contentBlock.Input))
+		}
+	}
+
+	if len(requestResult) < 1 {
+		errorMessage := "Could not get the result of the request to Claude"
+		log.Error(errorMessage)
+		return "", tokenUsage{}, errors.New(errorMessage)
+	}
+
+	usage := tokenUsage{
+		promptTokens:     anthropicResponse.Usage.InputTokens,
+		completionTokens: anthropicResponse.Usage.OutputTokens,
+	}
+	return strings.Join(requestResult, ""), usage, nil
+}
@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"os"
+	"vnc-summarizer/adapters/apis/llm/request"
+	"vnc-summarizer/adapters/apis/llm/response"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/utils/converters"
+	"vnc-summarizer/utils/prices"
+	"vnc-summarizer/utils/tokenizers"
+)
+
+type Embedding struct {
+	providerName  string
+	address       string
+	apiKey        string
+	model         string
+	client        rateLimitedClient
+	registerUsage func(context.Context, generationusage.GenerationUsage) *generationusage.GenerationUsage
+}
+
+func NewOpenAiEmbeddingApi(registerUsage func(context.Context,
+	generationusage.GenerationUsage) *generationusage.GenerationUsage) *Embedding {
+	return newEmbeddingApi("OpenAI Embeddings", getOpenAiApiAddress(), os.Getenv("OPENAI_API_KEY"),
+		os.Getenv("OPENAI_EMBEDDING_API_MODEL"), "OPENAI_EMBEDDING_API", registerUsage)
+}
+
+func NewOpenAiCompatibleEmbeddingApi(registerUsage func(context.Context,
+	generationusage.GenerationUsage) *generationusage.GenerationUsage) *Embedding {
+	return newEmbeddingApi("OpenAI-compatible embeddings", os.Getenv("OPENAI_COMPATIBLE_API_ADDRESS"),
+		os.Getenv("OPENAI_COMPATIBLE_API_KEY"), os.Getenv("OPENAI_COMPATIBLE_EMBEDDING_API_MODEL"),
+		"OPENAI_COMPATIBLE_API", registerUsage)
+}
+
+func newEmbeddingApi(providerName, address, apiKey, model, rateLimitEnvironmentVariablePrefix string,
+	registerUsage func(context.Context, generationusage.GenerationUsage) *generationusage.GenerationUsage) *Embedding {
+	return &Embedding{
+		providerName:  providerName,
+		address:       address,
+		apiKey:        apiKey,
+		model:         model,
+		registerUsage: registerUsage,
+		client:        newRateLimitedClient(providerName, model, rateLimitEnvironmentVariablePrefix),
+	}
+}
+
+func (instance Embedding) MakeRequest(ctx context.Context, text, purpose string) ([]float64,
+	*generationusage.GenerationUsage, error) {
+	log.Infof("Starting communication with %s: %s", instance.providerName, purpose)
+
+	requestBody, err := converters.ToJson(request.OpenAiEmbeddingRequest{Model: instance.model, Input: text})
+	if err != nil {
+		log.Error("converters.ToJson(): ", err.Error())
+		return nil, nil, err
+	}
+
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	if instance.apiKey != "" {
+		headers["Authorization"] = fmt.Sprintf("Bearer %s", instance.apiKey)
+	}
+
+	responseBody, err := instance.client.post(ctx, fmt.Sprint(instance.address, "/embeddings"), headers,
+		requestBody, tokenizers.GetTokenCounter(instance.model)(text))
+	if err != nil {
+		log.Error("client.post(): ", err.Error())
+		return nil, nil, err
+	}
+
+	var embeddingResponse response.OpenAiEmbeddingResponse
+	err = json.Unmarshal(responseBody, &embeddingResponse)
+	if err != nil {
+		log.Errorf("Error reading the response body returned by %s: %s", instance.providerName, err.Error())
+		return nil, nil, err
+	}
+
+	if len(embeddingResponse.Data) < 1 || len(embeddingResponse.Data[0].Embedding) < 1 {
+		errorMessage := fmt.Sprintf("Could not get the result of the request to %s: %s", instance.providerName,
+			purpose)
+		log.Error(errorMessage)
+		return nil, nil, errors.New(errorMessage)
+	}
+
+	generationUsage, err := instance.getGenerationUsage(embeddingResponse.Usage.PromptTokens)
+	if err != nil {
+		log.Error("getGenerationUsage(): ", err.Error())
+		return nil, nil, err
+	}
+
+	if instance.registerUsage != nil {
+		generationUsage = instance.registerUsage(ctx, *generationUsage)
+	}
+
+	log.Infof("Successful communication with %s: %s", instance.providerName, purpose)
+	return embeddingResponse.Data[0].Embedding, generationUsage, nil
+}
+
+// getGenerationUsage returns the tokens consumed by the request along with the estimated cost, which is calculated
+// from the price per million tokens configured in LLM_MODEL_PRICES
+func (instance Embedding) getGenerationUsage(promptTokens int) (*generationusage.GenerationUsage, error) {
+	var estimatedCost float64
+	modelPrices, err := prices.GetModelPrices("LLM_MODEL_PRICES", instance.model)
+	if err != nil {
+		log.Warn("prices.GetModelPrices(): ", err.Error())
+	} else if len(modelPrices) > 0 {
+		estimatedCost = float64(promptTokens) * modelPrices[0] / 1000000
+	}
+
+	generationUsage, err := generationusage.NewBuilder().
+		Provider(instance.providerName).
+		Model(instance.model).
+		PromptTokens(promptTokens).
+		EstimatedCost(estimatedCost).
+		Build()
+	if err != nil {
+		log.Errorf("Error validating usage data of %s: %s", instance.providerName, err.Error())
+		return nil, err
+	}
+
+	return generationUsage, nil
+}
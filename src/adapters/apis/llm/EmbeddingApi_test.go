@@ -0,0 +1,35 @@
+package llm
+
+import (
+	"context"
+	"testing"
+	"vnc-summarizer/adapters/apis/openaitest"
+	"vnc-summarizer/core/domains/generationusage"
+)
+
+func TestOpenAiEmbeddingApiWithStub(t *testing.T) {
+	stubServer := openaitest.NewServer(openaitest.Options{})
+	t.Cleanup(stubServer.Close)
+	t.Setenv("OPENAI_API_ADDRESS", stubServer.Address())
+	t.Setenv("OPENAI_API_KEY", "test")
+	t.Setenv("OPENAI_EMBEDDING_API_MODEL", t.Name())
+	t.Setenv("OPENAI_EMBEDDING_API_REQUESTS_PER_MINUTE", "")
+	t.Setenv("OPENAI_EMBEDDING_API_TOKENS_PER_MINUTE", "")
+	t.Setenv("LLM_MODEL_PRICES", "")
+
+	var numberOfRegisteredUsages int
+	embeddingApi := NewOpenAiEmbeddingApi(func(_ context.Context,
+		generationUsage generationusage.GenerationUsage) *generationusage.GenerationUsage {
+		numberOfRegisteredUsages++
+		return &generationUsage
+	})
+	embedding, generationUsage, err := embeddingApi.MakeRequest(context.Background(), "Reforma tributária",
+		"Image library search")
+	if err != nil {
+		t.Fatalf("MakeRequest(): %s", err.Error())
+	}
+	if len(embedding) == 0 || generationUsage.PromptTokens() < 1 || numberOfRegisteredUsages != 1 {
+		t.Fatalf("MakeRequest() returned %v with usage %+v after registering %d usages", embedding,
+			generationUsage, numberOfRegisteredUsages)
+	}
+}
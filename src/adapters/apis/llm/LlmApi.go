@@ -0,0 +1,786 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/core/interfaces/cache"
+	"vnc-summarizer/utils/chunkers"
+	"vnc-summarizer/utils/converters"
+	"vnc-summarizer/utils/datetime"
+	"vnc-summarizer/utils/prices"
+	"vnc-summarizer/utils/requesters"
+	"vnc-summarizer/utils/tokenizers"
+	"vnc-summarizer/utils/validators"
+)
+
+// Maximum number of chunks summarized simultaneously in the map step of the map-reduce strategy
+const maximumNumberOfParallelRequests = 4
+
+const defaultOpenAiApiAddress = "https://api.openai.com/v1"
+
+const structuredResponseSchemaName = "structured_response"
+const maximumNumberOfStructuredRequestAttempts = 3
+
+// Estimated number of tokens of an image sent to the vision models, which are charged by the size of the image rather
+// than by the length of its base64 content
+const estimatedImageTokens = 1500
+
+type provider interface {
+	name() string
+	sendTextMessage(ctx context.Context, text string) (string, tokenUsage, error)
+	sendImageMessage(ctx context.Context, text, imageUrl string) (string, tokenUsage, error)
+	sendStructuredMessage(ctx context.Context, text, imageUrl, schemaName string, schema map[string]interface{}) (
+		string, tokenUsage, error)
+	modelName() string
+	countTokens(text string) int
+}
+
+// estimateRequestTokens estimates the tokens of a request to the provider, which are used to respect its rate limits
+func estimateRequestTokens(provider provider, text, imageUrl string, schema map[string]interface{}) (int, error) {
+	estimatedTokens := provider.countTokens(text)
+	if imageUrl != "" {
+		estimatedTokens += estimatedImageTokens
+	}
+
+	if schema != nil {
+		schemaAsJson, err := converters.ToJson(schema)
+		if err != nil {
+			log.Error("converters.ToJson(): ", err.Error())
+			return 0, err
+		}
+		estimatedTokens += provider.countTokens(string(schemaAsJson))
+	}
+
+	return estimatedTokens, nil
+}
+
+type tokenUsage struct {
+	promptTokens     int
+	completionTokens int
+}
+
+// usageRecorder accumulates the tokens consumed by all requests of an operation, including the requests sent in
+// parallel in the map step of the map-reduce strategy
+type usageRecorder struct {
+	mutex sync.Mutex
+	usage tokenUsage
+}
+
+func (instance *usageRecorder) add(usage tokenUsage) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	instance.usage.promptTokens += usage.promptTokens
+	instance.usage.completionTokens += usage.completionTokens
+}
+
+func (instance *usageRecorder) get() tokenUsage {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	return instance.usage
+}
+
+type Llm struct {
+	provider                      provider
+	economyProvider               provider
+	isEconomyModeActive           func(context.Context) bool
+	tokenLimitEnvironmentVariable string
+	responseCache                 cache.LlmResponse
+	usage                         *usageRecorder
+
+	// registerUsage registers the usage of each operation as soon as it finishes, even when it fails
+	registerUsage func(context.Context, generationusage.GenerationUsage) *generationusage.GenerationUsage
+}
+
+func NewOpenAiApi(responseCache cache.LlmResponse, isEconomyModeActive func(context.Context) bool,
+	registerUsage func(context.Context, generationusage.GenerationUsage) *generationusage.GenerationUsage) *Llm {
+	providerName := "ChatGPT"
+	address := getOpenAiApiAddress()
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	environmentVariablePrefix := "OPENAI_CHATGPT_API"
+	return &Llm{
+		provider: newOpenAiProvider(providerName, address, apiKey, os.Getenv("OPENAI_CHATGPT_API_MODEL"),
+			environmentVariablePrefix),
+		economyProvider: newOpenAiProvider(providerName, address, apiKey,
+			os.Getenv("OPENAI_CHATGPT_API_ECONOMY_MODEL"), environmentVariablePrefix),
+		isEconomyModeActive:           isEconomyModeActive,
+		tokenLimitEnvironmentVariable: "OPENAI_CHATGPT_API_TOKEN_LIMIT_PER_REQUEST",
+		responseCache:                 responseCache,
+		registerUsage:                 registerUsage,
+	}
+}
+
+func NewOpenAiCompatibleApi(responseCache cache.LlmResponse, isEconomyModeActive func(context.Context) bool,
+	registerUsage func(context.Context, generationusage.GenerationUsage) *generationusage.GenerationUsage) *Llm {
+	providerName := "OpenAI-compatible LLM"
+	address := os.Getenv("OPENAI_COMPATIBLE_API_ADDRESS")
+	apiKey := os.Getenv("OPENAI_COMPATIBLE_API_KEY")
+	environmentVariablePrefix := "OPENAI_COMPATIBLE_API"
+	return &Llm{
+		provider: newOpenAiProvider(providerName, address, apiKey, os.Getenv("OPENAI_COMPATIBLE_API_MODEL"),
+			environmentVariablePrefix),
+		economyProvider: newOpenAiProvider(providerName, address, apiKey,
+			os.Getenv("OPENAI_COMPATIBLE_API_ECONOMY_MODEL"), environmentVariablePrefix),
+		isEconomyModeActive:           isEconomyModeActive,
+		tokenLimitEnvironmentVariable: "OPENAI_COMPATIBLE_API_TOKEN_LIMIT_PER_REQUEST",
+		responseCache:                 responseCache,
+		registerUsage:                 registerUsage,
+	}
+}
+
+func NewAnthropicApi(responseCache cache.LlmResponse, isEconomyModeActive func(context.Context) bool,
+	registerUsage func(context.Context, generationusage.GenerationUsage) *generationusage.GenerationUsage) *Llm {
+	return &Llm{
+		provider:                      newAnthropicProvider(os.Getenv("ANTHROPIC_API_MODEL")),
+		economyProvider:               newAnthropicProvider(os.Getenv("ANTHROPIC_API_ECONOMY_MODEL")),
+		isEconomyModeActive:           isEconomyModeActive,
+		tokenLimitEnvironmentVariable: "ANTHROPIC_API_TOKEN_LIMIT_PER_REQUEST",
+		responseCache:                 responseCache,
+		registerUsage:                 registerUsage,
+	}
+}
+
+// getOpenAiApiAddress returns the address defined in OPENAI_API_ADDRESS, which allows the OpenAI adapters to be used
+// with proxies and with the local stub of the API (adapters/apis/openaitest)
+func getOpenAiApiAddress() string {
+	address := strings.TrimSuffix(os.Getenv("OPENAI_API_ADDRESS"), "/")
+	if address == "" {
+		return defaultOpenAiApiAddress
+	}
+
+	return address
+}
+
+// newOpenAiProvider returns nil when the model is not configured, which only happens with the optional economy model
+func newOpenAiProvider(providerName, address, apiKey, model, rateLimitEnvironmentVariablePrefix string) provider {
+	if model == "" {
+		return nil
+	}
+
+	return &openAi{
+		providerName: providerName,
+		address:      address,
+		apiKey:       apiKey,
+		model:        model,
+		tokenCounter: tokenizers.GetTokenCounter(model),
+		client:       newRateLimitedClient(providerName, model, rateLimitEnvironmentVariablePrefix),
+	}
+}
+
+// newAnthropicProvider returns nil when the model is not configured, which only happens with the optional economy
+// model
+func newAnthropicProvider(model string) provider {
+	if model == "" {
+		return nil
+	}
+
+	return &anthropic{
+		apiKey:    os.Getenv("ANTHROPIC_API_KEY"),
+		model:     model,
+		maxTokens: os.Getenv("ANTHROPIC_API_MAX_TOKENS"),
+		client:    newRateLimitedClient("Claude", model, "ANTHROPIC_API"),
+	}
+}
+
+// startOperation prepares the copy of the instance used by an operation, selecting the economy model when the economy
+// mode is active and resetting the usage recorded by the requests of the operation
+func (instance *Llm) startOperation(ctx context.Context) {
+	if instance.economyProvider != nil && instance.isEconomyModeActive != nil && instance.isEconomyModeActive(ctx) {
+		log.Infof("Economy mode is active, using model %s of %s", instance.economyProvider.modelName(),
+			instance.economyProvider.name())
+		instance.provider = instance.economyProvider
+	}
+	instance.usage = &usageRecorder{}
+}
+
+// getGenerationUsage returns the tokens consumed by the requests of the operation along with the estimated cost, which
+// is calculated from the prices per million prompt and completion tokens configured in LLM_MODEL_PRICES
+func (instance Llm) getGenerationUsage() (*generationusage.GenerationUsage, error) {
+	usage := instance.usage.get()
+	model := instance.provider.modelName()
+
+	var estimatedCost float64
+	modelPrices, err := prices.GetModelPrices("LLM_MODEL_PRICES", model)
+	if err != nil {
+		log.Warn("prices.GetModelPrices(): ", err.Error())
+	} else if len(modelPrices) == 2 {
+		estimatedCost = (float64(usage.promptTokens)*modelPrices[0] +
+			float64(usage.completionTokens)*modelPrices[1]) / 1000000
+	} else if modelPrices != nil {
+		log.Warnf("The prices of model %s in environment variable LLM_MODEL_PRICES must follow the format "+
+			"model=prompt_price:completion_price", model)
+	}
+
+	generationUsage, err := generationusage.NewBuilder().
+		Provider(instance.provider.name()).
+		Model(model).
+		PromptTokens(usage.promptTokens).
+		CompletionTokens(usage.completionTokens).
+		EstimatedCost(estimatedCost).
+		Build()
+	if err != nil {
+		log.Errorf("Error validating usage data of %s: %s", instance.provider.name(), err.Error())
+		return nil, err
+	}
+
+	return generationUsage, nil
+}
+
+// finishOperation returns the usage of the requests of the operation after registering it. The usage is registered even
+// when the operation fails, since the requests sent before the failure are charged by the provider as well.
+func (instance Llm) finishOperation(ctx context.Context) (*generationusage.GenerationUsage, error) {
+	generationUsage, err := instance.getGenerationUsage()
+	if err != nil {
+		log.Error("getGenerationUsage(): ", err.Error())
+		return nil, err
+	}
+
+	// The responses retrieved from the cache do not consume tokens
+	if instance.registerUsage != nil && (generationUsage.PromptTokens() > 0 || generationUsage.CompletionTokens() > 0) {
+		generationUsage = instance.registerUsage(ctx, *generationUsage)
+	}
+
+	return generationUsage, nil
+}
+
+func (instance Llm) MakeRequest(ctx context.Context, command, content, purpose string) (string,
+	*generationusage.GenerationUsage, error) {
+	instance.startOperation(ctx)
+	requestResult, err := instance.makeRequest(ctx, command, content, purpose)
+	generationUsage, usageErr := instance.finishOperation(ctx)
+	if err != nil {
+		return "", nil, err
+	} else if usageErr != nil {
+		log.Error("finishOperation(): ", usageErr.Error())
+		return "", nil, usageErr
+	}
+
+	return requestResult, generationUsage, nil
+}
+
+func (instance Llm) makeRequest(ctx context.Context, command, content, purpose string) (string, error) {
+	providerName := instance.provider.name()
+	log.Infof("Starting communication with %s: %s", providerName, purpose)
+
+	tokenLimitPerRequest, err := instance.getTokenLimitPerRequest()
+	if err != nil {
+		log.Error("getTokenLimitPerRequest(): ", err.Error())
+		return "", err
+	}
+
+	// Part of the limit is reserved for the result of the previous request, which is sent along with each new chunk
+	contentTokenLimit := tokenLimitPerRequest - instance.provider.countTokens(command) - tokenLimitPerRequest/5
+	if contentTokenLimit <= 0 {
+		err = errors.New(fmt.Sprint("The command exceeds the token limit per request of ", providerName))
+		log.Error(err.Error())
+		return "", err
+	}
+
+	var requestResult string
+	contentParts := chunkers.Split(content, contentTokenLimit, instance.provider.countTokens)
+	for index, partOfTheContent := range contentParts {
+		requestResult, err = instance.sendMessage(ctx, fmt.Sprint(command, requestResult, partOfTheContent), "", nil)
+		if err != nil {
+			log.Errorf("Error communicating with %s: %s", providerName, err.Error())
+			return "", err
+		}
+
+		if len(contentParts) > 1 {
+			log.Infof("%dth successful communication with %s: %s", index+1, providerName, purpose)
+		}
+	}
+
+	log.Infof("Successful communication with %s: %s", providerName, purpose)
+	return requestResult, nil
+}
+
+func (instance Llm) MakeRequestUsingMapReduce(ctx context.Context, command, content, purpose string) (string,
+	*generationusage.GenerationUsage, error) {
+	instance.startOperation(ctx)
+	requestResult, err := instance.makeRequestUsingMapReduce(ctx, command, content, purpose)
+	generationUsage, usageErr := instance.finishOperation(ctx)
+	if err != nil {
+		return "", nil, err
+	} else if usageErr != nil {
+		log.Error("finishOperation(): ", usageErr.Error())
+		return "", nil, usageErr
+	}
+
+	return requestResult, generationUsage, nil
+}
+
+func (instance Llm) makeRequestUsingMapReduce(ctx context.Context, command, content, purpose string) (string, error) {
+	providerName := instance.provider.name()
+	log.Infof("Starting communication with %s using map-reduce: %s", providerName, purpose)
+
+	tokenLimitPerRequest, err := instance.getTokenLimitPerRequest()
+	if err != nil {
+		log.Error("getTokenLimitPerRequest(): ", err.Error())
+		return "", err
+	}
+
+	contentTokenLimit := tokenLimitPerRequest - instance.provider.countTokens(command)
+	if contentTokenLimit <= 0 {
+		err = errors.New(fmt.Sprint("The command exceeds the token limit per request of ", providerName))
+		log.Error(err.Error())
+		return "", err
+	}
+
+	content, err = instance.reduceContent(ctx, command, content, purpose, contentTokenLimit, instance.sendTextMessage)
+	if err != nil {
+		log.Error("reduceContent(): ", err.Error())
+		return "", err
+	}
+
+	requestResult, err := instance.sendMessage(ctx, fmt.Sprint(command, content), "", nil)
+	if err != nil {
+		log.Errorf("Error communicating with %s in the reduce step: %s", providerName, err.Error())
+		return "", err
+	}
+
+	log.Infof("Successful communication with %s using map-reduce: %s", providerName, purpose)
+	return requestResult, nil
+}
+
+func (instance Llm) MakeStructuredRequest(ctx context.Context, command, content, purpose string,
+	schema map[string]interface{}) (map[string]interface{}, *generationusage.GenerationUsage, error) {
+	instance.startOperation(ctx)
+	requestResult, err := instance.makeStructuredRequest(ctx, command, content, purpose, schema)
+	generationUsage, usageErr := instance.finishOperation(ctx)
+	if err != nil {
+		return nil, nil, err
+	} else if usageErr != nil {
+		log.Error("finishOperation(): ", usageErr.Error())
+		return nil, nil, usageErr
+	}
+
+	return requestResult, generationUsage, nil
+}
+
+func (instance Llm) makeStructuredRequest(ctx context.Context, command, content, purpose string,
+	schema map[string]interface{}) (map[string]interface{}, error) {
+	providerName := instance.provider.name()
+	log.Infof("Starting structured communication with %s: %s", providerName, purpose)
+
+	tokenLimitPerRequest, err := instance.getTokenLimitPerRequest()
+	if err != nil {
+		log.Error("getTokenLimitPerRequest(): ", err.Error())
+		return nil, err
+	}
+
+	schemaAsJson, err := converters.ToJson(schema)
+	if err != nil {
+		log.Error("converters.ToJson(): ", err.Error())
+		return nil, err
+	}
+
+	contentTokenLimit := tokenLimitPerRequest - instance.provider.countTokens(command) -
+		instance.provider.countTokens(string(schemaAsJson))
+	if contentTokenLimit <= 0 {
+		err = errors.New(fmt.Sprint("The command exceeds the token limit per request of ", providerName))
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// In the map step, each chunk of the content is converted into a partial structured result, which is sent as
+	// JSON in the next step
+	sendStructuredMessageAsJson := func(ctx context.Context, text string) (string, error) {
+		partialResult, err := instance.sendStructuredMessage(ctx, text, "", schema)
+		if err != nil {
+			return "", err
+		}
+
+		partialResultAsJson, err := converters.ToJson(partialResult)
+		if err != nil {
+			return "", err
+		}
+
+		return string(partialResultAsJson), nil
+	}
+
+	content, err = instance.reduceContent(ctx, command, content, purpose, contentTokenLimit,
+		sendStructuredMessageAsJson)
+	if err != nil {
+		log.Error("reduceContent(): ", err.Error())
+		return nil, err
+	}
+
+	requestResult, err := instance.sendStructuredMessage(ctx, fmt.Sprint(command, content), "", schema)
+	if err != nil {
+		log.Errorf("Error communicating with %s: %s", providerName, err.Error())
+		return nil, err
+	}
+
+	log.Infof("Successful structured communication with %s: %s", providerName, purpose)
+	return requestResult, nil
+}
+
+// sendStructuredMessage sends the message, along with the image when its URL is informed, to the provider and validates
+// the response against the schema. When the response is malformed, the message is sent again along with the
+// validation errors so the model can fix it.
+func (instance Llm) sendStructuredMessage(ctx context.Context, text, imageUrl string, schema map[string]interface{}) (
+	map[string]interface{}, error) {
+	providerName := instance.provider.name()
+	message := text
+
+	var err error
+	for attempt := 1; attempt <= maximumNumberOfStructuredRequestAttempts; attempt++ {
+		var requestResult string
+		requestResult, err = instance.sendMessage(ctx, message, imageUrl, schema)
+		if err != nil {
+			return nil, err
+		}
+
+		var structuredResult map[string]interface{}
+		structuredResult, err = parseStructuredResult(requestResult, schema)
+		if err == nil {
+			return structuredResult, nil
+		}
+
+		log.Warnf("The response of %s on the %dth attempt does not follow the requested schema: %s", providerName,
+			attempt, err.Error())
+		message = fmt.Sprintf("%s\n\nA resposta anterior não seguiu o formato solicitado (%s). Resposta anterior:\n%s"+
+			"\n\nResponda novamente somente com um JSON válido que siga o formato solicitado.", text, err.Error(),
+			requestResult)
+	}
+
+	return nil, errors.New(fmt.Sprintf("The response of %s does not follow the requested schema: %s",
+		providerName, err.Error()))
+}
+
+func parseStructuredResult(requestResult string, schema map[string]interface{}) (map[string]interface{}, error) {
+	// Some models surround the JSON with markdown code blocks or comments, so only the object is considered
+	jsonStart := strings.Index(requestResult, "{")
+	jsonEnd := strings.LastIndex(requestResult, "}")
+	if jsonStart < 0 || jsonEnd < jsonStart {
+		return nil, errors.New("the response does not contain a JSON object")
+	}
+
+	var structuredResult map[string]interface{}
+	err := json.Unmarshal([]byte(requestResult[jsonStart:jsonEnd+1]), &structuredResult)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validators.ValidateJsonSchema(structuredResult, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return structuredResult, nil
+}
+
+func (instance Llm) reduceContent(ctx context.Context, command, content, purpose string, contentTokenLimit int,
+	sendMessage func(ctx context.Context, text string) (string, error)) (string, error) {
+	providerName := instance.provider.name()
+	for round := 1; ; round++ {
+		contentParts := chunkers.Split(content, contentTokenLimit, instance.provider.countTokens)
+		if len(contentParts) <= 1 {
+			return content, nil
+		}
+
+		partialResults, err := summarizeContentParts(ctx, command, contentParts, sendMessage)
+		if err != nil {
+			log.Errorf("Error communicating with %s in the %dth map step: %s", providerName, round, err.Error())
+			return "", err
+		}
+		log.Infof("%dth map step with %d chunks successfully completed with %s: %s", round, len(contentParts),
+			providerName, purpose)
+
+		reducedContent := strings.Join(partialResults, "\n\n")
+		if instance.provider.countTokens(reducedContent) >= instance.provider.countTokens(content) {
+			err = errors.New(fmt.Sprint("The map step did not reduce the content sent to ", providerName))
+			log.Error(err.Error())
+			return "", err
+		}
+		content = reducedContent
+	}
+}
+
+func summarizeContentParts(ctx context.Context, command string, contentParts []string,
+	sendMessage func(ctx context.Context, text string) (string, error)) ([]string, error) {
+	partialResults := make([]string, len(contentParts))
+	errs := make([]error, len(contentParts))
+	semaphore := make(chan struct{}, maximumNumberOfParallelRequests)
+	var waitGroup sync.WaitGroup
+	for index, partOfTheContent := range contentParts {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			partialResults[index], errs[index] = sendMessage(ctx, fmt.Sprint(command, partOfTheContent))
+		}()
+	}
+	waitGroup.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return partialResults, nil
+}
+
+func (instance Llm) getTokenLimitPerRequest() (int, error) {
+	tokenLimitPerRequest, err := strconv.Atoi(os.Getenv(instance.tokenLimitEnvironmentVariable))
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("Error converting environment variable %s to integer: %s",
+			instance.tokenLimitEnvironmentVariable, err.Error()))
+	}
+
+	return tokenLimitPerRequest, nil
+}
+
+func (instance Llm) MakeRequestToVision(ctx context.Context, command, imageUrl string) (string,
+	*generationusage.GenerationUsage, error) {
+	instance.startOperation(ctx)
+	requestResult, err := instance.makeRequestToVision(ctx, command, imageUrl)
+	generationUsage, usageErr := instance.finishOperation(ctx)
+	if err != nil {
+		return "", nil, err
+	} else if usageErr != nil {
+		log.Error("finishOperation(): ", usageErr.Error())
+		return "", nil, usageErr
+	}
+
+	return requestResult, generationUsage, nil
+}
+
+func (instance Llm) makeRequestToVision(ctx context.Context, command, imageUrl string) (string, error) {
+	providerName := instance.provider.name()
+	purpose := fmt.Sprint("Description of the image available at ", imageUrl)
+	log.Infof("Starting communication with %s Vision: %s", providerName, purpose)
+
+	requestResult, err := instance.sendMessage(ctx, command, imageUrl, nil)
+	if err != nil {
+		log.Errorf("Error communicating with %s Vision: %s", providerName, err.Error())
+		return "", err
+	}
+
+	log.Infof("Successful communication with %s Vision: %s", providerName, purpose)
+	return requestResult, nil
+}
+
+// MakeStructuredRequestToVision sends the image along with the command and the content to the vision model and returns
+// the response validated against the schema
+func (instance Llm) MakeStructuredRequestToVision(ctx context.Context, command, content, imageUrl, purpose string,
+	schema map[string]interface{}) (map[string]interface{}, *generationusage.GenerationUsage, error) {
+	instance.startOperation(ctx)
+	requestResult, err := instance.makeStructuredRequestToVision(ctx, command, content, imageUrl, purpose, schema)
+	generationUsage, usageErr := instance.finishOperation(ctx)
+	if err != nil {
+		return nil, nil, err
+	} else if usageErr != nil {
+		log.Error("finishOperation(): ", usageErr.Error())
+		return nil, nil, usageErr
+	}
+
+	return requestResult, generationUsage, nil
+}
+
+func (instance Llm) makeStructuredRequestToVision(ctx context.Context, command, content, imageUrl, purpose string,
+	schema map[string]interface{}) (map[string]interface{}, error) {
+	providerName := instance.provider.name()
+	log.Infof("Starting structured communication with %s Vision: %s", providerName, purpose)
+
+	requestResult, err := instance.sendStructuredMessage(ctx, fmt.Sprint(command, content), imageUrl, schema)
+	if err != nil {
+		log.Errorf("Error communicating with %s Vision: %s", providerName, err.Error())
+		return nil, err
+	}
+
+	log.Infof("Successful structured communication with %s Vision: %s", providerName, purpose)
+	return requestResult, nil
+}
+
+func (instance Llm) sendTextMessage(ctx context.Context, text string) (string, error) {
+	return instance.sendMessage(ctx, text, "", nil)
+}
+
+// sendMessage sends the message to the provider, reusing the response of identical previous requests when the cache is
+// enabled. The cache key considers the provider, the model and the full message, which contains the rendered prompt,
+// so any change in the prompt template or in the content results in a new request.
+func (instance Llm) sendMessage(ctx context.Context, text, imageUrl string, schema map[string]interface{}) (string,
+	error) {
+	cacheKey, err := instance.getCacheKey(text, imageUrl, schema)
+	if err != nil {
+		log.Error("getCacheKey(): ", err.Error())
+		return "", err
+	}
+
+	if instance.responseCache != nil && !isCacheBypassed() {
+		cachedResponse, found, err := instance.responseCache.GetLlmResponse(ctx, cacheKey)
+		if err != nil {
+			log.Warn("responseCache.GetLlmResponse(): ", err.Error())
+		} else if found {
+			log.Infof("Response of %s retrieved from cache (Key: %s)", instance.provider.name(), cacheKey)
+			return cachedResponse, nil
+		}
+	}
+
+	if imageUrl != "" {
+		imageUrl, err = getImageUrlForProvider(ctx, imageUrl)
+		if err != nil {
+			log.Error("getImageUrlForProvider(): ", err.Error())
+			return "", err
+		}
+	}
+
+	var requestResult string
+	var usage tokenUsage
+	if schema != nil {
+		requestResult, usage, err = instance.provider.sendStructuredMessage(ctx, text, imageUrl,
+			structuredResponseSchemaName, schema)
+	} else if imageUrl != "" {
+		requestResult, usage, err = instance.provider.sendImageMessage(ctx, text, imageUrl)
+	} else {
+		requestResult, usage, err = instance.provider.sendTextMessage(ctx, text)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// Cached responses are not recorded since they do not consume tokens of the provider
+	if instance.usage != nil {
+		instance.usage.add(usage)
+	}
+
+	// Malformed structured responses are not stored, otherwise they would be returned again in the next attempts
+	if instance.responseCache != nil && (schema == nil || isStructuredResultValid(requestResult, schema)) {
+		instance.saveResponseInCache(ctx, cacheKey, requestResult)
+	}
+
+	return requestResult, nil
+}
+
+// getImageUrlForProvider returns the URL of the image sent to the provider. The images saved on disk or served by a
+// local address cannot be fetched by the provider, so they are sent inline as data URLs.
+func getImageUrlForProvider(ctx context.Context, imageUrl string) (string, error) {
+	parsedImageUrl, err := url.Parse(imageUrl)
+	if err != nil {
+		log.Errorf("Error interpreting the URL of the image %s: %s", imageUrl, err.Error())
+		return "", err
+	}
+
+	var image []byte
+	if parsedImageUrl.Scheme == "file" {
+		image, err = os.ReadFile(filepath.FromSlash(parsedImageUrl.Path))
+		if err != nil {
+			log.Errorf("Error reading the image %s: %s", imageUrl, err.Error())
+			return "", err
+		}
+	} else if isLocalHost(parsedImageUrl.Hostname()) {
+		image, err = downloadImage(ctx, imageUrl)
+		if err != nil {
+			log.Error("downloadImage(): ", err.Error())
+			return "", err
+		}
+	} else {
+		return imageUrl, nil
+	}
+
+	return fmt.Sprintf("data:%s;base64,%s", http.DetectContentType(image), base64.StdEncoding.EncodeToString(image)),
+		nil
+}
+
+// isLocalHost reports whether the host is only reachable from the network of the service, which includes the names of
+// the containers of Docker Compose since they have no domain
+func isLocalHost(host string) bool {
+	ip := net.ParseIP(host)
+	if ip != nil {
+		return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified()
+	}
+
+	return !strings.Contains(host, ".")
+}
+
+func downloadImage(ctx context.Context, imageUrl string) ([]byte, error) {
+	response, err := requesters.GetRequest(ctx, imageUrl)
+	if err != nil {
+		log.Error("requesters.GetRequest(): ", err.Error())
+		return nil, err
+	}
+	defer requesters.CloseResponseBody(response.Request, response)
+
+	if response.StatusCode != http.StatusOK {
+		errorMessage := fmt.Sprintf("Error downloading the image %s: [Status: %s]", imageUrl, response.Status)
+		log.Error(errorMessage)
+		return nil, errors.New(errorMessage)
+	}
+
+	image, err := io.ReadAll(response.Body)
+	if err != nil {
+		log.Errorf("Error reading the image %s: %s", imageUrl, err.Error())
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func isStructuredResultValid(requestResult string, schema map[string]interface{}) bool {
+	_, err := parseStructuredResult(requestResult, schema)
+	return err == nil
+}
+
+func (instance Llm) getCacheKey(text, imageUrl string, schema map[string]interface{}) (string, error) {
+	schemaAsJson, err := converters.ToJson(schema)
+	if err != nil {
+		log.Error("converters.ToJson(): ", err.Error())
+		return "", err
+	}
+
+	hash := sha256.New()
+	for _, keyPart := range []string{instance.provider.name(), instance.provider.modelName(), text, imageUrl,
+		string(schemaAsJson)} {
+		hash.Write([]byte(keyPart))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (instance Llm) saveResponseInCache(ctx context.Context, cacheKey, response string) {
+	cacheTtl, err := time.ParseDuration(os.Getenv("LLM_CACHE_TTL"))
+	if err != nil {
+		log.Warn("Error converting environment variable LLM_CACHE_TTL to duration, the response will not be "+
+			"cached: ", err.Error())
+		return
+	}
+
+	currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+	if err != nil {
+		log.Warn("datetime.GetCurrentDateTimeInBrazil(): ", err.Error())
+		return
+	}
+
+	err = instance.responseCache.SaveLlmResponse(ctx, cacheKey, response, currentDateTime.Add(cacheTtl))
+	if err != nil {
+		log.Warn("responseCache.SaveLlmResponse(): ", err.Error())
+	}
+}
+
+func isCacheBypassed() bool {
+	cacheBypassed, err := strconv.ParseBool(os.Getenv("LLM_CACHE_BYPASS"))
+	if err != nil {
+		return false
+	}
+
+	return cacheBypassed
+}
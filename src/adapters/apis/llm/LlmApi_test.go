@@ -0,0 +1,297 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"vnc-summarizer/adapters/apis/openaitest"
+	"vnc-summarizer/core/domains/generationusage"
+)
+
+var summarySchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"title": map[string]interface{}{
+			"type":      "string",
+			"maxLength": 20,
+		},
+		"content": map[string]interface{}{
+			"type":      "string",
+			"minLength": 200,
+		},
+		"topics": map[string]interface{}{
+			"type":     "array",
+			"minItems": 2,
+			"maxItems": 3,
+			"items": map[string]interface{}{
+				"type": "string",
+				"enum": []string{"Economia", "Saúde"},
+			},
+		},
+	},
+	"required":             []string{"title", "content", "topics"},
+	"additionalProperties": false,
+}
+
+var imageReviewSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"approved": map[string]interface{}{"type": "boolean"},
+		"reason":   map[string]interface{}{"type": "string"},
+	},
+	"required":             []string{"approved", "reason"},
+	"additionalProperties": false,
+}
+
+// newStubOpenAiApi creates the OpenAI client pointed to the local stub of the API. Each test uses its own model, since
+// the rate limiters are shared by the clients of the same model.
+func newStubOpenAiApi(t *testing.T, options openaitest.Options) (*Llm, *openaitest.Server) {
+	t.Helper()
+
+	stubServer := openaitest.NewServer(options)
+	t.Cleanup(stubServer.Close)
+
+	t.Setenv("OPENAI_API_ADDRESS", stubServer.Address())
+	t.Setenv("OPENAI_API_KEY", "test")
+	t.Setenv("OPENAI_CHATGPT_API_MODEL", t.Name())
+	t.Setenv("OPENAI_CHATGPT_API_ECONOMY_MODEL", "")
+	t.Setenv("OPENAI_CHATGPT_API_TOKEN_LIMIT_PER_REQUEST", "30000")
+	t.Setenv("OPENAI_CHATGPT_API_REQUESTS_PER_MINUTE", "")
+	t.Setenv("OPENAI_CHATGPT_API_TOKENS_PER_MINUTE", "")
+	t.Setenv("LLM_MODEL_PRICES", "")
+
+	return NewOpenAiApi(nil, nil, nil), stubServer
+}
+
+func TestOpenAiApiWithStub(t *testing.T) {
+	ctx := context.Background()
+	llmApi, _ := newStubOpenAiApi(t, openaitest.Options{})
+
+	t.Run("text request", func(t *testing.T) {
+		firstResult, usage, err := llmApi.MakeRequest(ctx, "Escreva a descrição do boletim: ", "Matérias do dia",
+			"Newsletter description")
+		if err != nil {
+			t.Fatalf("MakeRequest(): %s", err.Error())
+		}
+		if firstResult == "" || usage.PromptTokens() < 1 || usage.CompletionTokens() < 1 {
+			t.Fatalf("MakeRequest() returned %s with usage %+v", firstResult, usage)
+		}
+
+		secondResult, _, err := llmApi.MakeRequest(ctx, "Escreva a descrição do boletim: ", "Matérias do dia",
+			"Newsletter description")
+		if err != nil {
+			t.Fatalf("MakeRequest(): %s", err.Error())
+		}
+		if firstResult != secondResult {
+			t.Fatalf("The same request returned %s and %s", firstResult, secondResult)
+		}
+	})
+
+	t.Run("structured request", func(t *testing.T) {
+		result, _, err := llmApi.MakeStructuredRequest(ctx, "Resuma a proposição: ", "Texto da proposição",
+			"Proposition summary", summarySchema)
+		if err != nil {
+			t.Fatalf("MakeStructuredRequest(): %s", err.Error())
+		}
+
+		topics, _ := result["topics"].([]interface{})
+		if len([]rune(result["title"].(string))) > 20 || len(result["content"].(string)) < 200 || len(topics) != 2 ||
+			topics[0] != "Economia" {
+			t.Fatalf("MakeStructuredRequest() returned %+v", result)
+		}
+	})
+
+	t.Run("vision request", func(t *testing.T) {
+		result, _, err := llmApi.MakeRequestToVision(ctx, "Descreva a imagem", "https://example.com/image.png")
+		if err != nil {
+			t.Fatalf("MakeRequestToVision(): %s", err.Error())
+		}
+		if !strings.HasPrefix(result, "Descrição simulada da imagem") {
+			t.Fatalf("MakeRequestToVision() returned %s", result)
+		}
+	})
+
+	t.Run("structured vision request", func(t *testing.T) {
+		result, _, err := llmApi.MakeStructuredRequestToVision(ctx, "Revise a imagem: ", "Título da matéria",
+			"https://example.com/image.png", "Image review", imageReviewSchema)
+		if err != nil {
+			t.Fatalf("MakeStructuredRequestToVision(): %s", err.Error())
+		}
+		if result["approved"] != true || result["reason"] == "" {
+			t.Fatalf("MakeStructuredRequestToVision() returned %+v", result)
+		}
+	})
+}
+
+func TestOpenAiApiUsageRegistrationWithStub(t *testing.T) {
+	newStubOpenAiApi(t, openaitest.Options{})
+
+	var registeredUsages []generationusage.GenerationUsage
+	llmApi := NewOpenAiApi(nil, nil, func(_ context.Context,
+		generationUsage generationusage.GenerationUsage) *generationusage.GenerationUsage {
+		registeredUsages = append(registeredUsages, generationUsage)
+		return &generationUsage
+	})
+
+	_, usage, err := llmApi.MakeRequest(context.Background(), "Escreva a descrição do boletim: ", "Matérias do dia",
+		"Newsletter description")
+	if err != nil {
+		t.Fatalf("MakeRequest(): %s", err.Error())
+	}
+	if len(registeredUsages) != 1 || registeredUsages[0].PromptTokens() != usage.PromptTokens() {
+		t.Fatalf("MakeRequest() returned usage %+v, but registered %+v", usage, registeredUsages)
+	}
+}
+
+func TestOpenAiApiUsingMapReduceWithStub(t *testing.T) {
+	llmApi, stubServer := newStubOpenAiApi(t, openaitest.Options{})
+	t.Setenv("OPENAI_CHATGPT_API_TOKEN_LIMIT_PER_REQUEST", "300")
+
+	content := strings.Repeat("A proposição altera a legislação tributária e cria novas regras de transparência. ",
+		100)
+	result, _, err := llmApi.MakeRequestUsingMapReduce(context.Background(), "Resuma o conteúdo: ", content,
+		"Proposition summary")
+	if err != nil {
+		t.Fatalf("MakeRequestUsingMapReduce(): %s", err.Error())
+	}
+	if result == "" || stubServer.NumberOfRequests() < 3 {
+		t.Fatalf("MakeRequestUsingMapReduce() returned %s after %d requests", result, stubServer.NumberOfRequests())
+	}
+}
+
+func TestOpenAiApiFailuresWithStub(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		options                  openaitest.Options
+		requestTimeout           string
+		operationTimeout         time.Duration
+		expectsError             bool
+		expectedNumberOfRequests int
+	}{
+		{
+			name:                     "rate limited requests are sent again",
+			options:                  openaitest.Options{RateLimitedRequests: 2, RetryAfter: 10 * time.Millisecond},
+			expectsError:             false,
+			expectedNumberOfRequests: 3,
+		},
+		{
+			name:                     "rate limits that exceed the attempts",
+			options:                  openaitest.Options{RateLimitedRequests: 10, RetryAfter: time.Millisecond},
+			expectsError:             true,
+			expectedNumberOfRequests: maximumNumberOfAttemptsPerRequest,
+		},
+		{
+			name:                     "responses without choices",
+			options:                  openaitest.Options{EmptyChoices: true},
+			expectsError:             true,
+			expectedNumberOfRequests: 1,
+		},
+		{
+			name:                     "requests that exceed the timeout",
+			options:                  openaitest.Options{Delay: 5 * time.Second},
+			requestTimeout:           "50ms",
+			operationTimeout:         500 * time.Millisecond,
+			expectsError:             true,
+			expectedNumberOfRequests: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			llmApi, stubServer := newStubOpenAiApi(t, testCase.options)
+			t.Setenv("LLM_REQUEST_TIMEOUT", testCase.requestTimeout)
+
+			ctx := context.Background()
+			if testCase.operationTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, testCase.operationTimeout)
+				defer cancel()
+			}
+
+			startTime := time.Now()
+			_, _, err := llmApi.MakeRequest(ctx, "Escreva a descrição do boletim: ", "Matérias do dia",
+				"Newsletter description")
+			if testCase.expectsError != (err != nil) {
+				t.Fatalf("Expected error: %t, got: %v", testCase.expectsError, err)
+			}
+			if stubServer.NumberOfRequests() != testCase.expectedNumberOfRequests {
+				t.Fatalf("Expected %d requests, got %d", testCase.expectedNumberOfRequests,
+					stubServer.NumberOfRequests())
+			}
+			if testCase.operationTimeout > 0 && time.Since(startTime) > 2*testCase.operationTimeout {
+				t.Fatalf("The request took %s, but the operation was limited to %s", time.Since(startTime),
+					testCase.operationTimeout)
+			}
+		})
+	}
+}
+
+func TestGetImageUrlForProvider(t *testing.T) {
+	var imageContent bytes.Buffer
+	err := png.Encode(&imageContent, image.NewNRGBA(image.Rect(0, 0, 1, 1)))
+	if err != nil {
+		t.Fatalf("png.Encode(): %s", err.Error())
+	}
+	expectedDataUrl := "data:image/png;base64," + base64.StdEncoding.EncodeToString(imageContent.Bytes())
+
+	imagePath := filepath.Join(t.TempDir(), "image.png")
+	err = os.WriteFile(imagePath, imageContent.Bytes(), 0600)
+	if err != nil {
+		t.Fatalf("os.WriteFile(): %s", err.Error())
+	}
+
+	localServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, _ *http.Request) {
+		_, _ = responseWriter.Write(imageContent.Bytes())
+	}))
+	t.Cleanup(localServer.Close)
+
+	testCases := []struct {
+		name             string
+		imageUrl         string
+		expectedImageUrl string
+		expectsError     bool
+	}{
+		{
+			name:             "keeps the public URLs",
+			imageUrl:         "https://example.com/image.png",
+			expectedImageUrl: "https://example.com/image.png",
+		},
+		{
+			name:             "inlines the images saved on disk",
+			imageUrl:         (&url.URL{Scheme: "file", Path: filepath.ToSlash(imagePath)}).String(),
+			expectedImageUrl: expectedDataUrl,
+		},
+		{
+			name:             "inlines the images served by a local address",
+			imageUrl:         localServer.URL + "/image.png",
+			expectedImageUrl: expectedDataUrl,
+		},
+		{
+			name:         "fails when the image saved on disk does not exist",
+			imageUrl:     (&url.URL{Scheme: "file", Path: filepath.ToSlash(imagePath + ".missing")}).String(),
+			expectsError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			imageUrl, err := getImageUrlForProvider(context.Background(), testCase.imageUrl)
+			if testCase.expectsError != (err != nil) {
+				t.Fatalf("Expected error: %t, got: %v", testCase.expectsError, err)
+			}
+			if imageUrl != testCase.expectedImageUrl {
+				t.Fatalf("getImageUrlForProvider() returned %s, expected %s", imageUrl, testCase.expectedImageUrl)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"vnc-summarizer/adapters/apis/llm/request"
+	"vnc-summarizer/adapters/apis/llm/response"
+	"vnc-summarizer/utils/converters"
+)
+
+type openAi struct {
+	providerName string
+	address      string
+	apiKey       string
+	model        string
+	tokenCounter func(text string) int
+	client       rateLimitedClient
+}
+
+func (instance openAi) name() string {
+	return instance.providerName
+}
+
+func (instance openAi) modelName() string {
+	return instance.model
+}
+
+func (instance openAi) countTokens(text string) int {
+	return instance.tokenCounter(text)
+}
+
+func (instance openAi) sendTextMessage(ctx context.Context, text string) (string, tokenUsage, error) {
+	return instance.sendMessage(ctx, text, "", nil)
+}
+
+func (instance openAi) sendStructuredMessage(ctx context.Context, text, imageUrl, schemaName string,
+	schema map[string]interface{}) (string, tokenUsage, error) {
+	responseFormat := map[string]interface{}{
+		"type": "json_schema",
+		"json_schema": map[string]interface{}{
+			"name":   schemaName,
+			"schema": schema,
+			"strict": true,
+		},
+	}
+
+	return instance.sendMessage(ctx, text, imageUrl, responseFormat)
+}
+
+func (instance openAi) sendImageMessage(ctx context.Context, text, imageUrl string) (string, tokenUsage, error) {
+	return instance.sendMessage(ctx, text, imageUrl, nil)
+}
+
+func getOpenAiImageContent(text, imageUrl string) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"type": "text",
+			"text": text,
+		},
+		{
+			"type": "image_url",
+			"image_url": map[string]interface{}{
+				"url":    imageUrl,
+				"detail": "high",
+			},
+		},
+	}
+}
+
+func (instance openAi) sendMessage(ctx context.Context, text, imageUrl string, responseFormat map[string]interface{}) (
+	string, tokenUsage, error) {
+	estimatedTokens, err := estimateRequestTokens(instance, text, imageUrl, responseFormat)
+	if err != nil {
+		log.Error("estimateRequestTokens(): ", err.Error())
+		return "", tokenUsage{}, err
+	}
+
+	var content interface{} = text
+	if imageUrl != "" {
+		content = getOpenAiImageContent(text, imageUrl)
+	}
+
+	body := request.OpenAiRequest{
+		Model: instance.model,
+		Messages: []request.OpenAiMessage{
+			{
+				Role:    "user",
+				Content: content,
+			},
+		},
+		ResponseFormat: responseFormat,
+	}
+	requestBody, err := converters.ToJson(body)
+	if err != nil {
+		log.Error("converters.ToJson(): ", err.Error())
+		return "", tokenUsage{}, err
+	}
+
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	if instance.apiKey != "" {
+		headers["Authorization"] = fmt.Sprintf("Bearer %s", instance.apiKey)
+	}
+
+	responseBody, err := instance.client.post(ctx, fmt.Sprint(instance.address, "/chat/completions"), headers,
+		requestBody, estimatedTokens)
+	if err != nil {
+		log.Error("client.post(): ", err.Error())
+		return "", tokenUsage{}, err
+	}
+
+	var openAiResponse response.OpenAiResponse
+	err = json.Unmarshal(responseBody, &openAiResponse)
+	if err != nil {
+		log.Errorf("Error reading the response body returned by %s: %s", instance.providerName, err.Error())
+		return "", tokenUsage{}, err
+	}
+
+	if len(openAiResponse.Choices) < 1 {
+		errorMessage := fmt.Sprint("Could not get the result of the request to ", instance.providerName)
+		log.Error(errorMessage)
+		return "", tokenUsage{}, errors.New(errorMessage)
+	}
+
+	usage := tokenUsage{
+		promptTokens:     openAiResponse.Usage.PromptTokens,
+		completionTokens: openAiResponse.Usage.CompletionTokens,
+	}
+	return openAiResponse.Choices[0].Message.Content, usage, nil
+}
@@ -0,0 +1,196 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+	"vnc-summarizer/utils/contexts"
+	"vnc-summarizer/utils/ratelimiters"
+	"vnc-summarizer/utils/requesters"
+)
+
+const maximumNumberOfAttemptsPerRequest = 5
+const maximumBackoffTime = 2 * time.Minute
+
+type rateLimitedClient struct {
+	providerName                       string
+	model                              string
+	rateLimitEnvironmentVariablePrefix string
+	httpClient                         *http.Client
+}
+
+// newRateLimitedClient creates the client of the model, whose HTTP client is reused by all its requests so the
+// connections to the provider are kept open
+func newRateLimitedClient(providerName, model, rateLimitEnvironmentVariablePrefix string) rateLimitedClient {
+	return rateLimitedClient{
+		providerName:                       providerName,
+		model:                              model,
+		rateLimitEnvironmentVariablePrefix: rateLimitEnvironmentVariablePrefix,
+		httpClient: &http.Client{
+			Timeout: contexts.GetTimeout("LLM_REQUEST_TIMEOUT", 5*time.Minute),
+		},
+	}
+}
+
+// post sends the request respecting the requests and tokens per minute configured for the model and the quota
+// informed by the provider in the response headers. Requests rejected due to rate limits (429) or server errors (5xx)
+// are sent again after the time indicated by the provider or an exponential backoff with jitter.
+func (instance rateLimitedClient) post(ctx context.Context, address string, headers map[string]string, body []byte,
+	estimatedTokens int) ([]byte, error) {
+	limiter, err := instance.getLimiter()
+	if err != nil {
+		log.Error("getLimiter(): ", err.Error())
+		return nil, err
+	}
+
+	var lastError error
+	for attempt := 1; attempt <= maximumNumberOfAttemptsPerRequest; attempt++ {
+		err = limiter.Wait(ctx, estimatedTokens)
+		if err != nil {
+			log.Errorf("The request to %s was canceled while waiting for the rate limit: %s", instance.providerName,
+				err.Error())
+			return nil, err
+		}
+
+		request, err := http.NewRequestWithContext(ctx, "POST", address, bytes.NewBuffer(body))
+		if err != nil {
+			log.Errorf("Error building the request for communication with %s: %s", instance.providerName,
+				err.Error())
+			return nil, err
+		}
+		for header, value := range headers {
+			request.Header.Set(header, value)
+		}
+
+		response, err := instance.httpClient.Do(request)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Errorf("The request to %s was canceled: %s", instance.providerName, ctx.Err().Error())
+				return nil, ctx.Err()
+			}
+
+			lastError = err
+			waitingTime := requesters.GetBackoffTime(attempt, maximumBackoffTime)
+			log.Warnf("Error making request to %s on the %dth attempt, trying again in %s: %s",
+				instance.providerName, attempt, waitingTime, err.Error())
+			err = contexts.Sleep(ctx, waitingTime)
+			if err != nil {
+				log.Errorf("The request to %s was canceled: %s", instance.providerName, err.Error())
+				return nil, err
+			}
+			continue
+		}
+
+		responseBody, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			log.Errorf("Error interpreting %s response: %s", instance.providerName, err.Error())
+			return nil, err
+		}
+
+		updateLimiterFromHeaders(limiter, response.Header)
+
+		if response.StatusCode == http.StatusOK {
+			return responseBody, nil
+		}
+
+		lastError = errors.New(fmt.Sprintf("Error making request to %s: [Status: %s; Body: %s]",
+			instance.providerName, response.Status, string(responseBody)))
+		if response.StatusCode != http.StatusTooManyRequests && response.StatusCode < http.StatusInternalServerError {
+			log.Error(lastError.Error())
+			return nil, lastError
+		}
+
+		waitingTime, informedByTheProvider := requesters.GetRetryAfterTime(response.Header)
+		if !informedByTheProvider {
+			waitingTime = requesters.GetBackoffTime(attempt, maximumBackoffTime)
+		}
+		if response.StatusCode == http.StatusTooManyRequests {
+			limiter.BlockFor(waitingTime)
+		}
+		log.Warnf("%s returned status %s on the %dth attempt, trying again in %s", instance.providerName,
+			response.Status, attempt, waitingTime)
+		err = contexts.Sleep(ctx, waitingTime)
+		if err != nil {
+			log.Errorf("The request to %s was canceled: %s", instance.providerName, err.Error())
+			return nil, err
+		}
+	}
+
+	log.Errorf("It was not possible to communicate with %s after %d attempts: %s", instance.providerName,
+		maximumNumberOfAttemptsPerRequest, lastError.Error())
+	return nil, lastError
+}
+
+func (instance rateLimitedClient) getLimiter() (*ratelimiters.Limiter, error) {
+	var limits []int
+	for _, limitName := range []string{"REQUESTS_PER_MINUTE", "TOKENS_PER_MINUTE"} {
+		environmentVariable := fmt.Sprint(instance.rateLimitEnvironmentVariablePrefix, "_", limitName)
+		limitAsString := os.Getenv(environmentVariable)
+		if limitAsString == "" {
+			limits = append(limits, 0)
+			continue
+		}
+
+		limit, err := strconv.Atoi(limitAsString)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Error converting environment variable %s to integer: %s",
+				environmentVariable, err.Error()))
+		}
+		limits = append(limits, limit)
+	}
+
+	limiterKey := fmt.Sprint(instance.providerName, ":", instance.model)
+	return ratelimiters.Get(limiterKey, limits[0], limits[1]), nil
+}
+
+// updateLimiterFromHeaders reads the rate limit headers of OpenAI (x-ratelimit-*) and Anthropic
+// (anthropic-ratelimit-*)
+func updateLimiterFromHeaders(limiter *ratelimiters.Limiter, header http.Header) {
+	remainingRequests := getIntegerHeader(header, "x-ratelimit-remaining-requests",
+		"anthropic-ratelimit-requests-remaining")
+	remainingTokens := getIntegerHeader(header, "x-ratelimit-remaining-tokens",
+		"anthropic-ratelimit-tokens-remaining")
+	limiter.Update(remainingRequests, remainingTokens)
+
+	if remainingRequests == 0 {
+		limiter.BlockFor(getResetTime(header, "x-ratelimit-reset-requests", "anthropic-ratelimit-requests-reset"))
+	}
+	if remainingTokens == 0 {
+		limiter.BlockFor(getResetTime(header, "x-ratelimit-reset-tokens", "anthropic-ratelimit-tokens-reset"))
+	}
+}
+
+func getIntegerHeader(header http.Header, names ...string) int {
+	for _, name := range names {
+		value, err := strconv.Atoi(header.Get(name))
+		if err == nil {
+			return value
+		}
+	}
+
+	return -1
+}
+
+// getResetTime interprets the reset headers, which OpenAI informs as a duration (e.g. 6m0s) and Anthropic informs as
+// a date in the RFC 3339 format
+func getResetTime(header http.Header, names ...string) time.Duration {
+	for _, name := range names {
+		value := header.Get(name)
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+		if resetTime, err := time.Parse(time.RFC3339, value); err == nil {
+			return time.Until(resetTime)
+		}
+	}
+
+	return 0
+}
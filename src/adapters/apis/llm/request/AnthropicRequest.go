@@ -0,0 +1,20 @@
+package request
+
+type AnthropicRequest struct {
+	Model      string                 `json:"model"`
+	MaxTokens  int                    `json:"max_tokens"`
+	Messages   []AnthropicMessage     `json:"messages"`
+	Tools      []AnthropicTool        `json:"tools,omitempty"`
+	ToolChoice map[string]interface{} `json:"tool_choice,omitempty"`
+}
+
+type AnthropicMessage struct {
+	Role    string      `json:"role"`
+	Content interface{} `json:"content"`
+}
+
+type AnthropicTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
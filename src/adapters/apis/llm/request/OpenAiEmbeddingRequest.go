@@ -0,0 +1,6 @@
+package request
+
+type OpenAiEmbeddingRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
@@ -0,0 +1,12 @@
+package request
+
+type OpenAiRequest struct {
+	Model          string                 `json:"model"`
+	Messages       []OpenAiMessage        `json:"messages"`
+	ResponseFormat map[string]interface{} `json:"response_format,omitempty"`
+}
+
+type OpenAiMessage struct {
+	Role    string      `json:"role"`
+	Content interface{} `json:"content"`
+}
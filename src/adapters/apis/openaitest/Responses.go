@@ -0,0 +1,377 @@
+package openaitest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	embeddingDimensions   = 16
+	maximumImageDimension = 4096
+	defaultImageDimension = 1024
+)
+
+var imageFileNamePattern = regexp.MustCompile(`^([0-9a-f]+)-(\d+)x(\d+)\.png$`)
+
+type chatCompletionRequest struct {
+	Model          string                 `json:"model"`
+	Messages       []json.RawMessage      `json:"messages"`
+	ResponseFormat map[string]interface{} `json:"response_format"`
+}
+
+type imageRequest struct {
+	Model          string `json:"model"`
+	NumberOfImages int    `json:"n"`
+	Size           string `json:"size"`
+	Prompt         string `json:"prompt"`
+	ResponseFormat string `json:"response_format"`
+}
+
+type embeddingRequest struct {
+	Model string          `json:"model"`
+	Input json.RawMessage `json:"input"`
+}
+
+// createChatCompletion answers the structured requests with a JSON generated from the requested schema, the messages
+// with images with a description and the other messages with a text derived from the request
+func (instance *Handler) createChatCompletion(writer http.ResponseWriter, request *http.Request) {
+	var body chatCompletionRequest
+	err := json.NewDecoder(request.Body).Decode(&body)
+	if err != nil || len(body.Messages) == 0 {
+		writeError(writer, http.StatusBadRequest, "invalid_request", "The request must have at least one message")
+		return
+	}
+
+	messages, _ := json.Marshal(body.Messages)
+	seed := getHash(body.Model, string(messages))
+	promptTokens := max(len(messages)/4, 1)
+	if instance.options.EmptyChoices {
+		writeJson(writer, http.StatusOK, map[string]interface{}{
+			"id":      fmt.Sprint("chatcmpl-", seed[:12]),
+			"object":  "chat.completion",
+			"model":   body.Model,
+			"choices": []interface{}{},
+			"usage":   getUsage(promptTokens, 0),
+		})
+		return
+	}
+
+	var content string
+	jsonSchema, _ := body.ResponseFormat["json_schema"].(map[string]interface{})
+	schema, _ := jsonSchema["schema"].(map[string]interface{})
+	if schema != nil {
+		structuredContent, _ := json.Marshal(generateValue(schema, "resposta", seed))
+		content = string(structuredContent)
+	} else if hasImage(body.Messages[len(body.Messages)-1]) {
+		content = fmt.Sprintf("Descrição simulada da imagem %s: uma ilustração institucional com cores sóbrias.",
+			seed[:8])
+	} else {
+		content = fmt.Sprintf("Resposta simulada %s: texto determinístico gerado pelo stub da OpenAI.", seed[:8])
+	}
+
+	writeJson(writer, http.StatusOK, map[string]interface{}{
+		"id":     fmt.Sprint("chatcmpl-", seed[:12]),
+		"object": "chat.completion",
+		"model":  body.Model,
+		"choices": []interface{}{
+			map[string]interface{}{
+				"index":         0,
+				"finish_reason": "stop",
+				"message": map[string]interface{}{
+					"role":    "assistant",
+					"content": content,
+				},
+			},
+		},
+		"usage": getUsage(promptTokens, max(len(content)/4, 1)),
+	})
+}
+
+// createImage returns the content of the images, as the GPT Image models and the requests with the b64_json format,
+// or their addresses in the stub, as the DALL·E models by default
+func (instance *Handler) createImage(writer http.ResponseWriter, request *http.Request) {
+	var body imageRequest
+	err := json.NewDecoder(request.Body).Decode(&body)
+	if err != nil || body.Prompt == "" {
+		writeError(writer, http.StatusBadRequest, "invalid_request", "The request must have a prompt")
+		return
+	}
+
+	width, height := defaultImageDimension, defaultImageDimension
+	if body.Size != "" {
+		_, err = fmt.Sscanf(body.Size, "%dx%d", &width, &height)
+		if err != nil || width < 1 || height < 1 || width > maximumImageDimension || height > maximumImageDimension {
+			writeError(writer, http.StatusBadRequest, "invalid_size", fmt.Sprint("Invalid size: ", body.Size))
+			return
+		}
+	}
+
+	returnsContent := body.ResponseFormat == "b64_json" || strings.HasPrefix(body.Model, "gpt-image")
+	var data []interface{}
+	for index := 0; index < max(body.NumberOfImages, 1); index++ {
+		seed := getHash(body.Model, body.Prompt, strconv.Itoa(index))
+		if !returnsContent {
+			data = append(data, map[string]interface{}{
+				"url": fmt.Sprintf("http://%s%s/images/files/%s-%dx%d.png", request.Host, apiPath, seed[:16],
+					width, height),
+			})
+			continue
+		}
+
+		content, err := drawImage(seed, width, height)
+		if err != nil {
+			writeError(writer, http.StatusInternalServerError, "server_error", err.Error())
+			return
+		}
+		data = append(data, map[string]interface{}{
+			"b64_json": base64.StdEncoding.EncodeToString(content),
+		})
+	}
+
+	writeJson(writer, http.StatusOK, map[string]interface{}{
+		"created": time.Now().Unix(),
+		"data":    data,
+	})
+}
+
+func (instance *Handler) getImage(writer http.ResponseWriter, request *http.Request) {
+	nameParts := imageFileNamePattern.FindStringSubmatch(request.PathValue("name"))
+	if nameParts == nil {
+		http.NotFound(writer, request)
+		return
+	}
+
+	width, _ := strconv.Atoi(nameParts[2])
+	height, _ := strconv.Atoi(nameParts[3])
+	if width < 1 || height < 1 || width > maximumImageDimension || height > maximumImageDimension {
+		http.NotFound(writer, request)
+		return
+	}
+
+	content, err := drawImage(nameParts[1], width, height)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "image/png")
+	_, _ = writer.Write(content)
+}
+
+// createEmbedding returns unit vectors derived from the text, so equal texts have the same embedding and different
+// texts have embeddings with low similarity
+func (instance *Handler) createEmbedding(writer http.ResponseWriter, request *http.Request) {
+	var body embeddingRequest
+	err := json.NewDecoder(request.Body).Decode(&body)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, "invalid_request", "The request must have an input")
+		return
+	}
+
+	var inputs []string
+	var input string
+	if json.Unmarshal(body.Input, &input) == nil {
+		inputs = []string{input}
+	} else if json.Unmarshal(body.Input, &inputs) != nil || len(inputs) == 0 {
+		writeError(writer, http.StatusBadRequest, "invalid_request", "The input must be a text or a list of texts")
+		return
+	}
+
+	var data []interface{}
+	var promptTokens int
+	for index, input := range inputs {
+		data = append(data, map[string]interface{}{
+			"object":    "embedding",
+			"index":     index,
+			"embedding": getEmbedding(input),
+		})
+		promptTokens += max(len(input)/4, 1)
+	}
+
+	writeJson(writer, http.StatusOK, map[string]interface{}{
+		"object": "list",
+		"model":  body.Model,
+		"data":   data,
+		"usage": map[string]interface{}{
+			"prompt_tokens": promptTokens,
+			"total_tokens":  promptTokens,
+		},
+	})
+}
+
+// generateValue creates the value of the schema following the restrictions checked by validators.ValidateJsonSchema,
+// which are the type, the allowed values, the required properties and the limits of lists and texts
+func generateValue(schema map[string]interface{}, name, seed string) interface{} {
+	if allowedValues, ok := schema["enum"].([]interface{}); ok && len(allowedValues) > 0 {
+		return allowedValues[0]
+	}
+
+	switch getSchemaType(schema) {
+	case "object":
+		properties, _ := schema["properties"].(map[string]interface{})
+		propertyNames := make([]string, 0, len(properties))
+		for propertyName := range properties {
+			propertyNames = append(propertyNames, propertyName)
+		}
+		sort.Strings(propertyNames)
+
+		value := map[string]interface{}{}
+		for _, propertyName := range propertyNames {
+			propertySchema, _ := properties[propertyName].(map[string]interface{})
+			value[propertyName] = generateValue(propertySchema, propertyName, seed)
+		}
+		return value
+	case "array":
+		numberOfItems := max(getSchemaLimit(schema, "minItems", 1), 1)
+		if maxItems := getSchemaLimit(schema, "maxItems", numberOfItems); maxItems < numberOfItems {
+			numberOfItems = maxItems
+		}
+
+		itemSchema, _ := schema["items"].(map[string]interface{})
+		value := []interface{}{}
+		for index := 1; index <= numberOfItems; index++ {
+			value = append(value, generateValue(itemSchema, fmt.Sprintf("%s %d", name, index), seed))
+		}
+		return value
+	case "integer", "number":
+		return getSchemaLimit(schema, "minimum", 1)
+	case "boolean":
+		return true
+	case "null":
+		return nil
+	default:
+		return generateText(fmt.Sprintf("%s simulado %s", strings.ReplaceAll(name, "_", " "), seed[:8]),
+			getSchemaLimit(schema, "minLength", 0), getSchemaLimit(schema, "maxLength", math.MaxInt))
+	}
+}
+
+func getSchemaType(schema map[string]interface{}) string {
+	switch schemaType := schema["type"].(type) {
+	case string:
+		return schemaType
+	case []interface{}:
+		for _, allowedType := range schemaType {
+			if allowedType != "null" {
+				return fmt.Sprint(allowedType)
+			}
+		}
+	}
+
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	return "string"
+}
+
+func getSchemaLimit(schema map[string]interface{}, name string, defaultValue int) int {
+	if limit, ok := schema[name].(float64); ok {
+		return int(limit)
+	}
+
+	return defaultValue
+}
+
+func generateText(text string, minimumLength, maximumLength int) string {
+	for utf8.RuneCountInString(text) < minimumLength {
+		text = fmt.Sprint(text, " ", text)
+	}
+
+	runes := []rune(text)
+	if len(runes) > maximumLength {
+		return string(runes[:maximumLength])
+	}
+	return text
+}
+
+func hasImage(message json.RawMessage) bool {
+	var parsedMessage struct {
+		Content []struct {
+			Type string `json:"type"`
+		} `json:"content"`
+	}
+	if json.Unmarshal(message, &parsedMessage) != nil {
+		return false
+	}
+
+	for _, part := range parsedMessage.Content {
+		if part.Type == "image_url" {
+			return true
+		}
+	}
+	return false
+}
+
+func getUsage(promptTokens, completionTokens int) map[string]interface{} {
+	return map[string]interface{}{
+		"prompt_tokens":     promptTokens,
+		"completion_tokens": completionTokens,
+		"total_tokens":      promptTokens + completionTokens,
+	}
+}
+
+func getEmbedding(text string) []float64 {
+	embedding := make([]float64, embeddingDimensions)
+	var norm float64
+	for index := range embedding {
+		hash := sha256.Sum256([]byte(fmt.Sprint(index, ":", text)))
+		embedding[index] = float64(binary.BigEndian.Uint64(hash[:8]))/math.MaxUint64*2 - 1
+		norm += embedding[index] * embedding[index]
+	}
+
+	norm = math.Sqrt(norm)
+	for index := range embedding {
+		embedding[index] /= norm
+	}
+	return embedding
+}
+
+// drawImage draws a gradient whose colors are derived from the seed, so each prompt has its own image
+func drawImage(seed string, width, height int) ([]byte, error) {
+	colors, err := hex.DecodeString(fmt.Sprintf("%012s", seed)[:12])
+	if err != nil {
+		return nil, err
+	}
+
+	startColor := color.RGBA{R: colors[0], G: colors[1], B: colors[2], A: 255}
+	endColor := color.RGBA{R: colors[3], G: colors[4], B: colors[5], A: 255}
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		progress := float64(y) / float64(max(height-1, 1))
+		lineColor := color.RGBA{
+			R: uint8(float64(startColor.R) + (float64(endColor.R)-float64(startColor.R))*progress),
+			G: uint8(float64(startColor.G) + (float64(endColor.G)-float64(startColor.G))*progress),
+			B: uint8(float64(startColor.B) + (float64(endColor.B)-float64(startColor.B))*progress),
+			A: 255,
+		}
+		draw.Draw(canvas, image.Rect(0, y, width, y+1), &image.Uniform{C: lineColor}, image.Point{}, draw.Src)
+	}
+
+	var content bytes.Buffer
+	err = png.Encode(&content, canvas)
+	if err != nil {
+		return nil, err
+	}
+	return content.Bytes(), nil
+}
+
+func getHash(values ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(values, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
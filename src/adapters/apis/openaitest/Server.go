@@ -0,0 +1,173 @@
+package openaitest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const apiPath = "/v1"
+
+// Options are the switches that make the stub simulate the failures of the OpenAI API
+type Options struct {
+	// RateLimitedRequests is the number of requests, counted from the start of the stub, answered with 429
+	RateLimitedRequests int
+	// RetryAfter is the waiting time informed in the responses with 429
+	RetryAfter time.Duration
+	// Delay is the time waited before answering each request, which simulates the timeouts of the API
+	Delay time.Duration
+	// EmptyChoices makes the chat completions return no choices
+	EmptyChoices bool
+}
+
+// GetOptions returns the options defined in the OPENAI_STUB_RATE_LIMITED_REQUESTS, OPENAI_STUB_RETRY_AFTER,
+// OPENAI_STUB_DELAY and OPENAI_STUB_EMPTY_CHOICES variables
+func GetOptions() (Options, error) {
+	var options Options
+	var err error
+	if value := os.Getenv("OPENAI_STUB_RATE_LIMITED_REQUESTS"); value != "" {
+		options.RateLimitedRequests, err = strconv.Atoi(value)
+		if err != nil {
+			return Options{}, errors.New(fmt.Sprint("Error converting environment variable "+
+				"OPENAI_STUB_RATE_LIMITED_REQUESTS to integer: ", err.Error()))
+		}
+	}
+	if value := os.Getenv("OPENAI_STUB_RETRY_AFTER"); value != "" {
+		options.RetryAfter, err = time.ParseDuration(value)
+		if err != nil {
+			return Options{}, errors.New(fmt.Sprint("Error converting environment variable "+
+				"OPENAI_STUB_RETRY_AFTER to duration: ", err.Error()))
+		}
+	}
+	if value := os.Getenv("OPENAI_STUB_DELAY"); value != "" {
+		options.Delay, err = time.ParseDuration(value)
+		if err != nil {
+			return Options{}, errors.New(fmt.Sprint("Error converting environment variable OPENAI_STUB_DELAY "+
+				"to duration: ", err.Error()))
+		}
+	}
+	if value := os.Getenv("OPENAI_STUB_EMPTY_CHOICES"); value != "" {
+		options.EmptyChoices, err = strconv.ParseBool(value)
+		if err != nil {
+			return Options{}, errors.New(fmt.Sprint("Error converting environment variable "+
+				"OPENAI_STUB_EMPTY_CHOICES to boolean: ", err.Error()))
+		}
+	}
+
+	return options, nil
+}
+
+// Handler is a stub of the OpenAI API that answers the chat completions (including the messages with images and the
+// structured responses), the image generations and the embeddings with deterministic results, so the same request
+// always receives the same response without credentials or network access
+type Handler struct {
+	options          Options
+	mux              *http.ServeMux
+	mutex            sync.Mutex
+	numberOfRequests int
+	stopped          chan struct{}
+	stopOnce         sync.Once
+}
+
+func NewHandler(options Options) *Handler {
+	handler := &Handler{
+		options: options,
+		mux:     http.NewServeMux(),
+		stopped: make(chan struct{}),
+	}
+	handler.mux.HandleFunc(fmt.Sprint("POST ", apiPath, "/chat/completions"), handler.createChatCompletion)
+	handler.mux.HandleFunc(fmt.Sprint("POST ", apiPath, "/images/generations"), handler.createImage)
+	handler.mux.HandleFunc(fmt.Sprint("GET ", apiPath, "/images/files/{name}"), handler.getImage)
+	handler.mux.HandleFunc(fmt.Sprint("POST ", apiPath, "/embeddings"), handler.createEmbedding)
+
+	return handler
+}
+
+// stop interrupts the requests that are waiting for the delay, so the server can be closed without waiting for them
+func (instance *Handler) stop() {
+	instance.stopOnce.Do(func() {
+		close(instance.stopped)
+	})
+}
+
+// NumberOfRequests returns the number of requests received by the stub, including the ones answered with failures
+func (instance *Handler) NumberOfRequests() int {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	return instance.numberOfRequests
+}
+
+func (instance *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	instance.mutex.Lock()
+	instance.numberOfRequests++
+	isRateLimited := instance.numberOfRequests <= instance.options.RateLimitedRequests
+	instance.mutex.Unlock()
+
+	if instance.options.Delay > 0 {
+		select {
+		case <-time.After(instance.options.Delay):
+		case <-request.Context().Done():
+			return
+		case <-instance.stopped:
+			return
+		}
+	}
+
+	if isRateLimited {
+		writer.Header().Set("retry-after-ms", strconv.FormatInt(instance.options.RetryAfter.Milliseconds(), 10))
+		writeError(writer, http.StatusTooManyRequests, "rate_limit_exceeded", "Rate limit simulated by the stub")
+		return
+	}
+
+	instance.mux.ServeHTTP(writer, request)
+}
+
+// Server runs the stub on a local address, which is used in OPENAI_API_ADDRESS
+type Server struct {
+	*Handler
+	server *httptest.Server
+}
+
+func NewServer(options Options) *Server {
+	handler := NewHandler(options)
+	return &Server{
+		Handler: handler,
+		server:  httptest.NewServer(handler),
+	}
+}
+
+func (instance *Server) Address() string {
+	return fmt.Sprint(instance.server.URL, apiPath)
+}
+
+func (instance *Server) Close() {
+	instance.stop()
+	instance.server.Close()
+}
+
+func writeJson(writer http.ResponseWriter, statusCode int, body interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	err := json.NewEncoder(writer).Encode(body)
+	if err != nil {
+		log.Error("Error writing the response of the OpenAI stub: ", err.Error())
+	}
+}
+
+func writeError(writer http.ResponseWriter, statusCode int, code, message string) {
+	writeJson(writer, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"message": message,
+			"type":    "stub_error",
+			"code":    code,
+		},
+	})
+}
@@ -0,0 +1,199 @@
+package openaitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image/png"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+	"vnc-summarizer/utils/validators"
+)
+
+func postJson(t *testing.T, url string, body interface{}, responseBody interface{}) int {
+	t.Helper()
+
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("json.Marshal(): %s", err.Error())
+	}
+
+	response, err := http.Post(url, "application/json", bytes.NewReader(requestBody))
+	if err != nil {
+		t.Fatalf("http.Post(): %s", err.Error())
+	}
+	defer response.Body.Close()
+
+	if responseBody != nil {
+		err = json.NewDecoder(response.Body).Decode(responseBody)
+		if err != nil {
+			t.Fatalf("json.Decode(): %s", err.Error())
+		}
+	}
+	return response.StatusCode
+}
+
+func TestServerReturnsImageAddresses(t *testing.T) {
+	stubServer := NewServer(Options{})
+	t.Cleanup(stubServer.Close)
+
+	var imageResponse struct {
+		Data []struct {
+			Url string `json:"url"`
+		} `json:"data"`
+	}
+	statusCode := postJson(t, fmt.Sprint(stubServer.Address(), "/images/generations"), map[string]interface{}{
+		"model":  "dall-e-2",
+		"prompt": "Ilustração do Congresso",
+		"size":   "512x256",
+	}, &imageResponse)
+	if statusCode != http.StatusOK || len(imageResponse.Data) != 1 {
+		t.Fatalf("The stub returned %d: %+v", statusCode, imageResponse)
+	}
+
+	response, err := http.Get(imageResponse.Data[0].Url)
+	if err != nil {
+		t.Fatalf("http.Get(): %s", err.Error())
+	}
+	defer response.Body.Close()
+
+	image, err := png.Decode(response.Body)
+	if err != nil {
+		t.Fatalf("png.Decode(): %s", err.Error())
+	}
+	if image.Bounds().Dx() != 512 || image.Bounds().Dy() != 256 {
+		t.Fatalf("The stub returned an image of %s", image.Bounds())
+	}
+}
+
+func TestServerReturnsDeterministicEmbeddings(t *testing.T) {
+	stubServer := NewServer(Options{})
+	t.Cleanup(stubServer.Close)
+
+	getEmbedding := func(input string) []float64 {
+		var embeddingResponse struct {
+			Data []struct {
+				Embedding []float64 `json:"embedding"`
+			} `json:"data"`
+		}
+		statusCode := postJson(t, fmt.Sprint(stubServer.Address(), "/embeddings"), map[string]interface{}{
+			"model": "text-embedding-3-small",
+			"input": input,
+		}, &embeddingResponse)
+		if statusCode != http.StatusOK || len(embeddingResponse.Data) != 1 {
+			t.Fatalf("The stub returned %d: %+v", statusCode, embeddingResponse)
+		}
+		return embeddingResponse.Data[0].Embedding
+	}
+
+	getSimilarity := func(firstEmbedding, secondEmbedding []float64) float64 {
+		var similarity float64
+		for index := range firstEmbedding {
+			similarity += firstEmbedding[index] * secondEmbedding[index]
+		}
+		return similarity
+	}
+
+	embedding := getEmbedding("Reforma tributária")
+	if similarity := getSimilarity(embedding, getEmbedding("Reforma tributária")); math.Abs(similarity-1) > 1e-9 {
+		t.Fatalf("Equal texts have similarity %f", similarity)
+	}
+	if similarity := getSimilarity(embedding, getEmbedding("Saúde pública")); similarity > 0.99 {
+		t.Fatalf("Different texts have similarity %f", similarity)
+	}
+}
+
+func TestGenerateValueFollowsTheSchema(t *testing.T) {
+	testCases := []struct {
+		name   string
+		schema map[string]interface{}
+	}{
+		{
+			name: "text limits",
+			schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"title":   map[string]interface{}{"type": "string", "maxLength": 10},
+					"summary": map[string]interface{}{"type": "string", "minLength": 500},
+				},
+				"required":             []interface{}{"title", "summary"},
+				"additionalProperties": false,
+			},
+		},
+		{
+			name: "lists and allowed values",
+			schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"topics": map[string]interface{}{
+						"type":     "array",
+						"minItems": 2,
+						"maxItems": 3,
+						"items":    map[string]interface{}{"type": "string", "enum": []interface{}{"Economia"}},
+					},
+					"approved": map[string]interface{}{"type": "boolean"},
+					"score":    map[string]interface{}{"type": "integer", "minimum": 3},
+				},
+				"required": []interface{}{"topics", "approved", "score"},
+			},
+		},
+		{
+			name: "nullable values",
+			schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"reason": map[string]interface{}{"type": []interface{}{"null", "string"}},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// The schema is converted as it is received in the requests
+			schemaAsJson, _ := json.Marshal(testCase.schema)
+			var schema map[string]interface{}
+			_ = json.Unmarshal(schemaAsJson, &schema)
+
+			value, _ := generateValue(schema, "resposta", getHash(testCase.name)).(map[string]interface{})
+			valueAsJson, _ := json.Marshal(value)
+			var parsedValue map[string]interface{}
+			_ = json.Unmarshal(valueAsJson, &parsedValue)
+
+			err := validators.ValidateJsonSchema(parsedValue, schema)
+			if err != nil {
+				t.Fatalf("The value %s does not follow the schema: %s", string(valueAsJson), err.Error())
+			}
+		})
+	}
+}
+
+func TestGetOptions(t *testing.T) {
+	t.Setenv("OPENAI_STUB_RATE_LIMITED_REQUESTS", "2")
+	t.Setenv("OPENAI_STUB_RETRY_AFTER", "100ms")
+	t.Setenv("OPENAI_STUB_DELAY", "1s")
+	t.Setenv("OPENAI_STUB_EMPTY_CHOICES", "true")
+
+	options, err := GetOptions()
+	if err != nil {
+		t.Fatalf("GetOptions(): %s", err.Error())
+	}
+
+	expectedOptions := Options{
+		RateLimitedRequests: 2,
+		RetryAfter:          100 * time.Millisecond,
+		Delay:               time.Second,
+		EmptyChoices:        true,
+	}
+	if options != expectedOptions {
+		t.Fatalf("Expected %+v, got %+v", expectedOptions, options)
+	}
+
+	t.Setenv("OPENAI_STUB_DELAY", "one second")
+	_, err = GetOptions()
+	if err == nil {
+		t.Fatal("GetOptions() accepted an invalid delay")
+	}
+}
@@ -2,6 +2,7 @@ package pdfcontentextractor
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/labstack/gommon/log"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"time"
+	"vnc-summarizer/utils/contexts"
 	"vnc-summarizer/utils/converters"
 	"vnc-summarizer/utils/requesters"
 )
@@ -19,7 +21,7 @@ func NewVncPdfContentExtractorApi() *VncPdfContentExtractor {
 	return &VncPdfContentExtractor{}
 }
 
-func (instance VncPdfContentExtractor) MakeRequest(pdfUrl string) (string, error) {
+func (instance VncPdfContentExtractor) MakeRequest(ctx context.Context, pdfUrl string) (string, error) {
 	log.Info("Starting communication with VNC PDF Content Extractor API: Extraction of PDF content available at ",
 		pdfUrl)
 
@@ -34,7 +36,7 @@ func (instance VncPdfContentExtractor) MakeRequest(pdfUrl string) (string, error
 		return "", err
 	}
 
-	request, err := http.NewRequest("POST", pdfContentExtractorAddress, bytes.NewBuffer(requestBody))
+	request, err := http.NewRequestWithContext(ctx, "POST", pdfContentExtractorAddress, bytes.NewBuffer(requestBody))
 	if err != nil {
 		log.Error("Error building the request for communication with VNC PDF Content Extractor API: ", err.Error())
 		return "", err
@@ -42,7 +44,7 @@ func (instance VncPdfContentExtractor) MakeRequest(pdfUrl string) (string, error
 	request.Header.Set("Content-Type", "application/json")
 
 	client := &http.Client{
-		Timeout: time.Minute,
+		Timeout: contexts.GetTimeout("VNC_PDF_CONTENT_EXTRACTOR_API_TIMEOUT", time.Minute),
 	}
 	response, err := client.Do(request)
 	if err != nil {
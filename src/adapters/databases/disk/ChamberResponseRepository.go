@@ -0,0 +1,110 @@
+package disk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/labstack/gommon/log"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+	"vnc-summarizer/core/domains/chamberresponse"
+	"vnc-summarizer/utils/converters"
+)
+
+type ChamberResponse struct {
+	directory string
+}
+
+type chamberResponseFile struct {
+	Url          string    `json:"url"`
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag"`
+	LastModified string    `json:"last_modified"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func NewChamberResponseRepository() *ChamberResponse {
+	return &ChamberResponse{
+		directory: os.Getenv("CHAMBER_API_CACHE_DIRECTORY"),
+	}
+}
+
+// GetChamberResponse returns the cached response even after its expiration, so it can be revalidated, and returns nil
+// when the response is not cached
+func (instance ChamberResponse) GetChamberResponse(ctx context.Context, key string) (
+	*chamberresponse.ChamberResponse, error) {
+	fileContent, err := os.ReadFile(instance.getFilePath(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		log.Errorf("Error reading the Chamber response %s from disk: %s", key, err.Error())
+		return nil, err
+	}
+
+	var responseFile chamberResponseFile
+	err = json.Unmarshal(fileContent, &responseFile)
+	if err != nil {
+		log.Errorf("Error interpreting the Chamber response %s stored on disk: %s", key, err.Error())
+		return nil, err
+	}
+
+	chamberResponse, err := chamberresponse.NewBuilder().
+		Url(responseFile.Url).
+		Body(responseFile.Body).
+		ETag(responseFile.ETag).
+		LastModified(responseFile.LastModified).
+		ExpiresAt(responseFile.ExpiresAt).
+		Build()
+	if err != nil {
+		log.Errorf("Error validating the Chamber response %s stored on disk: %s", key, err.Error())
+		return nil, err
+	}
+
+	return chamberResponse, nil
+}
+
+func (instance ChamberResponse) SaveChamberResponse(ctx context.Context, key string,
+	response chamberresponse.ChamberResponse) error {
+	fileContent, err := converters.ToJson(chamberResponseFile{
+		Url:          response.Url(),
+		Body:         response.Body(),
+		ETag:         response.ETag(),
+		LastModified: response.LastModified(),
+		ExpiresAt:    response.ExpiresAt(),
+	})
+	if err != nil {
+		log.Error("converters.ToJson(): ", err.Error())
+		return err
+	}
+
+	filePath := instance.getFilePath(key)
+	err = os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		log.Errorf("Error creating the directory of the Chamber response %s: %s", key, err.Error())
+		return err
+	}
+
+	// The response is written to a temporary file first so that concurrent readers never see a partial file
+	temporaryFilePath := filePath + ".tmp"
+	err = os.WriteFile(temporaryFilePath, fileContent, 0644)
+	if err != nil {
+		log.Errorf("Error writing the Chamber response %s to disk: %s", key, err.Error())
+		return err
+	}
+
+	err = os.Rename(temporaryFilePath, filePath)
+	if err != nil {
+		log.Errorf("Error writing the Chamber response %s to disk: %s", key, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (instance ChamberResponse) getFilePath(key string) string {
+	return filepath.Join(instance.directory, key[:2], key+".json")
+}
@@ -0,0 +1,88 @@
+package disk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/labstack/gommon/log"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+	"vnc-summarizer/utils/converters"
+)
+
+type LlmResponse struct {
+	directory string
+}
+
+type llmResponseFile struct {
+	Response  string    `json:"response"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewLlmResponseRepository() *LlmResponse {
+	return &LlmResponse{
+		directory: os.Getenv("LLM_CACHE_DIRECTORY"),
+	}
+}
+
+func (instance LlmResponse) GetLlmResponse(ctx context.Context, key string) (string, bool, error) {
+	fileContent, err := os.ReadFile(instance.getFilePath(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", false, nil
+		}
+
+		log.Errorf("Error reading the LLM response %s from disk: %s", key, err.Error())
+		return "", false, err
+	}
+
+	var responseFile llmResponseFile
+	err = json.Unmarshal(fileContent, &responseFile)
+	if err != nil {
+		log.Errorf("Error interpreting the LLM response %s stored on disk: %s", key, err.Error())
+		return "", false, err
+	}
+
+	if time.Now().After(responseFile.ExpiresAt) {
+		return "", false, nil
+	}
+
+	return responseFile.Response, true, nil
+}
+
+func (instance LlmResponse) SaveLlmResponse(ctx context.Context, key, response string, expiresAt time.Time) error {
+	fileContent, err := converters.ToJson(llmResponseFile{Response: response, ExpiresAt: expiresAt})
+	if err != nil {
+		log.Error("converters.ToJson(): ", err.Error())
+		return err
+	}
+
+	filePath := instance.getFilePath(key)
+	err = os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		log.Errorf("Error creating the directory of the LLM response %s: %s", key, err.Error())
+		return err
+	}
+
+	// The response is written to a temporary file first so that concurrent readers never see a partial file
+	temporaryFilePath := filePath + ".tmp"
+	err = os.WriteFile(temporaryFilePath, fileContent, 0644)
+	if err != nil {
+		log.Errorf("Error writing the LLM response %s to disk: %s", key, err.Error())
+		return err
+	}
+
+	err = os.Rename(temporaryFilePath, filePath)
+	if err != nil {
+		log.Errorf("Error writing the LLM response %s to disk: %s", key, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (instance LlmResponse) getFilePath(key string) string {
+	return filepath.Join(instance.directory, key[:2], key+".json")
+}
@@ -0,0 +1,70 @@
+package disk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+type ObjectStorage struct {
+	directory     string
+	publicBaseUrl string
+}
+
+// NewObjectStorage creates the storage that saves the objects in a local directory, whose files must be served at the
+// URL configured in STORAGE_PUBLIC_BASE_URL. When no URL is configured, the objects are addressed by file URLs.
+func NewObjectStorage() *ObjectStorage {
+	return &ObjectStorage{
+		directory:     os.Getenv("LOCAL_STORAGE_DIRECTORY"),
+		publicBaseUrl: os.Getenv("STORAGE_PUBLIC_BASE_URL"),
+	}
+}
+
+func (instance ObjectStorage) SaveObject(ctx context.Context, key string, content []byte) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", errors.New(fmt.Sprint("The object key must be a relative path inside the storage: ", key))
+	}
+
+	filePath := filepath.Join(instance.directory, filepath.FromSlash(key))
+	err := os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		log.Errorf("Error creating the directory of object %s: %s", key, err.Error())
+		return "", err
+	}
+
+	// The object is written to a temporary file first so that the file is never served partially
+	temporaryFilePath := filePath + ".tmp"
+	err = os.WriteFile(temporaryFilePath, content, 0644)
+	if err != nil {
+		log.Errorf("Error writing object %s to disk: %s", key, err.Error())
+		return "", err
+	}
+
+	err = os.Rename(temporaryFilePath, filePath)
+	if err != nil {
+		log.Errorf("Error writing object %s to disk: %s", key, err.Error())
+		return "", err
+	}
+
+	if instance.publicBaseUrl == "" {
+		absoluteFilePath, err := filepath.Abs(filePath)
+		if err != nil {
+			log.Errorf("Error building the path of object %s: %s", key, err.Error())
+			return "", err
+		}
+
+		return (&url.URL{Scheme: "file", Path: filepath.ToSlash(absoluteFilePath)}).String(), nil
+	}
+
+	objectUrl, err := url.JoinPath(instance.publicBaseUrl, key)
+	if err != nil {
+		log.Errorf("Error building the URL of object %s: %s", key, err.Error())
+		return "", err
+	}
+
+	return objectUrl, nil
+}
@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type LibraryImage struct {
+	Id             uuid.UUID       `db:"library_image_id"`
+	Url            string          `db:"library_image_url"`
+	Description    string          `db:"library_image_description"`
+	BlurHash       string          `db:"library_image_blur_hash"`
+	DominantColor  string          `db:"library_image_dominant_color"`
+	Variants       string          `db:"library_image_variants"`
+	SubjectTags    string          `db:"library_image_subject_tags"`
+	Embedding      pq.Float64Array `db:"library_image_embedding"`
+	EmbeddingModel string          `db:"library_image_embedding_model"`
+}
+
+// LibraryImageVariant is the variant of the library image saved in the JSON column of the variants
+type LibraryImageVariant struct {
+	Kind   string `json:"kind"`
+	Format string `json:"format"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Url    string `json:"url"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+import (
+	"time"
+)
+
+type SchemaMigration struct {
+	Version   int       `db:"schema_migration_version"`
+	Name      string    `db:"schema_migration_name"`
+	AppliedAt time.Time `db:"schema_migration_applied_at"`
+}
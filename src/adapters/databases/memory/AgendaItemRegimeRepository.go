@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"github.com/devlucassantos/vnc-domains/src/domains/agendaitemregime"
+	"github.com/google/uuid"
+)
+
+type AgendaItemRegime struct {
+	database *Database
+}
+
+func NewAgendaItemRegimeRepository(database *Database) *AgendaItemRegime {
+	return &AgendaItemRegime{
+		database: database,
+	}
+}
+
+func (instance AgendaItemRegime) CreateAgendaItemRegime(ctx context.Context,
+	agendaItemRegimeData agendaitemregime.AgendaItemRegime) (*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateAgendaItemRegime")
+	if err != nil {
+		return nil, err
+	}
+
+	agendaItemRegimeId := uuid.New()
+	registeredAgendaItemRegime, err := agendaItemRegimeData.NewUpdater().Id(agendaItemRegimeId).Build()
+	if err != nil {
+		return nil, err
+	}
+	insertRecord(ctx, instance.database, &instance.database.agendaItemRegimes, *registeredAgendaItemRegime,
+		(*agendaitemregime.AgendaItemRegime).Id)
+
+	return &agendaItemRegimeId, nil
+}
+
+func (instance AgendaItemRegime) GetAgendaItemRegimeByCode(_ context.Context, code int) (
+	*agendaitemregime.AgendaItemRegime, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetAgendaItemRegimeByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, agendaItemRegimeData := range instance.database.agendaItemRegimes {
+		if agendaItemRegimeData.Code() == code {
+			return &agendaItemRegimeData, nil
+		}
+	}
+
+	return nil, nil
+}
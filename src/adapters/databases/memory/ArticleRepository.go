@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/article"
+	"github.com/devlucassantos/vnc-domains/src/domains/articletype"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+type Article struct {
+	database *Database
+}
+
+func NewArticleRepository(database *Database) *Article {
+	return &Article{
+		database: database,
+	}
+}
+
+func (instance Article) GetArticlesByReferenceDate(_ context.Context, referenceDate time.Time) ([]article.Article,
+	error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetArticlesByReferenceDate")
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := instance.database.getArticles()
+	if err != nil {
+		return nil, err
+	}
+
+	formattedReferenceDate := referenceDate.Format(time.DateOnly)
+	articles = slices.DeleteFunc(articles, func(articleData article.Article) bool {
+		return articleData.ReferenceDateTime().Format(time.DateOnly) != formattedReferenceDate
+	})
+	slices.SortStableFunc(articles, func(firstArticle, secondArticle article.Article) int {
+		return firstArticle.ReferenceDateTime().Compare(secondArticle.ReferenceDateTime())
+	})
+
+	return articles, nil
+}
+
+func (instance Article) GetNewsletterArticlesByNewsletterId(_ context.Context, newsletterId uuid.UUID) (
+	[]article.Article, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetNewsletterArticlesByNewsletterId")
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(instance.database.newsletters, func(newsletterData newsletterRecord) bool {
+		return newsletterData.id == newsletterId
+	})
+	if index < 0 {
+		return nil, nil
+	}
+
+	articles, err := instance.database.getArticles()
+	if err != nil {
+		return nil, err
+	}
+
+	newsletterArticleIds := instance.database.newsletters[index].articleIds
+	articles = slices.DeleteFunc(articles, func(articleData article.Article) bool {
+		return !slices.Contains(newsletterArticleIds, articleData.Id())
+	})
+
+	return articles, nil
+}
+
+// getArticles returns the articles of the registered propositions, votes and events with the title, content and
+// specific type that PostgreSQL retrieves from them
+func (instance *Database) getArticles() ([]article.Article, error) {
+	var articles []article.Article
+	for _, propositionData := range instance.propositions {
+		propositionType := propositionData.Type()
+		articleSpecificType, err := articletype.NewBuilder().
+			Id(propositionType.Id()).
+			Description(propositionType.Description()).
+			Codes(propositionType.Codes()).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+
+		propositionArticle := propositionData.Article()
+		articleData, err := propositionArticle.NewUpdater().
+			Title(propositionData.Title()).
+			Content(propositionData.Content()).
+			SpecificType(*articleSpecificType).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+
+		articles = append(articles, *articleData)
+	}
+
+	for _, votingData := range instance.votes {
+		votingArticle := votingData.Article()
+		articleData, err := votingArticle.NewUpdater().
+			Title(fmt.Sprint("Votação ", votingData.Code())).
+			Content(votingData.Description()).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+
+		articles = append(articles, *articleData)
+	}
+
+	for _, eventData := range instance.events {
+		eventType := eventData.Type()
+		articleSpecificType, err := articletype.NewBuilder().
+			Id(eventType.Id()).
+			Description(eventType.Description()).
+			Codes(eventType.Codes()).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+
+		eventArticle := eventData.Article()
+		articleData, err := eventArticle.NewUpdater().
+			Title(eventData.Title()).
+			Content(eventData.Description()).
+			SpecificType(*articleSpecificType).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+
+		articles = append(articles, *articleData)
+	}
+
+	return articles, nil
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/articletype"
+)
+
+type ArticleType struct {
+	database *Database
+}
+
+// NewArticleTypeRepository creates the repository of the article types, registering the informed article types in the
+// database like the migrations of PostgreSQL register them
+func NewArticleTypeRepository(database *Database, articleTypes ...articletype.ArticleType) *ArticleType {
+	database.mutex.Lock()
+	defer database.mutex.Unlock()
+
+	database.articleTypes = append(database.articleTypes, articleTypes...)
+	return &ArticleType{
+		database: database,
+	}
+}
+
+func (instance ArticleType) GetArticleTypeByCode(_ context.Context, code string) (*articletype.ArticleType, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetArticleTypeByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, articleType := range instance.database.articleTypes {
+		if hasCode(articleType.Codes(), code) {
+			return &articleType, nil
+		}
+	}
+
+	return nil, errors.New(fmt.Sprintf("Article type %s not found in database", code))
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+type BackfillCheckpoint struct {
+	database *Database
+}
+
+func NewBackfillCheckpointRepository(database *Database) *BackfillCheckpoint {
+	return &BackfillCheckpoint{
+		database: database,
+	}
+}
+
+func (instance BackfillCheckpoint) GetCompletedDates(_ context.Context, dataType string, startDate,
+	endDate time.Time) ([]time.Time, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetCompletedDates")
+	if err != nil {
+		return nil, err
+	}
+
+	formattedStartDate := startDate.Format(time.DateOnly)
+	formattedEndDate := endDate.Format(time.DateOnly)
+
+	var completedDates []time.Time
+	for _, referenceDate := range instance.database.backfillCheckpoints[dataType] {
+		formattedReferenceDate := referenceDate.Format(time.DateOnly)
+		if formattedReferenceDate >= formattedStartDate && formattedReferenceDate <= formattedEndDate {
+			completedDates = append(completedDates, referenceDate)
+		}
+	}
+
+	return completedDates, nil
+}
+
+func (instance BackfillCheckpoint) SaveCheckpoint(ctx context.Context, dataType string, referenceDate time.Time) error {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("SaveCheckpoint")
+	if err != nil {
+		return err
+	}
+
+	referenceDate = getDate(referenceDate)
+	if slices.ContainsFunc(instance.database.backfillCheckpoints[dataType], referenceDate.Equal) {
+		return nil
+	}
+
+	instance.database.backfillCheckpoints[dataType] = append(instance.database.backfillCheckpoints[dataType],
+		referenceDate)
+	instance.database.registerUndoOperation(ctx, func() {
+		instance.database.backfillCheckpoints[dataType] = slices.DeleteFunc(
+			instance.database.backfillCheckpoints[dataType], referenceDate.Equal)
+	})
+
+	return nil
+}
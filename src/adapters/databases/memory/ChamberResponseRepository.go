@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"vnc-summarizer/core/domains/chamberresponse"
+)
+
+type ChamberResponse struct {
+	mutex     *sync.RWMutex
+	responses map[string]chamberresponse.ChamberResponse
+}
+
+// NewChamberResponseRepository creates an empty cache, which is shared by the clients of the Chamber of Deputies API
+// that receive it
+func NewChamberResponseRepository() *ChamberResponse {
+	return &ChamberResponse{
+		mutex:     &sync.RWMutex{},
+		responses: map[string]chamberresponse.ChamberResponse{},
+	}
+}
+
+// GetChamberResponse returns the cached response even after its expiration, so it can be revalidated, and returns nil
+// when the response is not cached
+func (instance ChamberResponse) GetChamberResponse(_ context.Context, key string) (
+	*chamberresponse.ChamberResponse, error) {
+	instance.mutex.RLock()
+	defer instance.mutex.RUnlock()
+
+	response, exists := instance.responses[key]
+	if !exists {
+		return nil, nil
+	}
+
+	return &response, nil
+}
+
+func (instance ChamberResponse) SaveChamberResponse(_ context.Context, key string,
+	response chamberresponse.ChamberResponse) error {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	instance.responses[key] = response
+	return nil
+}
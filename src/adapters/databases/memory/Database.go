@@ -0,0 +1,248 @@
+package memory
+
+import (
+	"context"
+	"github.com/devlucassantos/vnc-domains/src/domains/agendaitemregime"
+	"github.com/devlucassantos/vnc-domains/src/domains/article"
+	"github.com/devlucassantos/vnc-domains/src/domains/articletype"
+	"github.com/devlucassantos/vnc-domains/src/domains/deputy"
+	"github.com/devlucassantos/vnc-domains/src/domains/event"
+	"github.com/devlucassantos/vnc-domains/src/domains/eventsituation"
+	"github.com/devlucassantos/vnc-domains/src/domains/eventtype"
+	"github.com/devlucassantos/vnc-domains/src/domains/externalauthor"
+	"github.com/devlucassantos/vnc-domains/src/domains/externalauthortype"
+	"github.com/devlucassantos/vnc-domains/src/domains/legislativebody"
+	"github.com/devlucassantos/vnc-domains/src/domains/legislativebodytype"
+	"github.com/devlucassantos/vnc-domains/src/domains/party"
+	"github.com/devlucassantos/vnc-domains/src/domains/proposition"
+	"github.com/devlucassantos/vnc-domains/src/domains/propositiontype"
+	"github.com/devlucassantos/vnc-domains/src/domains/voting"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"vnc-summarizer/core/domains/generation"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/core/domains/migration"
+)
+
+// Database keeps the records of the in-memory repositories, which implement the PostgreSQL ports without a database
+// server so the services can be tested in isolation. The repositories created with the same database share its
+// records like they share the tables of PostgreSQL, and the records changed inside a unit of work that fails are
+// restored.
+type Database struct {
+	mutex                sync.Mutex
+	failures             map[string]error
+	agendaItemRegimes    []agendaitemregime.AgendaItemRegime
+	articleTypes         []articletype.ArticleType
+	backfillCheckpoints  map[string][]time.Time
+	deputies             []deputy.Deputy
+	events               []event.Event
+	eventSituations      []eventsituation.EventSituation
+	eventTypes           []eventtype.EventType
+	externalAuthors      []externalauthor.ExternalAuthor
+	externalAuthorTypes  []externalauthortype.ExternalAuthorType
+	generations          []articleGeneration
+	generationUsages     []generationUsageRecord
+	legislativeBodies    []legislativebody.LegislativeBody
+	legislativeBodyTypes []legislativebodytype.LegislativeBodyType
+	libraryImages        []libraryImageRecord
+	migrations           []migration.Migration
+	newsletters          []newsletterRecord
+	parties              []party.Party
+	processingAttempts   []processingAttemptRecord
+	processingItems      []processingItemRecord
+	processingRuns       []processingRunRecord
+	propositions         []proposition.Proposition
+	propositionTypes     []propositiontype.PropositionType
+	scheduledJobs        map[string]time.Time
+	votes                []voting.Voting
+}
+
+// articleGeneration is the data of the generation of an article, which PostgreSQL keeps in the tables of prompts,
+// usages, images and image reviews of the article
+type articleGeneration struct {
+	id         uuid.UUID
+	articleId  uuid.UUID
+	generation generation.Generation
+	createdAt  time.Time
+}
+
+// generationUsageRecord is the usage of a generation, which is registered as soon as the generation is made and is
+// linked to the article that uses it when the article is registered
+type generationUsageRecord struct {
+	id              uuid.UUID
+	articleId       uuid.UUID
+	generationUsage generationusage.GenerationUsage
+	createdAt       time.Time
+}
+
+type transactionKey struct{}
+
+// transaction keeps the operations that undo the changes made inside a unit of work, in the order they were made
+type transaction struct {
+	undoOperations []func()
+}
+
+func NewDatabase() *Database {
+	return &Database{
+		failures:            map[string]error{},
+		backfillCheckpoints: map[string][]time.Time{},
+		scheduledJobs:       map[string]time.Time{},
+	}
+}
+
+// FailOperation makes the repositories return the error whenever the operation, identified by the name of the method
+// of the port, is called. The operation stops failing when this method is called again with a nil error.
+func (instance *Database) FailOperation(operation string, err error) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	if err == nil {
+		delete(instance.failures, operation)
+		return
+	}
+
+	instance.failures[operation] = err
+}
+
+// GetArticleGenerations returns the generation data registered for the article, in the order it was registered
+func (instance *Database) GetArticleGenerations(articleId uuid.UUID) []generation.Generation {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	var generations []generation.Generation
+	for _, generationData := range instance.generations {
+		if generationData.articleId == articleId {
+			generations = append(generations, generationData.generation)
+		}
+	}
+
+	return generations
+}
+
+// getFailure returns the error configured for the operation. The lock of the database must be held by the caller, as
+// in all the unexported methods of the database.
+func (instance *Database) getFailure(operation string) error {
+	return instance.failures[operation]
+}
+
+// registerUndoOperation keeps the operation that undoes a change when the change is made inside a unit of work
+func (instance *Database) registerUndoOperation(ctx context.Context, undoOperation func()) {
+	currentTransaction, _ := ctx.Value(transactionKey{}).(*transaction)
+	if currentTransaction != nil {
+		currentTransaction.undoOperations = append(currentTransaction.undoOperations, undoOperation)
+	}
+}
+
+// rollback undoes the changes made inside the unit of work in the reverse order they were made
+func (instance *Database) rollback(currentTransaction *transaction) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	for index := len(currentTransaction.undoOperations) - 1; index >= 0; index-- {
+		currentTransaction.undoOperations[index]()
+	}
+}
+
+func (instance *Database) registerArticleGeneration(ctx context.Context, articleId uuid.UUID,
+	generationData generation.Generation) {
+	for _, usageData := range generationData.Usages() {
+		// The usages are registered when the generations are made, unless the registration failed at that moment
+		if usageData.Id() == uuid.Nil {
+			insertRecord(ctx, instance, &instance.generationUsages, generationUsageRecord{
+				id:              uuid.New(),
+				articleId:       articleId,
+				generationUsage: usageData,
+				createdAt:       time.Now(),
+			}, func(usageRecord *generationUsageRecord) uuid.UUID {
+				return usageRecord.id
+			})
+			continue
+		}
+
+		generationUsageId := usageData.Id()
+		index := slices.IndexFunc(instance.generationUsages, func(usageRecord generationUsageRecord) bool {
+			return usageRecord.id == generationUsageId
+		})
+		if index < 0 {
+			continue
+		}
+
+		usageRecord := instance.generationUsages[index]
+		usageRecord.articleId = articleId
+		replaceRecord(ctx, instance, &instance.generationUsages, index, usageRecord,
+			func(usageRecord *generationUsageRecord) uuid.UUID {
+				return usageRecord.id
+			})
+	}
+
+	insertRecord(ctx, instance, &instance.generations, articleGeneration{
+		id:         uuid.New(),
+		articleId:  articleId,
+		generation: generationData,
+		createdAt:  time.Now(),
+	}, func(generationData *articleGeneration) uuid.UUID {
+		return generationData.id
+	})
+}
+
+// createGenerationUsage registers the usage outside the unit of work, like PostgreSQL registers the usages outside the
+// transaction of the article
+func (instance *Database) createGenerationUsage(generationUsage generationusage.GenerationUsage) uuid.UUID {
+	generationUsageId := uuid.New()
+	instance.generationUsages = append(instance.generationUsages, generationUsageRecord{
+		id:              generationUsageId,
+		generationUsage: generationUsage,
+		createdAt:       time.Now(),
+	})
+
+	return generationUsageId
+}
+
+// newArticle returns the article of a new record with a new ID and the reference date and time, like PostgreSQL
+// registers the article of the propositions, votes, events and newsletters alongside them
+func newArticle(articleData article.Article, referenceDateTime time.Time) (*article.Article, error) {
+	return articleData.NewUpdater().Id(uuid.New()).ReferenceDateTime(referenceDateTime).Build()
+}
+
+// insertRecord adds the record to the table and, inside a unit of work, registers the operation that removes it
+func insertRecord[T any](ctx context.Context, database *Database, table *[]T, record T, getId func(*T) uuid.UUID) {
+	*table = append(*table, record)
+
+	recordId := getId(&record)
+	database.registerUndoOperation(ctx, func() {
+		*table = slices.DeleteFunc(*table, func(registeredRecord T) bool {
+			return getId(&registeredRecord) == recordId
+		})
+	})
+}
+
+// replaceRecord replaces the record in the index of the table and, inside a unit of work, registers the operation
+// that restores the previous record
+func replaceRecord[T any](ctx context.Context, database *Database, table *[]T, index int, record T,
+	getId func(*T) uuid.UUID) {
+	previousRecord := (*table)[index]
+	(*table)[index] = record
+
+	recordId := getId(&record)
+	database.registerUndoOperation(ctx, func() {
+		index := slices.IndexFunc(*table, func(registeredRecord T) bool {
+			return getId(&registeredRecord) == recordId
+		})
+		if index >= 0 {
+			(*table)[index] = previousRecord
+		}
+	})
+}
+
+// getDate returns the date of the date and time in its location, as it is truncated to the day by PostgreSQL
+func getDate(dateTime time.Time) time.Time {
+	return time.Date(dateTime.Year(), dateTime.Month(), dateTime.Day(), 0, 0, 0, 0, dateTime.Location())
+}
+
+// hasCode reports whether the code is one of the comma-separated codes of the reference data
+func hasCode(codes, code string) bool {
+	return slices.Contains(strings.Split(codes, ","), code)
+}
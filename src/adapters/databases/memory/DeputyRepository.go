@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/deputy"
+	"github.com/google/uuid"
+	"slices"
+)
+
+type Deputy struct {
+	database *Database
+}
+
+func NewDeputyRepository(database *Database) *Deputy {
+	return &Deputy{
+		database: database,
+	}
+}
+
+func (instance Deputy) CreateDeputy(ctx context.Context, deputyData deputy.Deputy) (*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateDeputy")
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.getDeputyIndex(deputyData.Code()) >= 0 {
+		return nil, errors.New(fmt.Sprintf("Deputy %d is already registered", deputyData.Code()))
+	}
+
+	deputyId := uuid.New()
+	registeredDeputy, err := deputyData.NewUpdater().Id(deputyId).Build()
+	if err != nil {
+		return nil, err
+	}
+	insertRecord(ctx, instance.database, &instance.database.deputies, *registeredDeputy, (*deputy.Deputy).Id)
+
+	return &deputyId, nil
+}
+
+func (instance Deputy) UpdateDeputy(ctx context.Context, deputyData deputy.Deputy) error {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("UpdateDeputy")
+	if err != nil {
+		return err
+	}
+
+	index := instance.getDeputyIndex(deputyData.Code())
+	if index < 0 {
+		return nil
+	}
+
+	updatedDeputy, err := deputyData.NewUpdater().Id(instance.database.deputies[index].Id()).Build()
+	if err != nil {
+		return err
+	}
+	replaceRecord(ctx, instance.database, &instance.database.deputies, index, *updatedDeputy, (*deputy.Deputy).Id)
+
+	return nil
+}
+
+func (instance Deputy) GetDeputyByCode(_ context.Context, code int) (*deputy.Deputy, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetDeputyByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	index := instance.getDeputyIndex(code)
+	if index < 0 {
+		return nil, nil
+	}
+
+	deputyData := instance.database.deputies[index]
+	return &deputyData, nil
+}
+
+func (instance Deputy) getDeputyIndex(code int) int {
+	return slices.IndexFunc(instance.database.deputies, func(deputyData deputy.Deputy) bool {
+		return deputyData.Code() == code
+	})
+}
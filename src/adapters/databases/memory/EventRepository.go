@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/event"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+	"vnc-summarizer/core/domains/generation"
+	"vnc-summarizer/utils/datetime"
+)
+
+type Event struct {
+	database *Database
+}
+
+func NewEventRepository(database *Database) *Event {
+	return &Event{
+		database: database,
+	}
+}
+
+func (instance Event) CreateEvent(ctx context.Context, eventData event.Event, generationData generation.Generation) (
+	*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateEvent")
+	if err != nil {
+		return nil, err
+	}
+
+	isRegistered := slices.ContainsFunc(instance.database.events, func(registeredEvent event.Event) bool {
+		return registeredEvent.Code() == eventData.Code()
+	})
+	if isRegistered {
+		return nil, errors.New(fmt.Sprintf("Event %d is already registered", eventData.Code()))
+	}
+
+	eventArticle := eventData.Article()
+	articleData, err := newArticle(eventArticle, eventArticle.ReferenceDateTime())
+	if err != nil {
+		return nil, err
+	}
+
+	eventId := uuid.New()
+	registeredEvent, err := eventData.NewUpdater().Id(eventId).Article(*articleData).Build()
+	if err != nil {
+		return nil, err
+	}
+	insertRecord(ctx, instance.database, &instance.database.events, *registeredEvent, (*event.Event).Id)
+	instance.database.registerArticleGeneration(ctx, articleData.Id(), generationData)
+
+	return &eventId, nil
+}
+
+// UpdateEvent changes the data of the registered event with the same code that PostgreSQL changes, keeping the
+// remaining data as it was registered
+func (instance Event) UpdateEvent(ctx context.Context, eventData event.Event) error {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("UpdateEvent")
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(instance.database.events, func(registeredEvent event.Event) bool {
+		return registeredEvent.Code() == eventData.Code()
+	})
+	if index < 0 {
+		return nil
+	}
+
+	registeredEvent := instance.database.events[index]
+	eventBuilder := eventData.NewUpdater()
+
+	if registeredEvent.Title() != "" {
+		eventBuilder.Title(registeredEvent.Title())
+	}
+
+	if registeredEvent.SpecificType() != "" {
+		eventBuilder.SpecificType(registeredEvent.SpecificType())
+	}
+
+	updatedEvent, err := eventBuilder.
+		Id(registeredEvent.Id()).
+		Type(registeredEvent.Type()).
+		LegislativeBodies(registeredEvent.LegislativeBodies()).
+		Requirements(registeredEvent.Requirements()).
+		AgendaItems(registeredEvent.AgendaItems()).
+		Article(registeredEvent.Article()).
+		Build()
+	if err != nil {
+		return err
+	}
+	replaceRecord(ctx, instance.database, &instance.database.events, index, *updatedEvent, (*event.Event).Id)
+
+	return nil
+}
+
+func (instance Event) GetEventsByCodes(_ context.Context, codes []int) ([]event.Event, error) {
+	return instance.getEvents("GetEventsByCodes", func(eventData event.Event, _ time.Time) bool {
+		return slices.Contains(codes, eventData.Code())
+	})
+}
+
+// GetEventsOccurringToday compares the dates of the events as they are returned by the Chamber of Deputies API, in
+// the time zone of Brazil, like PostgreSQL compares the dates and times registered without time zone
+func (instance Event) GetEventsOccurringToday(_ context.Context) ([]event.Event, error) {
+	return instance.getEvents("GetEventsOccurringToday", func(eventData event.Event, currentDate time.Time) bool {
+		endsAt := eventData.EndsAt()
+		if endsAt.IsZero() {
+			endsAt = eventData.StartsAt()
+		}
+
+		formattedCurrentDate := currentDate.Format(time.DateOnly)
+		return eventData.StartsAt().Format(time.DateOnly) <= formattedCurrentDate &&
+			endsAt.Format(time.DateOnly) >= formattedCurrentDate
+	})
+}
+
+func (instance Event) GetEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished(_ context.Context) ([]event.Event,
+	error) {
+	return instance.getEvents("GetEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished",
+		func(eventData event.Event, currentDate time.Time) bool {
+			eventSituation := eventData.Situation()
+			return !eventSituation.IsFinished() &&
+				eventData.StartsAt().Format(time.DateOnly) >= currentDate.AddDate(0, -3, 0).Format(time.DateOnly)
+		})
+}
+
+func (instance Event) getEvents(operation string, isSelected func(eventData event.Event, currentDate time.Time) bool) (
+	[]event.Event, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+	if err != nil {
+		return nil, err
+	}
+	currentDate := getDate(*currentDateTime)
+
+	var events []event.Event
+	for _, eventData := range instance.database.events {
+		if isSelected(eventData, currentDate) {
+			events = append(events, eventData)
+		}
+	}
+
+	slices.SortStableFunc(events, func(firstEvent, secondEvent event.Event) int {
+		return firstEvent.StartsAt().Compare(secondEvent.StartsAt())
+	})
+
+	return events, nil
+}
@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/eventsituation"
+)
+
+type EventSituation struct {
+	database *Database
+}
+
+// NewEventSituationRepository creates the repository of the event situations, registering the informed event
+// situations in the database like the migrations of PostgreSQL register them
+func NewEventSituationRepository(database *Database,
+	eventSituations ...eventsituation.EventSituation) *EventSituation {
+	database.mutex.Lock()
+	defer database.mutex.Unlock()
+
+	database.eventSituations = append(database.eventSituations, eventSituations...)
+	return &EventSituation{
+		database: database,
+	}
+}
+
+func (instance EventSituation) GetEventSituationByCodeOrDefaultSituation(_ context.Context, code string) (
+	*eventsituation.EventSituation, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetEventSituationByCodeOrDefaultSituation")
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultSituation *eventsituation.EventSituation
+	for _, eventSituation := range instance.database.eventSituations {
+		if hasCode(eventSituation.Codes(), code) {
+			return &eventSituation, nil
+		} else if defaultSituation == nil && hasCode(eventSituation.Codes(), "default_option") {
+			defaultSituation = &eventSituation
+		}
+	}
+
+	if defaultSituation == nil {
+		return nil, errors.New(fmt.Sprintf("Neither event situation %s nor the default event situation were found "+
+			"in database", code))
+	}
+
+	return defaultSituation, nil
+}
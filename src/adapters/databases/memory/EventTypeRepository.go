@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/eventtype"
+)
+
+type EventType struct {
+	database *Database
+}
+
+// NewEventTypeRepository creates the repository of the event types, registering the informed event types in the
+// database like the migrations of PostgreSQL register them
+func NewEventTypeRepository(database *Database, eventTypes ...eventtype.EventType) *EventType {
+	database.mutex.Lock()
+	defer database.mutex.Unlock()
+
+	database.eventTypes = append(database.eventTypes, eventTypes...)
+	return &EventType{
+		database: database,
+	}
+}
+
+func (instance EventType) GetEventTypeByCodeOrDefaultType(_ context.Context, code string) (*eventtype.EventType,
+	error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetEventTypeByCodeOrDefaultType")
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultType *eventtype.EventType
+	for _, eventType := range instance.database.eventTypes {
+		if hasCode(eventType.Codes(), code) {
+			return &eventType, nil
+		} else if defaultType == nil && hasCode(eventType.Codes(), "default_option") {
+			defaultType = &eventType
+		}
+	}
+
+	if defaultType == nil {
+		return nil, errors.New(fmt.Sprintf("Neither event type %s nor the default event type were found in "+
+			"database", code))
+	}
+
+	return defaultType, nil
+}
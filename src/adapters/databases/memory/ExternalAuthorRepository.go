@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"github.com/devlucassantos/vnc-domains/src/domains/externalauthor"
+	"github.com/google/uuid"
+)
+
+type ExternalAuthor struct {
+	database *Database
+}
+
+func NewExternalAuthorRepository(database *Database) *ExternalAuthor {
+	return &ExternalAuthor{
+		database: database,
+	}
+}
+
+func (instance ExternalAuthor) CreateExternalAuthor(ctx context.Context,
+	externalAuthorData externalauthor.ExternalAuthor) (*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateExternalAuthor")
+	if err != nil {
+		return nil, err
+	}
+
+	externalAuthorId := uuid.New()
+	registeredExternalAuthor, err := externalAuthorData.NewUpdater().Id(externalAuthorId).Build()
+	if err != nil {
+		return nil, err
+	}
+	insertRecord(ctx, instance.database, &instance.database.externalAuthors, *registeredExternalAuthor,
+		(*externalauthor.ExternalAuthor).Id)
+
+	return &externalAuthorId, nil
+}
+
+func (instance ExternalAuthor) GetExternalAuthorByNameAndTypeCode(_ context.Context, name string, typeCode int) (
+	*externalauthor.ExternalAuthor, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetExternalAuthorByNameAndTypeCode")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, externalAuthorData := range instance.database.externalAuthors {
+		externalAuthorType := externalAuthorData.Type()
+		if externalAuthorData.Name() == name && externalAuthorType.Code() == typeCode {
+			return &externalAuthorData, nil
+		}
+	}
+
+	return nil, nil
+}
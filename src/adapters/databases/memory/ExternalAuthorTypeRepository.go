@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"github.com/devlucassantos/vnc-domains/src/domains/externalauthortype"
+	"github.com/google/uuid"
+)
+
+type ExternalAuthorType struct {
+	database *Database
+}
+
+func NewExternalAuthorTypeRepository(database *Database) *ExternalAuthorType {
+	return &ExternalAuthorType{
+		database: database,
+	}
+}
+
+func (instance ExternalAuthorType) CreateExternalAuthorType(ctx context.Context,
+	externalAuthorTypeData externalauthortype.ExternalAuthorType) (*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateExternalAuthorType")
+	if err != nil {
+		return nil, err
+	}
+
+	externalAuthorTypeId := uuid.New()
+	registeredExternalAuthorType, err := externalAuthorTypeData.NewUpdater().Id(externalAuthorTypeId).Build()
+	if err != nil {
+		return nil, err
+	}
+	insertRecord(ctx, instance.database, &instance.database.externalAuthorTypes, *registeredExternalAuthorType,
+		(*externalauthortype.ExternalAuthorType).Id)
+
+	return &externalAuthorTypeId, nil
+}
+
+func (instance ExternalAuthorType) GetExternalAuthorTypeByCode(_ context.Context, code int) (
+	*externalauthortype.ExternalAuthorType, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetExternalAuthorTypeByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, externalAuthorTypeData := range instance.database.externalAuthorTypes {
+		if externalAuthorTypeData.Code() == code {
+			return &externalAuthorTypeData, nil
+		}
+	}
+
+	return nil, nil
+}
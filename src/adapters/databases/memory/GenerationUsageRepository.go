@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+	"vnc-summarizer/core/domains/generationusage"
+)
+
+type GenerationUsage struct {
+	database *Database
+}
+
+func NewGenerationUsageRepository(database *Database) *GenerationUsage {
+	return &GenerationUsage{
+		database: database,
+	}
+}
+
+func (instance GenerationUsage) CreateGenerationUsage(_ context.Context,
+	generationUsage generationusage.GenerationUsage) (*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateGenerationUsage")
+	if err != nil {
+		return nil, err
+	}
+
+	generationUsageId := instance.database.createGenerationUsage(generationUsage)
+	return &generationUsageId, nil
+}
+
+// GetEstimatedCostByDate returns the sum of the estimated cost of the usages registered on the date
+func (instance GenerationUsage) GetEstimatedCostByDate(_ context.Context, date time.Time) (float64, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetEstimatedCostByDate")
+	if err != nil {
+		return 0, err
+	}
+
+	var estimatedCost float64
+	formattedDate := date.Format(time.DateOnly)
+	for _, usageRecord := range instance.database.generationUsages {
+		if usageRecord.createdAt.In(date.Location()).Format(time.DateOnly) == formattedDate {
+			estimatedCost += usageRecord.generationUsage.EstimatedCost()
+		}
+	}
+
+	return estimatedCost, nil
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/legislativebody"
+	"github.com/google/uuid"
+	"slices"
+)
+
+type LegislativeBody struct {
+	database *Database
+}
+
+func NewLegislativeBodyRepository(database *Database) *LegislativeBody {
+	return &LegislativeBody{
+		database: database,
+	}
+}
+
+func (instance LegislativeBody) CreateLegislativeBody(ctx context.Context,
+	legislativeBodyData legislativebody.LegislativeBody) (*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateLegislativeBody")
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.getLegislativeBodyIndex(legislativeBodyData.Code()) >= 0 {
+		return nil, errors.New(fmt.Sprintf("Legislative body %d is already registered", legislativeBodyData.Code()))
+	}
+
+	legislativeBodyId := uuid.New()
+	registeredLegislativeBody, err := legislativeBodyData.NewUpdater().Id(legislativeBodyId).Build()
+	if err != nil {
+		return nil, err
+	}
+	insertRecord(ctx, instance.database, &instance.database.legislativeBodies, *registeredLegislativeBody,
+		(*legislativebody.LegislativeBody).Id)
+
+	return &legislativeBodyId, nil
+}
+
+func (instance LegislativeBody) GetLegislativeBodyByCode(_ context.Context, code int) (
+	*legislativebody.LegislativeBody, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetLegislativeBodyByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	index := instance.getLegislativeBodyIndex(code)
+	if index < 0 {
+		return nil, nil
+	}
+
+	legislativeBodyData := instance.database.legislativeBodies[index]
+	return &legislativeBodyData, nil
+}
+
+func (instance LegislativeBody) GetLegislativeBodiesByCodes(_ context.Context, codes []int) (
+	[]legislativebody.LegislativeBody, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetLegislativeBodiesByCodes")
+	if err != nil {
+		return nil, err
+	}
+
+	var legislativeBodies []legislativebody.LegislativeBody
+	for _, legislativeBodyData := range instance.database.legislativeBodies {
+		if slices.Contains(codes, legislativeBodyData.Code()) {
+			legislativeBodies = append(legislativeBodies, legislativeBodyData)
+		}
+	}
+
+	return legislativeBodies, nil
+}
+
+func (instance LegislativeBody) getLegislativeBodyIndex(code int) int {
+	return slices.IndexFunc(instance.database.legislativeBodies,
+		func(legislativeBodyData legislativebody.LegislativeBody) bool {
+			return legislativeBodyData.Code() == code
+		})
+}
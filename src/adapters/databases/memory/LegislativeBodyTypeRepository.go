@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"github.com/devlucassantos/vnc-domains/src/domains/legislativebodytype"
+	"github.com/google/uuid"
+)
+
+type LegislativeBodyType struct {
+	database *Database
+}
+
+func NewLegislativeBodyTypeRepository(database *Database) *LegislativeBodyType {
+	return &LegislativeBodyType{
+		database: database,
+	}
+}
+
+func (instance LegislativeBodyType) CreateLegislativeBodyType(ctx context.Context,
+	legislativeBodyTypeData legislativebodytype.LegislativeBodyType) (*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateLegislativeBodyType")
+	if err != nil {
+		return nil, err
+	}
+
+	legislativeBodyTypeId := uuid.New()
+	registeredLegislativeBodyType, err := legislativeBodyTypeData.NewUpdater().Id(legislativeBodyTypeId).Build()
+	if err != nil {
+		return nil, err
+	}
+	insertRecord(ctx, instance.database, &instance.database.legislativeBodyTypes, *registeredLegislativeBodyType,
+		(*legislativebodytype.LegislativeBodyType).Id)
+
+	return &legislativeBodyTypeId, nil
+}
+
+func (instance LegislativeBodyType) GetLegislativeBodyTypeByCode(_ context.Context, code int) (
+	*legislativebodytype.LegislativeBodyType, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetLegislativeBodyTypeByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, legislativeBodyTypeData := range instance.database.legislativeBodyTypes {
+		if legislativeBodyTypeData.Code() == code {
+			return &legislativeBodyTypeData, nil
+		}
+	}
+
+	return nil, nil
+}
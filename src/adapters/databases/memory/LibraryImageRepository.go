@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"slices"
+	"vnc-summarizer/core/domains/libraryimage"
+)
+
+// libraryImageRecord is the library image with the number of times it was used as the image of an article
+type libraryImageRecord struct {
+	libraryImage libraryimage.LibraryImage
+	numberOfUses int
+}
+
+type LibraryImage struct {
+	database *Database
+}
+
+func NewLibraryImageRepository(database *Database) *LibraryImage {
+	return &LibraryImage{
+		database: database,
+	}
+}
+
+func (instance LibraryImage) CreateLibraryImage(ctx context.Context, libraryImage libraryimage.LibraryImage) (
+	*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateLibraryImage")
+	if err != nil {
+		return nil, err
+	}
+
+	libraryImageId := uuid.New()
+	registeredLibraryImage, err := libraryImage.NewUpdater().Id(libraryImageId).Build()
+	if err != nil {
+		return nil, err
+	}
+	insertRecord(ctx, instance.database, &instance.database.libraryImages, libraryImageRecord{
+		libraryImage: *registeredLibraryImage,
+	}, getLibraryImageRecordId)
+
+	return &libraryImageId, nil
+}
+
+// GetLibraryImagesBySubjectTags returns the library images generated with the embedding model that share at least one
+// subject tag with the informed tags, from the most recent to the oldest
+func (instance LibraryImage) GetLibraryImagesBySubjectTags(_ context.Context, subjectTags []string,
+	embeddingModel string) ([]libraryimage.LibraryImage, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetLibraryImagesBySubjectTags")
+	if err != nil {
+		return nil, err
+	}
+
+	var libraryImages []libraryimage.LibraryImage
+	for _, libraryImageData := range slices.Backward(instance.database.libraryImages) {
+		libraryImage := libraryImageData.libraryImage
+		if libraryImage.EmbeddingModel() != embeddingModel {
+			continue
+		}
+
+		hasSubjectTag := slices.ContainsFunc(libraryImage.SubjectTags(), func(subjectTag string) bool {
+			return slices.Contains(subjectTags, subjectTag)
+		})
+		if hasSubjectTag {
+			libraryImages = append(libraryImages, libraryImage)
+		}
+	}
+
+	return libraryImages, nil
+}
+
+func (instance LibraryImage) RegisterLibraryImageUse(ctx context.Context, id uuid.UUID) error {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("RegisterLibraryImageUse")
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(instance.database.libraryImages, func(libraryImageData libraryImageRecord) bool {
+		return libraryImageData.libraryImage.Id() == id
+	})
+	if index < 0 {
+		return nil
+	}
+
+	libraryImageData := instance.database.libraryImages[index]
+	libraryImageData.numberOfUses++
+	replaceRecord(ctx, instance.database, &instance.database.libraryImages, index, libraryImageData,
+		getLibraryImageRecordId)
+
+	return nil
+}
+
+func getLibraryImageRecordId(libraryImageData *libraryImageRecord) uuid.UUID {
+	return libraryImageData.libraryImage.Id()
+}
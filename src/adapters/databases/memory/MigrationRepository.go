@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+	"vnc-summarizer/core/domains/migration"
+)
+
+type Migration struct {
+	database   *Database
+	migrations []migration.Migration
+}
+
+// NewMigrationRepository creates the repository of the informed migrations, which take the place of the migration
+// scripts embedded in the PostgreSQL repository
+func NewMigrationRepository(database *Database, migrations ...migration.Migration) *Migration {
+	migrations = slices.Clone(migrations)
+	slices.SortFunc(migrations, compareMigrations)
+
+	return &Migration{
+		database:   database,
+		migrations: migrations,
+	}
+}
+
+func (instance Migration) MigrateUp(_ context.Context) ([]migration.Migration, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("MigrateUp")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrationsApplied []migration.Migration
+	for _, migrationData := range instance.migrations {
+		if instance.database.getAppliedMigrationIndex(migrationData.Version()) >= 0 {
+			continue
+		}
+
+		migrationApplied, err := migration.NewBuilder().
+			Version(migrationData.Version()).
+			Name(migrationData.Name()).
+			AppliedAt(time.Now()).
+			Build()
+		if err != nil {
+			return migrationsApplied, err
+		}
+
+		instance.database.migrations = append(instance.database.migrations, *migrationApplied)
+		migrationsApplied = append(migrationsApplied, *migrationApplied)
+	}
+
+	return migrationsApplied, nil
+}
+
+func (instance Migration) MigrateDown(_ context.Context, numberOfMigrations int, _ bool) ([]migration.Migration,
+	error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("MigrateDown")
+	if err != nil {
+		return nil, err
+	}
+
+	appliedMigrations := slices.Clone(instance.database.migrations)
+	slices.SortFunc(appliedMigrations, compareMigrations)
+	slices.Reverse(appliedMigrations)
+
+	var migrationsReverted []migration.Migration
+	for _, migrationData := range appliedMigrations[:min(numberOfMigrations, len(appliedMigrations))] {
+		index := instance.database.getAppliedMigrationIndex(migrationData.Version())
+		instance.database.migrations = slices.Delete(instance.database.migrations, index, index+1)
+
+		migrationReverted, err := migration.NewBuilder().
+			Version(migrationData.Version()).
+			Name(migrationData.Name()).
+			Build()
+		if err != nil {
+			return migrationsReverted, err
+		}
+		migrationsReverted = append(migrationsReverted, *migrationReverted)
+	}
+
+	return migrationsReverted, nil
+}
+
+func (instance Migration) GetMigrations(_ context.Context) ([]migration.Migration, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetMigrations")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := slices.Clone(instance.database.migrations)
+	for _, migrationData := range instance.migrations {
+		if instance.database.getAppliedMigrationIndex(migrationData.Version()) < 0 {
+			migrations = append(migrations, migrationData)
+		}
+	}
+	slices.SortFunc(migrations, compareMigrations)
+
+	return migrations, nil
+}
+
+func (instance *Database) getAppliedMigrationIndex(version int) int {
+	return slices.IndexFunc(instance.migrations, func(migrationData migration.Migration) bool {
+		return migrationData.Version() == version
+	})
+}
+
+func compareMigrations(firstMigration, secondMigration migration.Migration) int {
+	return firstMigration.Version() - secondMigration.Version()
+}
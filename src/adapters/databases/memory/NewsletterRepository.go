@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/article"
+	"github.com/devlucassantos/vnc-domains/src/domains/newsletter"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+	"vnc-summarizer/core/domains/generation"
+	"vnc-summarizer/utils/datetime"
+)
+
+// newsletterRecord is the newsletter with the IDs of the articles it is made of, which PostgreSQL keeps in the
+// newsletter_article table
+type newsletterRecord struct {
+	id         uuid.UUID
+	newsletter newsletter.Newsletter
+	articleIds []uuid.UUID
+}
+
+type Newsletter struct {
+	database *Database
+}
+
+func NewNewsletterRepository(database *Database) *Newsletter {
+	return &Newsletter{
+		database: database,
+	}
+}
+
+func (instance Newsletter) CreateNewsletter(ctx context.Context, newsletterData newsletter.Newsletter,
+	generationData generation.Generation) (*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateNewsletter")
+	if err != nil {
+		return nil, err
+	}
+
+	formattedReferenceDate := newsletterData.ReferenceDate().Format(time.DateOnly)
+	isRegistered := slices.ContainsFunc(instance.database.newsletters,
+		func(registeredNewsletter newsletterRecord) bool {
+			return registeredNewsletter.newsletter.ReferenceDate().Format(time.DateOnly) == formattedReferenceDate
+		})
+	if isRegistered {
+		return nil, errors.New(fmt.Sprintf("The newsletter of %s is already registered", formattedReferenceDate))
+	}
+
+	referenceDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+	if err != nil {
+		return nil, err
+	}
+
+	articleData, err := newArticle(newsletterData.Article(), *referenceDateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var articleIds []uuid.UUID
+	for _, newsletterArticle := range newsletterData.Articles() {
+		articleIds = append(articleIds, newsletterArticle.Id())
+	}
+
+	newsletterId := uuid.New()
+	registeredNewsletter, err := newsletterData.NewUpdater().Id(newsletterId).Article(*articleData).Build()
+	if err != nil {
+		return nil, err
+	}
+	insertRecord(ctx, instance.database, &instance.database.newsletters, newsletterRecord{
+		id:         newsletterId,
+		newsletter: *registeredNewsletter,
+		articleIds: articleIds,
+	}, getNewsletterRecordId)
+	instance.database.registerArticleGeneration(ctx, articleData.Id(), generationData)
+
+	return &newsletterId, nil
+}
+
+func (instance Newsletter) UpdateNewsletter(ctx context.Context, newsletterData newsletter.Newsletter,
+	newArticles []article.Article, generationData generation.Generation) error {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("UpdateNewsletter")
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(instance.database.newsletters, func(registeredNewsletter newsletterRecord) bool {
+		return registeredNewsletter.id == newsletterData.Id()
+	})
+	if index < 0 {
+		return errors.New(fmt.Sprintf("Newsletter %s is not registered", newsletterData.Id()))
+	}
+
+	referenceDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+	if err != nil {
+		return err
+	}
+
+	registeredNewsletter := instance.database.newsletters[index]
+	newsletterArticle := registeredNewsletter.newsletter.Article()
+	articleData, err := newsletterArticle.NewUpdater().ReferenceDateTime(*referenceDateTime).Build()
+	if err != nil {
+		return err
+	}
+
+	updatedNewsletter, err := registeredNewsletter.newsletter.NewUpdater().
+		Description(newsletterData.Description()).
+		Article(*articleData).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	articleIds := slices.Clone(registeredNewsletter.articleIds)
+	for _, newArticle := range newArticles {
+		articleIds = append(articleIds, newArticle.Id())
+	}
+
+	replaceRecord(ctx, instance.database, &instance.database.newsletters, index, newsletterRecord{
+		id:         registeredNewsletter.id,
+		newsletter: *updatedNewsletter,
+		articleIds: articleIds,
+	}, getNewsletterRecordId)
+	instance.database.registerArticleGeneration(ctx, articleData.Id(), generationData)
+
+	return nil
+}
+
+func (instance Newsletter) GetNewsletterByReferenceDate(_ context.Context, referenceDate time.Time) (
+	*newsletter.Newsletter, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetNewsletterByReferenceDate")
+	if err != nil {
+		return nil, err
+	}
+
+	formattedReferenceDate := referenceDate.Format(time.DateOnly)
+	for _, newsletterData := range instance.database.newsletters {
+		if newsletterData.newsletter.ReferenceDate().Format(time.DateOnly) == formattedReferenceDate {
+			registeredNewsletter := newsletterData.newsletter
+			return &registeredNewsletter, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func getNewsletterRecordId(newsletterData *newsletterRecord) uuid.UUID {
+	return newsletterData.id
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/party"
+	"github.com/google/uuid"
+	"slices"
+)
+
+type Party struct {
+	database *Database
+}
+
+func NewPartyRepository(database *Database) *Party {
+	return &Party{
+		database: database,
+	}
+}
+
+func (instance Party) CreateParty(ctx context.Context, partyData party.Party) (*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateParty")
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.getPartyIndex(partyData.Code()) >= 0 {
+		return nil, errors.New(fmt.Sprintf("Party %d is already registered", partyData.Code()))
+	}
+
+	partyId := uuid.New()
+	registeredParty, err := partyData.NewUpdater().Id(partyId).Build()
+	if err != nil {
+		return nil, err
+	}
+	insertRecord(ctx, instance.database, &instance.database.parties, *registeredParty, (*party.Party).Id)
+
+	return &partyId, nil
+}
+
+func (instance Party) UpdateParty(ctx context.Context, partyData party.Party) error {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("UpdateParty")
+	if err != nil {
+		return err
+	}
+
+	index := instance.getPartyIndex(partyData.Code())
+	if index < 0 {
+		return nil
+	}
+
+	updatedParty, err := partyData.NewUpdater().Id(instance.database.parties[index].Id()).Build()
+	if err != nil {
+		return err
+	}
+	replaceRecord(ctx, instance.database, &instance.database.parties, index, *updatedParty, (*party.Party).Id)
+
+	return nil
+}
+
+func (instance Party) GetPartyByCode(_ context.Context, code int) (*party.Party, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetPartyByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	index := instance.getPartyIndex(code)
+	if index < 0 {
+		return nil, nil
+	}
+
+	partyData := instance.database.parties[index]
+	return &partyData, nil
+}
+
+func (instance Party) getPartyIndex(code int) int {
+	return slices.IndexFunc(instance.database.parties, func(partyData party.Party) bool {
+		return partyData.Code() == code
+	})
+}
@@ -0,0 +1,207 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// processingItemRecord is the state of the processing of an item, identified by its type and code
+type processingItemRecord struct {
+	id               uuid.UUID
+	runId            uuid.UUID
+	itemType         string
+	code             string
+	status           string
+	numberOfAttempts int
+	errorMessage     string
+	lastFinishedAt   time.Time
+}
+
+// processingAttemptRecord is an attempt of processing an item, which is kept after the item is processed again
+type processingAttemptRecord struct {
+	id               uuid.UUID
+	processingItemId uuid.UUID
+	runId            uuid.UUID
+	attempt          int
+	status           string
+	errorMessage     string
+	finishedAt       time.Time
+}
+
+type ProcessingItem struct {
+	database *Database
+}
+
+func NewProcessingItemRepository(database *Database) *ProcessingItem {
+	return &ProcessingItem{
+		database: database,
+	}
+}
+
+func (instance ProcessingItem) StartProcessingItem(ctx context.Context, runId uuid.UUID, itemType, code string) (int,
+	error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("StartProcessingItem")
+	if err != nil {
+		return 0, err
+	}
+
+	index := instance.database.getProcessingItemIndex(itemType, code)
+	if index < 0 {
+		processingItemData := processingItemRecord{
+			id:               uuid.New(),
+			runId:            runId,
+			itemType:         itemType,
+			code:             code,
+			status:           "running",
+			numberOfAttempts: 1,
+		}
+		insertRecord(ctx, instance.database, &instance.database.processingItems, processingItemData,
+			getProcessingItemRecordId)
+		instance.database.startProcessingAttempt(ctx, processingItemData)
+		return 1, nil
+	}
+
+	processingItemData := instance.database.processingItems[index]
+	processingItemData.runId = runId
+	processingItemData.status = "running"
+	processingItemData.numberOfAttempts++
+	processingItemData.errorMessage = ""
+	processingItemData.lastFinishedAt = time.Time{}
+	replaceRecord(ctx, instance.database, &instance.database.processingItems, index, processingItemData,
+		getProcessingItemRecordId)
+	instance.database.startProcessingAttempt(ctx, processingItemData)
+
+	return processingItemData.numberOfAttempts, nil
+}
+
+func (instance ProcessingItem) FinishProcessingItem(ctx context.Context, itemType, code, status,
+	errorMessage string) error {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("FinishProcessingItem")
+	if err != nil {
+		return err
+	}
+
+	index := instance.database.getProcessingItemIndex(itemType, code)
+	if index < 0 {
+		return nil
+	}
+
+	processingItemData := instance.database.processingItems[index]
+	processingItemData.status = status
+	processingItemData.errorMessage = errorMessage
+	processingItemData.lastFinishedAt = time.Now()
+	replaceRecord(ctx, instance.database, &instance.database.processingItems, index, processingItemData,
+		getProcessingItemRecordId)
+
+	attemptIndex := slices.IndexFunc(instance.database.processingAttempts,
+		func(processingAttemptData processingAttemptRecord) bool {
+			return processingAttemptData.processingItemId == processingItemData.id &&
+				processingAttemptData.attempt == processingItemData.numberOfAttempts
+		})
+	if attemptIndex >= 0 {
+		processingAttemptData := instance.database.processingAttempts[attemptIndex]
+		processingAttemptData.status = status
+		processingAttemptData.errorMessage = errorMessage
+		processingAttemptData.finishedAt = processingItemData.lastFinishedAt
+		replaceRecord(ctx, instance.database, &instance.database.processingAttempts, attemptIndex,
+			processingAttemptData, getProcessingAttemptRecordId)
+	}
+
+	return nil
+}
+
+func (instance ProcessingItem) GetCodesOfTheItemsToRetry(_ context.Context, itemType string,
+	maximumNumberOfAttempts int) ([]string, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetCodesOfTheItemsToRetry")
+	if err != nil {
+		return nil, err
+	}
+
+	var failedItems []processingItemRecord
+	for _, processingItemData := range instance.database.processingItems {
+		if processingItemData.itemType == itemType && processingItemData.status == "failed" &&
+			processingItemData.numberOfAttempts < maximumNumberOfAttempts {
+			failedItems = append(failedItems, processingItemData)
+		}
+	}
+
+	slices.SortStableFunc(failedItems, func(firstItem, secondItem processingItemRecord) int {
+		return firstItem.lastFinishedAt.Compare(secondItem.lastFinishedAt)
+	})
+
+	var codes []string
+	for _, processingItemData := range failedItems {
+		codes = append(codes, processingItemData.code)
+	}
+
+	return codes, nil
+}
+
+// GetProcessingItemStatus returns the status and the number of attempts of the processing of the item, or an empty
+// status if the processing of the item was never started
+func (instance *Database) GetProcessingItemStatus(itemType, code string) (string, int) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	index := instance.getProcessingItemIndex(itemType, code)
+	if index < 0 {
+		return "", 0
+	}
+
+	return instance.processingItems[index].status, instance.processingItems[index].numberOfAttempts
+}
+
+// GetProcessingAttemptStatuses returns the status of each attempt of processing the item, in the order they were made
+func (instance *Database) GetProcessingAttemptStatuses(itemType, code string) []string {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	index := instance.getProcessingItemIndex(itemType, code)
+	if index < 0 {
+		return nil
+	}
+
+	var statuses []string
+	for _, processingAttemptData := range instance.processingAttempts {
+		if processingAttemptData.processingItemId == instance.processingItems[index].id {
+			statuses = append(statuses, processingAttemptData.status)
+		}
+	}
+
+	return statuses
+}
+
+func (instance *Database) startProcessingAttempt(ctx context.Context, processingItemData processingItemRecord) {
+	insertRecord(ctx, instance, &instance.processingAttempts, processingAttemptRecord{
+		id:               uuid.New(),
+		processingItemId: processingItemData.id,
+		runId:            processingItemData.runId,
+		attempt:          processingItemData.numberOfAttempts,
+		status:           "running",
+	}, getProcessingAttemptRecordId)
+}
+
+func (instance *Database) getProcessingItemIndex(itemType, code string) int {
+	return slices.IndexFunc(instance.processingItems, func(processingItemData processingItemRecord) bool {
+		return processingItemData.itemType == itemType && processingItemData.code == code
+	})
+}
+
+func getProcessingItemRecordId(processingItemData *processingItemRecord) uuid.UUID {
+	return processingItemData.id
+}
+
+func getProcessingAttemptRecordId(processingAttemptData *processingAttemptRecord) uuid.UUID {
+	return processingAttemptData.id
+}
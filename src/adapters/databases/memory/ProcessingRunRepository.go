@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// processingRunRecord is the execution of a job, which is failed if any of its items failed or was abandoned
+type processingRunRecord struct {
+	id            uuid.UUID
+	jobName       string
+	status        string
+	numberOfItems int
+	finishedAt    time.Time
+}
+
+type ProcessingRun struct {
+	database *Database
+}
+
+func NewProcessingRunRepository(database *Database) *ProcessingRun {
+	return &ProcessingRun{
+		database: database,
+	}
+}
+
+func (instance ProcessingRun) CreateProcessingRun(ctx context.Context, jobName string) (*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateProcessingRun")
+	if err != nil {
+		return nil, err
+	}
+
+	processingRunId := uuid.New()
+	insertRecord(ctx, instance.database, &instance.database.processingRuns, processingRunRecord{
+		id:      processingRunId,
+		jobName: jobName,
+		status:  "running",
+	}, getProcessingRunRecordId)
+
+	return &processingRunId, nil
+}
+
+func (instance ProcessingRun) FinishProcessingRun(ctx context.Context, id uuid.UUID) error {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("FinishProcessingRun")
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(instance.database.processingRuns, func(processingRunData processingRunRecord) bool {
+		return processingRunData.id == id
+	})
+	if index < 0 {
+		return nil
+	}
+
+	processingRunData := instance.database.processingRuns[index]
+	processingRunData.status = "succeeded"
+	processingRunData.numberOfItems = 0
+	for _, processingItemData := range instance.database.processingItems {
+		if processingItemData.runId != id {
+			continue
+		}
+
+		processingRunData.numberOfItems++
+		if processingItemData.status == "failed" || processingItemData.status == "abandoned" {
+			processingRunData.status = "failed"
+		}
+	}
+	processingRunData.finishedAt = time.Now()
+	replaceRecord(ctx, instance.database, &instance.database.processingRuns, index, processingRunData,
+		getProcessingRunRecordId)
+
+	return nil
+}
+
+func getProcessingRunRecordId(processingRunData *processingRunRecord) uuid.UUID {
+	return processingRunData.id
+}
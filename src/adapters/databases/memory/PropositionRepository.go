@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/proposition"
+	"github.com/google/uuid"
+	"slices"
+	"vnc-summarizer/core/domains/generation"
+)
+
+type Proposition struct {
+	database *Database
+}
+
+func NewPropositionRepository(database *Database) *Proposition {
+	return &Proposition{
+		database: database,
+	}
+}
+
+func (instance Proposition) CreateProposition(ctx context.Context, propositionData proposition.Proposition,
+	generationData generation.Generation) (*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateProposition")
+	if err != nil {
+		return nil, err
+	}
+
+	isRegistered := slices.ContainsFunc(instance.database.propositions,
+		func(registeredProposition proposition.Proposition) bool {
+			return registeredProposition.Code() == propositionData.Code()
+		})
+	if isRegistered {
+		return nil, errors.New(fmt.Sprintf("Proposition %d is already registered", propositionData.Code()))
+	}
+
+	propositionArticle := propositionData.Article()
+	articleData, err := newArticle(propositionArticle, propositionArticle.ReferenceDateTime())
+	if err != nil {
+		return nil, err
+	}
+
+	propositionId := uuid.New()
+	registeredProposition, err := propositionData.NewUpdater().Id(propositionId).Article(*articleData).Build()
+	if err != nil {
+		return nil, err
+	}
+	insertRecord(ctx, instance.database, &instance.database.propositions, *registeredProposition,
+		(*proposition.Proposition).Id)
+	instance.database.registerArticleGeneration(ctx, articleData.Id(), generationData)
+
+	return &propositionId, nil
+}
+
+func (instance Proposition) GetPropositionsByCodes(_ context.Context, codes []int) ([]proposition.Proposition,
+	error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetPropositionsByCodes")
+	if err != nil {
+		return nil, err
+	}
+
+	var propositions []proposition.Proposition
+	for _, propositionData := range instance.database.propositions {
+		if slices.Contains(codes, propositionData.Code()) {
+			propositions = append(propositions, propositionData)
+		}
+	}
+
+	return propositions, nil
+}
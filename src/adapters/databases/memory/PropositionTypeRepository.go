@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/propositiontype"
+)
+
+type PropositionType struct {
+	database *Database
+}
+
+// NewPropositionTypeRepository creates the repository of the proposition types, registering the informed proposition
+// types in the database like the migrations of PostgreSQL register them
+func NewPropositionTypeRepository(database *Database,
+	propositionTypes ...propositiontype.PropositionType) *PropositionType {
+	database.mutex.Lock()
+	defer database.mutex.Unlock()
+
+	database.propositionTypes = append(database.propositionTypes, propositionTypes...)
+	return &PropositionType{
+		database: database,
+	}
+}
+
+func (instance PropositionType) GetPropositionTypeByCodeOrDefaultType(_ context.Context, code string) (
+	*propositiontype.PropositionType, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetPropositionTypeByCodeOrDefaultType")
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultType *propositiontype.PropositionType
+	for _, propositionType := range instance.database.propositionTypes {
+		if hasCode(propositionType.Codes(), code) {
+			return &propositionType, nil
+		} else if defaultType == nil && hasCode(propositionType.Codes(), "default_option") {
+			defaultType = &propositionType
+		}
+	}
+
+	if defaultType == nil {
+		return nil, errors.New(fmt.Sprintf("Neither proposition type %s nor the default proposition type were found "+
+			"in database", code))
+	}
+
+	return defaultType, nil
+}
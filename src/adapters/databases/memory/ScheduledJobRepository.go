@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"time"
+)
+
+type ScheduledJob struct {
+	database *Database
+}
+
+func NewScheduledJobRepository(database *Database) *ScheduledJob {
+	return &ScheduledJob{
+		database: database,
+	}
+}
+
+func (instance ScheduledJob) GetLastExecutionTime(_ context.Context, jobName string) (*time.Time, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetLastExecutionTime")
+	if err != nil {
+		return nil, err
+	}
+
+	lastExecutionTime, exists := instance.database.scheduledJobs[jobName]
+	if !exists {
+		return nil, nil
+	}
+
+	return &lastExecutionTime, nil
+}
+
+func (instance ScheduledJob) SaveLastExecutionTime(ctx context.Context, jobName string, executedAt time.Time) error {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("SaveLastExecutionTime")
+	if err != nil {
+		return err
+	}
+
+	previousExecutionTime, existed := instance.database.scheduledJobs[jobName]
+	instance.database.scheduledJobs[jobName] = executedAt
+	instance.database.registerUndoOperation(ctx, func() {
+		if existed {
+			instance.database.scheduledJobs[jobName] = previousExecutionTime
+		} else {
+			delete(instance.database.scheduledJobs, jobName)
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package memory
+
+import "context"
+
+type UnitOfWork struct {
+	database *Database
+}
+
+func NewUnitOfWork(database *Database) *UnitOfWork {
+	return &UnitOfWork{
+		database: database,
+	}
+}
+
+// Execute runs the operation and, if it fails, undoes the changes made by the repositories called with the context
+// received by the operation. Units of work started inside the operation take part in the same unit of work.
+func (instance UnitOfWork) Execute(ctx context.Context, operation func(ctx context.Context) error) error {
+	if ctx.Value(transactionKey{}) != nil {
+		return operation(ctx)
+	}
+
+	currentTransaction := &transaction{}
+	err := operation(context.WithValue(ctx, transactionKey{}, currentTransaction))
+	if err != nil {
+		instance.database.rollback(currentTransaction)
+		return err
+	}
+
+	return nil
+}
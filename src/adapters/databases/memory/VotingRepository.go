@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/voting"
+	"github.com/google/uuid"
+	"slices"
+	"vnc-summarizer/core/domains/generation"
+	"vnc-summarizer/utils/datetime"
+)
+
+type Voting struct {
+	database *Database
+}
+
+func NewVotingRepository(database *Database) *Voting {
+	return &Voting{
+		database: database,
+	}
+}
+
+// CreateVoting registers the voting, which must reference a registered legislative body like it is required by the
+// foreign key of PostgreSQL
+func (instance Voting) CreateVoting(ctx context.Context, votingData voting.Voting,
+	generationData generation.Generation) (*uuid.UUID, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("CreateVoting")
+	if err != nil {
+		return nil, err
+	}
+
+	isRegistered := slices.ContainsFunc(instance.database.votes, func(registeredVoting voting.Voting) bool {
+		return registeredVoting.Code() == votingData.Code()
+	})
+	if isRegistered {
+		return nil, errors.New(fmt.Sprintf("Voting %s is already registered", votingData.Code()))
+	}
+
+	legislativeBody := votingData.LegislativeBody()
+	if legislativeBody.Id() == uuid.Nil {
+		return nil, errors.New(fmt.Sprintf("The legislative body %d of voting %s is not registered",
+			legislativeBody.Code(), votingData.Code()))
+	}
+
+	referenceDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+	if err != nil {
+		return nil, err
+	}
+
+	articleData, err := newArticle(votingData.Article(), *referenceDateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	votingId := uuid.New()
+	registeredVoting, err := votingData.NewUpdater().Id(votingId).Article(*articleData).Build()
+	if err != nil {
+		return nil, err
+	}
+	insertRecord(ctx, instance.database, &instance.database.votes, *registeredVoting, (*voting.Voting).Id)
+	instance.database.registerArticleGeneration(ctx, articleData.Id(), generationData)
+
+	return &votingId, nil
+}
+
+func (instance Voting) GetVotesByCodes(_ context.Context, codes []string) ([]voting.Voting, error) {
+	instance.database.mutex.Lock()
+	defer instance.database.mutex.Unlock()
+
+	err := instance.database.getFailure("GetVotesByCodes")
+	if err != nil {
+		return nil, err
+	}
+
+	var votes []voting.Voting
+	for _, votingData := range instance.database.votes {
+		if slices.Contains(codes, votingData.Code()) {
+			votes = append(votes, votingData)
+		}
+	}
+
+	return votes, nil
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/devlucassantos/vnc-domains/src/domains/agendaitemregime"
@@ -20,17 +21,12 @@ func NewAgendaItemRegimeRepository(connectionManager connectionManagerInterface)
 	}
 }
 
-func (instance AgendaItemRegime) CreateAgendaItemRegime(agendaItemRegime agendaitemregime.AgendaItemRegime) (*uuid.UUID,
-	error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance AgendaItemRegime) CreateAgendaItemRegime(ctx context.Context,
+	agendaItemRegime agendaitemregime.AgendaItemRegime) (*uuid.UUID, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var agendaItemRegimeId uuid.UUID
-	err = postgresConnection.QueryRow(queries.AgendaItemRegime().Insert(), agendaItemRegime.Code(),
+	err := postgresConnection.QueryRowContext(ctx, queries.AgendaItemRegime().Insert(), agendaItemRegime.Code(),
 		agendaItemRegime.Description()).Scan(&agendaItemRegimeId)
 	if err != nil {
 		log.Errorf("Error registering agenda item regime %d: %s", agendaItemRegime.Code(), err.Error())
@@ -42,16 +38,12 @@ func (instance AgendaItemRegime) CreateAgendaItemRegime(agendaItemRegime agendai
 	return &agendaItemRegimeId, nil
 }
 
-func (instance AgendaItemRegime) GetAgendaItemRegimeByCode(code int) (*agendaitemregime.AgendaItemRegime, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance AgendaItemRegime) GetAgendaItemRegimeByCode(ctx context.Context, code int) (
+	*agendaitemregime.AgendaItemRegime, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var agendaItemRegime dto.AgendaItemRegime
-	err = postgresConnection.Get(&agendaItemRegime, queries.AgendaItemRegime().Select().ByCode(), code)
+	err := postgresConnection.GetContext(ctx, &agendaItemRegime, queries.AgendaItemRegime().Select().ByCode(), code)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			log.Infof("Agenda item regime %d not found in database", code)
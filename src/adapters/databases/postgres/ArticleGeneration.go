@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/log"
+	"vnc-summarizer/adapters/databases/postgres/queries"
+	"vnc-summarizer/core/domains/articleimage"
+	"vnc-summarizer/core/domains/generation"
+	"vnc-summarizer/utils/converters"
+)
+
+func registerArticleGeneration(ctx context.Context, transaction transactionInterface, articleId uuid.UUID,
+	generationData generation.Generation) error {
+	for _, promptData := range generationData.Prompts() {
+		var articlePromptId uuid.UUID
+		err := transaction.QueryRowContext(ctx, queries.ArticlePrompt().Insert(), articleId, promptData.Code(),
+			promptData.Variant(), promptData.Version()).Scan(&articlePromptId)
+		if err != nil {
+			log.Errorf("Error registering version %d of prompt %s used to generate article %s: %s",
+				promptData.Version(), promptData.Code(), articleId, err.Error())
+			return err
+		}
+	}
+
+	for _, usageData := range generationData.Usages() {
+		// The usages are registered when the generations are made, unless the registration failed at that moment
+		generationUsageId := usageData.Id()
+		if generationUsageId == uuid.Nil {
+			err := insertGenerationUsage(ctx, transaction, usageData).Scan(&generationUsageId)
+			if err != nil {
+				log.Errorf("Error registering the usage of model %s to generate article %s: %s", usageData.Model(),
+					articleId, err.Error())
+				return err
+			}
+		}
+
+		_, err := transaction.ExecContext(ctx, queries.GenerationUsage().UpdateArticle(), articleId,
+			generationUsageId)
+		if err != nil {
+			log.Errorf("Error linking the usage %s of model %s to article %s: %s", generationUsageId,
+				usageData.Model(), articleId, err.Error())
+			return err
+		}
+	}
+
+	imageData := generationData.Image()
+	if imageData.BlurHash() != "" || len(imageData.Variants()) > 0 {
+		err := registerArticleImage(ctx, transaction, articleId, imageData)
+		if err != nil {
+			log.Error("registerArticleImage(): ", err.Error())
+			return err
+		}
+	}
+
+	for _, imageReviewData := range generationData.ImageReviews() {
+		var articleImageReviewId uuid.UUID
+		err := transaction.QueryRowContext(ctx, queries.ArticleImageReview().Insert(), articleId,
+			imageReviewData.ImageUrl(), imageReviewData.ImageProvider(), imageReviewData.ContainsText(),
+			imageReviewData.ContainsPoliticianLikeness(), imageReviewData.ContainsPartyLogo(),
+			imageReviewData.ContainsUnsafeContent(), imageReviewData.RelevanceScore(),
+			imageReviewData.Justification(), imageReviewData.Approved()).Scan(&articleImageReviewId)
+		if err != nil {
+			log.Errorf("Error registering the review of image %s of article %s: %s", imageReviewData.ImageUrl(),
+				articleId, err.Error())
+			return err
+		}
+	}
+
+	summaryData := generationData.Summary()
+	if summaryData.IsZero() {
+		return nil
+	}
+
+	keyPoints, err := converters.ToJson(summaryData.KeyPoints())
+	if err != nil {
+		log.Error("converters.ToJson(): ", err.Error())
+		return err
+	}
+
+	affectedGroups, err := converters.ToJson(summaryData.AffectedGroups())
+	if err != nil {
+		log.Error("converters.ToJson(): ", err.Error())
+		return err
+	}
+
+	subjectTags, err := converters.ToJson(summaryData.SubjectTags())
+	if err != nil {
+		log.Error("converters.ToJson(): ", err.Error())
+		return err
+	}
+
+	var articleSummaryId uuid.UUID
+	err = transaction.QueryRowContext(ctx, queries.ArticleSummary().Insert(), articleId, string(keyPoints),
+		string(affectedGroups), string(subjectTags)).Scan(&articleSummaryId)
+	if err != nil {
+		log.Errorf("Error registering the summary of article %s: %s", articleId, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func registerArticleImage(ctx context.Context, transaction transactionInterface, articleId uuid.UUID,
+	imageData articleimage.ArticleImage) error {
+	var articleImageId uuid.UUID
+	err := transaction.QueryRowContext(ctx, queries.ArticleImage().Insert(), articleId, imageData.BlurHash(),
+		imageData.DominantColor()).Scan(&articleImageId)
+	if err != nil {
+		log.Errorf("Error registering the image of article %s: %s", articleId, err.Error())
+		return err
+	}
+
+	for _, variantData := range imageData.Variants() {
+		var articleImageVariantId uuid.UUID
+		err = transaction.QueryRowContext(ctx, queries.ArticleImageVariant().Insert(), articleImageId,
+			variantData.Kind(), variantData.Format(), variantData.Width(), variantData.Height(),
+			variantData.Url()).Scan(&articleImageVariantId)
+		if err != nil {
+			log.Errorf("Error registering the %dx%d %s variant of the image of article %s: %s", variantData.Width(),
+				variantData.Height(), variantData.Format(), articleId, err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
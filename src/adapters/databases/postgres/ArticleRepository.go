@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"fmt"
 	"github.com/devlucassantos/vnc-domains/src/domains/article"
 	"github.com/devlucassantos/vnc-domains/src/domains/articletype"
@@ -21,16 +22,12 @@ func NewArticleRepository(connectionManager connectionManagerInterface) *Article
 	}
 }
 
-func (instance Article) GetArticlesByReferenceDate(referenceDate time.Time) ([]article.Article, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Article) GetArticlesByReferenceDate(ctx context.Context, referenceDate time.Time) ([]article.Article,
+	error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var articles []dto.Article
-	err = postgresConnection.Select(&articles, queries.Article().Select().ByReferenceDate(), referenceDate)
+	err := postgresConnection.SelectContext(ctx, &articles, queries.Article().Select().ByReferenceDate(), referenceDate)
 	if err != nil {
 		log.Errorf("Error retrieving articles by reference date %s from the database: %s", referenceDate, err.Error())
 		return nil, err
@@ -106,16 +103,13 @@ func (instance Article) GetArticlesByReferenceDate(referenceDate time.Time) ([]a
 	return articleSlice, nil
 }
 
-func (instance Article) GetNewsletterArticlesByNewsletterId(newsletterId uuid.UUID) ([]article.Article, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Article) GetNewsletterArticlesByNewsletterId(ctx context.Context, newsletterId uuid.UUID) (
+	[]article.Article, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var articles []dto.Article
-	err = postgresConnection.Select(&articles, queries.NewsletterArticle().Select().ByNewsletterId(), newsletterId)
+	err := postgresConnection.SelectContext(ctx, &articles, queries.NewsletterArticle().Select().ByNewsletterId(),
+		newsletterId)
 	if err != nil {
 		log.Errorf("Error retrieving articles related to newsletter %s from the database: %s", newsletterId,
 			err.Error())
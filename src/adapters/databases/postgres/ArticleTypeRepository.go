@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/articletype"
 	"github.com/labstack/gommon/log"
 	"vnc-summarizer/adapters/databases/dto"
@@ -17,16 +18,11 @@ func NewArticleTypeRepository(connectionManager connectionManagerInterface) *Art
 	}
 }
 
-func (instance ArticleType) GetArticleTypeByCode(code string) (*articletype.ArticleType, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance ArticleType) GetArticleTypeByCode(ctx context.Context, code string) (*articletype.ArticleType, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var articleType dto.ArticleType
-	err = postgresConnection.Get(&articleType, queries.ArticleType().Select().ByCode(), code)
+	err := postgresConnection.GetContext(ctx, &articleType, queries.ArticleType().Select().ByCode(), code)
 	if err != nil {
 		log.Errorf("Error retrieving article type data with code %s from the database: %s", code, err.Error())
 		return nil, err
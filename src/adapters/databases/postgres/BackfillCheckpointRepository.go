@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"github.com/labstack/gommon/log"
+	"time"
+	"vnc-summarizer/adapters/databases/postgres/queries"
+)
+
+type BackfillCheckpoint struct {
+	connectionManager connectionManagerInterface
+}
+
+func NewBackfillCheckpointRepository(connectionManager connectionManagerInterface) *BackfillCheckpoint {
+	return &BackfillCheckpoint{
+		connectionManager: connectionManager,
+	}
+}
+
+func (instance BackfillCheckpoint) GetCompletedDates(ctx context.Context, dataType string, startDate,
+	endDate time.Time) ([]time.Time, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	var completedDates []time.Time
+	err := postgresConnection.SelectContext(ctx, &completedDates,
+		queries.BackfillCheckpoint().Select().CompletedDatesByDataTypeAndDateRange(), dataType,
+		startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if err != nil {
+		log.Errorf("Error retrieving the backfill checkpoints of %s from the database: %s", dataType, err.Error())
+		return nil, err
+	}
+
+	return completedDates, nil
+}
+
+func (instance BackfillCheckpoint) SaveCheckpoint(ctx context.Context, dataType string, referenceDate time.Time) error {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	_, err := postgresConnection.ExecContext(ctx, queries.BackfillCheckpoint().Upsert(), dataType,
+		referenceDate.Format("2006-01-02"))
+	if err != nil {
+		log.Errorf("Error registering the backfill checkpoint of %s on %s: %s", dataType,
+			referenceDate.Format("02/01/2006"), err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/devlucassantos/vnc-domains/src/domains/deputy"
@@ -21,18 +22,14 @@ func NewDeputyRepository(connectionManager connectionManagerInterface) *Deputy {
 	}
 }
 
-func (instance Deputy) CreateDeputy(deputy deputy.Deputy) (*uuid.UUID, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Deputy) CreateDeputy(ctx context.Context, deputy deputy.Deputy) (*uuid.UUID, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var deputyId uuid.UUID
 	deputyParty := deputy.Party()
-	err = postgresConnection.QueryRow(queries.Deputy().Insert(), deputy.Code(), deputy.Cpf(), deputy.Name(),
-		deputy.ElectoralName(), deputy.ImageUrl(), deputyParty.Id(), deputy.FederatedUnit()).Scan(&deputyId)
+	err := postgresConnection.QueryRowContext(ctx, queries.Deputy().Insert(), deputy.Code(), deputy.Cpf(),
+		deputy.Name(), deputy.ElectoralName(), deputy.ImageUrl(), deputyParty.Id(),
+		deputy.FederatedUnit()).Scan(&deputyId)
 	if err != nil {
 		log.Errorf("Error registering deputy %d: %s", deputy.Code(), err.Error())
 		return nil, err
@@ -42,16 +39,11 @@ func (instance Deputy) CreateDeputy(deputy deputy.Deputy) (*uuid.UUID, error) {
 	return &deputyId, nil
 }
 
-func (instance Deputy) UpdateDeputy(deputy deputy.Deputy) error {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Deputy) UpdateDeputy(ctx context.Context, deputy deputy.Deputy) error {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	deputyParty := deputy.Party()
-	_, err = postgresConnection.Exec(queries.Deputy().Update(), deputy.Name(), deputy.ElectoralName(),
+	_, err := postgresConnection.ExecContext(ctx, queries.Deputy().Update(), deputy.Name(), deputy.ElectoralName(),
 		deputy.ImageUrl(), deputyParty.Id(), deputy.FederatedUnit(), deputy.Code())
 	if err != nil {
 		log.Errorf("Error updating deputy %d: %s", deputy.Code(), err.Error())
@@ -62,16 +54,11 @@ func (instance Deputy) UpdateDeputy(deputy deputy.Deputy) error {
 	return nil
 }
 
-func (instance Deputy) GetDeputyByCode(code int) (*deputy.Deputy, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Deputy) GetDeputyByCode(ctx context.Context, code int) (*deputy.Deputy, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var deputyData dto.Deputy
-	err = postgresConnection.Get(&deputyData, queries.Deputy().Select().ByCode(), code)
+	err := postgresConnection.GetContext(ctx, &deputyData, queries.Deputy().Select().ByCode(), code)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			log.Infof("Deputy %d not found in database", code)
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/event"
 	"github.com/devlucassantos/vnc-domains/src/domains/eventsituation"
 	"github.com/google/uuid"
@@ -8,6 +9,7 @@ import (
 	"time"
 	"vnc-summarizer/adapters/databases/dto"
 	"vnc-summarizer/adapters/databases/postgres/queries"
+	"vnc-summarizer/core/domains/generation"
 )
 
 type Event struct {
@@ -20,15 +22,9 @@ func NewEventRepository(connectionManager connectionManagerInterface) *Event {
 	}
 }
 
-func (instance Event) CreateEvent(event event.Event) (*uuid.UUID, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
-
-	transaction, err := postgresConnection.Beginx()
+func (instance Event) CreateEvent(ctx context.Context, event event.Event, generationData generation.Generation) (
+	*uuid.UUID, error) {
+	transaction, err := instance.connectionManager.beginTransaction(ctx)
 	if err != nil {
 		log.Errorf("Error starting transaction to register event %d: %s", event.Code(), err.Error())
 		return nil, err
@@ -38,8 +34,8 @@ func (instance Event) CreateEvent(event event.Event) (*uuid.UUID, error) {
 	var articleId uuid.UUID
 	eventArticle := event.Article()
 	articleType := eventArticle.Type()
-	err = transaction.QueryRow(queries.Article().Insert(), articleType.Id(), eventArticle.ReferenceDateTime()).Scan(
-		&articleId)
+	err = transaction.QueryRowContext(ctx, queries.Article().Insert(), articleType.Id(),
+		eventArticle.ReferenceDateTime()).Scan(&articleId)
 	if err != nil {
 		log.Errorf("Error registering event %s as article: %s", event.Id(), err.Error())
 		return nil, err
@@ -60,7 +56,7 @@ func (instance Event) CreateEvent(event event.Event) (*uuid.UUID, error) {
 	var eventId uuid.UUID
 	eventType := event.Type()
 	eventSituation := event.Situation()
-	err = transaction.QueryRow(queries.Event().Insert(), event.Code(), event.Title(), event.Description(),
+	err = transaction.QueryRowContext(ctx, queries.Event().Insert(), event.Code(), event.Title(), event.Description(),
 		event.StartsAt(), endsAt, event.Location(), event.IsInternal(), videoUrl, event.SpecificType(), eventType.Id(),
 		event.SpecificSituation(), eventSituation.Id(), articleId).Scan(&eventId)
 	if err != nil {
@@ -69,7 +65,7 @@ func (instance Event) CreateEvent(event event.Event) (*uuid.UUID, error) {
 	}
 
 	for _, legislativeBody := range event.LegislativeBodies() {
-		_, err = transaction.Exec(queries.EventLegislativeBody().Insert(), eventId, legislativeBody.Id())
+		_, err = transaction.ExecContext(ctx, queries.EventLegislativeBody().Insert(), eventId, legislativeBody.Id())
 		if err != nil {
 			log.Errorf("Error registering legislative body %s responsible for event %s: %s", legislativeBody.Id(),
 				eventId, err.Error())
@@ -80,7 +76,7 @@ func (instance Event) CreateEvent(event event.Event) (*uuid.UUID, error) {
 	}
 
 	for _, requirement := range event.Requirements() {
-		_, err = transaction.Exec(queries.EventRequirement().Insert(), eventId, requirement.Id())
+		_, err = transaction.ExecContext(ctx, queries.EventRequirement().Insert(), eventId, requirement.Id())
 		if err != nil {
 			log.Errorf("Error registering requeriment %s as part of event %s: %s", requirement.Id(), eventId,
 				err.Error())
@@ -123,9 +119,9 @@ func (instance Event) CreateEvent(event event.Event) (*uuid.UUID, error) {
 		}
 
 		var agendaItemId uuid.UUID
-		err = transaction.QueryRow(queries.EventAgendaItem().Insert(), agendaItem.Title(), agendaItem.Topic(),
-			agendaItemSituation, regime.Id(), rapporteurId, rapporteurPartyId, rapporteurFederatedUnit, proposition.Id(),
-			relatedPropositionId, votingId, eventId).Scan(&agendaItemId)
+		err = transaction.QueryRowContext(ctx, queries.EventAgendaItem().Insert(), agendaItem.Title(),
+			agendaItem.Topic(), agendaItemSituation, regime.Id(), rapporteurId, rapporteurPartyId,
+			rapporteurFederatedUnit, proposition.Id(), relatedPropositionId, votingId, eventId).Scan(&agendaItemId)
 		if err != nil {
 			log.Errorf("Error registering agenda item as part of event %s: %s", eventId, err.Error())
 			return nil, err
@@ -134,6 +130,12 @@ func (instance Event) CreateEvent(event event.Event) (*uuid.UUID, error) {
 		log.Infof("Agenda item %s successfully registered as part of event %s", agendaItemId, eventId)
 	}
 
+	err = registerArticleGeneration(ctx, transaction, articleId, generationData)
+	if err != nil {
+		log.Error("registerArticleGeneration(): ", err.Error())
+		return nil, err
+	}
+
 	err = transaction.Commit()
 	if err != nil {
 		log.Errorf("Error confirming transaction to register event %d: %s", event.Code(), err.Error())
@@ -144,13 +146,8 @@ func (instance Event) CreateEvent(event event.Event) (*uuid.UUID, error) {
 	return &eventId, nil
 }
 
-func (instance Event) UpdateEvent(event event.Event) error {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Event) UpdateEvent(ctx context.Context, event event.Event) error {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var endsAt *time.Time
 	if !event.EndsAt().IsZero() {
@@ -165,8 +162,9 @@ func (instance Event) UpdateEvent(event event.Event) error {
 	}
 
 	eventSituation := event.Situation()
-	_, err = postgresConnection.Exec(queries.Event().Update(), event.Description(), event.StartsAt(), endsAt,
-		event.Location(), event.IsInternal(), videoUrl, event.SpecificSituation(), eventSituation.Id(), event.Code())
+	_, err := postgresConnection.ExecContext(ctx, queries.Event().Update(), event.Description(), event.StartsAt(),
+		endsAt, event.Location(), event.IsInternal(), videoUrl, event.SpecificSituation(), eventSituation.Id(),
+		event.Code())
 	if err != nil {
 		log.Errorf("Error updating event %d: %s", event.Code(), err.Error())
 		return err
@@ -176,13 +174,8 @@ func (instance Event) UpdateEvent(event event.Event) error {
 	return nil
 }
 
-func (instance Event) GetEventsByCodes(codes []int) ([]event.Event, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Event) GetEventsByCodes(ctx context.Context, codes []int) ([]event.Event, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var eventCodes []interface{}
 	for _, code := range codes {
@@ -190,7 +183,8 @@ func (instance Event) GetEventsByCodes(codes []int) ([]event.Event, error) {
 	}
 
 	var events []dto.Event
-	err = postgresConnection.Select(&events, queries.Event().Select().ByCodes(len(eventCodes)), eventCodes...)
+	err := postgresConnection.SelectContext(ctx, &events, queries.Event().Select().ByCodes(len(eventCodes)),
+		eventCodes...)
 	if err != nil {
 		log.Error("Error retrieving the event data by codes from the database: ", err.Error())
 		return nil, err
@@ -228,15 +222,10 @@ func (instance Event) GetEventsByCodes(codes []int) ([]event.Event, error) {
 	return eventSlice, nil
 }
 
-func (instance Event) GetEventsOccurringToday() ([]event.Event, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Event) GetEventsOccurringToday(ctx context.Context) ([]event.Event, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 	var events []dto.Event
-	err = postgresConnection.Select(&events, queries.Event().Select().OccurringToday())
+	err := postgresConnection.SelectContext(ctx, &events, queries.Event().Select().OccurringToday())
 	if err != nil {
 		log.Error("Error retrieving events occurring today from the database: ", err.Error())
 		return nil, err
@@ -287,15 +276,12 @@ func (instance Event) GetEventsOccurringToday() ([]event.Event, error) {
 	return eventSlice, nil
 }
 
-func (instance Event) GetEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished() ([]event.Event, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Event) GetEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished(ctx context.Context) ([]event.Event,
+	error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 	var events []dto.Event
-	err = postgresConnection.Select(&events, queries.Event().Select().StartedInTheLastThreeMonthsAndHaveNotFinished())
+	err := postgresConnection.SelectContext(ctx, &events,
+		queries.Event().Select().StartedInTheLastThreeMonthsAndHaveNotFinished())
 	if err != nil {
 		log.Error("Error retrieving events that started in the last three months and have not finished from the "+
 			"database: ", err.Error())
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/devlucassantos/vnc-domains/src/domains/eventsituation"
@@ -19,20 +20,15 @@ func NewEventSituationRepository(connectionManager connectionManagerInterface) *
 	}
 }
 
-func (instance EventSituation) GetEventSituationByCodeOrDefaultSituation(code string) (*eventsituation.EventSituation,
-	error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance EventSituation) GetEventSituationByCodeOrDefaultSituation(ctx context.Context, code string) (
+	*eventsituation.EventSituation, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var eventSituation dto.EventSituation
-	err = postgresConnection.Get(&eventSituation, queries.EventSituation().Select().ByCode(), code)
+	err := postgresConnection.GetContext(ctx, &eventSituation, queries.EventSituation().Select().ByCode(), code)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			err = postgresConnection.Get(&eventSituation, queries.EventSituation().Select().DefaultOption())
+			err = postgresConnection.GetContext(ctx, &eventSituation, queries.EventSituation().Select().DefaultOption())
 			if err != nil {
 				log.Error("Error retrieving the default event situation data for cases where the event situation "+
 					"code searched was not found in the database: ", err.Error())
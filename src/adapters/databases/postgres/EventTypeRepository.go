@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/devlucassantos/vnc-domains/src/domains/eventtype"
@@ -19,19 +20,15 @@ func NewEventTypeRepository(connectionManager connectionManagerInterface) *Event
 	}
 }
 
-func (instance EventType) GetEventTypeByCodeOrDefaultType(code string) (*eventtype.EventType, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance EventType) GetEventTypeByCodeOrDefaultType(ctx context.Context, code string) (*eventtype.EventType,
+	error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var eventType dto.EventType
-	err = postgresConnection.Get(&eventType, queries.EventType().Select().ByCode(), code)
+	err := postgresConnection.GetContext(ctx, &eventType, queries.EventType().Select().ByCode(), code)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			err = postgresConnection.Get(&eventType, queries.EventType().Select().DefaultOption())
+			err = postgresConnection.GetContext(ctx, &eventType, queries.EventType().Select().DefaultOption())
 			if err != nil {
 				log.Error("Error retrieving the default event type data for cases where the event type code "+
 					"searched was not found in the database: ", err.Error())
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/devlucassantos/vnc-domains/src/domains/externalauthor"
@@ -21,17 +22,13 @@ func NewExternalAuthorRepository(connectionManager connectionManagerInterface) *
 	}
 }
 
-func (instance ExternalAuthor) CreateExternalAuthor(externalAuthor externalauthor.ExternalAuthor) (*uuid.UUID, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance ExternalAuthor) CreateExternalAuthor(ctx context.Context, externalAuthor externalauthor.ExternalAuthor) (
+	*uuid.UUID, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var externalAuthorId uuid.UUID
 	externalAuthorType := externalAuthor.Type()
-	err = postgresConnection.QueryRow(queries.ExternalAuthor().Insert(), externalAuthor.Name(),
+	err := postgresConnection.QueryRowContext(ctx, queries.ExternalAuthor().Insert(), externalAuthor.Name(),
 		externalAuthorType.Id()).Scan(&externalAuthorId)
 	if err != nil {
 		log.Errorf("Error registering external author %s (Type code: %d): %s", externalAuthor.Name(),
@@ -44,17 +41,13 @@ func (instance ExternalAuthor) CreateExternalAuthor(externalAuthor externalautho
 	return &externalAuthorId, nil
 }
 
-func (instance ExternalAuthor) GetExternalAuthorByNameAndTypeCode(name string, typeCode int) (
+func (instance ExternalAuthor) GetExternalAuthorByNameAndTypeCode(ctx context.Context, name string, typeCode int) (
 	*externalauthor.ExternalAuthor, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var externalAuthor dto.ExternalAuthor
-	err = postgresConnection.Get(&externalAuthor, queries.ExternalAuthor().Select().ByNameAndTypeCode(), name, typeCode)
+	err := postgresConnection.GetContext(ctx, &externalAuthor, queries.ExternalAuthor().Select().ByNameAndTypeCode(),
+		name, typeCode)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			log.Infof("External author %s (Type code: %d) not found in database", name, typeCode)
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/devlucassantos/vnc-domains/src/domains/externalauthortype"
@@ -20,17 +21,12 @@ func NewExternalAuthorTypeRepository(connectionManager connectionManagerInterfac
 	}
 }
 
-func (instance ExternalAuthorType) CreateExternalAuthorType(externalAuthorType externalauthortype.ExternalAuthorType) (
-	*uuid.UUID, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance ExternalAuthorType) CreateExternalAuthorType(ctx context.Context,
+	externalAuthorType externalauthortype.ExternalAuthorType) (*uuid.UUID, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var externalAuthorTypeId uuid.UUID
-	err = postgresConnection.QueryRow(queries.ExternalAuthorType().Insert(), externalAuthorType.Code(),
+	err := postgresConnection.QueryRowContext(ctx, queries.ExternalAuthorType().Insert(), externalAuthorType.Code(),
 		externalAuthorType.Description()).Scan(&externalAuthorTypeId)
 	if err != nil {
 		log.Errorf("Error registering external author type %d: %s", externalAuthorType.Code(), err.Error())
@@ -42,17 +38,12 @@ func (instance ExternalAuthorType) CreateExternalAuthorType(externalAuthorType e
 	return &externalAuthorTypeId, nil
 }
 
-func (instance ExternalAuthorType) GetExternalAuthorTypeByCode(code int) (*externalauthortype.ExternalAuthorType,
-	error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance ExternalAuthorType) GetExternalAuthorTypeByCode(ctx context.Context, code int) (
+	*externalauthortype.ExternalAuthorType, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var externalAuthorType dto.ExternalAuthorType
-	err = postgresConnection.Get(&externalAuthorType, queries.ExternalAuthorType().Select().ByCode(), code)
+	err := postgresConnection.GetContext(ctx, &externalAuthorType, queries.ExternalAuthorType().Select().ByCode(), code)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			log.Infof("External author type %d not found in database", code)
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/log"
+	"time"
+	"vnc-summarizer/adapters/databases/postgres/queries"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/utils/runs"
+)
+
+type GenerationUsage struct {
+	connectionManager connectionManagerInterface
+}
+
+func NewGenerationUsageRepository(connectionManager connectionManagerInterface) *GenerationUsage {
+	return &GenerationUsage{
+		connectionManager: connectionManager,
+	}
+}
+
+// CreateGenerationUsage registers the usage outside the transaction of the context, so the usages of the generations
+// are kept even when the registration of the article that would use them is undone
+func (instance GenerationUsage) CreateGenerationUsage(ctx context.Context,
+	generationUsage generationusage.GenerationUsage) (*uuid.UUID, error) {
+	postgresConnection := instance.connectionManager.getConnection(withoutTransaction(ctx))
+
+	var generationUsageId uuid.UUID
+	err := insertGenerationUsage(ctx, postgresConnection, generationUsage).Scan(&generationUsageId)
+	if err != nil {
+		log.Errorf("Error registering the usage of model %s: %s", generationUsage.Model(), err.Error())
+		return nil, err
+	}
+
+	return &generationUsageId, nil
+}
+
+func (instance GenerationUsage) GetEstimatedCostByDate(ctx context.Context, date time.Time) (float64, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	var estimatedCost float64
+	err := postgresConnection.GetContext(ctx, &estimatedCost, queries.GenerationUsage().Select().EstimatedCostByDate(),
+		date.Format("2006-01-02"))
+	if err != nil {
+		log.Errorf("Error retrieving the estimated cost of the generations of %s from the database: %s",
+			date.Format("02/01/2006"), err.Error())
+		return 0, err
+	}
+
+	return estimatedCost, nil
+}
+
+func insertGenerationUsage(ctx context.Context, postgresConnection connectionInterface,
+	generationUsage generationusage.GenerationUsage) *sql.Row {
+	runId := runs.GetRunId(ctx)
+	return postgresConnection.QueryRowContext(ctx, queries.GenerationUsage().Insert(),
+		uuid.NullUUID{UUID: runId, Valid: runId != uuid.Nil}, generationUsage.Provider(), generationUsage.Model(),
+		generationUsage.PromptTokens(), generationUsage.CompletionTokens(), generationUsage.NumberOfImages(),
+		generationUsage.EstimatedCost())
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/devlucassantos/vnc-domains/src/domains/legislativebody"
@@ -21,18 +22,14 @@ func NewLegislativeBodyRepository(connectionManager connectionManagerInterface)
 	}
 }
 
-func (instance LegislativeBody) CreateLegislativeBody(legislativeBody legislativebody.LegislativeBody) (*uuid.UUID, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance LegislativeBody) CreateLegislativeBody(ctx context.Context,
+	legislativeBody legislativebody.LegislativeBody) (*uuid.UUID, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var legislativeBodyId uuid.UUID
 	legislativeBodyType := legislativeBody.Type()
-	err = postgresConnection.QueryRow(queries.LegislativeBody().Insert(), legislativeBody.Code(), legislativeBody.Name(),
-		legislativeBody.Acronym(), legislativeBodyType.Id()).Scan(&legislativeBodyId)
+	err := postgresConnection.QueryRowContext(ctx, queries.LegislativeBody().Insert(), legislativeBody.Code(),
+		legislativeBody.Name(), legislativeBody.Acronym(), legislativeBodyType.Id()).Scan(&legislativeBodyId)
 	if err != nil {
 		log.Errorf("Error registering legislative body %d: %s", legislativeBody.Code(), err.Error())
 		return nil, err
@@ -42,16 +39,12 @@ func (instance LegislativeBody) CreateLegislativeBody(legislativeBody legislativ
 	return &legislativeBodyId, nil
 }
 
-func (instance LegislativeBody) GetLegislativeBodyByCode(code int) (*legislativebody.LegislativeBody, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance LegislativeBody) GetLegislativeBodyByCode(ctx context.Context, code int) (
+	*legislativebody.LegislativeBody, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var legislativeBody dto.LegislativeBody
-	err = postgresConnection.Get(&legislativeBody, queries.LegislativeBody().Select().ByCode(), code)
+	err := postgresConnection.GetContext(ctx, &legislativeBody, queries.LegislativeBody().Select().ByCode(), code)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			log.Infof("Legislative body %d not found in database", code)
@@ -85,13 +78,9 @@ func (instance LegislativeBody) GetLegislativeBodyByCode(code int) (*legislative
 	return legislativeBodyDomain, nil
 }
 
-func (instance LegislativeBody) GetLegislativeBodiesByCodes(codes []int) ([]legislativebody.LegislativeBody, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance LegislativeBody) GetLegislativeBodiesByCodes(ctx context.Context, codes []int) (
+	[]legislativebody.LegislativeBody, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var legislativeBodyCodes []interface{}
 	for _, code := range codes {
@@ -99,8 +88,8 @@ func (instance LegislativeBody) GetLegislativeBodiesByCodes(codes []int) ([]legi
 	}
 
 	var legislativeBodyData []dto.LegislativeBody
-	err = postgresConnection.Select(&legislativeBodyData, queries.LegislativeBody().Select().ByCodes(
-		len(legislativeBodyCodes)), legislativeBodyCodes...)
+	err := postgresConnection.SelectContext(ctx, &legislativeBodyData,
+		queries.LegislativeBody().Select().ByCodes(len(legislativeBodyCodes)), legislativeBodyCodes...)
 	if err != nil {
 		log.Error("Error retrieving the legislative body data by codes from the database: ", err.Error())
 		return nil, err
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/devlucassantos/vnc-domains/src/domains/legislativebodytype"
@@ -20,16 +21,12 @@ func NewLegislativeBodyTypeRepository(connectionManager connectionManagerInterfa
 	}
 }
 
-func (instance LegislativeBodyType) CreateLegislativeBodyType(legislativeBodyType legislativebodytype.LegislativeBodyType) (*uuid.UUID, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance LegislativeBodyType) CreateLegislativeBodyType(ctx context.Context,
+	legislativeBodyType legislativebodytype.LegislativeBodyType) (*uuid.UUID, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var legislativeBodyTypeId uuid.UUID
-	err = postgresConnection.QueryRow(queries.LegislativeBodyType().Insert(), legislativeBodyType.Code(),
+	err := postgresConnection.QueryRowContext(ctx, queries.LegislativeBodyType().Insert(), legislativeBodyType.Code(),
 		legislativeBodyType.Description()).Scan(&legislativeBodyTypeId)
 	if err != nil {
 		log.Errorf("Error registering legislative body type %d: %s", legislativeBodyType.Code(), err.Error())
@@ -41,16 +38,13 @@ func (instance LegislativeBodyType) CreateLegislativeBodyType(legislativeBodyTyp
 	return &legislativeBodyTypeId, nil
 }
 
-func (instance LegislativeBodyType) GetLegislativeBodyTypeByCode(code int) (*legislativebodytype.LegislativeBodyType, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance LegislativeBodyType) GetLegislativeBodyTypeByCode(ctx context.Context, code int) (
+	*legislativebodytype.LegislativeBodyType, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var legislativeBodyType dto.LegislativeBodyType
-	err = postgresConnection.Get(&legislativeBodyType, queries.LegislativeBodyType().Select().ByCode(), code)
+	err := postgresConnection.GetContext(ctx, &legislativeBodyType, queries.LegislativeBodyType().Select().ByCode(),
+		code)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			log.Infof("Legislative body type %d not found in database", code)
@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/log"
+	"github.com/lib/pq"
+	"vnc-summarizer/adapters/databases/dto"
+	"vnc-summarizer/adapters/databases/postgres/queries"
+	"vnc-summarizer/core/domains/articleimage"
+	"vnc-summarizer/core/domains/imagevariant"
+	"vnc-summarizer/core/domains/libraryimage"
+	"vnc-summarizer/utils/converters"
+)
+
+// Maximum number of library images compared with the subject of an article, since the similarity of the embeddings is
+// calculated by the service
+const maximumNumberOfLibraryImageCandidates = 500
+
+type LibraryImage struct {
+	connectionManager connectionManagerInterface
+}
+
+func NewLibraryImageRepository(connectionManager connectionManagerInterface) *LibraryImage {
+	return &LibraryImage{
+		connectionManager: connectionManager,
+	}
+}
+
+func (instance LibraryImage) CreateLibraryImage(ctx context.Context, libraryImage libraryimage.LibraryImage) (
+	*uuid.UUID, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	imageData := libraryImage.Image()
+	var variants []dto.LibraryImageVariant
+	for _, variantData := range imageData.Variants() {
+		variants = append(variants, dto.LibraryImageVariant{
+			Kind:   variantData.Kind(),
+			Format: variantData.Format(),
+			Width:  variantData.Width(),
+			Height: variantData.Height(),
+			Url:    variantData.Url(),
+		})
+	}
+	if variants == nil {
+		variants = []dto.LibraryImageVariant{}
+	}
+
+	variantsAsJson, err := converters.ToJson(variants)
+	if err != nil {
+		log.Error("converters.ToJson(): ", err.Error())
+		return nil, err
+	}
+
+	subjectTags, err := converters.ToJson(libraryImage.SubjectTags())
+	if err != nil {
+		log.Error("converters.ToJson(): ", err.Error())
+		return nil, err
+	}
+
+	var libraryImageId uuid.UUID
+	err = postgresConnection.QueryRowContext(ctx, queries.LibraryImage().Insert(), imageData.Url(),
+		imageData.Description(), imageData.BlurHash(), imageData.DominantColor(), string(variantsAsJson),
+		string(subjectTags), pq.Float64Array(libraryImage.Embedding()),
+		libraryImage.EmbeddingModel()).Scan(&libraryImageId)
+	if err != nil {
+		log.Errorf("Error registering image %s in the image library: %s", imageData.Url(), err.Error())
+		return nil, err
+	}
+
+	log.Infof("Image %s successfully registered in the image library with ID %s", imageData.Url(), libraryImageId)
+	return &libraryImageId, nil
+}
+
+func (instance LibraryImage) GetLibraryImagesBySubjectTags(ctx context.Context, subjectTags []string,
+	embeddingModel string) ([]libraryimage.LibraryImage, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	var libraryImages []dto.LibraryImage
+	err := postgresConnection.SelectContext(ctx, &libraryImages, queries.LibraryImage().Select().ActiveBySubjectTags(),
+		embeddingModel, pq.Array(subjectTags), maximumNumberOfLibraryImageCandidates)
+	if err != nil {
+		log.Error("Error retrieving the images of the image library from the database: ", err.Error())
+		return nil, err
+	}
+
+	var libraryImageData []libraryimage.LibraryImage
+	for _, libraryImage := range libraryImages {
+		libraryImageDomain, err := getLibraryImageDomain(libraryImage)
+		if err != nil {
+			log.Errorf("Error validating data of library image %s: %s", libraryImage.Id, err.Error())
+			continue
+		}
+		libraryImageData = append(libraryImageData, *libraryImageDomain)
+	}
+
+	return libraryImageData, nil
+}
+
+func (instance LibraryImage) RegisterLibraryImageUse(ctx context.Context, id uuid.UUID) error {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	_, err := postgresConnection.ExecContext(ctx, queries.LibraryImage().RegisterUse(), id)
+	if err != nil {
+		log.Errorf("Error registering the use of library image %s: %s", id, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func getLibraryImageDomain(libraryImage dto.LibraryImage) (*libraryimage.LibraryImage, error) {
+	var variants []dto.LibraryImageVariant
+	err := json.Unmarshal([]byte(libraryImage.Variants), &variants)
+	if err != nil {
+		return nil, err
+	}
+
+	var imageVariants []imagevariant.ImageVariant
+	for _, variant := range variants {
+		imageVariant, err := imagevariant.NewBuilder().
+			Kind(variant.Kind).
+			Format(variant.Format).
+			Width(variant.Width).
+			Height(variant.Height).
+			Url(variant.Url).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		imageVariants = append(imageVariants, *imageVariant)
+	}
+
+	imageBuilder := articleimage.NewBuilder().
+		Url(libraryImage.Url).
+		Description(libraryImage.Description).
+		Variants(imageVariants...)
+	if libraryImage.BlurHash != "" {
+		imageBuilder.BlurHash(libraryImage.BlurHash)
+	}
+	if libraryImage.DominantColor != "" {
+		imageBuilder.DominantColor(libraryImage.DominantColor)
+	}
+
+	imageData, err := imageBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var subjectTags []string
+	err = json.Unmarshal([]byte(libraryImage.SubjectTags), &subjectTags)
+	if err != nil {
+		return nil, err
+	}
+
+	return libraryimage.NewBuilder().
+		Id(libraryImage.Id).
+		Image(*imageData).
+		SubjectTags(subjectTags).
+		Embedding(libraryImage.Embedding).
+		EmbeddingModel(libraryImage.EmbeddingModel).
+		Build()
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/labstack/gommon/log"
+	"time"
+	"vnc-summarizer/adapters/databases/postgres/queries"
+)
+
+type LlmResponse struct {
+	connectionManager connectionManagerInterface
+}
+
+func NewLlmResponseRepository(connectionManager connectionManagerInterface) *LlmResponse {
+	return &LlmResponse{
+		connectionManager: connectionManager,
+	}
+}
+
+func (instance LlmResponse) GetLlmResponse(ctx context.Context, key string) (string, bool, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	var response string
+	err := postgresConnection.GetContext(ctx, &response, queries.LlmResponse().Select().ByKey(), key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, nil
+		}
+
+		log.Errorf("Error retrieving the LLM response %s from the database: %s", key, err.Error())
+		return "", false, err
+	}
+
+	return response, true, nil
+}
+
+func (instance LlmResponse) SaveLlmResponse(ctx context.Context, key, response string, expiresAt time.Time) error {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	_, err := postgresConnection.ExecContext(ctx, queries.LlmResponse().Upsert(), key, response, expiresAt)
+	if err != nil {
+		log.Errorf("Error registering the LLM response %s: %s", key, err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,334 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+	"vnc-summarizer/adapters/databases/dto"
+	"vnc-summarizer/adapters/databases/postgres/queries"
+	"vnc-summarizer/core/domains/migration"
+)
+
+// The migrations are named as <version>_<name>.<up|down>.sql and every version must have both scripts
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var migrationFileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type migrationScript struct {
+	version    int
+	name       string
+	upScript   string
+	downScript string
+}
+
+type Migration struct {
+	connectionManager connectionManagerInterface
+}
+
+func NewMigrationRepository(connectionManager connectionManagerInterface) *Migration {
+	return &Migration{
+		connectionManager: connectionManager,
+	}
+}
+
+// MigrateUp applies the pending migrations in ascending order of version, each one in its own transaction, and returns
+// the migrations applied
+func (instance Migration) MigrateUp(ctx context.Context) ([]migration.Migration, error) {
+	migrationScripts, err := getMigrationScripts()
+	if err != nil {
+		log.Error("getMigrationScripts(): ", err.Error())
+		return nil, err
+	}
+
+	appliedMigrations, err := instance.getAppliedMigrations(ctx)
+	if err != nil {
+		log.Error("getAppliedMigrations(): ", err.Error())
+		return nil, err
+	}
+
+	var migrationsApplied []migration.Migration
+	for _, migrationData := range migrationScripts {
+		if _, isApplied := appliedMigrations[migrationData.version]; isApplied {
+			continue
+		}
+
+		isExecuted, err := instance.executeMigration(ctx, migrationData, true, false)
+		if err != nil {
+			log.Error("executeMigration(): ", err.Error())
+			return migrationsApplied, err
+		} else if !isExecuted {
+			continue
+		}
+
+		migrationApplied, err := migration.NewBuilder().
+			Version(migrationData.version).
+			Name(migrationData.name).
+			AppliedAt(time.Now()).
+			Build()
+		if err != nil {
+			log.Errorf("Error validating data for migration %d: %s", migrationData.version, err.Error())
+			return migrationsApplied, err
+		}
+		migrationsApplied = append(migrationsApplied, *migrationApplied)
+	}
+
+	return migrationsApplied, nil
+}
+
+// MigrateDown reverts the informed number of applied migrations in descending order of version, each one in its own
+// transaction, and returns the migrations reverted. The tables of the platform data shared with the other services are
+// only dropped by the first migration when dropPlatformTables is true.
+func (instance Migration) MigrateDown(ctx context.Context, numberOfMigrations int, dropPlatformTables bool) (
+	[]migration.Migration, error) {
+	migrationScripts, err := getMigrationScripts()
+	if err != nil {
+		log.Error("getMigrationScripts(): ", err.Error())
+		return nil, err
+	}
+
+	appliedMigrations, err := instance.getAppliedMigrations(ctx)
+	if err != nil {
+		log.Error("getAppliedMigrations(): ", err.Error())
+		return nil, err
+	}
+
+	var appliedVersions []int
+	for version := range appliedMigrations {
+		appliedVersions = append(appliedVersions, version)
+	}
+	slices.Sort(appliedVersions)
+	slices.Reverse(appliedVersions)
+
+	var migrationsReverted []migration.Migration
+	for _, version := range appliedVersions[:min(numberOfMigrations, len(appliedVersions))] {
+		index := slices.IndexFunc(migrationScripts, func(migrationData migrationScript) bool {
+			return migrationData.version == version
+		})
+		if index < 0 {
+			return migrationsReverted, errors.New(fmt.Sprintf("The migration %d (%s) is applied but its scripts "+
+				"were not found", version, appliedMigrations[version].Name))
+		}
+
+		migrationData := migrationScripts[index]
+		isExecuted, err := instance.executeMigration(ctx, migrationData, false, dropPlatformTables)
+		if err != nil {
+			log.Error("executeMigration(): ", err.Error())
+			return migrationsReverted, err
+		} else if !isExecuted {
+			continue
+		}
+
+		migrationReverted, err := migration.NewBuilder().
+			Version(migrationData.version).
+			Name(migrationData.name).
+			Build()
+		if err != nil {
+			log.Errorf("Error validating data for migration %d: %s", migrationData.version, err.Error())
+			return migrationsReverted, err
+		}
+		migrationsReverted = append(migrationsReverted, *migrationReverted)
+	}
+
+	return migrationsReverted, nil
+}
+
+// GetMigrations returns the embedded migrations and the migrations applied by other versions of the summarizer in
+// ascending order of version, along with the date and time each one was applied
+func (instance Migration) GetMigrations(ctx context.Context) ([]migration.Migration, error) {
+	migrationScripts, err := getMigrationScripts()
+	if err != nil {
+		log.Error("getMigrationScripts(): ", err.Error())
+		return nil, err
+	}
+
+	appliedMigrations, err := instance.getAppliedMigrations(ctx)
+	if err != nil {
+		log.Error("getAppliedMigrations(): ", err.Error())
+		return nil, err
+	}
+
+	migrationNames := map[int]string{}
+	for _, migrationData := range migrationScripts {
+		migrationNames[migrationData.version] = migrationData.name
+	}
+	for version, appliedMigration := range appliedMigrations {
+		if _, exists := migrationNames[version]; !exists {
+			migrationNames[version] = appliedMigration.Name
+		}
+	}
+
+	var versions []int
+	for version := range migrationNames {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+
+	var migrations []migration.Migration
+	for _, version := range versions {
+		migrationBuilder := migration.NewBuilder().Version(version).Name(migrationNames[version])
+		if appliedMigration, isApplied := appliedMigrations[version]; isApplied {
+			migrationBuilder.AppliedAt(appliedMigration.AppliedAt)
+		}
+
+		migrationDomain, err := migrationBuilder.Build()
+		if err != nil {
+			log.Errorf("Error validating data for migration %d: %s", version, err.Error())
+			return nil, err
+		}
+		migrations = append(migrations, *migrationDomain)
+	}
+
+	return migrations, nil
+}
+
+func (instance Migration) getAppliedMigrations(ctx context.Context) (map[int]dto.SchemaMigration, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	_, err := postgresConnection.ExecContext(ctx, queries.SchemaMigration().CreateTable())
+	if err != nil {
+		log.Error("Error creating the table of the migrations: ", err.Error())
+		return nil, err
+	}
+
+	var schemaMigrations []dto.SchemaMigration
+	err = postgresConnection.SelectContext(ctx, &schemaMigrations, queries.SchemaMigration().Select().All())
+	if err != nil {
+		log.Error("Error retrieving the applied migrations from the database: ", err.Error())
+		return nil, err
+	}
+
+	appliedMigrations := map[int]dto.SchemaMigration{}
+	for _, schemaMigration := range schemaMigrations {
+		appliedMigrations[schemaMigration.Version] = schemaMigration
+	}
+
+	return appliedMigrations, nil
+}
+
+// executeMigration applies or reverts the migration, returning false when it has already been applied or reverted by
+// another process in the meantime
+func (instance Migration) executeMigration(ctx context.Context, migrationData migrationScript, isUp,
+	dropPlatformTables bool) (bool, error) {
+	transaction, err := instance.connectionManager.beginTransaction(ctx)
+	if err != nil {
+		log.Errorf("Error starting transaction to execute migration %d: %s", migrationData.version, err.Error())
+		return false, err
+	}
+	defer instance.connectionManager.rollbackTransaction(transaction)
+
+	_, err = transaction.ExecContext(ctx, queries.SchemaMigration().Lock())
+	if err != nil {
+		log.Error("Error locking the table of the migrations: ", err.Error())
+		return false, err
+	}
+
+	var isApplied bool
+	err = transaction.GetContext(ctx, &isApplied, queries.SchemaMigration().Select().ExistsByVersion(),
+		migrationData.version)
+	if err != nil {
+		log.Errorf("Error checking if migration %d is applied: %s", migrationData.version, err.Error())
+		return false, err
+	} else if isApplied == isUp {
+		return false, nil
+	}
+
+	if isUp {
+		log.Infof("Applying migration %d (%s)", migrationData.version, migrationData.name)
+		_, err = transaction.ExecContext(ctx, migrationData.upScript)
+		if err == nil {
+			_, err = transaction.ExecContext(ctx, queries.SchemaMigration().Insert(), migrationData.version,
+				migrationData.name)
+		}
+	} else {
+		log.Infof("Reverting migration %d (%s)", migrationData.version, migrationData.name)
+		if dropPlatformTables {
+			_, err = transaction.ExecContext(ctx, queries.SchemaMigration().EnablePlatformTablesDrop())
+		}
+		if err == nil {
+			_, err = transaction.ExecContext(ctx, migrationData.downScript)
+		}
+		if err == nil {
+			_, err = transaction.ExecContext(ctx, queries.SchemaMigration().Delete(), migrationData.version)
+		}
+	}
+	if err != nil {
+		log.Errorf("Error executing migration %d (%s): %s", migrationData.version, migrationData.name,
+			err.Error())
+		return false, err
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		log.Errorf("Error confirming transaction to execute migration %d: %s", migrationData.version,
+			err.Error())
+		return false, err
+	}
+
+	return true, nil
+}
+
+func getMigrationScripts() ([]migrationScript, error) {
+	files, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		log.Error("Error reading the migration files: ", err.Error())
+		return nil, err
+	}
+
+	migrationsByVersion := map[int]*migrationScript{}
+	for _, file := range files {
+		matches := migrationFileNamePattern.FindStringSubmatch(file.Name())
+		if matches == nil {
+			return nil, errors.New(fmt.Sprint("Invalid migration file name: ", file.Name()))
+		}
+
+		version, err := strconv.Atoi(matches[1])
+		if err != nil {
+			log.Errorf("Error converting the version of migration %s: %s", file.Name(), err.Error())
+			return nil, err
+		}
+
+		script, err := migrationFiles.ReadFile(path.Join("migrations", file.Name()))
+		if err != nil {
+			log.Errorf("Error reading migration %s: %s", file.Name(), err.Error())
+			return nil, err
+		}
+
+		migrationData, exists := migrationsByVersion[version]
+		if !exists {
+			migrationData = &migrationScript{version: version, name: matches[2]}
+			migrationsByVersion[version] = migrationData
+		} else if migrationData.name != matches[2] {
+			return nil, errors.New(fmt.Sprintf("The migration %d has scripts with different names: %s and %s",
+				version, migrationData.name, matches[2]))
+		}
+
+		if matches[3] == "up" {
+			migrationData.upScript = string(script)
+		} else {
+			migrationData.downScript = string(script)
+		}
+	}
+
+	var migrationScripts []migrationScript
+	for _, migrationData := range migrationsByVersion {
+		if migrationData.upScript == "" || migrationData.downScript == "" {
+			return nil, errors.New(fmt.Sprintf("The migration %d (%s) must have both the up and down scripts",
+				migrationData.version, migrationData.name))
+		}
+		migrationScripts = append(migrationScripts, *migrationData)
+	}
+	slices.SortFunc(migrationScripts, func(first, second migrationScript) int {
+		return first.version - second.version
+	})
+
+	return migrationScripts, nil
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/devlucassantos/vnc-domains/src/domains/article"
@@ -10,6 +11,7 @@ import (
 	"time"
 	"vnc-summarizer/adapters/databases/dto"
 	"vnc-summarizer/adapters/databases/postgres/queries"
+	"vnc-summarizer/core/domains/generation"
 	"vnc-summarizer/utils/datetime"
 )
 
@@ -23,17 +25,11 @@ func NewNewsletterRepository(connectionManager connectionManagerInterface) *News
 	}
 }
 
-func (instance Newsletter) CreateNewsletter(newsletter newsletter.Newsletter) (*uuid.UUID, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
-
+func (instance Newsletter) CreateNewsletter(ctx context.Context, newsletter newsletter.Newsletter,
+	generationData generation.Generation) (*uuid.UUID, error) {
 	formattedReferenceDate := newsletter.ReferenceDate().Format("02/01/2006")
 
-	transaction, err := postgresConnection.Beginx()
+	transaction, err := instance.connectionManager.beginTransaction(ctx)
 	if err != nil {
 		log.Errorf("Error starting transaction to register the newsletter of %s: %s", formattedReferenceDate,
 			err.Error())
@@ -50,22 +46,23 @@ func (instance Newsletter) CreateNewsletter(newsletter newsletter.Newsletter) (*
 	var articleId uuid.UUID
 	newsletterArticle := newsletter.Article()
 	articleType := newsletterArticle.Type()
-	err = transaction.QueryRow(queries.Article().Insert(), articleType.Id(), referenceDateTime).Scan(&articleId)
+	err = transaction.QueryRowContext(ctx, queries.Article().Insert(), articleType.Id(),
+		referenceDateTime).Scan(&articleId)
 	if err != nil {
 		log.Errorf("Error registering newsletter of %s as article: %s", formattedReferenceDate, err.Error())
 		return nil, err
 	}
 
 	var newsletterId uuid.UUID
-	err = transaction.QueryRow(queries.Newsletter().Insert(), newsletter.ReferenceDate(), newsletter.Description(),
-		articleId).Scan(&newsletterId)
+	err = transaction.QueryRowContext(ctx, queries.Newsletter().Insert(), newsletter.ReferenceDate(),
+		newsletter.Description(), articleId).Scan(&newsletterId)
 	if err != nil {
 		log.Errorf("Error registering the newsletter of %s: %s", formattedReferenceDate, err.Error())
 		return nil, err
 	}
 
 	for _, articleData := range newsletter.Articles() {
-		_, err = transaction.Exec(queries.NewsletterArticle().Insert(), newsletterId, articleData.Id())
+		_, err = transaction.ExecContext(ctx, queries.NewsletterArticle().Insert(), newsletterId, articleData.Id())
 		if err != nil {
 			log.Errorf("Error registering article %s as part of newsletter %s of %s: %s", articleData.Id(),
 				newsletterId, formattedReferenceDate, err.Error())
@@ -76,6 +73,12 @@ func (instance Newsletter) CreateNewsletter(newsletter newsletter.Newsletter) (*
 			formattedReferenceDate)
 	}
 
+	err = registerArticleGeneration(ctx, transaction, articleId, generationData)
+	if err != nil {
+		log.Error("registerArticleGeneration(): ", err.Error())
+		return nil, err
+	}
+
 	err = transaction.Commit()
 	if err != nil {
 		log.Errorf("Error confirming transaction to register newsletter of %s: %s", formattedReferenceDate,
@@ -88,17 +91,11 @@ func (instance Newsletter) CreateNewsletter(newsletter newsletter.Newsletter) (*
 	return &newsletterId, nil
 }
 
-func (instance Newsletter) UpdateNewsletter(newsletter newsletter.Newsletter, newArticles []article.Article) error {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
-
+func (instance Newsletter) UpdateNewsletter(ctx context.Context, newsletter newsletter.Newsletter,
+	newArticles []article.Article, generationData generation.Generation) error {
 	formattedReferenceDate := newsletter.ReferenceDate().Format("02/01/2006")
 
-	transaction, err := postgresConnection.Beginx()
+	transaction, err := instance.connectionManager.beginTransaction(ctx)
 	if err != nil {
 		log.Errorf("Error starting transaction to update newsletter %s of %s: %s", newsletter.Id(),
 			formattedReferenceDate, err.Error())
@@ -106,7 +103,7 @@ func (instance Newsletter) UpdateNewsletter(newsletter newsletter.Newsletter, ne
 	}
 	defer instance.connectionManager.rollbackTransaction(transaction)
 
-	_, err = transaction.Exec(queries.Newsletter().Update(), newsletter.Description(), newsletter.Id())
+	_, err = transaction.ExecContext(ctx, queries.Newsletter().Update(), newsletter.Description(), newsletter.Id())
 	if err != nil {
 		log.Errorf("Error updating newsletter %s of %s: %s", newsletter.Id(), formattedReferenceDate,
 			err.Error())
@@ -114,7 +111,7 @@ func (instance Newsletter) UpdateNewsletter(newsletter newsletter.Newsletter, ne
 	}
 
 	for _, articleData := range newArticles {
-		_, err = transaction.Exec(queries.NewsletterArticle().Insert(), newsletter.Id(), articleData.Id())
+		_, err = transaction.ExecContext(ctx, queries.NewsletterArticle().Insert(), newsletter.Id(), articleData.Id())
 		if err != nil {
 			log.Errorf("Error registering article %s as part of newsletter %s of %s: %s",
 				articleData.Id(), newsletter.Id(), formattedReferenceDate, err.Error())
@@ -125,13 +122,27 @@ func (instance Newsletter) UpdateNewsletter(newsletter newsletter.Newsletter, ne
 			formattedReferenceDate)
 	}
 
-	_, err = transaction.Exec(queries.Article().Update().NewsletterReferenceDateTime(), newsletter.Id())
+	_, err = transaction.ExecContext(ctx, queries.Article().Update().NewsletterReferenceDateTime(), newsletter.Id())
 	if err != nil {
 		log.Errorf("Error updating article reference date and time for newsletter %s of %s: %s",
 			newsletter.Id(), formattedReferenceDate, err.Error())
 		return err
 	}
 
+	var articleId uuid.UUID
+	err = transaction.GetContext(ctx, &articleId, queries.Newsletter().Select().ArticleIdById(), newsletter.Id())
+	if err != nil {
+		log.Errorf("Error retrieving the article of newsletter %s of %s: %s", newsletter.Id(),
+			formattedReferenceDate, err.Error())
+		return err
+	}
+
+	err = registerArticleGeneration(ctx, transaction, articleId, generationData)
+	if err != nil {
+		log.Error("registerArticleGeneration(): ", err.Error())
+		return err
+	}
+
 	err = transaction.Commit()
 	if err != nil {
 		log.Errorf("Error confirming transaction to update newsletter %s of %s: %s", newsletter.Id(),
@@ -143,16 +154,13 @@ func (instance Newsletter) UpdateNewsletter(newsletter newsletter.Newsletter, ne
 	return nil
 }
 
-func (instance Newsletter) GetNewsletterByReferenceDate(referenceDate time.Time) (*newsletter.Newsletter, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Newsletter) GetNewsletterByReferenceDate(ctx context.Context, referenceDate time.Time) (
+	*newsletter.Newsletter, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var newsletterData dto.Newsletter
-	err = postgresConnection.Get(&newsletterData, queries.Newsletter().Select().ByReferenceDate(), referenceDate)
+	err := postgresConnection.GetContext(ctx, &newsletterData, queries.Newsletter().Select().ByReferenceDate(),
+		referenceDate)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/devlucassantos/vnc-domains/src/domains/party"
@@ -20,17 +21,12 @@ func NewPartyRepository(connectionManager connectionManagerInterface) *Party {
 	}
 }
 
-func (instance Party) CreateParty(party party.Party) (*uuid.UUID, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Party) CreateParty(ctx context.Context, party party.Party) (*uuid.UUID, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var partyId uuid.UUID
-	err = postgresConnection.QueryRow(queries.Party().Insert(), party.Code(), party.Name(), party.Acronym(),
-		party.ImageUrl()).Scan(&partyId)
+	err := postgresConnection.QueryRowContext(ctx, queries.Party().Insert(), party.Code(), party.Name(),
+		party.Acronym(), party.ImageUrl()).Scan(&partyId)
 	if err != nil {
 		log.Errorf("Error registering party %d: %s", party.Code(), err.Error())
 		return nil, err
@@ -40,16 +36,11 @@ func (instance Party) CreateParty(party party.Party) (*uuid.UUID, error) {
 	return &partyId, nil
 }
 
-func (instance Party) UpdateParty(party party.Party) error {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Party) UpdateParty(ctx context.Context, party party.Party) error {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
-	_, err = postgresConnection.Exec(queries.Party().Update(), party.Name(), party.Acronym(), party.ImageUrl(),
-		party.Code())
+	_, err := postgresConnection.ExecContext(ctx, queries.Party().Update(), party.Name(), party.Acronym(),
+		party.ImageUrl(), party.Code())
 	if err != nil {
 		log.Errorf("Error updating party %d: %s", party.Code(), err.Error())
 		return err
@@ -59,16 +50,11 @@ func (instance Party) UpdateParty(party party.Party) error {
 	return nil
 }
 
-func (instance Party) GetPartyByCode(code int) (*party.Party, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Party) GetPartyByCode(ctx context.Context, code int) (*party.Party, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var partyData dto.Party
-	err = postgresConnection.Get(&partyData, queries.Party().Select().ByCode(), code)
+	err := postgresConnection.GetContext(ctx, &partyData, queries.Party().Select().ByCode(), code)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			log.Infof("Party %d not found in database", code)
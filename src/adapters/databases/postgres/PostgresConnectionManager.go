@@ -1,53 +1,207 @@
 package postgres
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/gommon/log"
 	_ "github.com/lib/pq"
 	"os"
+	"strconv"
 	"time"
 )
 
+const (
+	defaultMaximumOpenConnections = 20
+	defaultMaximumIdleConnections = 10
+	defaultConnectionMaxLifetime  = 30 * time.Minute
+	defaultConnectionMaxIdleTime  = 5 * time.Minute
+	defaultHealthCheckInterval    = time.Minute
+	healthCheckTimeout            = 10 * time.Second
+)
+
+// connectionInterface is implemented by both the pool and the transactions, so the repositories execute their queries
+// in the transaction of the unit of work when the context has one
+type connectionInterface interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+type transactionInterface interface {
+	connectionInterface
+	Commit() error
+	Rollback() error
+}
+
 type connectionManagerInterface interface {
-	createConnection() (*sqlx.DB, error)
-	closeConnection(*sqlx.DB)
-	rollbackTransaction(*sqlx.Tx)
+	getConnection(ctx context.Context) connectionInterface
+	beginTransaction(ctx context.Context) (transactionInterface, error)
+	rollbackTransaction(transactionInterface)
 }
 
-type ConnectionManager struct{}
+type transactionKey struct{}
 
-func NewPostgresConnectionManager() *ConnectionManager {
-	return &ConnectionManager{}
+// sharedTransaction is the transaction of the unit of work seen by the repositories, which can neither commit nor
+// roll it back since the unit of work decides the outcome of all its operations
+type sharedTransaction struct {
+	transactionInterface
+}
+
+func (sharedTransaction) Commit() error {
+	return nil
+}
+
+func (sharedTransaction) Rollback() error {
+	return nil
+}
+
+type ConnectionManager struct {
+	database *sqlx.DB
+	closed   chan struct{}
 }
 
-func (ConnectionManager) createConnection() (*sqlx.DB, error) {
-	db, err := sqlx.Open("postgres", getPostgresConnectionUri())
+// NewPostgresConnectionManager creates the connection pool shared by all the repositories of the process, which must
+// be created only once
+func NewPostgresConnectionManager() *ConnectionManager {
+	database, err := sqlx.Open("postgres", getPostgresConnectionUri())
+	if err != nil {
+		log.Fatal("Error creating the connection pool to the Postgres database: ", err.Error())
+	}
+	database.SetMaxOpenConns(getPositiveIntegerFromEnvironment("POSTGRESQL_MAX_OPEN_CONNECTIONS",
+		defaultMaximumOpenConnections))
+	database.SetMaxIdleConns(getPositiveIntegerFromEnvironment("POSTGRESQL_MAX_IDLE_CONNECTIONS",
+		defaultMaximumIdleConnections))
+	database.SetConnMaxLifetime(getDurationFromEnvironment("POSTGRESQL_CONNECTION_MAX_LIFETIME",
+		defaultConnectionMaxLifetime))
+	database.SetConnMaxIdleTime(getDurationFromEnvironment("POSTGRESQL_CONNECTION_MAX_IDLE_TIME",
+		defaultConnectionMaxIdleTime))
+
+	connectionManager := &ConnectionManager{database: database, closed: make(chan struct{})}
+	err = connectionManager.ping()
 	if err != nil {
-		log.Error("Error creating a connection to the Postgres database: ", err.Error())
-		return nil, err
+		log.Error("The Postgres database is unavailable: ", err.Error())
 	}
-	db.SetConnMaxLifetime(time.Minute)
-	db.SetMaxOpenConns(10000)
-	db.SetMaxIdleConns(10000)
+	go connectionManager.checkHealth(getDurationFromEnvironment("POSTGRESQL_HEALTH_CHECK_INTERVAL",
+		defaultHealthCheckInterval), err == nil)
 
-	return db, nil
+	return connectionManager
 }
 
-func (ConnectionManager) closeConnection(connection *sqlx.DB) {
-	err := connection.Close()
+// Close closes the connection pool, waiting for the queries in progress to finish
+func (instance ConnectionManager) Close() {
+	close(instance.closed)
+	err := instance.database.Close()
 	if err != nil {
-		log.Error("Error closing the connection to the Postgres database: ", err.Error())
+		log.Error("Error closing the connection pool to the Postgres database: ", err.Error())
 	}
 }
 
-func (ConnectionManager) rollbackTransaction(transaction *sqlx.Tx) {
+func (instance ConnectionManager) getConnection(ctx context.Context) connectionInterface {
+	transaction := getTransaction(ctx)
+	if transaction != nil {
+		return transaction
+	}
+
+	return instance.database
+}
+
+func (instance ConnectionManager) beginTransaction(ctx context.Context) (transactionInterface, error) {
+	transaction := getTransaction(ctx)
+	if transaction != nil {
+		return sharedTransaction{transaction}, nil
+	}
+
+	return instance.database.BeginTxx(ctx, nil)
+}
+
+func (ConnectionManager) rollbackTransaction(transaction transactionInterface) {
 	err := transaction.Rollback()
-	if err != nil {
+	if err != nil && !errors.Is(err, sql.ErrTxDone) {
 		log.Warn("Error canceling the transaction in the Postgres database: ", err.Error())
 	}
 }
 
+func (instance ConnectionManager) ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	return instance.database.PingContext(ctx)
+}
+
+// checkHealth pings the database periodically, so its unavailability is reported even when there are no queries
+// being executed, and the connections broken in the meantime are discarded by the pool
+func (instance ConnectionManager) checkHealth(interval time.Duration, isHealthy bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-instance.closed:
+			return
+		case <-ticker.C:
+		}
+
+		err := instance.ping()
+		if err != nil && isHealthy {
+			log.Error("The Postgres database is unavailable: ", err.Error())
+		} else if err == nil && !isHealthy {
+			log.Info("The Postgres database is available again")
+		}
+		isHealthy = err == nil
+	}
+}
+
+// withoutTransaction returns a copy of the context whose operations are executed outside the transaction of the unit
+// of work
+func withoutTransaction(ctx context.Context) context.Context {
+	return context.WithValue(ctx, transactionKey{}, nil)
+}
+
+func getTransaction(ctx context.Context) transactionInterface {
+	transaction, ok := ctx.Value(transactionKey{}).(transactionInterface)
+	if !ok {
+		return nil
+	}
+
+	return transaction
+}
+
+func getPositiveIntegerFromEnvironment(environmentVariable string, defaultValue int) int {
+	valueAsString := os.Getenv(environmentVariable)
+	if valueAsString == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(valueAsString)
+	if err != nil || value < 1 {
+		log.Warnf("The value of environment variable %s must be a positive integer, using the default value %d",
+			environmentVariable, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
+
+func getDurationFromEnvironment(environmentVariable string, defaultValue time.Duration) time.Duration {
+	valueAsString := os.Getenv(environmentVariable)
+	if valueAsString == "" {
+		return defaultValue
+	}
+
+	value, err := time.ParseDuration(valueAsString)
+	if err != nil || value <= 0 {
+		log.Warnf("The value of environment variable %s must be a positive duration, using the default value %s",
+			environmentVariable, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
+
 func getPostgresConnectionUri() string {
 	databaseUrl := os.Getenv("DATABASE_URL")
 	if len(databaseUrl) > 0 {
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/log"
+	"vnc-summarizer/adapters/databases/postgres/queries"
+)
+
+type ProcessingItem struct {
+	connectionManager connectionManagerInterface
+}
+
+func NewProcessingItemRepository(connectionManager connectionManagerInterface) *ProcessingItem {
+	return &ProcessingItem{
+		connectionManager: connectionManager,
+	}
+}
+
+func (instance ProcessingItem) StartProcessingItem(ctx context.Context, runId uuid.UUID, itemType, code string) (int,
+	error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	var numberOfAttempts int
+	err := postgresConnection.QueryRowContext(ctx, queries.ProcessingItem().Start(), uuid.NullUUID{UUID: runId,
+		Valid: runId != uuid.Nil}, itemType, code).Scan(&numberOfAttempts)
+	if err != nil {
+		log.Errorf("Error registering the start of the processing of %s %s: %s", itemType, code, err.Error())
+		return 0, err
+	}
+
+	return numberOfAttempts, nil
+}
+
+func (instance ProcessingItem) FinishProcessingItem(ctx context.Context, itemType, code, status,
+	errorMessage string) error {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	_, err := postgresConnection.ExecContext(ctx, queries.ProcessingItem().Finish(), itemType, code, status,
+		errorMessage)
+	if err != nil {
+		log.Errorf("Error registering the end of the processing of %s %s: %s", itemType, code, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (instance ProcessingItem) GetCodesOfTheItemsToRetry(ctx context.Context, itemType string,
+	maximumNumberOfAttempts int) ([]string, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	var codes []string
+	err := postgresConnection.SelectContext(ctx, &codes,
+		queries.ProcessingItem().Select().CodesOfTheFailedItemsByType(), itemType, maximumNumberOfAttempts)
+	if err != nil {
+		log.Errorf("Error retrieving the failed items of type %s from the database: %s", itemType, err.Error())
+		return nil, err
+	}
+
+	return codes, nil
+}
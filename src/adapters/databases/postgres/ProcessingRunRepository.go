@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/log"
+	"vnc-summarizer/adapters/databases/postgres/queries"
+)
+
+type ProcessingRun struct {
+	connectionManager connectionManagerInterface
+}
+
+func NewProcessingRunRepository(connectionManager connectionManagerInterface) *ProcessingRun {
+	return &ProcessingRun{
+		connectionManager: connectionManager,
+	}
+}
+
+func (instance ProcessingRun) CreateProcessingRun(ctx context.Context, jobName string) (*uuid.UUID, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	var processingRunId uuid.UUID
+	err := postgresConnection.QueryRowContext(ctx, queries.ProcessingRun().Insert(), jobName).Scan(&processingRunId)
+	if err != nil {
+		log.Errorf("Error registering the processing run of job %s: %s", jobName, err.Error())
+		return nil, err
+	}
+
+	return &processingRunId, nil
+}
+
+func (instance ProcessingRun) FinishProcessingRun(ctx context.Context, id uuid.UUID) error {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	_, err := postgresConnection.ExecContext(ctx, queries.ProcessingRun().Finish(), id)
+	if err != nil {
+		log.Errorf("Error registering the end of processing run %s: %s", id, err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -1,11 +1,13 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/proposition"
 	"github.com/google/uuid"
 	"github.com/labstack/gommon/log"
 	"vnc-summarizer/adapters/databases/dto"
 	"vnc-summarizer/adapters/databases/postgres/queries"
+	"vnc-summarizer/core/domains/generation"
 )
 
 type Proposition struct {
@@ -18,15 +20,9 @@ func NewPropositionRepository(connectionManager connectionManagerInterface) *Pro
 	}
 }
 
-func (instance Proposition) CreateProposition(proposition proposition.Proposition) (*uuid.UUID, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
-
-	transaction, err := postgresConnection.Beginx()
+func (instance Proposition) CreateProposition(ctx context.Context, proposition proposition.Proposition,
+	generationData generation.Generation) (*uuid.UUID, error) {
+	transaction, err := instance.connectionManager.beginTransaction(ctx)
 	if err != nil {
 		log.Errorf("Error starting transaction to register the proposition %d: %s", proposition.Code(),
 			err.Error())
@@ -37,8 +33,8 @@ func (instance Proposition) CreateProposition(proposition proposition.Propositio
 	var articleId uuid.UUID
 	propositionArticle := proposition.Article()
 	articleType := propositionArticle.Type()
-	err = transaction.QueryRow(queries.Article().Insert(), articleType.Id(), propositionArticle.ReferenceDateTime()).
-		Scan(&articleId)
+	err = transaction.QueryRowContext(ctx, queries.Article().Insert(), articleType.Id(),
+		propositionArticle.ReferenceDateTime()).Scan(&articleId)
 	if err != nil {
 		log.Errorf("Error registering proposition %d as article:  %s", proposition.Code(), err.Error())
 		return nil, err
@@ -56,10 +52,10 @@ func (instance Proposition) CreateProposition(proposition proposition.Propositio
 
 	var propositionId uuid.UUID
 	propositionType := proposition.Type()
-	err = transaction.QueryRow(queries.Proposition().Insert(), proposition.Code(), proposition.OriginalTextUrl(),
-		proposition.OriginalTextMimeType(), proposition.Title(), proposition.Content(), proposition.SubmittedAt(),
-		propositionImageUrl, imageDescription, proposition.SpecificType(), propositionType.Id(), articleId).Scan(
-		&propositionId)
+	err = transaction.QueryRowContext(ctx, queries.Proposition().Insert(), proposition.Code(),
+		proposition.OriginalTextUrl(), proposition.OriginalTextMimeType(), proposition.Title(), proposition.Content(),
+		proposition.SubmittedAt(), propositionImageUrl, imageDescription, proposition.SpecificType(),
+		propositionType.Id(), articleId).Scan(&propositionId)
 	if err != nil {
 		log.Errorf("Error registering the proposition %d: %s", proposition.Code(), err.Error())
 		return nil, err
@@ -68,8 +64,8 @@ func (instance Proposition) CreateProposition(proposition proposition.Propositio
 	for _, deputyData := range proposition.Deputies() {
 		var propositionAuthorId uuid.UUID
 		deputyParty := deputyData.Party()
-		err = transaction.QueryRow(queries.PropositionAuthor().Insert().Deputy(), propositionId, deputyData.Id(),
-			deputyParty.Id(), deputyData.FederatedUnit()).Scan(&propositionAuthorId)
+		err = transaction.QueryRowContext(ctx, queries.PropositionAuthor().Insert().Deputy(), propositionId,
+			deputyData.Id(), deputyParty.Id(), deputyData.FederatedUnit()).Scan(&propositionAuthorId)
 		if err != nil {
 			log.Errorf("Error registering deputy %s as the author of the proposition %d: %s", deputyData.Id(),
 				proposition.Code(), err.Error())
@@ -81,7 +77,7 @@ func (instance Proposition) CreateProposition(proposition proposition.Propositio
 
 	for _, externalAuthorData := range proposition.ExternalAuthors() {
 		var propositionAuthorId uuid.UUID
-		err = transaction.QueryRow(queries.PropositionAuthor().Insert().ExternalAuthor(), propositionId,
+		err = transaction.QueryRowContext(ctx, queries.PropositionAuthor().Insert().ExternalAuthor(), propositionId,
 			externalAuthorData.Id()).Scan(&propositionAuthorId)
 		if err != nil {
 			log.Errorf("Error registering external author %s as the author of the proposition %d: %s",
@@ -92,6 +88,12 @@ func (instance Proposition) CreateProposition(proposition proposition.Propositio
 			externalAuthorData.Id(), proposition.Code(), propositionAuthorId)
 	}
 
+	err = registerArticleGeneration(ctx, transaction, articleId, generationData)
+	if err != nil {
+		log.Error("registerArticleGeneration(): ", err.Error())
+		return nil, err
+	}
+
 	err = transaction.Commit()
 	if err != nil {
 		log.Errorf("Error confirming transaction to register proposition %d: %s", proposition.Code(),
@@ -104,13 +106,9 @@ func (instance Proposition) CreateProposition(proposition proposition.Propositio
 	return &propositionId, err
 }
 
-func (instance Proposition) GetPropositionsByCodes(codes []int) ([]proposition.Proposition, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Proposition) GetPropositionsByCodes(ctx context.Context, codes []int) ([]proposition.Proposition,
+	error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var propositionCodes []interface{}
 	for _, code := range codes {
@@ -118,8 +116,8 @@ func (instance Proposition) GetPropositionsByCodes(codes []int) ([]proposition.P
 	}
 
 	var propositions []dto.Proposition
-	err = postgresConnection.Select(&propositions, queries.Proposition().Select().ByCodes(len(propositionCodes)),
-		propositionCodes...)
+	err := postgresConnection.SelectContext(ctx, &propositions,
+		queries.Proposition().Select().ByCodes(len(propositionCodes)), propositionCodes...)
 	if err != nil {
 		log.Error("Error retrieving the proposition data by codes from the database: ", err.Error())
 		return nil, err
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/devlucassantos/vnc-domains/src/domains/propositiontype"
@@ -19,20 +20,16 @@ func NewPropositionTypeRepository(connectionManager connectionManagerInterface)
 	}
 }
 
-func (instance PropositionType) GetPropositionTypeByCodeOrDefaultType(code string) (*propositiontype.PropositionType,
-	error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance PropositionType) GetPropositionTypeByCodeOrDefaultType(ctx context.Context, code string) (
+	*propositiontype.PropositionType, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var propositionType dto.PropositionType
-	err = postgresConnection.Get(&propositionType, queries.PropositionType().Select().ByCode(), code)
+	err := postgresConnection.GetContext(ctx, &propositionType, queries.PropositionType().Select().ByCode(), code)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			err = postgresConnection.Get(&propositionType, queries.PropositionType().Select().DefaultOption())
+			err = postgresConnection.GetContext(ctx, &propositionType,
+				queries.PropositionType().Select().DefaultOption())
 			if err != nil {
 				log.Error("Error retrieving the default proposition type data for cases where the proposition type "+
 					"code searched was not found in the database: ", err.Error())
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/labstack/gommon/log"
+	"time"
+	"vnc-summarizer/adapters/databases/postgres/queries"
+)
+
+type ScheduledJob struct {
+	connectionManager connectionManagerInterface
+}
+
+func NewScheduledJobRepository(connectionManager connectionManagerInterface) *ScheduledJob {
+	return &ScheduledJob{
+		connectionManager: connectionManager,
+	}
+}
+
+func (instance ScheduledJob) GetLastExecutionTime(ctx context.Context, jobName string) (*time.Time, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	var lastExecutedAt time.Time
+	err := postgresConnection.GetContext(ctx, &lastExecutedAt,
+		queries.ScheduledJob().Select().LastExecutionTimeByName(), jobName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		log.Errorf("Error retrieving the last execution time of job %s from the database: %s", jobName,
+			err.Error())
+		return nil, err
+	}
+
+	return &lastExecutedAt, nil
+}
+
+func (instance ScheduledJob) SaveLastExecutionTime(ctx context.Context, jobName string, executedAt time.Time) error {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
+
+	_, err := postgresConnection.ExecContext(ctx, queries.ScheduledJob().Upsert(), jobName, executedAt)
+	if err != nil {
+		log.Errorf("Error registering the last execution time of job %s: %s", jobName, err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"github.com/labstack/gommon/log"
+)
+
+type UnitOfWork struct {
+	connectionManager connectionManagerInterface
+}
+
+func NewUnitOfWork(connectionManager connectionManagerInterface) *UnitOfWork {
+	return &UnitOfWork{
+		connectionManager: connectionManager,
+	}
+}
+
+// Execute runs the operation in a single transaction, which is committed only if the operation succeeds. The
+// repositories called with the context received by the operation take part in the transaction, so the operation must
+// not call them concurrently. Units of work started inside the operation take part in the same transaction.
+func (instance UnitOfWork) Execute(ctx context.Context, operation func(ctx context.Context) error) error {
+	if getTransaction(ctx) != nil {
+		return operation(ctx)
+	}
+
+	transaction, err := instance.connectionManager.beginTransaction(ctx)
+	if err != nil {
+		log.Error("Error starting the transaction of the unit of work: ", err.Error())
+		return err
+	}
+	defer instance.connectionManager.rollbackTransaction(transaction)
+
+	err = operation(context.WithValue(ctx, transactionKey{}, transaction))
+	if err != nil {
+		return err
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		log.Error("Error confirming the transaction of the unit of work: ", err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -1,11 +1,13 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/voting"
 	"github.com/google/uuid"
 	"github.com/labstack/gommon/log"
 	"vnc-summarizer/adapters/databases/dto"
 	"vnc-summarizer/adapters/databases/postgres/queries"
+	"vnc-summarizer/core/domains/generation"
 	"vnc-summarizer/utils/datetime"
 )
 
@@ -19,15 +21,9 @@ func NewVotingRepository(connectionManager connectionManagerInterface) *Voting {
 	}
 }
 
-func (instance Voting) CreateVoting(voting voting.Voting) (*uuid.UUID, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
-
-	transaction, err := postgresConnection.Beginx()
+func (instance Voting) CreateVoting(ctx context.Context, voting voting.Voting, generationData generation.Generation) (
+	*uuid.UUID, error) {
+	transaction, err := instance.connectionManager.beginTransaction(ctx)
 	if err != nil {
 		log.Errorf("Error starting transaction to register the voting %s: %s", voting.Code(), err.Error())
 		return nil, err
@@ -43,7 +39,8 @@ func (instance Voting) CreateVoting(voting voting.Voting) (*uuid.UUID, error) {
 	var articleId uuid.UUID
 	votingArticle := voting.Article()
 	articleType := votingArticle.Type()
-	err = transaction.QueryRow(queries.Article().Insert(), articleType.Id(), referenceDateTime).Scan(&articleId)
+	err = transaction.QueryRowContext(ctx, queries.Article().Insert(), articleType.Id(),
+		referenceDateTime).Scan(&articleId)
 	if err != nil {
 		log.Errorf("Error registering voting %s as article: %s", voting.Id(), err.Error())
 		return nil, err
@@ -58,16 +55,17 @@ func (instance Voting) CreateVoting(voting voting.Voting) (*uuid.UUID, error) {
 
 	var votingId uuid.UUID
 	legislativeBody := voting.LegislativeBody()
-	err = transaction.QueryRow(queries.Voting().Insert(), voting.Code(), voting.Description(), voting.Result(),
-		voting.ResultAnnouncedAt(), voting.IsApproved(), legislativeBody.Id(), mainPropositionId, articleId).
-		Scan(&votingId)
+	err = transaction.QueryRowContext(ctx, queries.Voting().Insert(), voting.Code(), voting.Description(),
+		voting.Result(), voting.ResultAnnouncedAt(), voting.IsApproved(), legislativeBody.Id(), mainPropositionId,
+		articleId).Scan(&votingId)
 	if err != nil {
 		log.Errorf("Error registering voting %s: %s", voting.Code(), err.Error())
 		return nil, err
 	}
 
 	for _, relatedProposition := range voting.RelatedPropositions() {
-		_, err = transaction.Exec(queries.PropositionRelatedToVoting().Insert(), relatedProposition.Id(), votingId)
+		_, err = transaction.ExecContext(ctx, queries.PropositionRelatedToVoting().Insert(), relatedProposition.Id(),
+			votingId)
 		if err != nil {
 			log.Errorf("Error registering proposition %s related to voting %s: %s", relatedProposition.Id(),
 				votingId, err.Error())
@@ -78,7 +76,8 @@ func (instance Voting) CreateVoting(voting voting.Voting) (*uuid.UUID, error) {
 	}
 
 	for _, affectedProposition := range voting.AffectedPropositions() {
-		_, err = transaction.Exec(queries.PropositionAffectedByVoting().Insert(), affectedProposition.Id(), votingId)
+		_, err = transaction.ExecContext(ctx, queries.PropositionAffectedByVoting().Insert(), affectedProposition.Id(),
+			votingId)
 		if err != nil {
 			log.Errorf("Error registering proposition %s affected by voting %s: %s", affectedProposition.Id(),
 				votingId, err.Error())
@@ -88,6 +87,12 @@ func (instance Voting) CreateVoting(voting voting.Voting) (*uuid.UUID, error) {
 			votingId)
 	}
 
+	err = registerArticleGeneration(ctx, transaction, articleId, generationData)
+	if err != nil {
+		log.Error("registerArticleGeneration(): ", err.Error())
+		return nil, err
+	}
+
 	err = transaction.Commit()
 	if err != nil {
 		log.Errorf("Error confirming transaction to register voting %s: %s", voting.Code(), err.Error())
@@ -99,13 +104,8 @@ func (instance Voting) CreateVoting(voting voting.Voting) (*uuid.UUID, error) {
 	return &votingId, nil
 }
 
-func (instance Voting) GetVotesByCodes(codes []string) ([]voting.Voting, error) {
-	postgresConnection, err := instance.connectionManager.createConnection()
-	if err != nil {
-		log.Error("connectionManager.createConnection(): ", err.Error())
-		return nil, err
-	}
-	defer instance.connectionManager.closeConnection(postgresConnection)
+func (instance Voting) GetVotesByCodes(ctx context.Context, codes []string) ([]voting.Voting, error) {
+	postgresConnection := instance.connectionManager.getConnection(ctx)
 
 	var votingCodes []interface{}
 	for _, code := range codes {
@@ -113,7 +113,8 @@ func (instance Voting) GetVotesByCodes(codes []string) ([]voting.Voting, error)
 	}
 
 	var votes []dto.Voting
-	err = postgresConnection.Select(&votes, queries.Voting().Select().ByCodes(len(votingCodes)), votingCodes...)
+	err := postgresConnection.SelectContext(ctx, &votes, queries.Voting().Select().ByCodes(len(votingCodes)),
+		votingCodes...)
 	if err != nil {
 		log.Error("Error retrieving the voting data by codes from the database: ", err.Error())
 		return nil, err
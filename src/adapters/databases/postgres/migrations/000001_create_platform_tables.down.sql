@@ -0,0 +1,18 @@
+-- The tables of the platform data are shared with the other services and are owned by vnc-databases, so reverting this
+-- migration only drops them when the vnc_summarizer.drop_platform_tables setting is enabled by the
+-- --drop-platform-tables flag of the migrate command. Otherwise, only the record of the migration is removed, which
+-- allows it to be applied again.
+
+DO $$
+BEGIN
+    IF COALESCE(current_setting('vnc_summarizer.drop_platform_tables', true), '') <> 'on' THEN
+        RAISE NOTICE 'The tables of the platform data were kept';
+        RETURN;
+    END IF;
+
+    DROP TABLE IF EXISTS newsletter_article, newsletter, event_agenda_item, agenda_item_regime, event_requirement,
+        event_legislative_body, event, event_situation, event_type, proposition_affected_by_voting,
+        proposition_related_to_voting, voting, legislative_body, legislative_body_type, proposition_author,
+        proposition, proposition_type, external_author, external_author_type, deputy, party, article, article_type;
+END
+$$;
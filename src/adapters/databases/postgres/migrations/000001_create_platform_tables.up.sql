@@ -0,0 +1,288 @@
+-- Tables of the platform data shared with the other services. They are only created when they do not exist yet, so
+-- the migration can also be applied to databases created by vnc-databases.
+
+CREATE TABLE IF NOT EXISTS article_type (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    description VARCHAR(100) NOT NULL,
+    codes       VARCHAR(100) NOT NULL,
+    color       VARCHAR(7) NOT NULL,
+    active      BOOLEAN NOT NULL DEFAULT true,
+    created_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS article (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_type_id     UUID NOT NULL REFERENCES article_type (id),
+    reference_date_time TIMESTAMP NOT NULL,
+    active              BOOLEAN NOT NULL DEFAULT true,
+    created_at          TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at          TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE INDEX IF NOT EXISTS article_reference_date_time_index ON article (reference_date_time);
+
+CREATE TABLE IF NOT EXISTS party (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code       INT NOT NULL UNIQUE,
+    name       VARCHAR(255) NOT NULL,
+    acronym    VARCHAR(50) NOT NULL,
+    image_url  VARCHAR(255) NOT NULL,
+    active     BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS deputy (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code           INT NOT NULL UNIQUE,
+    cpf            VARCHAR(11),
+    name           VARCHAR(255) NOT NULL,
+    electoral_name VARCHAR(255) NOT NULL,
+    image_url      VARCHAR(255) NOT NULL,
+    party_id       UUID NOT NULL REFERENCES party (id),
+    federated_unit VARCHAR(2) NOT NULL,
+    active         BOOLEAN NOT NULL DEFAULT true,
+    created_at     TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at     TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS external_author_type (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code        INT NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL,
+    active      BOOLEAN NOT NULL DEFAULT true,
+    created_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS external_author (
+    id                      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name                    VARCHAR(255) NOT NULL,
+    external_author_type_id UUID NOT NULL REFERENCES external_author_type (id),
+    active                  BOOLEAN NOT NULL DEFAULT true,
+    created_at              TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at              TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    UNIQUE (name, external_author_type_id)
+);
+
+CREATE TABLE IF NOT EXISTS proposition_type (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    description VARCHAR(100) NOT NULL,
+    codes       VARCHAR(255) NOT NULL,
+    color       VARCHAR(7) NOT NULL,
+    active      BOOLEAN NOT NULL DEFAULT true,
+    created_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS proposition (
+    id                      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code                    INT NOT NULL UNIQUE,
+    original_text_url       VARCHAR(255) NOT NULL,
+    original_text_mime_type VARCHAR(100) NOT NULL,
+    title                   TEXT NOT NULL,
+    content                 TEXT NOT NULL,
+    submitted_at            TIMESTAMP NOT NULL,
+    image_url               VARCHAR(255),
+    image_description       TEXT,
+    specific_type           VARCHAR(255) NOT NULL,
+    proposition_type_id     UUID NOT NULL REFERENCES proposition_type (id),
+    article_id              UUID NOT NULL UNIQUE REFERENCES article (id),
+    active                  BOOLEAN NOT NULL DEFAULT true,
+    created_at              TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at              TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS proposition_author (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    proposition_id     UUID NOT NULL REFERENCES proposition (id),
+    deputy_id          UUID REFERENCES deputy (id),
+    party_id           UUID REFERENCES party (id),
+    federated_unit     VARCHAR(2),
+    external_author_id UUID REFERENCES external_author (id),
+    active             BOOLEAN NOT NULL DEFAULT true,
+    created_at         TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at         TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    CHECK ((deputy_id IS NULL) <> (external_author_id IS NULL))
+);
+
+CREATE TABLE IF NOT EXISTS legislative_body_type (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code        INT NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL,
+    active      BOOLEAN NOT NULL DEFAULT true,
+    created_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS legislative_body (
+    id                       UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code                     INT NOT NULL UNIQUE,
+    name                     VARCHAR(255) NOT NULL,
+    acronym                  VARCHAR(100) NOT NULL,
+    legislative_body_type_id UUID NOT NULL REFERENCES legislative_body_type (id),
+    active                   BOOLEAN NOT NULL DEFAULT true,
+    created_at               TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at               TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS voting (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code                VARCHAR(50) NOT NULL UNIQUE,
+    description         TEXT NOT NULL,
+    result              TEXT NOT NULL,
+    result_announced_at TIMESTAMP NOT NULL,
+    is_approved         BOOLEAN,
+    legislative_body_id UUID NOT NULL REFERENCES legislative_body (id),
+    main_proposition_id UUID REFERENCES proposition (id),
+    article_id          UUID NOT NULL UNIQUE REFERENCES article (id),
+    active              BOOLEAN NOT NULL DEFAULT true,
+    created_at          TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at          TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS proposition_related_to_voting (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    proposition_id UUID NOT NULL REFERENCES proposition (id),
+    voting_id      UUID NOT NULL REFERENCES voting (id),
+    active         BOOLEAN NOT NULL DEFAULT true,
+    created_at     TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at     TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS proposition_affected_by_voting (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    proposition_id UUID NOT NULL REFERENCES proposition (id),
+    voting_id      UUID NOT NULL REFERENCES voting (id),
+    active         BOOLEAN NOT NULL DEFAULT true,
+    created_at     TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at     TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS event_type (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    description VARCHAR(100) NOT NULL,
+    codes       VARCHAR(255) NOT NULL,
+    color       VARCHAR(7) NOT NULL,
+    active      BOOLEAN NOT NULL DEFAULT true,
+    created_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS event_situation (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    description VARCHAR(100) NOT NULL,
+    codes       VARCHAR(255) NOT NULL,
+    color       VARCHAR(7) NOT NULL,
+    is_finished BOOLEAN NOT NULL DEFAULT false,
+    active      BOOLEAN NOT NULL DEFAULT true,
+    created_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS event (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code               INT NOT NULL UNIQUE,
+    title              TEXT NOT NULL,
+    description        TEXT NOT NULL,
+    starts_at          TIMESTAMP NOT NULL,
+    ends_at            TIMESTAMP,
+    location           VARCHAR(255) NOT NULL,
+    is_internal        BOOLEAN NOT NULL,
+    video_url          VARCHAR(255),
+    specific_type      VARCHAR(255) NOT NULL,
+    event_type_id      UUID NOT NULL REFERENCES event_type (id),
+    specific_situation VARCHAR(255) NOT NULL,
+    event_situation_id UUID NOT NULL REFERENCES event_situation (id),
+    article_id         UUID NOT NULL UNIQUE REFERENCES article (id),
+    active             BOOLEAN NOT NULL DEFAULT true,
+    created_at         TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at         TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS event_legislative_body (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id            UUID NOT NULL REFERENCES event (id),
+    legislative_body_id UUID NOT NULL REFERENCES legislative_body (id),
+    active              BOOLEAN NOT NULL DEFAULT true,
+    created_at          TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at          TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS event_requirement (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id       UUID NOT NULL REFERENCES event (id),
+    proposition_id UUID NOT NULL REFERENCES proposition (id),
+    active         BOOLEAN NOT NULL DEFAULT true,
+    created_at     TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at     TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS agenda_item_regime (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code        INT NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL,
+    active      BOOLEAN NOT NULL DEFAULT true,
+    created_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at  TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS event_agenda_item (
+    id                        UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title                     TEXT NOT NULL,
+    topic                     TEXT,
+    situation                 TEXT,
+    agenda_item_regime_id     UUID NOT NULL REFERENCES agenda_item_regime (id),
+    rapporteur_id             UUID REFERENCES deputy (id),
+    rapporteur_party_id       UUID REFERENCES party (id),
+    rapporteur_federated_unit VARCHAR(2),
+    proposition_id            UUID NOT NULL REFERENCES proposition (id),
+    related_proposition_id    UUID REFERENCES proposition (id),
+    voting_id                 UUID REFERENCES voting (id),
+    event_id                  UUID NOT NULL REFERENCES event (id),
+    active                    BOOLEAN NOT NULL DEFAULT true,
+    created_at                TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at                TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS newsletter (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reference_date DATE NOT NULL UNIQUE,
+    description    TEXT NOT NULL,
+    article_id     UUID NOT NULL UNIQUE REFERENCES article (id),
+    active         BOOLEAN NOT NULL DEFAULT true,
+    created_at     TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at     TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS newsletter_article (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    newsletter_id UUID NOT NULL REFERENCES newsletter (id),
+    article_id    UUID NOT NULL REFERENCES article (id),
+    active        BOOLEAN NOT NULL DEFAULT true,
+    created_at    TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at    TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+-- The types required by the summarizer. The specific types of propositions and events and the event situations are
+-- optional, since the items without a registered type are linked to the default option.
+INSERT INTO article_type (description, codes, color)
+SELECT description, codes, color
+FROM (VALUES ('Proposições', 'proposition', '#0047AB'),
+             ('Votações', 'voting', '#008000'),
+             ('Eventos', 'event', '#FF8C00'),
+             ('Boletins', 'newsletter', '#8B0000')) AS article_types (description, codes, color)
+WHERE NOT EXISTS(SELECT 1 FROM article_type WHERE article_type.codes = article_types.codes);
+
+INSERT INTO proposition_type (description, codes, color)
+SELECT 'Outras Proposições', 'default_option', '#808080'
+WHERE NOT EXISTS(SELECT 1 FROM proposition_type WHERE 'default_option' = ANY(string_to_array(codes, ',')));
+
+INSERT INTO event_type (description, codes, color)
+SELECT 'Outros Eventos', 'default_option', '#808080'
+WHERE NOT EXISTS(SELECT 1 FROM event_type WHERE 'default_option' = ANY(string_to_array(codes, ',')));
+
+INSERT INTO event_situation (description, codes, color, is_finished)
+SELECT 'Outras Situações', 'default_option', '#808080', false
+WHERE NOT EXISTS(SELECT 1 FROM event_situation WHERE 'default_option' = ANY(string_to_array(codes, ',')));
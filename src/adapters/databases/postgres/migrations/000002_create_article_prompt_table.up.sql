@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS article_prompt (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id     UUID NOT NULL REFERENCES article (id),
+    prompt_code    VARCHAR(100) NOT NULL,
+    prompt_variant VARCHAR(100) NOT NULL DEFAULT '',
+    prompt_version INT NOT NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE INDEX IF NOT EXISTS article_prompt_article_id_index ON article_prompt (article_id);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS article_summary (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id      UUID NOT NULL UNIQUE REFERENCES article (id),
+    key_points      JSONB NOT NULL,
+    affected_groups JSONB NOT NULL,
+    subject_tags    JSONB NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
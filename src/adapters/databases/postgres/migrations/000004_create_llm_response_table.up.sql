@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS llm_response (
+    key        VARCHAR(64) PRIMARY KEY,
+    response   TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
@@ -0,0 +1,16 @@
+-- The usages are registered as soon as the generations are made, so the article is only informed when the generation
+-- is used by an article that is registered
+CREATE TABLE IF NOT EXISTS generation_usage (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id        UUID REFERENCES article (id),
+    provider          VARCHAR(50) NOT NULL,
+    model             VARCHAR(100) NOT NULL,
+    prompt_tokens     INT NOT NULL DEFAULT 0,
+    completion_tokens INT NOT NULL DEFAULT 0,
+    number_of_images  INT NOT NULL DEFAULT 0,
+    estimated_cost    NUMERIC(12, 6) NOT NULL DEFAULT 0,
+    created_at        TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE INDEX IF NOT EXISTS generation_usage_article_id_index ON generation_usage (article_id);
+CREATE INDEX IF NOT EXISTS generation_usage_created_at_index ON generation_usage (created_at);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS backfill_checkpoint (
+    data_type      VARCHAR(50) NOT NULL,
+    reference_date DATE NOT NULL,
+    completed_at   TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    PRIMARY KEY (data_type, reference_date)
+);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS scheduled_job (
+    name             VARCHAR(100) PRIMARY KEY,
+    last_executed_at TIMESTAMPTZ NOT NULL,
+    created_at       TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at       TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
@@ -0,0 +1,4 @@
+ALTER TABLE generation_usage DROP COLUMN IF EXISTS run_id;
+DROP TABLE IF EXISTS processing_attempt;
+DROP TABLE IF EXISTS processing_item;
+DROP TABLE IF EXISTS processing_run;
@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS processing_run (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_name        VARCHAR(100) NOT NULL,
+    status          VARCHAR(20) NOT NULL DEFAULT 'running',
+    number_of_items INT NOT NULL DEFAULT 0,
+    started_at      TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    finished_at     TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at      TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS processing_item (
+    id                       UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    run_id                   UUID REFERENCES processing_run (id),
+    item_type                VARCHAR(50) NOT NULL,
+    code                     VARCHAR(50) NOT NULL,
+    status                   VARCHAR(20) NOT NULL,
+    number_of_attempts       INT NOT NULL DEFAULT 0,
+    error                    TEXT,
+    last_started_at          TIMESTAMP NOT NULL,
+    last_finished_at         TIMESTAMP,
+    duration_in_milliseconds BIGINT,
+    created_at               TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at               TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    UNIQUE (item_type, code)
+);
+
+CREATE INDEX IF NOT EXISTS processing_item_run_id_index ON processing_item (run_id);
+
+-- The item keeps only the state of its last attempt, which is used to retry it, while each attempt is kept here
+CREATE TABLE IF NOT EXISTS processing_attempt (
+    id                       UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    processing_item_id       UUID NOT NULL REFERENCES processing_item (id),
+    run_id                   UUID REFERENCES processing_run (id),
+    attempt                  INT NOT NULL,
+    status                   VARCHAR(20) NOT NULL,
+    error                    TEXT,
+    started_at               TIMESTAMP NOT NULL,
+    finished_at              TIMESTAMP,
+    duration_in_milliseconds BIGINT,
+    created_at               TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at               TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    UNIQUE (processing_item_id, attempt)
+);
+
+CREATE INDEX IF NOT EXISTS processing_attempt_run_id_index ON processing_attempt (run_id);
+
+ALTER TABLE generation_usage ADD COLUMN IF NOT EXISTS run_id UUID REFERENCES processing_run (id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS article_image_variant;
+DROP TABLE IF EXISTS article_image;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS article_image (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id     UUID NOT NULL UNIQUE REFERENCES article (id),
+    blur_hash      VARCHAR(100),
+    dominant_color VARCHAR(7),
+    created_at     TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE TABLE IF NOT EXISTS article_image_variant (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_image_id UUID NOT NULL REFERENCES article_image (id),
+    kind             VARCHAR(50) NOT NULL,
+    format           VARCHAR(10) NOT NULL,
+    width            INT NOT NULL,
+    height           INT NOT NULL,
+    url              VARCHAR(255) NOT NULL,
+    created_at       TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE INDEX IF NOT EXISTS article_image_variant_article_image_id_index ON article_image_variant (article_image_id);
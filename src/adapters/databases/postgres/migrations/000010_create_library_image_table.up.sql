@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS library_image (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    image_url         VARCHAR(255) NOT NULL,
+    image_description TEXT NOT NULL,
+    blur_hash         VARCHAR(100),
+    dominant_color    VARCHAR(7),
+    variants          JSONB NOT NULL DEFAULT '[]'::JSONB,
+    subject_tags      JSONB NOT NULL,
+    embedding         DOUBLE PRECISION[] NOT NULL,
+    embedding_model   VARCHAR(100) NOT NULL,
+    number_of_uses    INT NOT NULL DEFAULT 0,
+    active            BOOLEAN NOT NULL DEFAULT TRUE,
+    last_used_at      TIMESTAMP,
+    created_at        TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+    updated_at        TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE INDEX IF NOT EXISTS library_image_subject_tags_index ON library_image USING GIN (subject_tags);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS article_image_review;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS article_image_review (
+    id                           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id                   UUID NOT NULL REFERENCES article (id),
+    image_url                    VARCHAR(255) NOT NULL,
+    image_provider               VARCHAR(100) NOT NULL,
+    contains_text                BOOLEAN NOT NULL,
+    contains_politician_likeness BOOLEAN NOT NULL,
+    contains_party_logo          BOOLEAN NOT NULL,
+    contains_unsafe_content      BOOLEAN NOT NULL,
+    relevance_score              NUMERIC(3, 2) NOT NULL,
+    justification                TEXT NOT NULL DEFAULT '',
+    approved                     BOOLEAN NOT NULL,
+    created_at                   TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+);
+
+CREATE INDEX IF NOT EXISTS article_image_review_article_id_index ON article_image_review (article_id);
@@ -0,0 +1,13 @@
+package queries
+
+type articleImageSqlManager struct{}
+
+func ArticleImage() *articleImageSqlManager {
+	return &articleImageSqlManager{}
+}
+
+func (articleImageSqlManager) Insert() string {
+	return `INSERT INTO article_image(article_id, blur_hash, dominant_color)
+			VALUES ($1, NULLIF($2, ''), NULLIF($3, ''))
+			RETURNING id`
+}
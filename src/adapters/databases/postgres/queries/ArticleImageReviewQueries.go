@@ -0,0 +1,15 @@
+package queries
+
+type articleImageReviewSqlManager struct{}
+
+func ArticleImageReview() *articleImageReviewSqlManager {
+	return &articleImageReviewSqlManager{}
+}
+
+func (articleImageReviewSqlManager) Insert() string {
+	return `INSERT INTO article_image_review(article_id, image_url, image_provider, contains_text,
+				contains_politician_likeness, contains_party_logo, contains_unsafe_content, relevance_score,
+				justification, approved)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING id`
+}
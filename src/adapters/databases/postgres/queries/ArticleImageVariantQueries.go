@@ -0,0 +1,13 @@
+package queries
+
+type articleImageVariantSqlManager struct{}
+
+func ArticleImageVariant() *articleImageVariantSqlManager {
+	return &articleImageVariantSqlManager{}
+}
+
+func (articleImageVariantSqlManager) Insert() string {
+	return `INSERT INTO article_image_variant(article_image_id, kind, format, width, height, url)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id`
+}
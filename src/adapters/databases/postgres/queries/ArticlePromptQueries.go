@@ -0,0 +1,13 @@
+package queries
+
+type articlePromptSqlManager struct{}
+
+func ArticlePrompt() *articlePromptSqlManager {
+	return &articlePromptSqlManager{}
+}
+
+func (articlePromptSqlManager) Insert() string {
+	return `INSERT INTO article_prompt(article_id, prompt_code, prompt_variant, prompt_version)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id`
+}
@@ -0,0 +1,13 @@
+package queries
+
+type articleSummarySqlManager struct{}
+
+func ArticleSummary() *articleSummarySqlManager {
+	return &articleSummarySqlManager{}
+}
+
+func (articleSummarySqlManager) Insert() string {
+	return `INSERT INTO article_summary(article_id, key_points, affected_groups, subject_tags)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id`
+}
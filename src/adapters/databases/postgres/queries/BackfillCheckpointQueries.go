@@ -0,0 +1,26 @@
+package queries
+
+type backfillCheckpointSqlManager struct{}
+
+func BackfillCheckpoint() *backfillCheckpointSqlManager {
+	return &backfillCheckpointSqlManager{}
+}
+
+func (backfillCheckpointSqlManager) Upsert() string {
+	return `INSERT INTO backfill_checkpoint(data_type, reference_date)
+			VALUES ($1, $2)
+			ON CONFLICT (data_type, reference_date) DO UPDATE SET
+				completed_at = TIMEZONE('America/Sao_Paulo'::TEXT, NOW())`
+}
+
+type backfillCheckpointSelectSqlManager struct{}
+
+func (backfillCheckpointSqlManager) Select() *backfillCheckpointSelectSqlManager {
+	return &backfillCheckpointSelectSqlManager{}
+}
+
+func (backfillCheckpointSelectSqlManager) CompletedDatesByDataTypeAndDateRange() string {
+	return `SELECT reference_date
+			FROM backfill_checkpoint
+			WHERE data_type = $1 AND reference_date BETWEEN $2::DATE AND $3::DATE`
+}
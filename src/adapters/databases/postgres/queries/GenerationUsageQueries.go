@@ -0,0 +1,31 @@
+package queries
+
+type generationUsageSqlManager struct{}
+
+func GenerationUsage() *generationUsageSqlManager {
+	return &generationUsageSqlManager{}
+}
+
+func (generationUsageSqlManager) Insert() string {
+	return `INSERT INTO generation_usage(run_id, provider, model, prompt_tokens, completion_tokens, number_of_images,
+				estimated_cost)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id`
+}
+
+func (generationUsageSqlManager) UpdateArticle() string {
+	return `UPDATE generation_usage SET article_id = $1
+			WHERE id = $2`
+}
+
+type generationUsageSelectSqlManager struct{}
+
+func (generationUsageSqlManager) Select() *generationUsageSelectSqlManager {
+	return &generationUsageSelectSqlManager{}
+}
+
+func (generationUsageSelectSqlManager) EstimatedCostByDate() string {
+	return `SELECT COALESCE(SUM(estimated_cost), 0)
+			FROM generation_usage
+			WHERE created_at::DATE = $1::DATE`
+}
@@ -0,0 +1,41 @@
+package queries
+
+type libraryImageSqlManager struct{}
+
+func LibraryImage() *libraryImageSqlManager {
+	return &libraryImageSqlManager{}
+}
+
+func (libraryImageSqlManager) Insert() string {
+	return `INSERT INTO library_image(image_url, image_description, blur_hash, dominant_color, variants, subject_tags,
+				embedding, embedding_model)
+			VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7, $8)
+			RETURNING id`
+}
+
+func (libraryImageSqlManager) RegisterUse() string {
+	return `UPDATE library_image SET number_of_uses = number_of_uses + 1,
+				last_used_at = TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+				updated_at = TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+			WHERE id = $1`
+}
+
+type libraryImageSelectSqlManager struct{}
+
+func (libraryImageSqlManager) Select() *libraryImageSelectSqlManager {
+	return &libraryImageSelectSqlManager{}
+}
+
+// ActiveBySubjectTags returns the active images with at least one of the subject tags, limited to the most recent
+// ones, whose embeddings were generated by the informed model
+func (libraryImageSelectSqlManager) ActiveBySubjectTags() string {
+	return `SELECT id AS library_image_id, image_url AS library_image_url,
+				image_description AS library_image_description, COALESCE(blur_hash, '') AS library_image_blur_hash,
+				COALESCE(dominant_color, '') AS library_image_dominant_color, variants AS library_image_variants,
+				subject_tags AS library_image_subject_tags, embedding AS library_image_embedding,
+				embedding_model AS library_image_embedding_model
+			FROM library_image
+			WHERE active AND embedding_model = $1 AND subject_tags ?| $2
+			ORDER BY created_at DESC
+			LIMIT $3`
+}
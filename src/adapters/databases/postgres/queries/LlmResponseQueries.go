@@ -0,0 +1,26 @@
+package queries
+
+type llmResponseSqlManager struct{}
+
+func LlmResponse() *llmResponseSqlManager {
+	return &llmResponseSqlManager{}
+}
+
+func (llmResponseSqlManager) Upsert() string {
+	return `INSERT INTO llm_response(key, response, expires_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (key) DO UPDATE SET response = EXCLUDED.response, expires_at = EXCLUDED.expires_at,
+				updated_at = TIMEZONE('America/Sao_Paulo'::TEXT, NOW())`
+}
+
+type llmResponseSelectSqlManager struct{}
+
+func (llmResponseSqlManager) Select() *llmResponseSelectSqlManager {
+	return &llmResponseSelectSqlManager{}
+}
+
+func (llmResponseSelectSqlManager) ByKey() string {
+	return `SELECT response
+			FROM llm_response
+			WHERE key = $1 AND expires_at > TIMEZONE('America/Sao_Paulo'::TEXT, NOW())`
+}
@@ -30,3 +30,9 @@ func (newsletterSelectSqlManager) ByReferenceDate() string {
     		FROM newsletter
     		WHERE active = true AND reference_date = $1`
 }
+
+func (newsletterSelectSqlManager) ArticleIdById() string {
+	return `SELECT article_id
+			FROM newsletter
+			WHERE active = true AND id = $1`
+}
@@ -0,0 +1,54 @@
+package queries
+
+type processingItemSqlManager struct{}
+
+func ProcessingItem() *processingItemSqlManager {
+	return &processingItemSqlManager{}
+}
+
+func (processingItemSqlManager) Start() string {
+	return `WITH started_item AS (
+				INSERT INTO processing_item(run_id, item_type, code, status, number_of_attempts, last_started_at)
+				VALUES ($1, $2, $3, 'running', 1, TIMEZONE('America/Sao_Paulo'::TEXT, NOW()))
+				ON CONFLICT (item_type, code) DO UPDATE SET run_id = EXCLUDED.run_id, status = 'running',
+					number_of_attempts = processing_item.number_of_attempts + 1, error = NULL,
+					last_started_at = EXCLUDED.last_started_at, last_finished_at = NULL,
+					duration_in_milliseconds = NULL, updated_at = TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+				RETURNING id, run_id, number_of_attempts, last_started_at
+			)
+			INSERT INTO processing_attempt(processing_item_id, run_id, attempt, status, started_at)
+			SELECT id, run_id, number_of_attempts, 'running', last_started_at FROM started_item
+			RETURNING attempt`
+}
+
+func (processingItemSqlManager) Finish() string {
+	return `WITH finished_item AS (
+				UPDATE processing_item SET status = $3, error = NULLIF($4, ''),
+					last_finished_at = TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+					duration_in_milliseconds = FLOOR(EXTRACT(EPOCH FROM
+						TIMEZONE('America/Sao_Paulo'::TEXT, NOW()) - last_started_at) * 1000),
+					updated_at = TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+				WHERE item_type = $1 AND code = $2
+				RETURNING id, number_of_attempts, status, error, last_finished_at, duration_in_milliseconds
+			)
+			UPDATE processing_attempt SET status = finished_item.status, error = finished_item.error,
+				finished_at = finished_item.last_finished_at,
+				duration_in_milliseconds = finished_item.duration_in_milliseconds,
+				updated_at = TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+			FROM finished_item
+			WHERE processing_attempt.processing_item_id = finished_item.id AND
+				processing_attempt.attempt = finished_item.number_of_attempts`
+}
+
+type processingItemSelectSqlManager struct{}
+
+func (processingItemSqlManager) Select() *processingItemSelectSqlManager {
+	return &processingItemSelectSqlManager{}
+}
+
+func (processingItemSelectSqlManager) CodesOfTheFailedItemsByType() string {
+	return `SELECT code
+			FROM processing_item
+			WHERE item_type = $1 AND status = 'failed' AND number_of_attempts < $2
+			ORDER BY last_finished_at`
+}
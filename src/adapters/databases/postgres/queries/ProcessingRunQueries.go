@@ -0,0 +1,23 @@
+package queries
+
+type processingRunSqlManager struct{}
+
+func ProcessingRun() *processingRunSqlManager {
+	return &processingRunSqlManager{}
+}
+
+func (processingRunSqlManager) Insert() string {
+	return `INSERT INTO processing_run(job_name)
+			VALUES ($1)
+			RETURNING id`
+}
+
+func (processingRunSqlManager) Finish() string {
+	return `UPDATE processing_run SET
+				status = CASE WHEN EXISTS(SELECT 1 FROM processing_item
+					WHERE run_id = $1 AND status IN ('failed', 'abandoned')) THEN 'failed' ELSE 'succeeded' END,
+				number_of_items = (SELECT COUNT(*) FROM processing_item WHERE run_id = $1),
+				finished_at = TIMEZONE('America/Sao_Paulo'::TEXT, NOW()),
+				updated_at = TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+			WHERE id = $1`
+}
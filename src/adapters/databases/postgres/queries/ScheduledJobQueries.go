@@ -0,0 +1,26 @@
+package queries
+
+type scheduledJobSqlManager struct{}
+
+func ScheduledJob() *scheduledJobSqlManager {
+	return &scheduledJobSqlManager{}
+}
+
+func (scheduledJobSqlManager) Upsert() string {
+	return `INSERT INTO scheduled_job(name, last_executed_at)
+			VALUES ($1, $2)
+			ON CONFLICT (name) DO UPDATE SET last_executed_at = EXCLUDED.last_executed_at,
+				updated_at = TIMEZONE('America/Sao_Paulo'::TEXT, NOW())`
+}
+
+type scheduledJobSelectSqlManager struct{}
+
+func (scheduledJobSqlManager) Select() *scheduledJobSelectSqlManager {
+	return &scheduledJobSelectSqlManager{}
+}
+
+func (scheduledJobSelectSqlManager) LastExecutionTimeByName() string {
+	return `SELECT last_executed_at
+			FROM scheduled_job
+			WHERE name = $1`
+}
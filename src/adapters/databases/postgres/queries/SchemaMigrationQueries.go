@@ -0,0 +1,53 @@
+package queries
+
+type schemaMigrationSqlManager struct{}
+
+func SchemaMigration() *schemaMigrationSqlManager {
+	return &schemaMigrationSqlManager{}
+}
+
+func (schemaMigrationSqlManager) CreateTable() string {
+	return `CREATE TABLE IF NOT EXISTS schema_migration (
+				version    INT PRIMARY KEY,
+				name       VARCHAR(255) NOT NULL,
+				applied_at TIMESTAMP NOT NULL DEFAULT TIMEZONE('America/Sao_Paulo'::TEXT, NOW())
+			)`
+}
+
+// Lock prevents migrations from being applied or reverted concurrently until the end of the transaction
+func (schemaMigrationSqlManager) Lock() string {
+	return `SELECT pg_advisory_xact_lock(hashtext('schema_migration'))`
+}
+
+// EnablePlatformTablesDrop allows the first migration to drop the tables of the platform data when it is reverted in
+// the transaction
+func (schemaMigrationSqlManager) EnablePlatformTablesDrop() string {
+	return `SET LOCAL vnc_summarizer.drop_platform_tables = 'on'`
+}
+
+func (schemaMigrationSqlManager) Insert() string {
+	return `INSERT INTO schema_migration(version, name)
+			VALUES ($1, $2)`
+}
+
+func (schemaMigrationSqlManager) Delete() string {
+	return `DELETE FROM schema_migration
+			WHERE version = $1`
+}
+
+type schemaMigrationSelectSqlManager struct{}
+
+func (schemaMigrationSqlManager) Select() *schemaMigrationSelectSqlManager {
+	return &schemaMigrationSelectSqlManager{}
+}
+
+func (schemaMigrationSelectSqlManager) All() string {
+	return `SELECT version AS schema_migration_version, name AS schema_migration_name,
+				applied_at AS schema_migration_applied_at
+			FROM schema_migration
+			ORDER BY version`
+}
+
+func (schemaMigrationSelectSqlManager) ExistsByVersion() string {
+	return `SELECT EXISTS(SELECT 1 FROM schema_migration WHERE version = $1)`
+}
@@ -0,0 +1,121 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/labstack/gommon/log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+	"vnc-summarizer/utils/contexts"
+)
+
+type ObjectStorage struct {
+	storageName   string
+	client        *s3.Client
+	bucket        string
+	publicBaseUrl string
+}
+
+func NewAwsS3ObjectStorage() *ObjectStorage {
+	awsConfig := aws.Config{
+		Region: os.Getenv("AWS_REGION"),
+		Credentials: credentials.NewStaticCredentialsProvider(os.Getenv("AWS_ACCESS_KEY_ID"),
+			os.Getenv("AWS_SECRET_ACCESS_KEY"), os.Getenv("AWS_SESSION_TOKEN")),
+	}
+
+	bucket := os.Getenv("AWS_S3_BUCKET")
+	publicBaseUrl := os.Getenv("STORAGE_PUBLIC_BASE_URL")
+	if publicBaseUrl == "" {
+		publicBaseUrl = fmt.Sprintf("https://%s.s3.amazonaws.com", bucket)
+	}
+
+	return &ObjectStorage{
+		storageName:   "AWS S3",
+		client:        s3.NewFromConfig(awsConfig),
+		bucket:        bucket,
+		publicBaseUrl: publicBaseUrl,
+	}
+}
+
+// NewS3CompatibleObjectStorage creates the storage of services that implement the AWS S3 API, such as MinIO, whose
+// objects are addressed by path (<endpoint>/<bucket>/<key>) unless S3_COMPATIBLE_USE_PATH_STYLE is false
+func NewS3CompatibleObjectStorage() *ObjectStorage {
+	region := os.Getenv("S3_COMPATIBLE_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	awsConfig := aws.Config{
+		Region: region,
+		Credentials: credentials.NewStaticCredentialsProvider(os.Getenv("S3_COMPATIBLE_ACCESS_KEY_ID"),
+			os.Getenv("S3_COMPATIBLE_SECRET_ACCESS_KEY"), ""),
+	}
+
+	endpoint := strings.TrimSuffix(os.Getenv("S3_COMPATIBLE_ENDPOINT"), "/")
+	usePathStyle := os.Getenv("S3_COMPATIBLE_USE_PATH_STYLE") != "false"
+	client := s3.NewFromConfig(awsConfig, func(options *s3.Options) {
+		options.BaseEndpoint = aws.String(endpoint)
+		options.UsePathStyle = usePathStyle
+	})
+
+	bucket := os.Getenv("S3_COMPATIBLE_BUCKET")
+	publicBaseUrl := os.Getenv("STORAGE_PUBLIC_BASE_URL")
+	if publicBaseUrl == "" {
+		publicBaseUrl = getS3CompatiblePublicBaseUrl(endpoint, bucket, usePathStyle)
+	}
+
+	return &ObjectStorage{
+		storageName:   "S3-compatible storage",
+		client:        client,
+		bucket:        bucket,
+		publicBaseUrl: publicBaseUrl,
+	}
+}
+
+func (instance ObjectStorage) SaveObject(ctx context.Context, key string, content []byte) (string, error) {
+	uploader := s3.PutObjectInput{
+		Bucket:      aws.String(instance.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(content),
+		ContentType: aws.String(http.DetectContentType(content)),
+	}
+
+	ctx, cancel := contexts.WithTimeout(ctx, "STORAGE_TIMEOUT", time.Minute)
+	defer cancel()
+
+	_, err := instance.client.PutObject(ctx, &uploader)
+	if err != nil {
+		log.Errorf("Error saving object %s to %s: %s", key, instance.storageName, err.Error())
+		return "", err
+	}
+
+	objectUrl, err := url.JoinPath(instance.publicBaseUrl, key)
+	if err != nil {
+		log.Errorf("Error building the URL of object %s: %s", key, err.Error())
+		return "", err
+	}
+
+	return objectUrl, nil
+}
+
+func getS3CompatiblePublicBaseUrl(endpoint, bucket string, usePathStyle bool) string {
+	if usePathStyle {
+		return fmt.Sprint(endpoint, "/", bucket)
+	}
+
+	endpointUrl, err := url.Parse(endpoint)
+	if err != nil {
+		log.Warn("The value of environment variable S3_COMPATIBLE_ENDPOINT is not a valid URL: ", err.Error())
+		return fmt.Sprint(endpoint, "/", bucket)
+	}
+	endpointUrl.Host = fmt.Sprint(bucket, ".", endpointUrl.Host)
+
+	return endpointUrl.String()
+}
@@ -0,0 +1,348 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/buckket/go-blurhash"
+	"github.com/labstack/gommon/log"
+	"golang.org/x/image/draw"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"vnc-summarizer/core/domains/articleimage"
+	"vnc-summarizer/core/domains/imagevariant"
+	"vnc-summarizer/utils/contexts"
+)
+
+const (
+	defaultQuality    = 80
+	socialImageWidth  = 1200
+	socialImageHeight = 630
+	placeholderWidth  = 32
+)
+
+var defaultResponsiveWidths = []int{320, 640, 1024}
+
+type ImageProcessor struct {
+	responsiveWidths []int
+	quality          int
+	cwebpPath        string
+	avifencPath      string
+}
+
+// NewImageProcessor creates the processor that generates the variants of the images. The WebP variants are encoded
+// losslessly by the processor itself unless the cwebp encoder is configured in IMAGE_CWEBP_PATH, and the AVIF
+// variants are only generated when the avifenc encoder is configured in IMAGE_AVIFENC_PATH.
+func NewImageProcessor() *ImageProcessor {
+	// The native encoder only supports lossless compression, whose images are several times larger than the lossy ones
+	if os.Getenv("IMAGE_CWEBP_PATH") == "" {
+		log.Warn("Environment variable IMAGE_CWEBP_PATH is not defined, so the WebP variants will be encoded " +
+			"losslessly, which makes them several times larger, and IMAGE_QUALITY will not be applied to them")
+	}
+
+	return &ImageProcessor{
+		responsiveWidths: getResponsiveWidths(),
+		quality:          getQuality(),
+		cwebpPath:        os.Getenv("IMAGE_CWEBP_PATH"),
+		avifencPath:      os.Getenv("IMAGE_AVIFENC_PATH"),
+	}
+}
+
+func (instance ImageProcessor) ProcessImage(ctx context.Context, imageContent []byte) (*articleimage.ArticleImage,
+	error) {
+	ctx, cancel := contexts.WithTimeout(ctx, "IMAGE_PROCESSING_TIMEOUT", 2*time.Minute)
+	defer cancel()
+
+	sourceImage, _, err := image.Decode(bytes.NewReader(imageContent))
+	if err != nil {
+		log.Error("Error decoding the image to be processed: ", err.Error())
+		return nil, err
+	}
+	sourceWidth := sourceImage.Bounds().Dx()
+	sourceHeight := sourceImage.Bounds().Dy()
+
+	var variants []imagevariant.ImageVariant
+	for _, width := range instance.responsiveWidths {
+		if width > sourceWidth {
+			continue
+		}
+
+		height := max(1, sourceHeight*width/sourceWidth)
+		resizedImage := resizeImage(sourceImage, width, height)
+
+		webpContent, err := instance.encodeWebp(ctx, resizedImage)
+		if err != nil {
+			log.Error("encodeWebp(): ", err.Error())
+			return nil, err
+		}
+
+		variant, err := buildVariant("responsive", "webp", width, height, webpContent)
+		if err != nil {
+			log.Error("buildVariant(): ", err.Error())
+			return nil, err
+		}
+		variants = append(variants, *variant)
+
+		if instance.avifencPath == "" {
+			continue
+		}
+
+		avifContent, err := instance.encodeAvif(ctx, resizedImage)
+		if err != nil {
+			log.Error("encodeAvif(): ", err.Error())
+			return nil, err
+		}
+
+		variant, err = buildVariant("responsive", "avif", width, height, avifContent)
+		if err != nil {
+			log.Error("buildVariant(): ", err.Error())
+			return nil, err
+		}
+		variants = append(variants, *variant)
+	}
+
+	// The preview of the social networks is encoded as JPEG since not all of them accept WebP images
+	socialImage := cropImage(sourceImage, socialImageWidth, socialImageHeight)
+	var socialImageContent bytes.Buffer
+	err = jpeg.Encode(&socialImageContent, socialImage, &jpeg.Options{Quality: instance.quality})
+	if err != nil {
+		log.Error("Error encoding the social network preview of the image: ", err.Error())
+		return nil, err
+	}
+
+	variant, err := buildVariant("social", "jpeg", socialImage.Bounds().Dx(), socialImage.Bounds().Dy(),
+		socialImageContent.Bytes())
+	if err != nil {
+		log.Error("buildVariant(): ", err.Error())
+		return nil, err
+	}
+	variants = append(variants, *variant)
+
+	placeholderImage := resizeImage(sourceImage, placeholderWidth, max(1, sourceHeight*placeholderWidth/sourceWidth))
+	blurHash, err := blurhash.Encode(4, 3, placeholderImage)
+	if err != nil {
+		log.Error("Error calculating the blurhash of the image: ", err.Error())
+		return nil, err
+	}
+
+	articleImage, err := articleimage.NewBuilder().
+		BlurHash(blurHash).
+		DominantColor(getDominantColor(placeholderImage)).
+		Variants(variants...).
+		Build()
+	if err != nil {
+		log.Error("Error validating the processed image data: ", err.Error())
+		return nil, err
+	}
+
+	return articleImage, nil
+}
+
+func (instance ImageProcessor) encodeWebp(ctx context.Context, imageData image.Image) ([]byte, error) {
+	if instance.cwebpPath != "" {
+		return encodeWithExternalEncoder(ctx, imageData, instance.cwebpPath, "webp", func(input,
+			output string) []string {
+			return []string{"-quiet", "-q", strconv.Itoa(instance.quality), input, "-o", output}
+		})
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var webpContent bytes.Buffer
+	err := nativewebp.Encode(&webpContent, imageData, nil)
+	if err != nil {
+		log.Error("Error encoding the image as WebP: ", err.Error())
+		return nil, err
+	}
+
+	return webpContent.Bytes(), nil
+}
+
+func (instance ImageProcessor) encodeAvif(ctx context.Context, imageData image.Image) ([]byte, error) {
+	return encodeWithExternalEncoder(ctx, imageData, instance.avifencPath, "avif", func(input,
+		output string) []string {
+		return []string{"-q", strconv.Itoa(instance.quality), "-s", "6", input, output}
+	})
+}
+
+// encodeWithExternalEncoder saves the image as PNG in a temporary directory, where the encoder writes the encoded
+// image, since the encoders only read and write files
+func encodeWithExternalEncoder(ctx context.Context, imageData image.Image, encoderPath, format string,
+	getArguments func(input, output string) []string) ([]byte, error) {
+	temporaryDirectory, err := os.MkdirTemp("", "vnc-summarizer-image-")
+	if err != nil {
+		log.Error("Error creating the temporary directory of the image: ", err.Error())
+		return nil, err
+	}
+	defer os.RemoveAll(temporaryDirectory)
+
+	var pngContent bytes.Buffer
+	err = (&png.Encoder{CompressionLevel: png.NoCompression}).Encode(&pngContent, imageData)
+	if err != nil {
+		log.Error("Error encoding the image as PNG: ", err.Error())
+		return nil, err
+	}
+
+	inputPath := filepath.Join(temporaryDirectory, "input.png")
+	err = os.WriteFile(inputPath, pngContent.Bytes(), 0600)
+	if err != nil {
+		log.Error("Error writing the image to the temporary directory: ", err.Error())
+		return nil, err
+	}
+
+	outputPath := filepath.Join(temporaryDirectory, fmt.Sprint("output.", format))
+	output, err := exec.CommandContext(ctx, encoderPath, getArguments(inputPath, outputPath)...).CombinedOutput()
+	if err != nil {
+		errorMessage := fmt.Sprintf("Error encoding the image as %s with %s: %s (%s)", strings.ToUpper(format),
+			encoderPath, err.Error(), strings.TrimSpace(string(output)))
+		log.Error(errorMessage)
+		return nil, errors.New(errorMessage)
+	}
+
+	encodedContent, err := os.ReadFile(outputPath)
+	if err != nil {
+		log.Errorf("Error reading the image encoded as %s: %s", strings.ToUpper(format), err.Error())
+		return nil, err
+	}
+
+	return encodedContent, nil
+}
+
+func buildVariant(kind, format string, width, height int, content []byte) (*imagevariant.ImageVariant, error) {
+	return imagevariant.NewBuilder().
+		Kind(kind).
+		Format(format).
+		Width(width).
+		Height(height).
+		Content(content).
+		Build()
+}
+
+func resizeImage(imageData image.Image, width, height int) image.Image {
+	if imageData.Bounds().Dx() == width && imageData.Bounds().Dy() == height {
+		return imageData
+	}
+
+	resizedImage := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(resizedImage, resizedImage.Bounds(), imageData, imageData.Bounds(), draw.Src, nil)
+	return resizedImage
+}
+
+// cropImage resizes the image to cover the informed dimensions, discarding the excess of its center, without
+// enlarging it
+func cropImage(imageData image.Image, width, height int) image.Image {
+	bounds := imageData.Bounds()
+	cropBounds := bounds
+	if bounds.Dx()*height > bounds.Dy()*width {
+		cropWidth := bounds.Dy() * width / height
+		cropBounds.Min.X += (bounds.Dx() - cropWidth) / 2
+		cropBounds.Max.X = cropBounds.Min.X + cropWidth
+	} else {
+		cropHeight := bounds.Dx() * height / width
+		cropBounds.Min.Y += (bounds.Dy() - cropHeight) / 2
+		cropBounds.Max.Y = cropBounds.Min.Y + cropHeight
+	}
+
+	// The crop is not enlarged when it is smaller than the informed size, since the enlarged image would only be
+	// blurrier and heavier than the original, so the images of 1024 pixels produce previews of 1024x537 pixels
+	if cropBounds.Dx() < width {
+		width, height = cropBounds.Dx(), max(1, cropBounds.Dx()*height/width)
+	}
+
+	croppedImage := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(croppedImage, croppedImage.Bounds(), imageData, cropBounds, draw.Src, nil)
+	return croppedImage
+}
+
+// getDominantColor groups the pixels of the image by similar colors and returns the average color of the largest
+// group in hexadecimal notation
+func getDominantColor(imageData image.Image) string {
+	type colorGroup struct {
+		red, green, blue, numberOfPixels int
+	}
+
+	colorGroups := map[int]*colorGroup{}
+	var dominantColorGroup *colorGroup
+	bounds := imageData.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := imageData.At(x, y).RGBA()
+			red, green, blue := int(r>>8), int(g>>8), int(b>>8)
+
+			groupKey := red>>4<<8 | green>>4<<4 | blue>>4
+			group, ok := colorGroups[groupKey]
+			if !ok {
+				group = &colorGroup{}
+				colorGroups[groupKey] = group
+			}
+			group.red += red
+			group.green += green
+			group.blue += blue
+			group.numberOfPixels++
+
+			if dominantColorGroup == nil || group.numberOfPixels > dominantColorGroup.numberOfPixels {
+				dominantColorGroup = group
+			}
+		}
+	}
+
+	if dominantColorGroup == nil {
+		return "#000000"
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", dominantColorGroup.red/dominantColorGroup.numberOfPixels,
+		dominantColorGroup.green/dominantColorGroup.numberOfPixels,
+		dominantColorGroup.blue/dominantColorGroup.numberOfPixels)
+}
+
+func getResponsiveWidths() []int {
+	widthsAsString := os.Getenv("IMAGE_RESPONSIVE_WIDTHS")
+	if widthsAsString == "" {
+		return defaultResponsiveWidths
+	}
+
+	var widths []int
+	for _, widthAsString := range strings.Split(widthsAsString, ",") {
+		width, err := strconv.Atoi(strings.TrimSpace(widthAsString))
+		if err != nil || width < 1 {
+			log.Warnf("The value of environment variable IMAGE_RESPONSIVE_WIDTHS must be a list of positive "+
+				"integers separated by commas, using the default widths %v", defaultResponsiveWidths)
+			return defaultResponsiveWidths
+		}
+
+		if !slices.Contains(widths, width) {
+			widths = append(widths, width)
+		}
+	}
+	slices.Sort(widths)
+
+	return widths
+}
+
+func getQuality() int {
+	qualityAsString := os.Getenv("IMAGE_QUALITY")
+	if qualityAsString == "" {
+		return defaultQuality
+	}
+
+	quality, err := strconv.Atoi(qualityAsString)
+	if err != nil || quality < 1 || quality > 100 {
+		log.Warnf("The value of environment variable IMAGE_QUALITY must be an integer between 1 and 100, using the "+
+			"default quality %d", defaultQuality)
+		return defaultQuality
+	}
+
+	return quality
+}
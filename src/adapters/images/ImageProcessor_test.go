@@ -0,0 +1,51 @@
+package images
+
+import (
+	"image"
+	"testing"
+)
+
+func TestCropImage(t *testing.T) {
+	testCases := []struct {
+		name           string
+		sourceWidth    int
+		sourceHeight   int
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{
+			name:           "scales down the images larger than the crop",
+			sourceWidth:    2048,
+			sourceHeight:   2048,
+			expectedWidth:  socialImageWidth,
+			expectedHeight: socialImageHeight,
+		},
+		{
+			name:           "does not enlarge the square images smaller than the crop",
+			sourceWidth:    1024,
+			sourceHeight:   1024,
+			expectedWidth:  1024,
+			expectedHeight: 537,
+		},
+		{
+			name:           "does not enlarge the wide images smaller than the crop",
+			sourceWidth:    1000,
+			sourceHeight:   300,
+			expectedWidth:  571,
+			expectedHeight: 299,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			sourceImage := image.NewNRGBA(image.Rect(0, 0, testCase.sourceWidth, testCase.sourceHeight))
+
+			croppedImage := cropImage(sourceImage, socialImageWidth, socialImageHeight)
+			if croppedImage.Bounds().Dx() != testCase.expectedWidth ||
+				croppedImage.Bounds().Dy() != testCase.expectedHeight {
+				t.Fatalf("cropImage() returned an image of %dx%d, expected %dx%d", croppedImage.Bounds().Dx(),
+					croppedImage.Bounds().Dy(), testCase.expectedWidth, testCase.expectedHeight)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package prompts
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"io/fs"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"vnc-summarizer/core/domains/prompt"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// The template files must be named as <code>[.<variant>].v<version>.tmpl (e.g. proposition_summary.pec.v2.tmpl)
+var templateFileNameRegex = regexp.MustCompile(`^([a-z0-9_]+)(?:\.([a-z0-9_]+))?\.v([0-9]+)\.tmpl$`)
+
+type Registry struct {
+	templatesDirectory string
+}
+
+type templateFile struct {
+	fileSystem fs.FS
+	name       string
+	variant    string
+	version    int
+}
+
+func NewPromptRegistry() *Registry {
+	return &Registry{
+		templatesDirectory: os.Getenv("PROMPT_TEMPLATES_DIRECTORY"),
+	}
+}
+
+// GetPrompt returns the latest version of the prompt template identified by the code, giving priority to the templates
+// of the informed variant. The templates directory is read on each call, so the templates can be edited without
+// restarting the application, and its templates replace the embedded templates of the same version.
+func (instance Registry) GetPrompt(code, variant string, variables prompt.Variables) (*prompt.Prompt, error) {
+	variant = strings.ToLower(strings.TrimSpace(variant))
+
+	embeddedTemplates, err := fs.Sub(defaultTemplates, "templates")
+	if err != nil {
+		log.Error("Error accessing the embedded prompt templates: ", err.Error())
+		return nil, err
+	}
+
+	fileSystems := []fs.FS{embeddedTemplates}
+	if instance.templatesDirectory != "" {
+		fileSystems = append(fileSystems, os.DirFS(instance.templatesDirectory))
+	}
+
+	var selectedTemplate *templateFile
+	for _, fileSystem := range fileSystems {
+		templateFiles, err := findTemplateFiles(fileSystem, code)
+		if err != nil {
+			log.Errorf("Error searching for the templates of prompt %s: %s", code, err.Error())
+			return nil, err
+		}
+
+		for _, templateFileData := range templateFiles {
+			if templateFileData.variant != "" && templateFileData.variant != variant {
+				continue
+			}
+			if selectedTemplate == nil || isPreferredTemplate(templateFileData, *selectedTemplate) {
+				selectedTemplate = &templateFileData
+			}
+		}
+	}
+
+	if selectedTemplate == nil {
+		errorMessage := fmt.Sprintf("No template was found for prompt %s", code)
+		log.Error(errorMessage)
+		return nil, errors.New(errorMessage)
+	}
+
+	templateContent, err := fs.ReadFile(selectedTemplate.fileSystem, selectedTemplate.name)
+	if err != nil {
+		log.Errorf("Error reading the template %s: %s", selectedTemplate.name, err.Error())
+		return nil, err
+	}
+
+	promptTemplate, err := template.New(selectedTemplate.name).Option("missingkey=error").Parse(string(templateContent))
+	if err != nil {
+		log.Errorf("Error interpreting the template %s: %s", selectedTemplate.name, err.Error())
+		return nil, err
+	}
+
+	var text bytes.Buffer
+	err = promptTemplate.Execute(&text, variables)
+	if err != nil {
+		log.Errorf("Error rendering the template %s: %s", selectedTemplate.name, err.Error())
+		return nil, err
+	}
+
+	promptData, err := prompt.NewBuilder().
+		Code(code).
+		Variant(selectedTemplate.variant).
+		Version(selectedTemplate.version).
+		Text(text.String()).
+		Build()
+	if err != nil {
+		log.Errorf("Error validating data for prompt %s: %s", code, err.Error())
+		return nil, err
+	}
+
+	return promptData, nil
+}
+
+func findTemplateFiles(fileSystem fs.FS, code string) ([]templateFile, error) {
+	entries, err := fs.ReadDir(fileSystem, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var templateFiles []templateFile
+	for _, entry := range entries {
+		matches := templateFileNameRegex.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || matches == nil || matches[1] != code {
+			continue
+		}
+
+		version, err := strconv.Atoi(matches[3])
+		if err != nil {
+			return nil, err
+		}
+
+		templateFiles = append(templateFiles, templateFile{
+			fileSystem: fileSystem,
+			name:       entry.Name(),
+			variant:    matches[2],
+			version:    version,
+		})
+	}
+
+	return templateFiles, nil
+}
+
+func isPreferredTemplate(candidate, selected templateFile) bool {
+	if (candidate.variant != "") != (selected.variant != "") {
+		return candidate.variant != ""
+	}
+
+	return candidate.version >= selected.version
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"vnc-summarizer/config/dicontainer"
+)
+
+func backfill(ctx context.Context, arguments []string) error {
+	if len(arguments) == 0 {
+		return errors.New("The backfill command requires the type of data to register (propositions, votes, " +
+			"events or all)")
+	}
+
+	backfilledType := arguments[0]
+	flagSet := newFlagSet("backfill")
+	from := flagSet.String("from", "", "First date of the range in the format YYYY-MM-DD")
+	to := flagSet.String("to", "", "Last date of the range in the format YYYY-MM-DD (default today)")
+	err := flagSet.Parse(arguments[1:])
+	if err != nil {
+		return err
+	}
+
+	if *from == "" {
+		return errors.New("The backfill command requires the --from flag")
+	}
+
+	startDate, err := parseDate("from", *from)
+	if err != nil {
+		return err
+	}
+
+	endDate, err := parseDate("to", *to)
+	if err != nil {
+		return err
+	}
+
+	if endDate.Before(startDate) {
+		return errors.New("The value of --to must not be before the value of --from")
+	}
+
+	ctx, finishRun := startRun(ctx, fmt.Sprint("backfill_", backfilledType))
+	defer finishRun()
+	log.Infof("Starting the backfill of the %s between %s and %s", backfilledType, startDate.Format("02/01/2006"),
+		endDate.Format("02/01/2006"))
+
+	dataTypes := []string{backfilledType}
+	if backfilledType == "all" {
+		dataTypes = []string{"propositions", "votes", "events"}
+	}
+
+	return dicontainer.GetBackfillService().Backfill(ctx, dataTypes, startDate, endDate)
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"os"
+	"time"
+	"vnc-summarizer/config/dicontainer"
+	"vnc-summarizer/utils/datetime"
+)
+
+const usage = `Usage: vnc-summarizer <command> [arguments]
+
+Commands:
+  run [--once]                                      Executes the jobs following their schedules (default command)
+  register proposition <code>                       Registers the proposition with the informed code
+  register voting <code>                            Registers the voting with the informed code
+  register event <code>                             Registers the event with the informed code
+  newsletter [--date YYYY-MM-DD]                    Registers or updates the newsletter of the date (default today)
+  backfill <propositions|votes|events|all> --from YYYY-MM-DD [--to YYYY-MM-DD]
+                                                    Registers the data of the date range day by day, resuming
+                                                    from the last completed day
+  migrate <up|down|status> [--steps N] [--drop-platform-tables]
+                                                    Applies the pending database migrations, reverts the last N
+                                                    migrations (default 1) or lists the migrations. The tables of
+                                                    the platform data are only dropped with --drop-platform-tables
+  openai-stub [--address :8090]                     Serves a local stub of the OpenAI API with deterministic
+                                                    responses, which is used in OPENAI_API_ADDRESS
+  help                                              Shows this message
+`
+
+// Execute runs the command informed in the arguments. When no command is informed, the summarizer runs continuously,
+// which is how the service is executed in the container.
+func Execute(ctx context.Context, arguments []string) error {
+	defer dicontainer.ClosePostgresDatabaseManager()
+
+	err := executeCommand(ctx, arguments)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+
+	return err
+}
+
+func executeCommand(ctx context.Context, arguments []string) error {
+	if len(arguments) == 0 {
+		return run(ctx, nil)
+	}
+
+	command, commandArguments := arguments[0], arguments[1:]
+	switch command {
+	case "run":
+		return run(ctx, commandArguments)
+	case "register":
+		return register(ctx, commandArguments)
+	case "newsletter":
+		return registerNewsletter(ctx, commandArguments)
+	case "backfill":
+		return backfill(ctx, commandArguments)
+	case "migrate":
+		return migrate(ctx, commandArguments)
+	case "openai-stub":
+		return startOpenAiStub(ctx, commandArguments)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return errors.New(fmt.Sprint("Unknown command: ", command))
+	}
+}
+
+// startRun registers a processing run for the command and returns the context linked to the run along with the
+// function that registers its end
+func startRun(ctx context.Context, jobName string) (context.Context, func()) {
+	processingLedgerService := dicontainer.GetProcessingLedgerService()
+	ctx, err := processingLedgerService.StartRun(ctx, jobName)
+	if err != nil {
+		log.Error("processingLedgerService.StartRun(): ", err.Error())
+	}
+
+	return ctx, func() {
+		processingLedgerService.FinishRun(ctx)
+	}
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+	flagSet.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+	}
+	return flagSet
+}
+
+// parseDate converts a date in the format YYYY-MM-DD to the Brazil time zone, returning the current date when the
+// value is empty
+func parseDate(flagName, date string) (time.Time, error) {
+	if date == "" {
+		currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Date(currentDateTime.Year(), currentDateTime.Month(), currentDateTime.Day(), 0, 0, 0, 0,
+			currentDateTime.Location()), nil
+	}
+
+	parsedDate, err := datetime.ParseDateInBrazil(date)
+	if err != nil {
+		return time.Time{}, errors.New(fmt.Sprintf("The value of --%s must follow the format YYYY-MM-DD: %s",
+			flagName, err.Error()))
+	}
+
+	return *parsedDate, nil
+}
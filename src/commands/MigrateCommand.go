@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"os"
+	"text/tabwriter"
+	"vnc-summarizer/config/dicontainer"
+)
+
+// The first migration creates the tables of the platform data shared with the other services, which are only dropped
+// when it is reverted with the --drop-platform-tables flag
+const platformTablesMigrationVersion = 1
+
+func migrate(ctx context.Context, arguments []string) error {
+	if len(arguments) == 0 {
+		return errors.New("The migrate command requires the direction (up, down or status)")
+	}
+
+	direction := arguments[0]
+	flagSet := newFlagSet("migrate")
+	steps := flagSet.Int("steps", 1, "Number of migrations to revert with the down direction")
+	dropPlatformTables := flagSet.Bool("drop-platform-tables", false, "Drops the tables of the platform data shared "+
+		"with the other services when the first migration is reverted")
+	err := flagSet.Parse(arguments[1:])
+	if err != nil {
+		return err
+	}
+
+	migrationRepository := dicontainer.GetMigrationPostgresRepository()
+	switch direction {
+	case "up":
+		migrationsApplied, err := migrationRepository.MigrateUp(ctx)
+		if err != nil {
+			return err
+		}
+
+		if migrationsApplied == nil {
+			log.Info("The database is already up to date")
+			return nil
+		}
+
+		for _, migrationData := range migrationsApplied {
+			log.Infof("Migration %d (%s) successfully applied", migrationData.Version(), migrationData.Name())
+		}
+		return nil
+	case "down":
+		if *steps < 1 {
+			return errors.New("The value of --steps must be a positive integer")
+		}
+
+		migrationsReverted, err := migrationRepository.MigrateDown(ctx, *steps, *dropPlatformTables)
+		if err != nil {
+			return err
+		}
+
+		if migrationsReverted == nil {
+			log.Info("No migrations are applied to be reverted")
+			return nil
+		}
+
+		for _, migrationData := range migrationsReverted {
+			log.Infof("Migration %d (%s) successfully reverted", migrationData.Version(), migrationData.Name())
+			if migrationData.Version() == platformTablesMigrationVersion && !*dropPlatformTables {
+				log.Info("The tables of the platform data were kept, use --drop-platform-tables to drop them")
+			}
+		}
+		return nil
+	case "status":
+		migrations, err := migrationRepository.GetMigrations(ctx)
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED AT\tDOWN")
+		for _, migrationData := range migrations {
+			appliedAt := "pending"
+			if migrationData.IsApplied() {
+				appliedAt = migrationData.AppliedAt().Format("02/01/2006 15:04:05")
+			}
+			down := "reverts its changes"
+			if migrationData.Version() == platformTablesMigrationVersion {
+				down = "keeps the platform tables unless --drop-platform-tables is informed"
+			}
+			fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", migrationData.Version(), migrationData.Name(), appliedAt, down)
+		}
+		return writer.Flush()
+	default:
+		return errors.New(fmt.Sprint("Unknown migration direction: ", direction))
+	}
+}
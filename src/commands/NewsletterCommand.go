@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"context"
+	"vnc-summarizer/config/dicontainer"
+)
+
+func registerNewsletter(ctx context.Context, arguments []string) error {
+	flagSet := newFlagSet("newsletter")
+	date := flagSet.String("date", "", "Reference date of the newsletter in the format YYYY-MM-DD")
+	err := flagSet.Parse(arguments)
+	if err != nil {
+		return err
+	}
+
+	referenceDate, err := parseDate("date", *date)
+	if err != nil {
+		return err
+	}
+
+	ctx, finishRun := startRun(ctx, "newsletter")
+	defer finishRun()
+
+	dicontainer.GetNewsletterService().RegisterNewNewsletter(ctx, referenceDate)
+	return nil
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"github.com/labstack/gommon/log"
+	"net/http"
+	"time"
+	"vnc-summarizer/adapters/apis/openaitest"
+)
+
+// startOpenAiStub serves the local stub of the OpenAI API until the service receives a SIGTERM or SIGINT, so the
+// jobs can be executed without credentials by pointing OPENAI_API_ADDRESS to http://<address>/v1
+func startOpenAiStub(ctx context.Context, arguments []string) error {
+	flagSet := newFlagSet("openai-stub")
+	address := flagSet.String("address", ":8090", "Address on which the stub listens")
+	err := flagSet.Parse(arguments)
+	if err != nil {
+		return err
+	}
+
+	options, err := openaitest.GetOptions()
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Addr:              *address,
+		Handler:           openaitest.NewHandler(options),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := server.Shutdown(shutdownCtx)
+		if err != nil {
+			log.Error("server.Shutdown(): ", err.Error())
+		}
+	}()
+
+	log.Infof("OpenAI stub listening on %s", *address)
+	err = server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
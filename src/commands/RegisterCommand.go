@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/log"
+	"strconv"
+	"vnc-summarizer/config/dicontainer"
+)
+
+func register(ctx context.Context, arguments []string) error {
+	if len(arguments) != 2 {
+		return errors.New("The register command requires the type (proposition, voting or event) and the code")
+	}
+
+	registeredType, code := arguments[0], arguments[1]
+	ctx, finishRun := startRun(ctx, fmt.Sprint("register_", registeredType))
+	defer finishRun()
+
+	var articleId *uuid.UUID
+	var err error
+	switch registeredType {
+	case "proposition":
+		var propositionCode int
+		propositionCode, err = strconv.Atoi(code)
+		if err != nil {
+			return errors.New(fmt.Sprint("The proposition code must be an integer: ", code))
+		}
+		articleId, err = dicontainer.GetPropositionService().RegisterNewPropositionByCode(ctx, propositionCode)
+	case "voting":
+		articleId, err = dicontainer.GetVotingService().RegisterNewVotingByCode(ctx, code)
+	case "event":
+		var eventCode int
+		eventCode, err = strconv.Atoi(code)
+		if err != nil {
+			return errors.New(fmt.Sprint("The event code must be an integer: ", code))
+		}
+		articleId, err = dicontainer.GetEventService().RegisterNewEventByCode(ctx, eventCode)
+	default:
+		return errors.New(fmt.Sprint("Unknown type to register: ", registeredType))
+	}
+	if err != nil {
+		return err
+	}
+
+	if articleId == nil {
+		log.Warnf("The %s %s was not registered", registeredType, code)
+		return nil
+	}
+
+	log.Infof("The %s %s was successfully registered (ID: %s)", registeredType, code, articleId)
+	return nil
+}
@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"context"
+	"vnc-summarizer/config/dicontainer"
+)
+
+func run(ctx context.Context, arguments []string) error {
+	flagSet := newFlagSet("run")
+	once := flagSet.Bool("once", false, "Executes each job a single time instead of following the schedules")
+	err := flagSet.Parse(arguments)
+	if err != nil {
+		return err
+	}
+
+	schedulerService := dicontainer.GetSchedulerService()
+	if *once {
+		return schedulerService.RunJobsOnce(ctx)
+	}
+
+	return schedulerService.Start(ctx)
+}
@@ -0,0 +1,57 @@
+package dicontainer
+
+import (
+	"github.com/labstack/gommon/log"
+	"os"
+	"sync"
+	"vnc-summarizer/adapters/databases/disk"
+	"vnc-summarizer/adapters/databases/memory"
+	"vnc-summarizer/adapters/databases/postgres"
+	interfaces "vnc-summarizer/core/interfaces/cache"
+)
+
+func GetLlmResponseCache() interfaces.LlmResponse {
+	llmCacheBackend := os.Getenv("LLM_CACHE_BACKEND")
+	switch llmCacheBackend {
+	case "":
+		return nil
+	case "postgres":
+		return postgres.NewLlmResponseRepository(GetPostgresDatabaseManager())
+	case "disk":
+		return disk.NewLlmResponseRepository()
+	default:
+		log.Warnf("LLM cache backend %s is not supported, the LLM responses will not be cached", llmCacheBackend)
+		return nil
+	}
+}
+
+var (
+	chamberResponseCache     interfaces.ChamberResponse
+	chamberResponseCacheOnce sync.Once
+)
+
+// GetChamberResponseCache returns the cache shared by all the clients of the Chamber of Deputies API, which is created
+// on first use so the responses kept in memory are not lost between the jobs
+func GetChamberResponseCache() interfaces.ChamberResponse {
+	chamberResponseCacheOnce.Do(func() {
+		chamberResponseCache = newChamberResponseCache()
+	})
+
+	return chamberResponseCache
+}
+
+func newChamberResponseCache() interfaces.ChamberResponse {
+	chamberCacheBackend := os.Getenv("CHAMBER_API_CACHE_BACKEND")
+	switch chamberCacheBackend {
+	case "":
+		return nil
+	case "memory":
+		return memory.NewChamberResponseRepository()
+	case "disk":
+		return disk.NewChamberResponseRepository()
+	default:
+		log.Warnf("Chamber API cache backend %s is not supported, the Chamber API responses will not be cached",
+			chamberCacheBackend)
+		return nil
+	}
+}
@@ -1,10 +1,22 @@
 package dicontainer
 
 import (
+	"sync"
 	"vnc-summarizer/adapters/apis/chamber"
 	interfaces "vnc-summarizer/core/interfaces/chamber"
 )
 
+var (
+	chamberApi     *chamber.Chamber
+	chamberApiOnce sync.Once
+)
+
+// GetChamberApi returns the client of the Chamber of Deputies API shared by all the services, which is created on
+// first use
 func GetChamberApi() interfaces.Chamber {
-	return chamber.NewChamberApi()
+	chamberApiOnce.Do(func() {
+		chamberApi = chamber.NewChamberApi(GetChamberResponseCache())
+	})
+
+	return chamberApi
 }
@@ -0,0 +1,23 @@
+package dicontainer
+
+import (
+	"github.com/labstack/gommon/log"
+	"os"
+	"vnc-summarizer/adapters/apis/llm"
+	interfaces "vnc-summarizer/core/interfaces/embeddings"
+)
+
+func GetEmbeddingApi() interfaces.Embedding {
+	embeddingProvider := os.Getenv("EMBEDDING_PROVIDER")
+	switch embeddingProvider {
+	case "":
+		return nil
+	case "openai":
+		return llm.NewOpenAiEmbeddingApi(GetBudgetService().RegisterGenerationUsage)
+	case "openai_compatible":
+		return llm.NewOpenAiCompatibleEmbeddingApi(GetBudgetService().RegisterGenerationUsage)
+	default:
+		log.Warnf("Embedding provider %s is not supported, the image library will not be used", embeddingProvider)
+		return nil
+	}
+}
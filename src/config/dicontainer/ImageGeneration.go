@@ -0,0 +1,40 @@
+package dicontainer
+
+import (
+	"github.com/labstack/gommon/log"
+	"os"
+	"vnc-summarizer/adapters/apis/imagegeneration"
+	interfaces "vnc-summarizer/core/interfaces/imagegeneration"
+)
+
+func GetImageGenerator() interfaces.ImageGenerator {
+	imageGenerator := getImageGeneratorByProvider(os.Getenv("IMAGE_GENERATION_PROVIDER"))
+
+	fallbackProvider := os.Getenv("IMAGE_GENERATION_FALLBACK_PROVIDER")
+	if fallbackProvider == "" {
+		return imageGenerator
+	}
+
+	return imagegeneration.NewFallbackImageGenerator(imageGenerator, getImageGeneratorByProvider(fallbackProvider))
+}
+
+func GetPlaceholderImageGenerator() interfaces.ImageGenerator {
+	return imagegeneration.NewPlaceholderImageGenerator()
+}
+
+func getImageGeneratorByProvider(imageGenerationProvider string) interfaces.ImageGenerator {
+	switch imageGenerationProvider {
+	case "", "openai":
+		return imagegeneration.NewOpenAiImageApi(GetBudgetService().RegisterGenerationUsage)
+	case "stable_diffusion":
+		return imagegeneration.NewStableDiffusionApi(GetBudgetService().RegisterGenerationUsage)
+	case "comfyui":
+		return imagegeneration.NewComfyUiApi(GetBudgetService().RegisterGenerationUsage)
+	case "placeholder":
+		return imagegeneration.NewPlaceholderImageGenerator()
+	default:
+		log.Warnf("Image generation provider %s is not supported, using the OpenAI provider",
+			imageGenerationProvider)
+		return imagegeneration.NewOpenAiImageApi(GetBudgetService().RegisterGenerationUsage)
+	}
+}
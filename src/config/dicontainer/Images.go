@@ -0,0 +1,10 @@
+package dicontainer
+
+import (
+	"vnc-summarizer/adapters/images"
+	interfaces "vnc-summarizer/core/interfaces/images"
+)
+
+func GetImageProcessor() interfaces.ImageProcessor {
+	return images.NewImageProcessor()
+}
@@ -0,0 +1,27 @@
+package dicontainer
+
+import (
+	"github.com/labstack/gommon/log"
+	"os"
+	"vnc-summarizer/adapters/apis/llm"
+	interfaces "vnc-summarizer/core/interfaces/llm"
+)
+
+func GetLlmApi() interfaces.Llm {
+	llmProvider := os.Getenv("LLM_PROVIDER")
+	switch llmProvider {
+	case "", "openai":
+		return llm.NewOpenAiApi(GetLlmResponseCache(), GetBudgetService().IsDailyBudgetExceeded,
+			GetBudgetService().RegisterGenerationUsage)
+	case "openai_compatible":
+		return llm.NewOpenAiCompatibleApi(GetLlmResponseCache(), GetBudgetService().IsDailyBudgetExceeded,
+			GetBudgetService().RegisterGenerationUsage)
+	case "anthropic":
+		return llm.NewAnthropicApi(GetLlmResponseCache(), GetBudgetService().IsDailyBudgetExceeded,
+			GetBudgetService().RegisterGenerationUsage)
+	default:
+		log.Warnf("LLM provider %s is not supported, using the OpenAI provider", llmProvider)
+		return llm.NewOpenAiApi(GetLlmResponseCache(), GetBudgetService().IsDailyBudgetExceeded,
+			GetBudgetService().RegisterGenerationUsage)
+	}
+}
@@ -1,12 +1,35 @@
 package dicontainer
 
 import (
+	"sync"
 	"vnc-summarizer/adapters/databases/postgres"
 	interfaces "vnc-summarizer/core/interfaces/postgres"
 )
 
+var (
+	postgresDatabaseManager     *postgres.ConnectionManager
+	postgresDatabaseManagerOnce sync.Once
+)
+
+// GetPostgresDatabaseManager returns the connection pool shared by all the repositories, which is created on first use
 func GetPostgresDatabaseManager() *postgres.ConnectionManager {
-	return postgres.NewPostgresConnectionManager()
+	postgresDatabaseManagerOnce.Do(func() {
+		postgresDatabaseManager = postgres.NewPostgresConnectionManager()
+	})
+
+	return postgresDatabaseManager
+}
+
+// ClosePostgresDatabaseManager closes the connection pool if it has been created
+func ClosePostgresDatabaseManager() {
+	postgresDatabaseManagerOnce.Do(func() {})
+	if postgresDatabaseManager != nil {
+		postgresDatabaseManager.Close()
+	}
+}
+
+func GetPostgresUnitOfWork() interfaces.UnitOfWork {
+	return postgres.NewUnitOfWork(GetPostgresDatabaseManager())
 }
 
 func GetExternalAuthorTypePostgresRepository() interfaces.ExternalAuthorType {
@@ -72,3 +95,31 @@ func GetEventPostgresRepository() interfaces.Event {
 func GetNewsletterPostgresRepository() interfaces.Newsletter {
 	return postgres.NewNewsletterRepository(GetPostgresDatabaseManager())
 }
+
+func GetGenerationUsagePostgresRepository() interfaces.GenerationUsage {
+	return postgres.NewGenerationUsageRepository(GetPostgresDatabaseManager())
+}
+
+func GetBackfillCheckpointPostgresRepository() interfaces.BackfillCheckpoint {
+	return postgres.NewBackfillCheckpointRepository(GetPostgresDatabaseManager())
+}
+
+func GetScheduledJobPostgresRepository() interfaces.ScheduledJob {
+	return postgres.NewScheduledJobRepository(GetPostgresDatabaseManager())
+}
+
+func GetProcessingRunPostgresRepository() interfaces.ProcessingRun {
+	return postgres.NewProcessingRunRepository(GetPostgresDatabaseManager())
+}
+
+func GetProcessingItemPostgresRepository() interfaces.ProcessingItem {
+	return postgres.NewProcessingItemRepository(GetPostgresDatabaseManager())
+}
+
+func GetMigrationPostgresRepository() interfaces.Migration {
+	return postgres.NewMigrationRepository(GetPostgresDatabaseManager())
+}
+
+func GetLibraryImagePostgresRepository() interfaces.LibraryImage {
+	return postgres.NewLibraryImageRepository(GetPostgresDatabaseManager())
+}
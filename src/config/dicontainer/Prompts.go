@@ -0,0 +1,10 @@
+package dicontainer
+
+import (
+	"vnc-summarizer/adapters/prompts"
+	interfaces "vnc-summarizer/core/interfaces/prompts"
+)
+
+func GetPromptRegistry() interfaces.Prompt {
+	return prompts.NewPromptRegistry()
+}
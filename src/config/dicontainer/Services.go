@@ -1,10 +1,16 @@
 package dicontainer
 
 import (
+	"sync"
 	interfaces "vnc-summarizer/core/interfaces/services"
 	"vnc-summarizer/core/services"
 )
 
+var (
+	budgetService     *services.Budget
+	budgetServiceOnce sync.Once
+)
+
 func GetDeputyService() interfaces.Deputy {
 	return services.NewDeputyService(GetChamberApi(), GetDeputyPostgresRepository(), GetPartyPostgresRepository())
 }
@@ -15,13 +21,38 @@ func GetExternalAuthorService() interfaces.ExternalAuthor {
 }
 
 func GetAuthorService() interfaces.Author {
-	return services.NewAuthorService(GetDeputyService(), GetExternalAuthorService())
+	return services.NewAuthorService(GetChamberApi(), GetDeputyService(), GetExternalAuthorService())
+}
+
+func GetProcessingLedgerService() interfaces.ProcessingLedger {
+	return services.NewProcessingLedgerService(GetProcessingRunPostgresRepository(),
+		GetProcessingItemPostgresRepository())
+}
+
+// GetBudgetService returns the budget service shared by all the services and adapters, since it caches the estimated
+// cost of the day
+func GetBudgetService() interfaces.Budget {
+	budgetServiceOnce.Do(func() {
+		budgetService = services.NewBudgetService(GetGenerationUsagePostgresRepository())
+	})
+
+	return budgetService
+}
+
+func GetImageLibraryService() interfaces.ImageLibrary {
+	return services.NewImageLibraryService(GetEmbeddingApi(), GetLibraryImagePostgresRepository())
+}
+
+func GetImageReviewService() interfaces.ImageReview {
+	return services.NewImageReviewService(GetLlmApi(), GetPromptRegistry())
 }
 
 func GetPropositionService() interfaces.Proposition {
-	return services.NewPropositionService(GetAuthorService(), GetChamberApi(), GetChatGptApi(), GetDallEApi(),
-		GetVncPdfContentExtractorApi(), GetAwsS3(), GetPropositionPostgresRepository(),
-		GetPropositionTypePostgresRepository(), GetArticleTypePostgresRepository())
+	return services.NewPropositionService(GetAuthorService(), GetChamberApi(), GetLlmApi(), GetPromptRegistry(),
+		GetImageGenerator(), GetPlaceholderImageGenerator(), GetVncPdfContentExtractorApi(), GetImageProcessor(),
+		GetObjectStorage(), GetImageLibraryService(), GetImageReviewService(), GetBudgetService(),
+		GetProcessingLedgerService(), GetPropositionPostgresRepository(), GetPropositionTypePostgresRepository(),
+		GetArticleTypePostgresRepository())
 }
 
 func GetLegislativeBodyService() interfaces.LegislativeBody {
@@ -30,18 +61,29 @@ func GetLegislativeBodyService() interfaces.LegislativeBody {
 }
 
 func GetVotingService() interfaces.Voting {
-	return services.NewVotingService(GetChamberApi(), GetChatGptApi(), GetVotingPostgresRepository(),
-		GetArticleTypePostgresRepository(), GetLegislativeBodyService(), GetPropositionService())
+	return services.NewVotingService(GetChamberApi(), GetLlmApi(), GetPromptRegistry(),
+		GetVotingPostgresRepository(), GetArticleTypePostgresRepository(), GetPostgresUnitOfWork(),
+		GetLegislativeBodyService(), GetPropositionService(), GetProcessingLedgerService())
 }
 
 func GetEventService() interfaces.Event {
 	return services.NewEventService(GetDeputyService(), GetLegislativeBodyService(), GetPropositionService(),
-		GetVotingService(), GetChamberApi(), GetChatGptApi(), GetEventPostgresRepository(),
-		GetArticleTypePostgresRepository(), GetEventTypePostgresRepository(), GetEventSituationPostgresRepository(),
-		GetAgendaItemRegimeRepository())
+		GetVotingService(), GetProcessingLedgerService(), GetChamberApi(), GetLlmApi(), GetPromptRegistry(),
+		GetEventPostgresRepository(), GetArticleTypePostgresRepository(), GetEventTypePostgresRepository(),
+		GetEventSituationPostgresRepository(), GetAgendaItemRegimeRepository())
 }
 
 func GetNewsletterService() interfaces.Newsletter {
-	return services.NewNewsletterService(GetChatGptApi(), GetNewsletterPostgresRepository(),
+	return services.NewNewsletterService(GetLlmApi(), GetPromptRegistry(), GetNewsletterPostgresRepository(),
 		GetArticleTypePostgresRepository(), GetArticlePostgresRepository())
 }
+
+func GetBackfillService() interfaces.Backfill {
+	return services.NewBackfillService(GetPropositionService(), GetVotingService(), GetEventService(),
+		GetBackfillCheckpointPostgresRepository())
+}
+
+func GetSchedulerService() interfaces.Scheduler {
+	return services.NewSchedulerService(GetPropositionService(), GetVotingService(), GetEventService(),
+		GetNewsletterService(), GetProcessingLedgerService(), GetScheduledJobPostgresRepository())
+}
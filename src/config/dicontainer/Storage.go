@@ -0,0 +1,24 @@
+package dicontainer
+
+import (
+	"github.com/labstack/gommon/log"
+	"os"
+	"vnc-summarizer/adapters/databases/disk"
+	"vnc-summarizer/adapters/databases/s3"
+	interfaces "vnc-summarizer/core/interfaces/storage"
+)
+
+func GetObjectStorage() interfaces.ObjectStorage {
+	storageBackend := os.Getenv("STORAGE_BACKEND")
+	switch storageBackend {
+	case "", "aws_s3":
+		return s3.NewAwsS3ObjectStorage()
+	case "s3_compatible":
+		return s3.NewS3CompatibleObjectStorage()
+	case "disk":
+		return disk.NewObjectStorage()
+	default:
+		log.Warnf("Storage backend %s is not supported, using AWS S3", storageBackend)
+		return s3.NewAwsS3ObjectStorage()
+	}
+}
@@ -0,0 +1,44 @@
+package articleimage
+
+import (
+	"reflect"
+	"vnc-summarizer/core/domains/imagevariant"
+)
+
+// ArticleImage gathers the image of an article with the variants generated from it and the placeholders shown while
+// the variants are loaded
+type ArticleImage struct {
+	url           string
+	description   string
+	blurHash      string
+	dominantColor string
+	variants      []imagevariant.ImageVariant
+}
+
+func (instance *ArticleImage) NewUpdater() *builder {
+	return &builder{articleImage: instance}
+}
+
+func (instance *ArticleImage) Url() string {
+	return instance.url
+}
+
+func (instance *ArticleImage) Description() string {
+	return instance.description
+}
+
+func (instance *ArticleImage) BlurHash() string {
+	return instance.blurHash
+}
+
+func (instance *ArticleImage) DominantColor() string {
+	return instance.dominantColor
+}
+
+func (instance *ArticleImage) Variants() []imagevariant.ImageVariant {
+	return instance.variants
+}
+
+func (instance *ArticleImage) IsZero() bool {
+	return reflect.DeepEqual(instance, &ArticleImage{})
+}
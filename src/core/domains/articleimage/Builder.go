@@ -0,0 +1,77 @@
+package articleimage
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"vnc-summarizer/core/domains/imagevariant"
+)
+
+var hexadecimalColorRegex = regexp.MustCompile("^#[0-9a-f]{6}$")
+
+type builder struct {
+	articleImage  *ArticleImage
+	invalidFields []string
+}
+
+func NewBuilder() *builder {
+	return &builder{articleImage: &ArticleImage{}}
+}
+
+func (instance *builder) Url(url string) *builder {
+	url = strings.TrimSpace(url)
+	if len(url) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The article image URL is invalid")
+		return instance
+	}
+	instance.articleImage.url = url
+	return instance
+}
+
+func (instance *builder) Description(description string) *builder {
+	description = strings.TrimSpace(description)
+	if len(description) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The article image description is invalid")
+		return instance
+	}
+	instance.articleImage.description = description
+	return instance
+}
+
+func (instance *builder) BlurHash(blurHash string) *builder {
+	blurHash = strings.TrimSpace(blurHash)
+	if len(blurHash) < 6 {
+		instance.invalidFields = append(instance.invalidFields, "The article image blurhash is invalid")
+		return instance
+	}
+	instance.articleImage.blurHash = blurHash
+	return instance
+}
+
+func (instance *builder) DominantColor(dominantColor string) *builder {
+	dominantColor = strings.ToLower(strings.TrimSpace(dominantColor))
+	if !hexadecimalColorRegex.MatchString(dominantColor) {
+		instance.invalidFields = append(instance.invalidFields, "The article image dominant color is invalid")
+		return instance
+	}
+	instance.articleImage.dominantColor = dominantColor
+	return instance
+}
+
+func (instance *builder) Variants(variants ...imagevariant.ImageVariant) *builder {
+	for _, variantData := range variants {
+		if variantData.IsZero() {
+			instance.invalidFields = append(instance.invalidFields, "The article image variants are invalid")
+			return instance
+		}
+	}
+	instance.articleImage.variants = variants
+	return instance
+}
+
+func (instance *builder) Build() (*ArticleImage, error) {
+	if len(instance.invalidFields) > 0 {
+		return nil, errors.New(strings.Join(instance.invalidFields, "; "))
+	}
+	return instance.articleImage, nil
+}
@@ -0,0 +1,61 @@
+package chamberresponse
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type builder struct {
+	chamberResponse *ChamberResponse
+	invalidFields   []string
+}
+
+func NewBuilder() *builder {
+	return &builder{chamberResponse: &ChamberResponse{}}
+}
+
+func (instance *builder) Url(url string) *builder {
+	url = strings.TrimSpace(url)
+	if len(url) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The Chamber response URL is invalid")
+		return instance
+	}
+	instance.chamberResponse.url = url
+	return instance
+}
+
+func (instance *builder) Body(body []byte) *builder {
+	if len(body) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The Chamber response body is invalid")
+		return instance
+	}
+	instance.chamberResponse.body = body
+	return instance
+}
+
+func (instance *builder) ETag(eTag string) *builder {
+	instance.chamberResponse.eTag = strings.TrimSpace(eTag)
+	return instance
+}
+
+func (instance *builder) LastModified(lastModified string) *builder {
+	instance.chamberResponse.lastModified = strings.TrimSpace(lastModified)
+	return instance
+}
+
+func (instance *builder) ExpiresAt(expiresAt time.Time) *builder {
+	if expiresAt.IsZero() {
+		instance.invalidFields = append(instance.invalidFields, "The Chamber response expiration date is invalid")
+		return instance
+	}
+	instance.chamberResponse.expiresAt = expiresAt
+	return instance
+}
+
+func (instance *builder) Build() (*ChamberResponse, error) {
+	if len(instance.invalidFields) > 0 {
+		return nil, errors.New(strings.Join(instance.invalidFields, "; "))
+	}
+	return instance.chamberResponse, nil
+}
@@ -0,0 +1,49 @@
+package chamberresponse
+
+import (
+	"reflect"
+	"time"
+)
+
+// ChamberResponse is a response of the Chamber of Deputies API kept in the cache until it expires. After the
+// expiration, the validators of the response (ETag and Last-Modified) allow the cached content to be revalidated
+// without downloading it again.
+type ChamberResponse struct {
+	url          string
+	body         []byte
+	eTag         string
+	lastModified string
+	expiresAt    time.Time
+}
+
+func (instance *ChamberResponse) NewUpdater() *builder {
+	return &builder{chamberResponse: instance}
+}
+
+func (instance *ChamberResponse) Url() string {
+	return instance.url
+}
+
+func (instance *ChamberResponse) Body() []byte {
+	return instance.body
+}
+
+func (instance *ChamberResponse) ETag() string {
+	return instance.eTag
+}
+
+func (instance *ChamberResponse) LastModified() string {
+	return instance.lastModified
+}
+
+func (instance *ChamberResponse) ExpiresAt() time.Time {
+	return instance.expiresAt
+}
+
+func (instance *ChamberResponse) IsExpired() bool {
+	return time.Now().After(instance.expiresAt)
+}
+
+func (instance *ChamberResponse) IsZero() bool {
+	return reflect.DeepEqual(instance, &ChamberResponse{})
+}
@@ -0,0 +1,78 @@
+package generation
+
+import (
+	"errors"
+	"strings"
+	"vnc-summarizer/core/domains/articleimage"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/core/domains/imagereview"
+	"vnc-summarizer/core/domains/prompt"
+	"vnc-summarizer/core/domains/summary"
+)
+
+type builder struct {
+	generation    *Generation
+	invalidFields []string
+}
+
+func NewBuilder() *builder {
+	return &builder{generation: &Generation{}}
+}
+
+func (instance *builder) Prompts(prompts ...prompt.Prompt) *builder {
+	for _, promptData := range prompts {
+		if promptData.IsZero() {
+			instance.invalidFields = append(instance.invalidFields, "The generation prompts are invalid")
+			return instance
+		}
+	}
+	instance.generation.prompts = append(instance.generation.prompts, prompts...)
+	return instance
+}
+
+func (instance *builder) Summary(summary summary.Summary) *builder {
+	if summary.IsZero() {
+		instance.invalidFields = append(instance.invalidFields, "The generation summary is invalid")
+		return instance
+	}
+	instance.generation.summary = summary
+	return instance
+}
+
+func (instance *builder) Usages(usages ...generationusage.GenerationUsage) *builder {
+	for _, usageData := range usages {
+		if usageData.IsZero() {
+			instance.invalidFields = append(instance.invalidFields, "The generation usages are invalid")
+			return instance
+		}
+	}
+	instance.generation.usages = append(instance.generation.usages, usages...)
+	return instance
+}
+
+func (instance *builder) Image(image articleimage.ArticleImage) *builder {
+	if image.IsZero() {
+		instance.invalidFields = append(instance.invalidFields, "The generation image is invalid")
+		return instance
+	}
+	instance.generation.image = image
+	return instance
+}
+
+func (instance *builder) ImageReviews(imageReviews ...imagereview.ImageReview) *builder {
+	for _, imageReviewData := range imageReviews {
+		if imageReviewData.IsZero() {
+			instance.invalidFields = append(instance.invalidFields, "The generation image reviews are invalid")
+			return instance
+		}
+	}
+	instance.generation.imageReviews = append(instance.generation.imageReviews, imageReviews...)
+	return instance
+}
+
+func (instance *builder) Build() (*Generation, error) {
+	if len(instance.invalidFields) > 0 {
+		return nil, errors.New(strings.Join(instance.invalidFields, "; "))
+	}
+	return instance.generation, nil
+}
@@ -0,0 +1,42 @@
+package generation
+
+import (
+	"vnc-summarizer/core/domains/articleimage"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/core/domains/imagereview"
+	"vnc-summarizer/core/domains/prompt"
+	"vnc-summarizer/core/domains/summary"
+)
+
+// Generation gathers the metadata of the content generated by the LLM for an article
+type Generation struct {
+	prompts      []prompt.Prompt
+	summary      summary.Summary
+	usages       []generationusage.GenerationUsage
+	image        articleimage.ArticleImage
+	imageReviews []imagereview.ImageReview
+}
+
+func (instance *Generation) NewUpdater() *builder {
+	return &builder{generation: instance}
+}
+
+func (instance *Generation) Prompts() []prompt.Prompt {
+	return instance.prompts
+}
+
+func (instance *Generation) Summary() summary.Summary {
+	return instance.summary
+}
+
+func (instance *Generation) Usages() []generationusage.GenerationUsage {
+	return instance.usages
+}
+
+func (instance *Generation) Image() articleimage.ArticleImage {
+	return instance.image
+}
+
+func (instance *Generation) ImageReviews() []imagereview.ImageReview {
+	return instance.imageReviews
+}
@@ -0,0 +1,90 @@
+package generationusage
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type builder struct {
+	generationUsage *GenerationUsage
+	invalidFields   []string
+}
+
+func NewBuilder() *builder {
+	return &builder{generationUsage: &GenerationUsage{}}
+}
+
+func (instance *builder) Id(id uuid.UUID) *builder {
+	if id == uuid.Nil {
+		instance.invalidFields = append(instance.invalidFields, "The generation usage ID is invalid")
+		return instance
+	}
+	instance.generationUsage.id = id
+	return instance
+}
+
+func (instance *builder) Provider(provider string) *builder {
+	provider = strings.TrimSpace(provider)
+	if len(provider) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The generation usage provider is invalid")
+		return instance
+	}
+	instance.generationUsage.provider = provider
+	return instance
+}
+
+func (instance *builder) Model(model string) *builder {
+	model = strings.TrimSpace(model)
+	if len(model) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The generation usage model is invalid")
+		return instance
+	}
+	instance.generationUsage.model = model
+	return instance
+}
+
+func (instance *builder) PromptTokens(promptTokens int) *builder {
+	if promptTokens < 0 {
+		instance.invalidFields = append(instance.invalidFields, "The generation usage prompt tokens are invalid")
+		return instance
+	}
+	instance.generationUsage.promptTokens = promptTokens
+	return instance
+}
+
+func (instance *builder) CompletionTokens(completionTokens int) *builder {
+	if completionTokens < 0 {
+		instance.invalidFields = append(instance.invalidFields,
+			"The generation usage completion tokens are invalid")
+		return instance
+	}
+	instance.generationUsage.completionTokens = completionTokens
+	return instance
+}
+
+func (instance *builder) NumberOfImages(numberOfImages int) *builder {
+	if numberOfImages < 0 {
+		instance.invalidFields = append(instance.invalidFields,
+			"The generation usage number of images is invalid")
+		return instance
+	}
+	instance.generationUsage.numberOfImages = numberOfImages
+	return instance
+}
+
+func (instance *builder) EstimatedCost(estimatedCost float64) *builder {
+	if estimatedCost < 0 {
+		instance.invalidFields = append(instance.invalidFields, "The generation usage estimated cost is invalid")
+		return instance
+	}
+	instance.generationUsage.estimatedCost = estimatedCost
+	return instance
+}
+
+func (instance *builder) Build() (*GenerationUsage, error) {
+	if len(instance.invalidFields) > 0 {
+		return nil, errors.New(strings.Join(instance.invalidFields, "; "))
+	}
+	return instance.generationUsage, nil
+}
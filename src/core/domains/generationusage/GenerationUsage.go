@@ -0,0 +1,52 @@
+package generationusage
+
+import (
+	"github.com/google/uuid"
+	"reflect"
+)
+
+type GenerationUsage struct {
+	id               uuid.UUID
+	provider         string
+	model            string
+	promptTokens     int
+	completionTokens int
+	numberOfImages   int
+	estimatedCost    float64
+}
+
+func (instance *GenerationUsage) NewUpdater() *builder {
+	return &builder{generationUsage: instance}
+}
+
+func (instance *GenerationUsage) Id() uuid.UUID {
+	return instance.id
+}
+
+func (instance *GenerationUsage) Provider() string {
+	return instance.provider
+}
+
+func (instance *GenerationUsage) Model() string {
+	return instance.model
+}
+
+func (instance *GenerationUsage) PromptTokens() int {
+	return instance.promptTokens
+}
+
+func (instance *GenerationUsage) CompletionTokens() int {
+	return instance.completionTokens
+}
+
+func (instance *GenerationUsage) NumberOfImages() int {
+	return instance.numberOfImages
+}
+
+func (instance *GenerationUsage) EstimatedCost() float64 {
+	return instance.estimatedCost
+}
+
+func (instance *GenerationUsage) IsZero() bool {
+	return reflect.DeepEqual(instance, &GenerationUsage{})
+}
@@ -0,0 +1,81 @@
+package imagereview
+
+import (
+	"errors"
+	"strings"
+)
+
+type builder struct {
+	imageReview   *ImageReview
+	invalidFields []string
+}
+
+func NewBuilder() *builder {
+	return &builder{imageReview: &ImageReview{}}
+}
+
+func (instance *builder) ImageUrl(imageUrl string) *builder {
+	imageUrl = strings.TrimSpace(imageUrl)
+	if len(imageUrl) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The image review URL is invalid")
+		return instance
+	}
+	instance.imageReview.imageUrl = imageUrl
+	return instance
+}
+
+func (instance *builder) ImageProvider(imageProvider string) *builder {
+	imageProvider = strings.TrimSpace(imageProvider)
+	if len(imageProvider) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The image review provider is invalid")
+		return instance
+	}
+	instance.imageReview.imageProvider = imageProvider
+	return instance
+}
+
+func (instance *builder) ContainsText(containsText bool) *builder {
+	instance.imageReview.containsText = containsText
+	return instance
+}
+
+func (instance *builder) ContainsPoliticianLikeness(containsPoliticianLikeness bool) *builder {
+	instance.imageReview.containsPoliticianLikeness = containsPoliticianLikeness
+	return instance
+}
+
+func (instance *builder) ContainsPartyLogo(containsPartyLogo bool) *builder {
+	instance.imageReview.containsPartyLogo = containsPartyLogo
+	return instance
+}
+
+func (instance *builder) ContainsUnsafeContent(containsUnsafeContent bool) *builder {
+	instance.imageReview.containsUnsafeContent = containsUnsafeContent
+	return instance
+}
+
+func (instance *builder) RelevanceScore(relevanceScore float64) *builder {
+	if relevanceScore < 0 || relevanceScore > 1 {
+		instance.invalidFields = append(instance.invalidFields, "The image review relevance score is invalid")
+		return instance
+	}
+	instance.imageReview.relevanceScore = relevanceScore
+	return instance
+}
+
+func (instance *builder) Justification(justification string) *builder {
+	instance.imageReview.justification = strings.TrimSpace(justification)
+	return instance
+}
+
+func (instance *builder) Approved(approved bool) *builder {
+	instance.imageReview.approved = approved
+	return instance
+}
+
+func (instance *builder) Build() (*ImageReview, error) {
+	if len(instance.invalidFields) > 0 {
+		return nil, errors.New(strings.Join(instance.invalidFields, "; "))
+	}
+	return instance.imageReview, nil
+}
@@ -0,0 +1,61 @@
+package imagereview
+
+import "reflect"
+
+// ImageReview is the verdict of the vision model on a generated image, which is only published when it does not contain
+// rendered text, likenesses of real politicians, party logos or unsafe content and is relevant to the article
+type ImageReview struct {
+	imageUrl                   string
+	imageProvider              string
+	containsText               bool
+	containsPoliticianLikeness bool
+	containsPartyLogo          bool
+	containsUnsafeContent      bool
+	relevanceScore             float64
+	justification              string
+	approved                   bool
+}
+
+func (instance *ImageReview) NewUpdater() *builder {
+	return &builder{imageReview: instance}
+}
+
+func (instance *ImageReview) ImageUrl() string {
+	return instance.imageUrl
+}
+
+func (instance *ImageReview) ImageProvider() string {
+	return instance.imageProvider
+}
+
+func (instance *ImageReview) ContainsText() bool {
+	return instance.containsText
+}
+
+func (instance *ImageReview) ContainsPoliticianLikeness() bool {
+	return instance.containsPoliticianLikeness
+}
+
+func (instance *ImageReview) ContainsPartyLogo() bool {
+	return instance.containsPartyLogo
+}
+
+func (instance *ImageReview) ContainsUnsafeContent() bool {
+	return instance.containsUnsafeContent
+}
+
+func (instance *ImageReview) RelevanceScore() float64 {
+	return instance.relevanceScore
+}
+
+func (instance *ImageReview) Justification() string {
+	return instance.justification
+}
+
+func (instance *ImageReview) Approved() bool {
+	return instance.approved
+}
+
+func (instance *ImageReview) IsZero() bool {
+	return reflect.DeepEqual(instance, &ImageReview{})
+}
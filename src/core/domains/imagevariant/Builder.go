@@ -0,0 +1,79 @@
+package imagevariant
+
+import (
+	"errors"
+	"strings"
+)
+
+type builder struct {
+	imageVariant  *ImageVariant
+	invalidFields []string
+}
+
+func NewBuilder() *builder {
+	return &builder{imageVariant: &ImageVariant{}}
+}
+
+func (instance *builder) Kind(kind string) *builder {
+	kind = strings.TrimSpace(kind)
+	if len(kind) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The image variant kind is invalid")
+		return instance
+	}
+	instance.imageVariant.kind = kind
+	return instance
+}
+
+func (instance *builder) Format(format string) *builder {
+	format = strings.TrimSpace(format)
+	if len(format) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The image variant format is invalid")
+		return instance
+	}
+	instance.imageVariant.format = format
+	return instance
+}
+
+func (instance *builder) Width(width int) *builder {
+	if width <= 0 {
+		instance.invalidFields = append(instance.invalidFields, "The image variant width is invalid")
+		return instance
+	}
+	instance.imageVariant.width = width
+	return instance
+}
+
+func (instance *builder) Height(height int) *builder {
+	if height <= 0 {
+		instance.invalidFields = append(instance.invalidFields, "The image variant height is invalid")
+		return instance
+	}
+	instance.imageVariant.height = height
+	return instance
+}
+
+func (instance *builder) Content(content []byte) *builder {
+	if len(content) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The image variant content is invalid")
+		return instance
+	}
+	instance.imageVariant.content = content
+	return instance
+}
+
+func (instance *builder) Url(url string) *builder {
+	url = strings.TrimSpace(url)
+	if len(url) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The image variant URL is invalid")
+		return instance
+	}
+	instance.imageVariant.url = url
+	return instance
+}
+
+func (instance *builder) Build() (*ImageVariant, error) {
+	if len(instance.invalidFields) > 0 {
+		return nil, errors.New(strings.Join(instance.invalidFields, "; "))
+	}
+	return instance.imageVariant, nil
+}
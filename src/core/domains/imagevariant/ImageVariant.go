@@ -0,0 +1,46 @@
+package imagevariant
+
+import "reflect"
+
+// ImageVariant is one of the encodings of an image generated for a specific use, such as a responsive width or the
+// preview of the article on social networks. Its content is only used until it is saved in the object storage.
+type ImageVariant struct {
+	kind    string
+	format  string
+	width   int
+	height  int
+	content []byte
+	url     string
+}
+
+func (instance *ImageVariant) NewUpdater() *builder {
+	return &builder{imageVariant: instance}
+}
+
+func (instance *ImageVariant) Kind() string {
+	return instance.kind
+}
+
+func (instance *ImageVariant) Format() string {
+	return instance.format
+}
+
+func (instance *ImageVariant) Width() int {
+	return instance.width
+}
+
+func (instance *ImageVariant) Height() int {
+	return instance.height
+}
+
+func (instance *ImageVariant) Content() []byte {
+	return instance.content
+}
+
+func (instance *ImageVariant) Url() string {
+	return instance.url
+}
+
+func (instance *ImageVariant) IsZero() bool {
+	return reflect.DeepEqual(instance, &ImageVariant{})
+}
@@ -0,0 +1,78 @@
+package libraryimage
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"vnc-summarizer/core/domains/articleimage"
+)
+
+type builder struct {
+	libraryImage  *LibraryImage
+	invalidFields []string
+}
+
+func NewBuilder() *builder {
+	return &builder{libraryImage: &LibraryImage{}}
+}
+
+func (instance *builder) Id(id uuid.UUID) *builder {
+	if id == uuid.Nil {
+		instance.invalidFields = append(instance.invalidFields, "The library image ID is invalid")
+		return instance
+	}
+	instance.libraryImage.id = id
+	return instance
+}
+
+func (instance *builder) Image(image articleimage.ArticleImage) *builder {
+	if image.IsZero() || image.Url() == "" || image.Description() == "" {
+		instance.invalidFields = append(instance.invalidFields, "The library image data is invalid")
+		return instance
+	}
+	instance.libraryImage.image = image
+	return instance
+}
+
+func (instance *builder) SubjectTags(subjectTags []string) *builder {
+	var tags []string
+	for _, tag := range subjectTags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if len(tag) > 0 {
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(tags) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The library image subject tags are invalid")
+		return instance
+	}
+	instance.libraryImage.subjectTags = tags
+	return instance
+}
+
+func (instance *builder) Embedding(embedding []float64) *builder {
+	if len(embedding) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The library image embedding is invalid")
+		return instance
+	}
+	instance.libraryImage.embedding = embedding
+	return instance
+}
+
+func (instance *builder) EmbeddingModel(embeddingModel string) *builder {
+	embeddingModel = strings.TrimSpace(embeddingModel)
+	if len(embeddingModel) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The library image embedding model is invalid")
+		return instance
+	}
+	instance.libraryImage.embeddingModel = embeddingModel
+	return instance
+}
+
+func (instance *builder) Build() (*LibraryImage, error) {
+	if len(instance.invalidFields) > 0 {
+		return nil, errors.New(strings.Join(instance.invalidFields, "; "))
+	}
+	return instance.libraryImage, nil
+}
@@ -0,0 +1,45 @@
+package libraryimage
+
+import (
+	"github.com/google/uuid"
+	"reflect"
+	"vnc-summarizer/core/domains/articleimage"
+)
+
+// LibraryImage is an image available to be reused by the articles whose subjects are similar to its description,
+// which is compared through the embedding generated by the embedding model
+type LibraryImage struct {
+	id             uuid.UUID
+	image          articleimage.ArticleImage
+	subjectTags    []string
+	embedding      []float64
+	embeddingModel string
+}
+
+func (instance *LibraryImage) NewUpdater() *builder {
+	return &builder{libraryImage: instance}
+}
+
+func (instance *LibraryImage) Id() uuid.UUID {
+	return instance.id
+}
+
+func (instance *LibraryImage) Image() articleimage.ArticleImage {
+	return instance.image
+}
+
+func (instance *LibraryImage) SubjectTags() []string {
+	return instance.subjectTags
+}
+
+func (instance *LibraryImage) Embedding() []float64 {
+	return instance.embedding
+}
+
+func (instance *LibraryImage) EmbeddingModel() string {
+	return instance.embeddingModel
+}
+
+func (instance *LibraryImage) IsZero() bool {
+	return reflect.DeepEqual(instance, &LibraryImage{})
+}
@@ -0,0 +1,52 @@
+package migration
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type builder struct {
+	migration     *Migration
+	invalidFields []string
+}
+
+func NewBuilder() *builder {
+	return &builder{migration: &Migration{}}
+}
+
+func (instance *builder) Version(version int) *builder {
+	if version <= 0 {
+		instance.invalidFields = append(instance.invalidFields, "The migration version is invalid")
+		return instance
+	}
+	instance.migration.version = version
+	return instance
+}
+
+func (instance *builder) Name(name string) *builder {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The migration name is invalid")
+		return instance
+	}
+	instance.migration.name = name
+	return instance
+}
+
+func (instance *builder) AppliedAt(appliedAt time.Time) *builder {
+	if appliedAt.IsZero() {
+		instance.invalidFields = append(instance.invalidFields, "The application date and time of the migration "+
+			"is invalid")
+		return instance
+	}
+	instance.migration.appliedAt = appliedAt
+	return instance
+}
+
+func (instance *builder) Build() (*Migration, error) {
+	if len(instance.invalidFields) > 0 {
+		return nil, errors.New(strings.Join(instance.invalidFields, "; "))
+	}
+	return instance.migration, nil
+}
@@ -0,0 +1,29 @@
+package migration
+
+import "time"
+
+type Migration struct {
+	version   int
+	name      string
+	appliedAt time.Time
+}
+
+func (instance *Migration) NewUpdater() *builder {
+	return &builder{migration: instance}
+}
+
+func (instance *Migration) Version() int {
+	return instance.version
+}
+
+func (instance *Migration) Name() string {
+	return instance.name
+}
+
+func (instance *Migration) AppliedAt() time.Time {
+	return instance.appliedAt
+}
+
+func (instance *Migration) IsApplied() bool {
+	return !instance.appliedAt.IsZero()
+}
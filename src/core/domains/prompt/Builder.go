@@ -0,0 +1,55 @@
+package prompt
+
+import (
+	"errors"
+	"strings"
+)
+
+type builder struct {
+	prompt        *Prompt
+	invalidFields []string
+}
+
+func NewBuilder() *builder {
+	return &builder{prompt: &Prompt{}}
+}
+
+func (instance *builder) Code(code string) *builder {
+	code = strings.TrimSpace(code)
+	if len(code) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The prompt code is invalid")
+		return instance
+	}
+	instance.prompt.code = code
+	return instance
+}
+
+func (instance *builder) Variant(variant string) *builder {
+	instance.prompt.variant = strings.TrimSpace(variant)
+	return instance
+}
+
+func (instance *builder) Version(version int) *builder {
+	if version <= 0 {
+		instance.invalidFields = append(instance.invalidFields, "The prompt version is invalid")
+		return instance
+	}
+	instance.prompt.version = version
+	return instance
+}
+
+func (instance *builder) Text(text string) *builder {
+	if len(strings.TrimSpace(text)) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The prompt text is invalid")
+		return instance
+	}
+	instance.prompt.text = text
+	return instance
+}
+
+func (instance *builder) Build() (*Prompt, error) {
+	if len(instance.invalidFields) > 0 {
+		return nil, errors.New(strings.Join(instance.invalidFields, "; "))
+	}
+	return instance.prompt, nil
+}
@@ -0,0 +1,34 @@
+package prompt
+
+import "reflect"
+
+type Prompt struct {
+	code    string
+	variant string
+	version int
+	text    string
+}
+
+func (instance *Prompt) NewUpdater() *builder {
+	return &builder{prompt: instance}
+}
+
+func (instance *Prompt) Code() string {
+	return instance.code
+}
+
+func (instance *Prompt) Variant() string {
+	return instance.variant
+}
+
+func (instance *Prompt) Version() int {
+	return instance.version
+}
+
+func (instance *Prompt) Text() string {
+	return instance.text
+}
+
+func (instance *Prompt) IsZero() bool {
+	return reflect.DeepEqual(instance, &Prompt{})
+}
@@ -0,0 +1,12 @@
+package prompt
+
+import "time"
+
+// Variables contains the data that can be referenced by the prompt templates (e.g. {{.VotingResult}})
+type Variables struct {
+	PropositionType         string
+	PropositionSpecificType string
+	VotingResult            string
+	EventType               string
+	ReferenceDate           time.Time
+}
@@ -0,0 +1,73 @@
+package summary
+
+import (
+	"errors"
+	"strings"
+)
+
+type builder struct {
+	summary       *Summary
+	invalidFields []string
+}
+
+func NewBuilder() *builder {
+	return &builder{summary: &Summary{}}
+}
+
+func (instance *builder) Title(title string) *builder {
+	title = strings.TrimSpace(title)
+	if len(title) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The summary title is invalid")
+		return instance
+	}
+	instance.summary.title = title
+	return instance
+}
+
+func (instance *builder) Paragraphs(paragraphs []string) *builder {
+	paragraphs = removeBlankItems(paragraphs)
+	if len(paragraphs) == 0 {
+		instance.invalidFields = append(instance.invalidFields, "The summary paragraphs are invalid")
+		return instance
+	}
+	instance.summary.paragraphs = paragraphs
+	return instance
+}
+
+func (instance *builder) KeyPoints(keyPoints []string) *builder {
+	instance.summary.keyPoints = removeBlankItems(keyPoints)
+	return instance
+}
+
+func (instance *builder) AffectedGroups(affectedGroups []string) *builder {
+	instance.summary.affectedGroups = removeBlankItems(affectedGroups)
+	return instance
+}
+
+func (instance *builder) SubjectTags(subjectTags []string) *builder {
+	var tags []string
+	for _, tag := range removeBlankItems(subjectTags) {
+		tags = append(tags, strings.ToLower(tag))
+	}
+	instance.summary.subjectTags = tags
+	return instance
+}
+
+func (instance *builder) Build() (*Summary, error) {
+	if len(instance.invalidFields) > 0 {
+		return nil, errors.New(strings.Join(instance.invalidFields, "; "))
+	}
+	return instance.summary, nil
+}
+
+func removeBlankItems(items []string) []string {
+	var filledItems []string
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if len(item) > 0 {
+			filledItems = append(filledItems, item)
+		}
+	}
+
+	return filledItems
+}
@@ -0,0 +1,46 @@
+package summary
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Summary struct {
+	title          string
+	paragraphs     []string
+	keyPoints      []string
+	affectedGroups []string
+	subjectTags    []string
+}
+
+func (instance *Summary) NewUpdater() *builder {
+	return &builder{summary: instance}
+}
+
+func (instance *Summary) Title() string {
+	return instance.title
+}
+
+func (instance *Summary) Paragraphs() []string {
+	return instance.paragraphs
+}
+
+func (instance *Summary) Content() string {
+	return strings.Join(instance.paragraphs, "\n\n")
+}
+
+func (instance *Summary) KeyPoints() []string {
+	return instance.keyPoints
+}
+
+func (instance *Summary) AffectedGroups() []string {
+	return instance.affectedGroups
+}
+
+func (instance *Summary) SubjectTags() []string {
+	return instance.subjectTags
+}
+
+func (instance *Summary) IsZero() bool {
+	return reflect.DeepEqual(instance, &Summary{})
+}
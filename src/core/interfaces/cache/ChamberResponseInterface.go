@@ -0,0 +1,11 @@
+package cache
+
+import (
+	"context"
+	"vnc-summarizer/core/domains/chamberresponse"
+)
+
+type ChamberResponse interface {
+	GetChamberResponse(ctx context.Context, key string) (*chamberresponse.ChamberResponse, error)
+	SaveChamberResponse(ctx context.Context, key string, response chamberresponse.ChamberResponse) error
+}
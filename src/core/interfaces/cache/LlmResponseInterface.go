@@ -0,0 +1,11 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+type LlmResponse interface {
+	GetLlmResponse(ctx context.Context, key string) (string, bool, error)
+	SaveLlmResponse(ctx context.Context, key, response string, expiresAt time.Time) error
+}
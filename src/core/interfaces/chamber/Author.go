@@ -0,0 +1,13 @@
+package chamber
+
+// DeputyAuthorTypeCode is the code of the authors that are deputies, whose data must be searched in the deputies
+// resource of the Chamber of Deputies API
+const DeputyAuthorTypeCode = 10000
+
+type Author struct {
+	Uri            *string `json:"uri"`
+	Name           string  `json:"nome"`
+	Type           string  `json:"tipo"`
+	TypeCode       int     `json:"codTipo"`
+	SignatureOrder int     `json:"ordemAssinatura"`
+}
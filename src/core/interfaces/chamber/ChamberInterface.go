@@ -1,18 +1,29 @@
 package chamber
 
+import (
+	"context"
+	"time"
+)
+
 type Chamber interface {
-	GetMostRecentPropositions() ([]map[string]interface{}, error)
-	GetPropositionByCode(code int) (map[string]interface{}, error)
-	GetPropositionContentDirectly(propositionUrl string) (string, string, error)
-	GetPropositionTypes() ([]map[string]interface{}, error)
-	GetPartyByAcronym(acronym string) (map[string]interface{}, error)
-	GetLegislativeBodyByCode(code int) (map[string]interface{}, error)
-	GetLegislativeBodyTypes() ([]map[string]interface{}, error)
-	GetMostRecentVotes() ([]map[string]interface{}, error)
-	GetVotingByCode(code string) (map[string]interface{}, error)
-	GetMostRecentEvents() ([]map[string]interface{}, error)
-	GetEventByCode(code int) (map[string]interface{}, error)
-	GetEventsByCodes(eventCodes []string) ([]map[string]interface{}, error)
-	GetEventTypes() ([]map[string]interface{}, error)
-	GetEventSituations() ([]map[string]interface{}, error)
+	GetMostRecentPropositions(ctx context.Context) ([]Proposition, error)
+	GetPropositionsByDateRange(ctx context.Context, startDate, endDate time.Time) ([]Proposition, error)
+	GetPropositionByCode(ctx context.Context, code int) (*Proposition, error)
+	GetPropositionAuthors(ctx context.Context, propositionCode int) ([]Author, error)
+	GetPropositionContentDirectly(ctx context.Context, propositionUrl string) (string, string, error)
+	GetPropositionTypes(ctx context.Context) ([]PropositionType, error)
+	GetDeputyByCode(ctx context.Context, code int) (*Deputy, error)
+	GetPartyByAcronym(ctx context.Context, acronym string) (*Party, error)
+	GetLegislativeBodyByCode(ctx context.Context, code int) (*LegislativeBody, error)
+	GetLegislativeBodyTypes(ctx context.Context) ([]LegislativeBodyType, error)
+	GetMostRecentVotes(ctx context.Context) ([]Voting, error)
+	GetVotesByDateRange(ctx context.Context, startDate, endDate time.Time) ([]Voting, error)
+	GetVotingByCode(ctx context.Context, code string) (*Voting, error)
+	GetMostRecentEvents(ctx context.Context) ([]Event, error)
+	GetEventsByDateRange(ctx context.Context, startDate, endDate time.Time) ([]Event, error)
+	GetEventByCode(ctx context.Context, code int) (*Event, error)
+	GetEventsByCodes(ctx context.Context, eventCodes []string) ([]Event, error)
+	GetEventAgendaItems(ctx context.Context, eventCode int) ([]EventAgendaItem, error)
+	GetEventTypes(ctx context.Context) ([]EventType, error)
+	GetEventSituations(ctx context.Context) ([]EventSituation, error)
 }
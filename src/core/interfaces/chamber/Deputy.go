@@ -0,0 +1,22 @@
+package chamber
+
+type Deputy struct {
+	Id         int          `json:"id"`
+	Cpf        string       `json:"cpf"`
+	CivilName  string       `json:"nomeCivil"`
+	LastStatus DeputyStatus `json:"ultimoStatus"`
+}
+
+// DeputyStatus is the status of the deputy in the most recent legislature in which the deputy held office
+type DeputyStatus struct {
+	ElectoralName string `json:"nomeEleitoral"`
+	PartyAcronym  string `json:"siglaPartido"`
+	FederatedUnit string `json:"siglaUf"`
+	ImageUrl      string `json:"urlFoto"`
+}
+
+// DeputyReference is the reference to a deputy made by the other resources of the Chamber of Deputies API
+type DeputyReference struct {
+	Id   int    `json:"id"`
+	Name string `json:"nome"`
+}
@@ -0,0 +1,47 @@
+package chamber
+
+const EventDateTimeLayout = "2006-01-02T15:04"
+
+// Event is the event returned by the Chamber of Deputies API. The fields that may be null in the API are pointers, and
+// the details returned only when the event is searched by its code are empty in the lists.
+type Event struct {
+	Id                int                        `json:"id"`
+	StartsAt          string                     `json:"dataHoraInicio"`
+	EndsAt            *string                    `json:"dataHoraFim"`
+	Description       string                     `json:"descricao"`
+	TypeDescription   string                     `json:"descricaoTipo"`
+	Situation         string                     `json:"situacao"`
+	LocationInChamber *EventLocation             `json:"localCamara"`
+	ExternalLocation  *string                    `json:"localExterno"`
+	VideoUrl          *string                    `json:"urlRegistro"`
+	LegislativeBodies []LegislativeBodyReference `json:"orgaos"`
+	Requirements      []PropositionReference     `json:"requerimentos"`
+}
+
+type EventLocation struct {
+	Name *string `json:"nome"`
+}
+
+// EventAgendaItem is the item of the agenda of an event, which is returned by the Chamber of Deputies API in the
+// agenda resource of the event
+type EventAgendaItem struct {
+	Title              string                `json:"titulo"`
+	Topic              string                `json:"topico"`
+	RegimeCode         int                   `json:"codRegime"`
+	Regime             string                `json:"regime"`
+	Situation          *string               `json:"situacaoItem"`
+	Rapporteur         *DeputyReference      `json:"relator"`
+	Proposition        PropositionReference  `json:"proposicao_"`
+	RelatedProposition *PropositionReference `json:"proposicaoRelacionada_"`
+	VotingUri          *string               `json:"uriVotacao"`
+}
+
+type EventType struct {
+	Code string `json:"cod"`
+	Name string `json:"nome"`
+}
+
+type EventSituation struct {
+	Code string `json:"cod"`
+	Name string `json:"nome"`
+}
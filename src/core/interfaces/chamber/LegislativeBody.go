@@ -0,0 +1,19 @@
+package chamber
+
+type LegislativeBody struct {
+	Id       int    `json:"id"`
+	TypeCode int    `json:"codTipoOrgao"`
+	Name     string `json:"nome"`
+	Acronym  string `json:"sigla"`
+}
+
+// LegislativeBodyReference is the reference to a legislative body made by the other resources of the Chamber of
+// Deputies API
+type LegislativeBodyReference struct {
+	Id int `json:"id"`
+}
+
+type LegislativeBodyType struct {
+	Code string `json:"cod"`
+	Name string `json:"nome"`
+}
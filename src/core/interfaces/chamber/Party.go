@@ -0,0 +1,8 @@
+package chamber
+
+type Party struct {
+	Id       int    `json:"id"`
+	Name     string `json:"nome"`
+	Acronym  string `json:"sigla"`
+	ImageUrl string `json:"urlLogo"`
+}
@@ -0,0 +1,24 @@
+package chamber
+
+const PropositionDateTimeLayout = "2006-01-02T15:04"
+
+// Proposition is the proposition returned by the Chamber of Deputies API. The fields that may be null in the API are
+// pointers, and the details returned only when the proposition is searched by its code are empty in the lists.
+type Proposition struct {
+	Id              int     `json:"id"`
+	TypeCode        int     `json:"codTipo"`
+	TypeAcronym     string  `json:"siglaTipo"`
+	SubmittedAt     string  `json:"dataApresentacao"`
+	OriginalTextUrl *string `json:"urlInteiroTeor"`
+}
+
+// PropositionReference is the reference to a proposition made by the other resources of the Chamber of Deputies API
+type PropositionReference struct {
+	Id  int    `json:"id"`
+	Uri string `json:"uri"`
+}
+
+type PropositionType struct {
+	Code string `json:"cod"`
+	Name string `json:"nome"`
+}
@@ -0,0 +1,26 @@
+package chamber
+
+const (
+	VotingDateLayout     = "2006-01-02"
+	VotingDateTimeLayout = "2006-01-02T15:04:05"
+)
+
+// Voting is the voting returned by the Chamber of Deputies API. The fields that may be null in the API are pointers,
+// and the details returned only when the voting is searched by its code are empty in the lists.
+type Voting struct {
+	Id                   string                   `json:"id"`
+	Date                 string                   `json:"data"`
+	ResultAnnouncedAt    *string                  `json:"dataHoraRegistro"`
+	Result               string                   `json:"descricao"`
+	Approval             *int                     `json:"aprovacao"`
+	LegislativeBodyCode  int                      `json:"idOrgao"`
+	LastPresentation     *PropositionPresentation `json:"ultimaApresentacaoProposicao"`
+	RelatedPropositions  []PropositionReference   `json:"objetosPossiveis"`
+	AffectedPropositions []PropositionReference   `json:"proposicoesAfetadas"`
+}
+
+// PropositionPresentation is the last presentation of a proposition before the voting, which cites the main
+// proposition of the voting
+type PropositionPresentation struct {
+	CitedPropositionUri *string `json:"uriProposicaoCitada"`
+}
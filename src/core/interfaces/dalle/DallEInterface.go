@@ -1,5 +0,0 @@
-package dalle
-
-type DallE interface {
-	MakeRequest(prompt, purpose string) (string, error)
-}
@@ -0,0 +1,10 @@
+package embeddings
+
+import (
+	"context"
+	"vnc-summarizer/core/domains/generationusage"
+)
+
+type Embedding interface {
+	MakeRequest(ctx context.Context, text, purpose string) ([]float64, *generationusage.GenerationUsage, error)
+}
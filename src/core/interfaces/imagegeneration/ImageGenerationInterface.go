@@ -0,0 +1,15 @@
+package imagegeneration
+
+import (
+	"context"
+	"vnc-summarizer/core/domains/generationusage"
+)
+
+// PlaceholderProvider is the provider of the usages of the placeholder images, which are drawn locally instead of
+// being generated from the prompt
+const PlaceholderProvider = "Placeholder"
+
+type ImageGenerator interface {
+	GenerateImage(ctx context.Context, prompt, theme, purpose string) ([]byte, *generationusage.GenerationUsage,
+		error)
+}
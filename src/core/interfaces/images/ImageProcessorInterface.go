@@ -0,0 +1,10 @@
+package images
+
+import (
+	"context"
+	"vnc-summarizer/core/domains/articleimage"
+)
+
+type ImageProcessor interface {
+	ProcessImage(ctx context.Context, image []byte) (*articleimage.ArticleImage, error)
+}
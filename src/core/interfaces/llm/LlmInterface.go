@@ -0,0 +1,17 @@
+package llm
+
+import (
+	"context"
+	"vnc-summarizer/core/domains/generationusage"
+)
+
+type Llm interface {
+	MakeRequest(ctx context.Context, command, content, purpose string) (string, *generationusage.GenerationUsage, error)
+	MakeRequestUsingMapReduce(ctx context.Context, command, content, purpose string) (string,
+		*generationusage.GenerationUsage, error)
+	MakeStructuredRequest(ctx context.Context, command, content, purpose string, schema map[string]interface{}) (
+		map[string]interface{}, *generationusage.GenerationUsage, error)
+	MakeRequestToVision(ctx context.Context, command, imageUrl string) (string, *generationusage.GenerationUsage, error)
+	MakeStructuredRequestToVision(ctx context.Context, command, content, imageUrl, purpose string,
+		schema map[string]interface{}) (map[string]interface{}, *generationusage.GenerationUsage, error)
+}
@@ -1,5 +1,7 @@
 package pdfcontentextractor
 
+import "context"
+
 type VncPdfContentExtractor interface {
-	MakeRequest(pdfUrl string) (string, error)
+	MakeRequest(ctx context.Context, pdfUrl string) (string, error)
 }
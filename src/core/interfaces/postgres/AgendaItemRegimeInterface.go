@@ -1,11 +1,12 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/agendaitemregime"
 	"github.com/google/uuid"
 )
 
 type AgendaItemRegime interface {
-	CreateAgendaItemRegime(agendaItemRegime agendaitemregime.AgendaItemRegime) (*uuid.UUID, error)
-	GetAgendaItemRegimeByCode(code int) (*agendaitemregime.AgendaItemRegime, error)
+	CreateAgendaItemRegime(ctx context.Context, agendaItemRegime agendaitemregime.AgendaItemRegime) (*uuid.UUID, error)
+	GetAgendaItemRegimeByCode(ctx context.Context, code int) (*agendaitemregime.AgendaItemRegime, error)
 }
@@ -1,12 +1,13 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/article"
 	"github.com/google/uuid"
 	"time"
 )
 
 type Article interface {
-	GetArticlesByReferenceDate(date time.Time) ([]article.Article, error)
-	GetNewsletterArticlesByNewsletterId(newsletterId uuid.UUID) ([]article.Article, error)
+	GetArticlesByReferenceDate(ctx context.Context, date time.Time) ([]article.Article, error)
+	GetNewsletterArticlesByNewsletterId(ctx context.Context, newsletterId uuid.UUID) ([]article.Article, error)
 }
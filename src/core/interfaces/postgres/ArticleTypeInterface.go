@@ -1,9 +1,10 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/articletype"
 )
 
 type ArticleType interface {
-	GetArticleTypeByCode(code string) (*articletype.ArticleType, error)
+	GetArticleTypeByCode(ctx context.Context, code string) (*articletype.ArticleType, error)
 }
@@ -0,0 +1,11 @@
+package postgres
+
+import (
+	"context"
+	"time"
+)
+
+type BackfillCheckpoint interface {
+	GetCompletedDates(ctx context.Context, dataType string, startDate, endDate time.Time) ([]time.Time, error)
+	SaveCheckpoint(ctx context.Context, dataType string, referenceDate time.Time) error
+}
@@ -1,12 +1,13 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/deputy"
 	"github.com/google/uuid"
 )
 
 type Deputy interface {
-	CreateDeputy(deputy deputy.Deputy) (*uuid.UUID, error)
-	UpdateDeputy(deputy deputy.Deputy) error
-	GetDeputyByCode(code int) (*deputy.Deputy, error)
+	CreateDeputy(ctx context.Context, deputy deputy.Deputy) (*uuid.UUID, error)
+	UpdateDeputy(ctx context.Context, deputy deputy.Deputy) error
+	GetDeputyByCode(ctx context.Context, code int) (*deputy.Deputy, error)
 }
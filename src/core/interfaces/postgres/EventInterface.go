@@ -1,14 +1,16 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/event"
 	"github.com/google/uuid"
+	"vnc-summarizer/core/domains/generation"
 )
 
 type Event interface {
-	CreateEvent(event event.Event) (*uuid.UUID, error)
-	UpdateEvent(event event.Event) error
-	GetEventsByCodes(codes []int) ([]event.Event, error)
-	GetEventsOccurringToday() ([]event.Event, error)
-	GetEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished() ([]event.Event, error)
+	CreateEvent(ctx context.Context, event event.Event, generationData generation.Generation) (*uuid.UUID, error)
+	UpdateEvent(ctx context.Context, event event.Event) error
+	GetEventsByCodes(ctx context.Context, codes []int) ([]event.Event, error)
+	GetEventsOccurringToday(ctx context.Context) ([]event.Event, error)
+	GetEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished(ctx context.Context) ([]event.Event, error)
 }
@@ -1,9 +1,10 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/eventsituation"
 )
 
 type EventSituation interface {
-	GetEventSituationByCodeOrDefaultSituation(code string) (*eventsituation.EventSituation, error)
+	GetEventSituationByCodeOrDefaultSituation(ctx context.Context, code string) (*eventsituation.EventSituation, error)
 }
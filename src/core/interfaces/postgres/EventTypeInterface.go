@@ -1,9 +1,10 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/eventtype"
 )
 
 type EventType interface {
-	GetEventTypeByCodeOrDefaultType(code string) (*eventtype.EventType, error)
+	GetEventTypeByCodeOrDefaultType(ctx context.Context, code string) (*eventtype.EventType, error)
 }
@@ -1,11 +1,13 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/externalauthor"
 	"github.com/google/uuid"
 )
 
 type ExternalAuthor interface {
-	CreateExternalAuthor(externalAuthor externalauthor.ExternalAuthor) (*uuid.UUID, error)
-	GetExternalAuthorByNameAndTypeCode(name string, typeCode int) (*externalauthor.ExternalAuthor, error)
+	CreateExternalAuthor(ctx context.Context, externalAuthor externalauthor.ExternalAuthor) (*uuid.UUID, error)
+	GetExternalAuthorByNameAndTypeCode(ctx context.Context, name string, typeCode int) (*externalauthor.ExternalAuthor,
+		error)
 }
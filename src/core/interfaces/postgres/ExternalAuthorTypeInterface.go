@@ -1,11 +1,13 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/externalauthortype"
 	"github.com/google/uuid"
 )
 
 type ExternalAuthorType interface {
-	CreateExternalAuthorType(externalAuthorType externalauthortype.ExternalAuthorType) (*uuid.UUID, error)
-	GetExternalAuthorTypeByCode(code int) (*externalauthortype.ExternalAuthorType, error)
+	CreateExternalAuthorType(ctx context.Context, externalAuthorType externalauthortype.ExternalAuthorType) (*uuid.UUID,
+		error)
+	GetExternalAuthorTypeByCode(ctx context.Context, code int) (*externalauthortype.ExternalAuthorType, error)
 }
@@ -0,0 +1,13 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+	"vnc-summarizer/core/domains/generationusage"
+)
+
+type GenerationUsage interface {
+	CreateGenerationUsage(ctx context.Context, generationUsage generationusage.GenerationUsage) (*uuid.UUID, error)
+	GetEstimatedCostByDate(ctx context.Context, date time.Time) (float64, error)
+}
@@ -1,11 +1,13 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/legislativebodytype"
 	"github.com/google/uuid"
 )
 
 type LegislativeBodyType interface {
-	CreateLegislativeBodyType(legislativeBodyType legislativebodytype.LegislativeBodyType) (*uuid.UUID, error)
-	GetLegislativeBodyTypeByCode(code int) (*legislativebodytype.LegislativeBodyType, error)
+	CreateLegislativeBodyType(ctx context.Context, legislativeBodyType legislativebodytype.LegislativeBodyType) (
+		*uuid.UUID, error)
+	GetLegislativeBodyTypeByCode(ctx context.Context, code int) (*legislativebodytype.LegislativeBodyType, error)
 }
@@ -0,0 +1,14 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"vnc-summarizer/core/domains/libraryimage"
+)
+
+type LibraryImage interface {
+	CreateLibraryImage(ctx context.Context, libraryImage libraryimage.LibraryImage) (*uuid.UUID, error)
+	GetLibraryImagesBySubjectTags(ctx context.Context, subjectTags []string, embeddingModel string) (
+		[]libraryimage.LibraryImage, error)
+	RegisterLibraryImageUse(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,12 @@
+package postgres
+
+import (
+	"context"
+	"vnc-summarizer/core/domains/migration"
+)
+
+type Migration interface {
+	MigrateUp(ctx context.Context) ([]migration.Migration, error)
+	MigrateDown(ctx context.Context, numberOfMigrations int, dropPlatformTables bool) ([]migration.Migration, error)
+	GetMigrations(ctx context.Context) ([]migration.Migration, error)
+}
@@ -1,14 +1,18 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/article"
 	"github.com/devlucassantos/vnc-domains/src/domains/newsletter"
 	"github.com/google/uuid"
 	"time"
+	"vnc-summarizer/core/domains/generation"
 )
 
 type Newsletter interface {
-	CreateNewsletter(newsletter newsletter.Newsletter) (*uuid.UUID, error)
-	UpdateNewsletter(newsletter newsletter.Newsletter, newArticles []article.Article) error
-	GetNewsletterByReferenceDate(referenceDate time.Time) (*newsletter.Newsletter, error)
+	CreateNewsletter(ctx context.Context, newsletter newsletter.Newsletter, generationData generation.Generation) (
+		*uuid.UUID, error)
+	UpdateNewsletter(ctx context.Context, newsletter newsletter.Newsletter, newArticles []article.Article,
+		generationData generation.Generation) error
+	GetNewsletterByReferenceDate(ctx context.Context, referenceDate time.Time) (*newsletter.Newsletter, error)
 }
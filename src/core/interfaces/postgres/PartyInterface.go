@@ -1,12 +1,13 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/party"
 	"github.com/google/uuid"
 )
 
 type Party interface {
-	CreateParty(party party.Party) (*uuid.UUID, error)
-	UpdateParty(party party.Party) error
-	GetPartyByCode(code int) (*party.Party, error)
+	CreateParty(ctx context.Context, party party.Party) (*uuid.UUID, error)
+	UpdateParty(ctx context.Context, party party.Party) error
+	GetPartyByCode(ctx context.Context, code int) (*party.Party, error)
 }
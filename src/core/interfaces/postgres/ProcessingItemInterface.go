@@ -0,0 +1,12 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+type ProcessingItem interface {
+	StartProcessingItem(ctx context.Context, runId uuid.UUID, itemType, code string) (int, error)
+	FinishProcessingItem(ctx context.Context, itemType, code, status, errorMessage string) error
+	GetCodesOfTheItemsToRetry(ctx context.Context, itemType string, maximumNumberOfAttempts int) ([]string, error)
+}
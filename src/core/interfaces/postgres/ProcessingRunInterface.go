@@ -0,0 +1,11 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+type ProcessingRun interface {
+	CreateProcessingRun(ctx context.Context, jobName string) (*uuid.UUID, error)
+	FinishProcessingRun(ctx context.Context, id uuid.UUID) error
+}
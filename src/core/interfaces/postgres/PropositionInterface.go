@@ -1,11 +1,14 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/proposition"
 	"github.com/google/uuid"
+	"vnc-summarizer/core/domains/generation"
 )
 
 type Proposition interface {
-	CreateProposition(proposition proposition.Proposition) (*uuid.UUID, error)
-	GetPropositionsByCodes(codes []int) ([]proposition.Proposition, error)
+	CreateProposition(ctx context.Context, proposition proposition.Proposition, generationData generation.Generation) (
+		*uuid.UUID, error)
+	GetPropositionsByCodes(ctx context.Context, codes []int) ([]proposition.Proposition, error)
 }
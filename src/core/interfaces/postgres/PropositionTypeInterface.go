@@ -1,9 +1,10 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/propositiontype"
 )
 
 type PropositionType interface {
-	GetPropositionTypeByCodeOrDefaultType(code string) (*propositiontype.PropositionType, error)
+	GetPropositionTypeByCodeOrDefaultType(ctx context.Context, code string) (*propositiontype.PropositionType, error)
 }
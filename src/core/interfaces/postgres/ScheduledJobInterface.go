@@ -0,0 +1,11 @@
+package postgres
+
+import (
+	"context"
+	"time"
+)
+
+type ScheduledJob interface {
+	GetLastExecutionTime(ctx context.Context, jobName string) (*time.Time, error)
+	SaveLastExecutionTime(ctx context.Context, jobName string, executedAt time.Time) error
+}
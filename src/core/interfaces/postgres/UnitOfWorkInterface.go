@@ -0,0 +1,7 @@
+package postgres
+
+import "context"
+
+type UnitOfWork interface {
+	Execute(ctx context.Context, operation func(ctx context.Context) error) error
+}
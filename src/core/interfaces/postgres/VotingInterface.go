@@ -1,11 +1,13 @@
 package postgres
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/voting"
 	"github.com/google/uuid"
+	"vnc-summarizer/core/domains/generation"
 )
 
 type Voting interface {
-	CreateVoting(voting voting.Voting) (*uuid.UUID, error)
-	GetVotesByCodes(codes []string) ([]voting.Voting, error)
+	CreateVoting(ctx context.Context, voting voting.Voting, generationData generation.Generation) (*uuid.UUID, error)
+	GetVotesByCodes(ctx context.Context, codes []string) ([]voting.Voting, error)
 }
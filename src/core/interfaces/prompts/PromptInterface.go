@@ -0,0 +1,7 @@
+package prompts
+
+import "vnc-summarizer/core/domains/prompt"
+
+type Prompt interface {
+	GetPrompt(code, variant string, variables prompt.Variables) (*prompt.Prompt, error)
+}
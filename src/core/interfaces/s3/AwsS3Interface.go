@@ -1,5 +0,0 @@
-package s3
-
-type AwsS3 interface {
-	SavePropositionImage(propositionCode int, image []byte) (string, error)
-}
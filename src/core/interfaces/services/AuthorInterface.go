@@ -1,10 +1,12 @@
 package services
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/deputy"
 	"github.com/devlucassantos/vnc-domains/src/domains/externalauthor"
 )
 
 type Author interface {
-	GetAuthorsFromAuthorsUrl(authorsUrl string) ([]deputy.Deputy, []externalauthor.ExternalAuthor, error)
+	GetAuthorsByPropositionCode(ctx context.Context, propositionCode int) ([]deputy.Deputy,
+		[]externalauthor.ExternalAuthor, error)
 }
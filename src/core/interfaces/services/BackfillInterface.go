@@ -0,0 +1,10 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+type Backfill interface {
+	Backfill(ctx context.Context, dataTypes []string, startDate, endDate time.Time) error
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"context"
+	"vnc-summarizer/core/domains/generationusage"
+)
+
+type Budget interface {
+	IsDailyBudgetExceeded(ctx context.Context) bool
+	RegisterGenerationUsage(ctx context.Context,
+		generationUsage generationusage.GenerationUsage) *generationusage.GenerationUsage
+}
@@ -1,7 +1,10 @@
 package services
 
-import "github.com/devlucassantos/vnc-domains/src/domains/deputy"
+import (
+	"context"
+	"github.com/devlucassantos/vnc-domains/src/domains/deputy"
+)
 
 type Deputy interface {
-	GetDeputyFromDeputyData(deputyData map[string]interface{}) (*deputy.Deputy, error)
+	GetDeputyByCode(ctx context.Context, code int) (*deputy.Deputy, error)
 }
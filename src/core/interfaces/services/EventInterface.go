@@ -1,10 +1,15 @@
 package services
 
-import "github.com/google/uuid"
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
 
 type Event interface {
-	RegisterNewEvents()
-	UpdateEventsOccurringToday()
-	UpdateEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished()
-	RegisterNewEventByCode(code int) (*uuid.UUID, error)
+	RegisterNewEvents(ctx context.Context)
+	RegisterNewEventsByDateRange(ctx context.Context, startDate, endDate time.Time) error
+	UpdateEventsOccurringToday(ctx context.Context)
+	UpdateEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished(ctx context.Context)
+	RegisterNewEventByCode(ctx context.Context, code int) (*uuid.UUID, error)
 }
@@ -1,8 +1,11 @@
 package services
 
-import "github.com/devlucassantos/vnc-domains/src/domains/externalauthor"
+import (
+	"context"
+	"github.com/devlucassantos/vnc-domains/src/domains/externalauthor"
+)
 
 type ExternalAuthor interface {
-	GetExternalAuthorFromAuthorData(authorName string, authorTypeCode int, authorType string) (
+	GetExternalAuthorFromAuthorData(ctx context.Context, authorName string, authorTypeCode int, authorType string) (
 		*externalauthor.ExternalAuthor, error)
 }
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+	"vnc-summarizer/core/domains/articleimage"
+	"vnc-summarizer/core/domains/generationusage"
+)
+
+type ImageLibrary interface {
+	GetSimilarImage(ctx context.Context, imagePrompt string, subjectTags []string) (*articleimage.ArticleImage,
+		*generationusage.GenerationUsage, error)
+	AddImage(ctx context.Context, image articleimage.ArticleImage, subjectTags []string) (
+		*generationusage.GenerationUsage, error)
+}
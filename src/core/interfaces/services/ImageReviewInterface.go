@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/core/domains/imagereview"
+	"vnc-summarizer/core/domains/prompt"
+)
+
+type ImageReview interface {
+	ReviewImage(ctx context.Context, imageUrl, imageProvider, articleContent, promptVariant string,
+		promptVariables prompt.Variables) (*imagereview.ImageReview, *prompt.Prompt, *generationusage.GenerationUsage,
+		error)
+}
@@ -1,12 +1,13 @@
 package services
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/legislativebody"
 	"github.com/google/uuid"
 )
 
 type LegislativeBody interface {
-	RegisterNewLegislativeBodyByCode(code int) (*uuid.UUID, error)
-	GetLegislativeBodyByCode(code int) (*legislativebody.LegislativeBody, error)
-	GetLegislativeBodiesByCodes(codes []int) ([]legislativebody.LegislativeBody, error)
+	RegisterNewLegislativeBodyByCode(ctx context.Context, code int) (*uuid.UUID, error)
+	GetLegislativeBodyByCode(ctx context.Context, code int) (*legislativebody.LegislativeBody, error)
+	GetLegislativeBodiesByCodes(ctx context.Context, codes []int) ([]legislativebody.LegislativeBody, error)
 }
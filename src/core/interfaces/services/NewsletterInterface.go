@@ -1,7 +1,10 @@
 package services
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type Newsletter interface {
-	RegisterNewNewsletter(referenceDate time.Time)
+	RegisterNewNewsletter(ctx context.Context, referenceDate time.Time)
 }
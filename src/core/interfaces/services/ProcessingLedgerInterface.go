@@ -0,0 +1,10 @@
+package services
+
+import "context"
+
+type ProcessingLedger interface {
+	StartRun(ctx context.Context, jobName string) (context.Context, error)
+	FinishRun(ctx context.Context)
+	ProcessItem(ctx context.Context, itemType, code string, process func(ctx context.Context) error) error
+	GetCodesOfTheItemsToRetry(ctx context.Context, itemType string) []string
+}
@@ -1,12 +1,15 @@
 package services
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/proposition"
 	"github.com/google/uuid"
+	"time"
 )
 
 type Proposition interface {
-	RegisterNewPropositions()
-	RegisterNewPropositionByCode(code int) (*uuid.UUID, error)
-	GetPropositionsByCodes(codes []int) ([]proposition.Proposition, error)
+	RegisterNewPropositions(ctx context.Context)
+	RegisterNewPropositionsByDateRange(ctx context.Context, startDate, endDate time.Time) error
+	RegisterNewPropositionByCode(ctx context.Context, code int) (*uuid.UUID, error)
+	GetPropositionsByCodes(ctx context.Context, codes []int) ([]proposition.Proposition, error)
 }
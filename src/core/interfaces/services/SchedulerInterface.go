@@ -0,0 +1,8 @@
+package services
+
+import "context"
+
+type Scheduler interface {
+	Start(ctx context.Context) error
+	RunJobsOnce(ctx context.Context) error
+}
@@ -1,12 +1,15 @@
 package services
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/voting"
 	"github.com/google/uuid"
+	"time"
 )
 
 type Voting interface {
-	RegisterNewVotes()
-	RegisterNewVotingByCode(code string) (*uuid.UUID, error)
-	GetVotesByCodes(codes []string) ([]voting.Voting, error)
+	RegisterNewVotes(ctx context.Context)
+	RegisterNewVotesByDateRange(ctx context.Context, startDate, endDate time.Time) error
+	RegisterNewVotingByCode(ctx context.Context, code string) (*uuid.UUID, error)
+	GetVotesByCodes(ctx context.Context, codes []string) ([]voting.Voting, error)
 }
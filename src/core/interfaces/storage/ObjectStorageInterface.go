@@ -0,0 +1,7 @@
+package storage
+
+import "context"
+
+type ObjectStorage interface {
+	SaveObject(ctx context.Context, key string, content []byte) (string, error)
+}
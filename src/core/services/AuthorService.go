@@ -1,76 +1,77 @@
 package services
 
 import (
-	"fmt"
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/deputy"
 	"github.com/devlucassantos/vnc-domains/src/domains/externalauthor"
 	"github.com/labstack/gommon/log"
+	"path"
 	"sort"
+	"strconv"
+	"vnc-summarizer/core/interfaces/chamber"
 	"vnc-summarizer/core/interfaces/services"
-	"vnc-summarizer/utils/converters"
-	"vnc-summarizer/utils/requesters"
 )
 
 type Author struct {
+	chamberApi            chamber.Chamber
 	deputyService         services.Deputy
 	externalAuthorService services.ExternalAuthor
 }
 
-func NewAuthorService(deputyService services.Deputy, externalAuthorService services.ExternalAuthor) *Author {
+func NewAuthorService(chamberApi chamber.Chamber, deputyService services.Deputy,
+	externalAuthorService services.ExternalAuthor) *Author {
 	return &Author{
+		chamberApi:            chamberApi,
 		deputyService:         deputyService,
 		externalAuthorService: externalAuthorService,
 	}
 }
 
-func (instance Author) GetAuthorsFromAuthorsUrl(authorsUrl string) ([]deputy.Deputy, []externalauthor.ExternalAuthor,
-	error) {
-	authors, err := requesters.GetDataSliceFromUrl(authorsUrl)
+func (instance Author) GetAuthorsByPropositionCode(ctx context.Context, propositionCode int) ([]deputy.Deputy,
+	[]externalauthor.ExternalAuthor, error) {
+	authors, err := instance.chamberApi.GetPropositionAuthors(ctx, propositionCode)
 	if err != nil {
-		log.Error("requests.GetDataSliceFromUrl(): ", err.Error())
+		log.Error("chamberApi.GetPropositionAuthors(): ", err.Error())
 		return nil, nil, err
 	}
 
-	deputies, externalAuthors, err := instance.convertAuthorsMapToDeputiesAndExternalAuthors(authors)
+	deputies, externalAuthors, err := instance.convertAuthorsToDeputiesAndExternalAuthors(ctx, authors)
 	if err != nil {
-		log.Error("convertAuthorsMapToDeputiesAndExternalAuthors(): ", err.Error())
+		log.Error("convertAuthorsToDeputiesAndExternalAuthors(): ", err.Error())
 		return nil, nil, err
 	}
 
 	return deputies, externalAuthors, nil
 }
 
-func (instance Author) convertAuthorsMapToDeputiesAndExternalAuthors(authors []map[string]interface{}) ([]deputy.Deputy,
-	[]externalauthor.ExternalAuthor, error) {
+func (instance Author) convertAuthorsToDeputiesAndExternalAuthors(ctx context.Context, authors []chamber.Author) (
+	[]deputy.Deputy, []externalauthor.ExternalAuthor, error) {
 	var deputies []deputy.Deputy
 	var externalAuthors []externalauthor.ExternalAuthor
 
 	sort.Slice(authors, func(i, j int) bool {
-		return authors[i]["ordemAssinatura"].(float64) < authors[j]["ordemAssinatura"].(float64)
+		return authors[i].SignatureOrder < authors[j].SignatureOrder
 	})
 
 	for authorIndex, author := range authors {
-		authorName := fmt.Sprint(author["nome"])
-		authorType := fmt.Sprint(author["tipo"])
-		authorTypeCode, err := converters.ToInt(author["codTipo"])
-		if err != nil {
-			log.Error("converters.ToInt(): ", err.Error())
-			return nil, nil, err
-		}
+		log.Infof("Starting the search for the %dth author: %s - %s", authorIndex+1, author.Name, author.Type)
 
-		log.Infof("Starting the search for the %dth author: %s - %s", authorIndex+1, authorName, authorType)
+		if author.TypeCode == chamber.DeputyAuthorTypeCode {
+			deputyCode, err := strconv.Atoi(path.Base(*author.Uri))
+			if err != nil {
+				log.Errorf("Error converting the code of deputy %s to integer: %s", author.Name, err.Error())
+				return nil, nil, err
+			}
 
-		deputyTypeCode := 10000
-		if authorTypeCode == deputyTypeCode {
-			deputyData, err := instance.deputyService.GetDeputyFromDeputyData(author)
+			deputyData, err := instance.deputyService.GetDeputyByCode(ctx, deputyCode)
 			if err != nil {
-				log.Error("deputyService.GetDeputyFromDeputyData(): ", err.Error())
+				log.Error("deputyService.GetDeputyByCode(): ", err.Error())
 				return nil, nil, err
 			}
 			deputies = append(deputies, *deputyData)
 		} else {
-			externalAuthorData, err := instance.externalAuthorService.GetExternalAuthorFromAuthorData(authorName,
-				authorTypeCode, authorType)
+			externalAuthorData, err := instance.externalAuthorService.GetExternalAuthorFromAuthorData(ctx, author.Name,
+				author.TypeCode, author.Type)
 			if err != nil {
 				log.Error("externalAuthorService.GetExternalAuthorFromAuthorData(): ", err.Error())
 				return nil, nil, err
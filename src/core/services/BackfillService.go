@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"os"
+	"slices"
+	"time"
+	"vnc-summarizer/core/interfaces/postgres"
+	"vnc-summarizer/core/interfaces/services"
+	"vnc-summarizer/utils/contexts"
+)
+
+// The data types are backfilled in this order on each day, since votes and events reference the propositions
+var backfillDataTypes = []string{"propositions", "votes", "events"}
+
+type Backfill struct {
+	propositionService           services.Proposition
+	votingService                services.Voting
+	eventService                 services.Event
+	backfillCheckpointRepository postgres.BackfillCheckpoint
+}
+
+func NewBackfillService(propositionService services.Proposition, votingService services.Voting,
+	eventService services.Event, backfillCheckpointRepository postgres.BackfillCheckpoint) *Backfill {
+	return &Backfill{
+		propositionService:           propositionService,
+		votingService:                votingService,
+		eventService:                 eventService,
+		backfillCheckpointRepository: backfillCheckpointRepository,
+	}
+}
+
+// Backfill registers the data of the informed types day by day within the date range. Each completed day is recorded
+// as a checkpoint, so days already completed are skipped when the backfill is executed again after a failure. Days
+// with failures are not recorded and are returned in the error to be processed again later.
+func (instance Backfill) Backfill(ctx context.Context, dataTypes []string, startDate, endDate time.Time) error {
+	for _, dataType := range dataTypes {
+		if !slices.Contains(backfillDataTypes, dataType) {
+			return errors.New(fmt.Sprint("Unknown type to backfill: ", dataType))
+		}
+	}
+
+	intervalBetweenDays, err := time.ParseDuration(os.Getenv("BACKFILL_INTERVAL_BETWEEN_DAYS"))
+	if err != nil {
+		log.Warn("Error converting environment variable BACKFILL_INTERVAL_BETWEEN_DAYS to duration, the backfill "+
+			"will not be throttled: ", err.Error())
+		intervalBetweenDays = 0
+	}
+
+	completedDatesByDataType := map[string][]string{}
+	for _, dataType := range dataTypes {
+		completedDates, err := instance.backfillCheckpointRepository.GetCompletedDates(ctx, dataType, startDate,
+			endDate)
+		if err != nil {
+			log.Error("backfillCheckpointRepository.GetCompletedDates(): ", err.Error())
+			return err
+		}
+
+		for _, completedDate := range completedDates {
+			completedDatesByDataType[dataType] = append(completedDatesByDataType[dataType],
+				completedDate.Format("2006-01-02"))
+		}
+	}
+
+	var failedBackfills []string
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		if ctx.Err() != nil {
+			return errors.New(fmt.Sprintf("The backfill was interrupted before %s: %s", day.Format("02/01/2006"),
+				ctx.Err().Error()))
+		}
+
+		var isDayProcessed bool
+		for _, dataType := range backfillDataTypes {
+			if !slices.Contains(dataTypes, dataType) ||
+				slices.Contains(completedDatesByDataType[dataType], day.Format("2006-01-02")) {
+				continue
+			}
+
+			isDayProcessed = true
+			err = instance.backfillDay(ctx, dataType, day)
+			if err != nil {
+				log.Errorf("Error backfilling the %s of %s: %s", dataType, day.Format("02/01/2006"), err.Error())
+				failedBackfills = append(failedBackfills, fmt.Sprintf("%s (%s)", day.Format("2006-01-02"), dataType))
+				continue
+			}
+
+			err = instance.backfillCheckpointRepository.SaveCheckpoint(ctx, dataType, day)
+			if err != nil {
+				log.Error("backfillCheckpointRepository.SaveCheckpoint(): ", err.Error())
+				return err
+			}
+		}
+
+		if !isDayProcessed {
+			log.Infof("The backfill of %s was already completed", day.Format("02/01/2006"))
+		} else if intervalBetweenDays > 0 && day.Before(endDate) {
+			_ = contexts.Sleep(ctx, intervalBetweenDays)
+		}
+	}
+
+	if failedBackfills != nil {
+		return errors.New(fmt.Sprintf("%d daily backfills failed and must be executed again: %v",
+			len(failedBackfills), failedBackfills))
+	}
+
+	return nil
+}
+
+func (instance Backfill) backfillDay(ctx context.Context, dataType string, day time.Time) error {
+	log.Infof("Starting the backfill of the %s of %s", dataType, day.Format("02/01/2006"))
+
+	switch dataType {
+	case "propositions":
+		return instance.propositionService.RegisterNewPropositionsByDateRange(ctx, day, day)
+	case "votes":
+		return instance.votingService.RegisterNewVotesByDateRange(ctx, day, day)
+	default:
+		return instance.eventService.RegisterNewEventsByDateRange(ctx, day, day)
+	}
+}
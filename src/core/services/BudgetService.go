@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"github.com/labstack/gommon/log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/core/interfaces/postgres"
+	"vnc-summarizer/utils/datetime"
+)
+
+// Interval in which the estimated cost of the day is reused instead of being queried again, since the budget is
+// checked before every generation
+const estimatedCostCacheDuration = time.Minute
+
+type Budget struct {
+	generationUsageRepository postgres.GenerationUsage
+	estimatedCost             *dailyEstimatedCost
+}
+
+// dailyEstimatedCost is the estimated cost of the generations of a day, which is queried at most once per interval and
+// is increased by the usages registered in the meantime
+type dailyEstimatedCost struct {
+	mutex     sync.Mutex
+	date      string
+	value     float64
+	updatedAt time.Time
+}
+
+func NewBudgetService(generationUsageRepository postgres.GenerationUsage) *Budget {
+	return &Budget{
+		generationUsageRepository: generationUsageRepository,
+		estimatedCost:             &dailyEstimatedCost{},
+	}
+}
+
+// IsDailyBudgetExceeded reports whether the estimated cost of the generations of the current day has reached the
+// ceiling configured in DAILY_BUDGET_CEILING. If the ceiling is not configured, the budget is never exceeded.
+func (instance Budget) IsDailyBudgetExceeded(ctx context.Context) bool {
+	dailyBudgetCeilingAsString := os.Getenv("DAILY_BUDGET_CEILING")
+	if dailyBudgetCeilingAsString == "" {
+		return false
+	}
+
+	dailyBudgetCeiling, err := strconv.ParseFloat(dailyBudgetCeilingAsString, 64)
+	if err != nil {
+		log.Error("Error converting environment variable DAILY_BUDGET_CEILING to number, this setting is "+
+			"disabled: ", err.Error())
+		return false
+	}
+
+	currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+	if err != nil {
+		log.Error("datetime.GetCurrentDateTimeInBrazil(): ", err.Error())
+		return false
+	}
+
+	estimatedCost, err := instance.getEstimatedCost(ctx, *currentDateTime)
+	if err != nil {
+		log.Error("getEstimatedCost(): ", err.Error())
+		return false
+	}
+
+	if estimatedCost >= dailyBudgetCeiling {
+		log.Warnf("The estimated cost of the generations of today (US$ %.4f) has reached the daily budget ceiling "+
+			"(US$ %.2f), the economy mode is active", estimatedCost, dailyBudgetCeiling)
+		return true
+	}
+
+	return false
+}
+
+// RegisterGenerationUsage registers the usage of a generation as soon as it is made, so the generations whose article
+// is not registered are also considered by the daily budget. If the usage cannot be registered, it is returned without
+// its ID and it is only registered along with the article.
+func (instance Budget) RegisterGenerationUsage(ctx context.Context,
+	generationUsage generationusage.GenerationUsage) *generationusage.GenerationUsage {
+	generationUsageId, err := instance.generationUsageRepository.CreateGenerationUsage(ctx, generationUsage)
+	if err != nil {
+		log.Error("generationUsageRepository.CreateGenerationUsage(): ", err.Error())
+		return &generationUsage
+	}
+	instance.addEstimatedCost(generationUsage.EstimatedCost())
+
+	registeredGenerationUsage, err := generationUsage.NewUpdater().Id(*generationUsageId).Build()
+	if err != nil {
+		log.Error("Error validating the data of the registered generation usage: ", err.Error())
+		return &generationUsage
+	}
+
+	return registeredGenerationUsage
+}
+
+// getEstimatedCost returns the estimated cost of the generations of the date, which is only queried again when the
+// last query is older than the cache interval or was made for another date
+func (instance Budget) getEstimatedCost(ctx context.Context, date time.Time) (float64, error) {
+	instance.estimatedCost.mutex.Lock()
+	defer instance.estimatedCost.mutex.Unlock()
+
+	formattedDate := date.Format(time.DateOnly)
+	if instance.estimatedCost.date == formattedDate &&
+		time.Since(instance.estimatedCost.updatedAt) < estimatedCostCacheDuration {
+		return instance.estimatedCost.value, nil
+	}
+
+	estimatedCost, err := instance.generationUsageRepository.GetEstimatedCostByDate(ctx, date)
+	if err != nil {
+		log.Error("generationUsageRepository.GetEstimatedCostByDate(): ", err.Error())
+		return 0, err
+	}
+
+	instance.estimatedCost.date = formattedDate
+	instance.estimatedCost.value = estimatedCost
+	instance.estimatedCost.updatedAt = time.Now()
+	return estimatedCost, nil
+}
+
+// addEstimatedCost adds the cost of a registered usage to the cached estimated cost, so the budget is not exceeded by
+// the generations made while the cached value is reused
+func (instance Budget) addEstimatedCost(estimatedCost float64) {
+	currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+	if err != nil {
+		log.Error("datetime.GetCurrentDateTimeInBrazil(): ", err.Error())
+		return
+	}
+
+	instance.estimatedCost.mutex.Lock()
+	defer instance.estimatedCost.mutex.Unlock()
+
+	if instance.estimatedCost.date == currentDateTime.Format(time.DateOnly) {
+		instance.estimatedCost.value += estimatedCost
+	}
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"testing"
+	"vnc-summarizer/adapters/databases/memory"
+	"vnc-summarizer/core/domains/generationusage"
+)
+
+func TestIsDailyBudgetExceeded(t *testing.T) {
+	t.Setenv("DAILY_BUDGET_CEILING", "1")
+	ctx := context.Background()
+	generationUsageRepository := memory.NewGenerationUsageRepository(memory.NewDatabase())
+	budgetService := NewBudgetService(generationUsageRepository)
+	otherBudgetService := NewBudgetService(generationUsageRepository)
+
+	generationUsage, err := generationusage.NewBuilder().
+		Provider("OpenAI").
+		Model("gpt-image-1").
+		NumberOfImages(1).
+		EstimatedCost(0.6).
+		Build()
+	if err != nil {
+		t.Fatalf("generationusage.NewBuilder(): %s", err.Error())
+	}
+
+	if budgetService.IsDailyBudgetExceeded(ctx) {
+		t.Fatal("The budget was exceeded without any generation")
+	}
+
+	otherBudgetService.RegisterGenerationUsage(ctx, *generationUsage)
+	otherBudgetService.RegisterGenerationUsage(ctx, *generationUsage)
+	if budgetService.IsDailyBudgetExceeded(ctx) {
+		t.Fatal("The estimated cost was queried again before the end of the cache interval")
+	}
+	if !otherBudgetService.IsDailyBudgetExceeded(ctx) {
+		t.Fatal("The budget was not exceeded by the usages registered before the first query")
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		registeredGenerationUsage := budgetService.RegisterGenerationUsage(ctx, *generationUsage)
+		if registeredGenerationUsage.Id() == uuid.Nil {
+			t.Fatal("RegisterGenerationUsage() returned the usage without its ID")
+		}
+	}
+	if !budgetService.IsDailyBudgetExceeded(ctx) {
+		t.Fatal("The budget was not exceeded by the usages registered after the query")
+	}
+}
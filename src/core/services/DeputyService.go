@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"github.com/devlucassantos/vnc-domains/src/domains/deputy"
 	"github.com/devlucassantos/vnc-domains/src/domains/party"
@@ -11,9 +12,8 @@ import (
 	"strings"
 	"vnc-summarizer/core/interfaces/chamber"
 	"vnc-summarizer/core/interfaces/postgres"
-	"vnc-summarizer/utils/converters"
+	"vnc-summarizer/utils/locks"
 	"vnc-summarizer/utils/replacers"
-	"vnc-summarizer/utils/requesters"
 )
 
 type Deputy struct {
@@ -30,75 +30,48 @@ func NewDeputyService(chamberApi chamber.Chamber, deputyRepository postgres.Depu
 	}
 }
 
-func (instance Deputy) GetDeputyFromDeputyData(deputyData map[string]interface{}) (*deputy.Deputy, error) {
-	deputyDataUrl := fmt.Sprint(deputyData["uri"])
-	deputyDetails, err := requesters.GetDataObjectFromUrl(deputyDataUrl)
+func (instance Deputy) GetDeputyByCode(ctx context.Context, code int) (*deputy.Deputy, error) {
+	deputyData, err := instance.chamberApi.GetDeputyByCode(ctx, code)
 	if err != nil {
-		log.Errorf("Error searching data for deputy %v: %s", deputyData["nome"], err.Error())
+		log.Errorf("Error searching data for deputy %d: %s", code, err.Error())
 		return nil, err
 	}
 
-	deputyCode, err := converters.ToInt(deputyDetails["id"])
-	if err != nil {
-		log.Error("converters.ToInt(): ", err.Error())
-		return nil, err
-	}
-
-	deputyLastStatus, err := converters.ToMap(deputyDetails["ultimoStatus"])
-	if err != nil {
-		log.Error("converters.ToMap(): ", err.Error())
-		return nil, err
-	}
-
-	partyAcronym := fmt.Sprint(deputyLastStatus["siglaPartido"])
-	partyAcronym = strings.ToUpper(strings.Trim(partyAcronym, "*"))
+	partyAcronym := strings.ToUpper(strings.Trim(deputyData.LastStatus.PartyAcronym, "*"))
 	partyAcronym = replacers.RemoveSpellingAccents(partyAcronym)
 
-	partyData, err := instance.chamberApi.GetPartyByAcronym(partyAcronym)
+	partyData, err := instance.chamberApi.GetPartyByAcronym(ctx, partyAcronym)
 	if err != nil {
 		log.Error("chamberApi.GetPartyByAcronym(): ", err.Error())
 		return nil, err
 	}
 
-	partyUrl := fmt.Sprint(partyData["uri"])
-	partyData, err = requesters.GetDataObjectFromUrl(partyUrl)
-	if err != nil {
-		log.Error("getDataObjectFromUrl(): ", err.Error())
-		return nil, err
-	}
-
-	partyCode, err := converters.ToInt(partyData["id"])
-	if err != nil {
-		log.Error("converters.ToInt(): ", err.Error())
-		return nil, err
-	}
-
 	partyDomain, err := party.NewBuilder().
-		Code(partyCode).
-		Name(fmt.Sprint(partyData["nome"])).
-		Acronym(fmt.Sprint(partyData["sigla"])).
-		ImageUrl(fmt.Sprint(partyData["urlLogo"])).
+		Code(partyData.Id).
+		Name(partyData.Name).
+		Acronym(partyData.Acronym).
+		ImageUrl(partyData.ImageUrl).
 		Build()
 	if err != nil {
-		log.Errorf("Error validating data for party %d of deputy %d: %s", partyCode, deputyCode, err.Error())
+		log.Errorf("Error validating data for party %d of deputy %d: %s", partyData.Id, code, err.Error())
 		return nil, err
 	}
 
 	deputyDomain, err := deputy.NewBuilder().
-		Code(deputyCode).
-		Cpf(fmt.Sprint(deputyDetails["cpf"])).
-		Name(cases.Title(language.BrazilianPortuguese).String(fmt.Sprint(deputyDetails["nomeCivil"]))).
-		ElectoralName(cases.Title(language.BrazilianPortuguese).String(fmt.Sprint(deputyLastStatus["nomeEleitoral"]))).
-		ImageUrl(fmt.Sprint(deputyLastStatus["urlFoto"])).
+		Code(deputyData.Id).
+		Cpf(deputyData.Cpf).
+		Name(cases.Title(language.BrazilianPortuguese).String(deputyData.CivilName)).
+		ElectoralName(cases.Title(language.BrazilianPortuguese).String(deputyData.LastStatus.ElectoralName)).
+		ImageUrl(deputyData.LastStatus.ImageUrl).
 		Party(*partyDomain).
-		FederatedUnit(fmt.Sprint(deputyLastStatus["siglaUf"])).
+		FederatedUnit(deputyData.LastStatus.FederatedUnit).
 		Build()
 	if err != nil {
-		log.Errorf("Error validating data for deputy %d: %s", deputyCode, err.Error())
+		log.Errorf("Error validating data for deputy %d: %s", code, err.Error())
 		return nil, err
 	}
 
-	updatedDeputy, err := instance.getDeputyFromDatabase(deputyDomain)
+	updatedDeputy, err := instance.getDeputyFromDatabase(ctx, deputyDomain)
 	if err != nil {
 		log.Error("getDeputyFromDatabase(): ", err.Error())
 		return nil, err
@@ -107,51 +80,24 @@ func (instance Deputy) GetDeputyFromDeputyData(deputyData map[string]interface{}
 	return updatedDeputy, nil
 }
 
-func (instance Deputy) getDeputyFromDatabase(deputyDomain *deputy.Deputy) (*deputy.Deputy, error) {
-	deputyParty := deputyDomain.Party()
-	registeredParty, err := instance.partyRepository.GetPartyByCode(deputyParty.Code())
+func (instance Deputy) getDeputyFromDatabase(ctx context.Context, deputyDomain *deputy.Deputy) (*deputy.Deputy, error) {
+	updatedParty, err := instance.getPartyFromDatabase(ctx, deputyDomain.Party())
 	if err != nil {
-		log.Error("partyRepository.GetPartyByCode(): ", err.Error())
+		log.Error("getPartyFromDatabase(): ", err.Error())
 		return nil, err
 	}
 
-	var partyId *uuid.UUID
-	if registeredParty == nil {
-		partyId, err = instance.partyRepository.CreateParty(deputyParty)
-		if err != nil {
-			log.Error("partyRepository.CreateParty(): ", err.Error())
-			return nil, err
-		}
-	} else if !registeredParty.IsEqual(deputyParty) {
-		err = instance.partyRepository.UpdateParty(deputyParty)
-		if err != nil {
-			log.Error("partyRepository.UpdateParty(): ", err.Error())
-			return nil, err
-		}
-	}
-
-	var updatedParty *party.Party
-	if partyId == nil {
-		updatedParty, err = deputyParty.NewUpdater().Id(registeredParty.Id()).Build()
-		if err != nil {
-			log.Errorf("Error updating party %s: %s", registeredParty.Id(), err.Error())
-			return nil, err
-		}
-	} else {
-		updatedParty, err = deputyParty.NewUpdater().Id(*partyId).Build()
-		if err != nil {
-			log.Errorf("Error updating party %s: %s", partyId, err.Error())
-			return nil, err
-		}
-	}
-
 	updatedDeputy, err := deputyDomain.NewUpdater().Party(*updatedParty).Build()
 	if err != nil {
-		log.Errorf("Error updating party %s of deputy %d: %s", partyId, deputyDomain.Code(), err.Error())
+		log.Errorf("Error updating party %s of deputy %d: %s", updatedParty.Id(), deputyDomain.Code(), err.Error())
 		return nil, err
 	}
 
-	registeredDeputy, err := instance.deputyRepository.GetDeputyByCode(updatedDeputy.Code())
+	// The same deputy can be the author of propositions registered by different workers, so the search and the
+	// registration of the deputy are never executed concurrently
+	defer locks.Lock(fmt.Sprint("deputy:", updatedDeputy.Code()))()
+
+	registeredDeputy, err := instance.deputyRepository.GetDeputyByCode(ctx, updatedDeputy.Code())
 	if err != nil {
 		log.Error("deputyRepository.GetDeputyByCode(): ", err.Error())
 		return nil, err
@@ -159,13 +105,13 @@ func (instance Deputy) getDeputyFromDatabase(deputyDomain *deputy.Deputy) (*depu
 
 	var deputyId *uuid.UUID
 	if registeredDeputy == nil {
-		deputyId, err = instance.deputyRepository.CreateDeputy(*updatedDeputy)
+		deputyId, err = instance.deputyRepository.CreateDeputy(ctx, *updatedDeputy)
 		if err != nil {
 			log.Error("deputyRepository.CreateDeputy(): ", err.Error())
 			return nil, err
 		}
 	} else if !registeredDeputy.IsEqual(*updatedDeputy) {
-		err = instance.deputyRepository.UpdateDeputy(*updatedDeputy)
+		err = instance.deputyRepository.UpdateDeputy(ctx, *updatedDeputy)
 		if err != nil {
 			log.Error("deputyRepository.UpdateDeputy(): ", err.Error())
 			return nil, err
@@ -188,3 +134,45 @@ func (instance Deputy) getDeputyFromDatabase(deputyDomain *deputy.Deputy) (*depu
 
 	return updatedDeputy, nil
 }
+
+func (instance Deputy) getPartyFromDatabase(ctx context.Context, partyDomain party.Party) (*party.Party, error) {
+	// The same party can be shared by deputies searched by different workers, so the search and the registration of
+	// the party are never executed concurrently
+	defer locks.Lock(fmt.Sprint("party:", partyDomain.Code()))()
+
+	registeredParty, err := instance.partyRepository.GetPartyByCode(ctx, partyDomain.Code())
+	if err != nil {
+		log.Error("partyRepository.GetPartyByCode(): ", err.Error())
+		return nil, err
+	}
+
+	if registeredParty == nil {
+		partyId, err := instance.partyRepository.CreateParty(ctx, partyDomain)
+		if err != nil {
+			log.Error("partyRepository.CreateParty(): ", err.Error())
+			return nil, err
+		}
+
+		updatedParty, err := partyDomain.NewUpdater().Id(*partyId).Build()
+		if err != nil {
+			log.Errorf("Error updating party %s: %s", partyId, err.Error())
+			return nil, err
+		}
+
+		return updatedParty, nil
+	} else if !registeredParty.IsEqual(partyDomain) {
+		err = instance.partyRepository.UpdateParty(ctx, partyDomain)
+		if err != nil {
+			log.Error("partyRepository.UpdateParty(): ", err.Error())
+			return nil, err
+		}
+	}
+
+	updatedParty, err := partyDomain.NewUpdater().Id(registeredParty.Id()).Build()
+	if err != nil {
+		log.Errorf("Error updating party %s: %s", registeredParty.Id(), err.Error())
+		return nil, err
+	}
+
+	return updatedParty, nil
+}
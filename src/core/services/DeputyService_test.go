@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+	"vnc-summarizer/core/interfaces/chamber"
+)
+
+func TestGetDeputyByCode(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		previousPartyAcronym string
+		partyAcronym         string
+		expectedPartyAcronym string
+	}{
+		{
+			name:                 "registers the deputy and the party",
+			partyAcronym:         fakePartyAcronym,
+			expectedPartyAcronym: fakePartyAcronym,
+		},
+		{
+			name:                 "normalizes the acronym of the party of the deputy",
+			partyAcronym:         "pt*",
+			expectedPartyAcronym: fakePartyAcronym,
+		},
+		{
+			name:                 "keeps the deputy that has not changed",
+			previousPartyAcronym: fakePartyAcronym,
+			partyAcronym:         fakePartyAcronym,
+			expectedPartyAcronym: fakePartyAcronym,
+		},
+		{
+			name:                 "updates the deputy that changed party",
+			previousPartyAcronym: fakePartyAcronym,
+			partyAcronym:         "PSOL",
+			expectedPartyAcronym: "PSOL",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			ctx := context.Background()
+
+			environment.chamberApi.parties["PSOL"] = chamber.Party{
+				Id:       36839,
+				Name:     "Partido Socialismo e Liberdade",
+				Acronym:  "PSOL",
+				ImageUrl: "https://www.camara.leg.br/internet/Deputado/img/partidos/PSOL.gif",
+			}
+			setDeputyParty := func(partyAcronym string) {
+				deputyData := environment.chamberApi.deputies[fakeDeputyCode]
+				deputyData.LastStatus.PartyAcronym = partyAcronym
+				environment.chamberApi.deputies[fakeDeputyCode] = deputyData
+			}
+
+			var previousDeputyId uuid.UUID
+			if testCase.previousPartyAcronym != "" {
+				setDeputyParty(testCase.previousPartyAcronym)
+				previousDeputy, err := environment.deputyService.GetDeputyByCode(ctx, fakeDeputyCode)
+				if err != nil {
+					t.Fatalf("GetDeputyByCode(): %s", err.Error())
+				}
+				previousDeputyId = previousDeputy.Id()
+			}
+
+			setDeputyParty(testCase.partyAcronym)
+			deputyData, err := environment.deputyService.GetDeputyByCode(ctx, fakeDeputyCode)
+			if err != nil {
+				t.Fatalf("GetDeputyByCode(): %s", err.Error())
+			}
+
+			deputyParty := deputyData.Party()
+			if deputyData.Id() == uuid.Nil || deputyParty.Id() == uuid.Nil {
+				t.Error("The deputy and the party were expected to be returned with their IDs")
+			}
+			if previousDeputyId != uuid.Nil && deputyData.Id() != previousDeputyId {
+				t.Errorf("The deputy was expected to keep the ID %s, but it has the ID %s", previousDeputyId,
+					deputyData.Id())
+			}
+			if deputyData.Name() != "Maria Da Silva" || deputyData.ElectoralName() != "Maria Silva" {
+				t.Errorf("The names of the deputy were not formatted: %s, %s", deputyData.Name(),
+					deputyData.ElectoralName())
+			}
+
+			registeredDeputy, err := environment.deputyRepository.GetDeputyByCode(ctx, fakeDeputyCode)
+			if err != nil {
+				t.Fatalf("GetDeputyByCode(): %s", err.Error())
+			}
+			if registeredDeputy == nil {
+				t.Fatal("The deputy was not registered")
+			}
+			registeredParty := registeredDeputy.Party()
+			if registeredDeputy.Id() != deputyData.Id() || registeredParty.Acronym() != testCase.expectedPartyAcronym {
+				t.Errorf("The deputy was expected to be registered with party %s, but it was registered with party "+
+					"%s", testCase.expectedPartyAcronym, registeredParty.Acronym())
+			}
+		})
+	}
+}
+
+func TestGetDeputyByCodeErrors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configure func(environment *testEnvironment)
+	}{
+		{
+			name: "returns the error of the search for the deputy",
+			configure: func(environment *testEnvironment) {
+				environment.chamberApi.failOperation("GetDeputyByCode", errors.New("Service unavailable"), -1)
+			},
+		},
+		{
+			name: "returns the error of the search for the party",
+			configure: func(environment *testEnvironment) {
+				environment.chamberApi.failOperation("GetPartyByAcronym", errors.New("Service unavailable"), -1)
+			},
+		},
+		{
+			name: "returns the error of the registration of the party",
+			configure: func(environment *testEnvironment) {
+				environment.database.FailOperation("CreateParty", errors.New("The connection was closed"))
+			},
+		},
+		{
+			name: "returns the error of the registration of the deputy",
+			configure: func(environment *testEnvironment) {
+				environment.database.FailOperation("CreateDeputy", errors.New("The connection was closed"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			ctx := context.Background()
+			testCase.configure(environment)
+
+			deputyData, err := environment.deputyService.GetDeputyByCode(ctx, fakeDeputyCode)
+			if err == nil {
+				t.Fatalf("An error was expected, but deputy %s was returned", deputyData.Id())
+			}
+
+			registeredDeputy, err := environment.deputyRepository.GetDeputyByCode(ctx, fakeDeputyCode)
+			if err != nil {
+				t.Fatalf("GetDeputyByCode(): %s", err.Error())
+			}
+			if registeredDeputy != nil {
+				t.Error("The deputy was registered despite the error")
+			}
+		})
+	}
+}
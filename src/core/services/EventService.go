@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/devlucassantos/vnc-domains/src/domains/agendaitemregime"
 	"github.com/devlucassantos/vnc-domains/src/domains/article"
@@ -15,25 +17,46 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/gommon/log"
 	"path"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"vnc-summarizer/core/domains/generation"
+	"vnc-summarizer/core/domains/prompt"
 	"vnc-summarizer/core/interfaces/chamber"
-	"vnc-summarizer/core/interfaces/chatgpt"
+	"vnc-summarizer/core/interfaces/llm"
 	"vnc-summarizer/core/interfaces/postgres"
+	"vnc-summarizer/core/interfaces/prompts"
 	"vnc-summarizer/core/interfaces/services"
 	"vnc-summarizer/utils/converters"
-	"vnc-summarizer/utils/requesters"
+	"vnc-summarizer/utils/locks"
 	"vnc-summarizer/utils/splitters"
 	"vnc-summarizer/utils/validators"
+	"vnc-summarizer/utils/workerpools"
 )
 
+var eventTitleSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"title": map[string]interface{}{
+			"type": "string",
+		},
+	},
+	"required":             []string{"title"},
+	"additionalProperties": false,
+}
+
+const eventItemType = "event"
+
 type Event struct {
 	deputyService              services.Deputy
 	legislativeBodyService     services.LegislativeBody
 	propositionService         services.Proposition
 	votingService              services.Voting
+	processingLedgerService    services.ProcessingLedger
 	chamberApi                 chamber.Chamber
-	chatGptApi                 chatgpt.ChatGpt
+	llmApi                     llm.Llm
+	promptRegistry             prompts.Prompt
 	eventRepository            postgres.Event
 	articleTypeRepository      postgres.ArticleType
 	eventTypeRepository        postgres.EventType
@@ -42,8 +65,9 @@ type Event struct {
 }
 
 func NewEventService(deputyService services.Deputy, legislativeBodyService services.LegislativeBody,
-	propositionService services.Proposition, votingService services.Voting, chamberApi chamber.Chamber,
-	chatGptApi chatgpt.ChatGpt, eventRepository postgres.Event, articleTypeRepository postgres.ArticleType,
+	propositionService services.Proposition, votingService services.Voting,
+	processingLedgerService services.ProcessingLedger, chamberApi chamber.Chamber, llmApi llm.Llm,
+	promptRegistry prompts.Prompt, eventRepository postgres.Event, articleTypeRepository postgres.ArticleType,
 	eventTypeRepository postgres.EventType, eventSituationRepository postgres.EventSituation,
 	agendaItemRegimeRepository postgres.AgendaItemRegime) *Event {
 	return &Event{
@@ -51,8 +75,10 @@ func NewEventService(deputyService services.Deputy, legislativeBodyService servi
 		legislativeBodyService:     legislativeBodyService,
 		propositionService:         propositionService,
 		votingService:              votingService,
+		processingLedgerService:    processingLedgerService,
 		chamberApi:                 chamberApi,
-		chatGptApi:                 chatGptApi,
+		llmApi:                     llmApi,
+		promptRegistry:             promptRegistry,
 		eventRepository:            eventRepository,
 		articleTypeRepository:      articleTypeRepository,
 		eventTypeRepository:        eventTypeRepository,
@@ -61,72 +87,117 @@ func NewEventService(deputyService services.Deputy, legislativeBodyService servi
 	}
 }
 
-func (instance Event) RegisterNewEvents() {
-	codesOfTheMostRecentEventsReturned, err := instance.getCodesOfTheMostRecentEventsRegisteredInTheChamber()
+func (instance Event) RegisterNewEvents(ctx context.Context) {
+	codesOfTheMostRecentEventsReturned, err := instance.getCodesOfTheMostRecentEventsRegisteredInTheChamber(ctx)
 	if err != nil {
 		log.Error("getCodesOfTheMostRecentEventsRegisteredInTheChamber(): ", err.Error())
 		return
-	} else if codesOfTheMostRecentEventsReturned == nil {
+	}
+
+	for _, code := range instance.processingLedgerService.GetCodesOfTheItemsToRetry(ctx, eventItemType) {
+		eventCode, err := strconv.Atoi(code)
+		if err != nil {
+			log.Errorf("Error converting the code of event %s to integer: %s", code, err.Error())
+			continue
+		}
+		codesOfTheMostRecentEventsReturned = append(codesOfTheMostRecentEventsReturned, eventCode)
+	}
+
+	err = instance.registerNewEvents(ctx, codesOfTheMostRecentEventsReturned)
+	if err != nil {
+		log.Error("registerNewEvents(): ", err.Error())
+	}
+}
+
+func (instance Event) RegisterNewEventsByDateRange(ctx context.Context, startDate, endDate time.Time) error {
+	formattedStartDate := startDate.Format("02/01/2006")
+	formattedEndDate := endDate.Format("02/01/2006")
+	log.Infof("Starting the search for the events held between %s and %s", formattedStartDate, formattedEndDate)
+
+	events, err := instance.chamberApi.GetEventsByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		log.Error("chamberApi.GetEventsByDateRange(): ", err.Error())
+		return err
+	}
+
+	eventCodes := extractCodesFromEvents(events)
+
+	log.Infof("Successful search for the events held between %s and %s: %v", formattedStartDate, formattedEndDate,
+		eventCodes)
+	return instance.registerNewEvents(ctx, eventCodes)
+}
+
+// registerNewEvents registers the events that are not yet registered and returns an error if any of them could not be
+// registered
+func (instance Event) registerNewEvents(ctx context.Context, eventCodes []int) error {
+	if eventCodes == nil {
 		log.Info("No new events were identified for registration")
-		return
+		return nil
 	}
 
-	eventsRegistered, err := instance.eventRepository.GetEventsByCodes(codesOfTheMostRecentEventsReturned)
+	eventsRegistered, err := instance.eventRepository.GetEventsByCodes(ctx, eventCodes)
 	if err != nil {
 		log.Error("eventRepository.GetEventsByCodes(): ", err.Error())
-		return
+		return err
 	}
 
-	codesOfTheNewEvents := getCodesOfTheNewEvents(codesOfTheMostRecentEventsReturned, eventsRegistered)
+	codesOfTheNewEvents := getCodesOfTheNewEvents(eventCodes, eventsRegistered)
 	if codesOfTheNewEvents != nil {
 		log.Infof("%d new events were identified for registration: %v", len(codesOfTheNewEvents),
 			codesOfTheNewEvents)
 	} else {
 		log.Info("No new events were identified for registration")
-		return
+		return nil
 	}
 
-	for _, eventCode := range codesOfTheNewEvents {
-		_, err = instance.RegisterNewEventByCode(eventCode)
+	var codesOfTheEventsNotRegistered []int
+	var mutex sync.Mutex
+	numberOfWorkers := workerpools.GetNumberOfWorkers("EVENT_REGISTRATION_CONCURRENCY")
+	workerpools.Process(ctx, codesOfTheNewEvents, numberOfWorkers, func(ctx context.Context, eventCode int) {
+		_, err := instance.RegisterNewEventByCode(ctx, eventCode)
 		if err != nil {
 			log.Error("RegisterNewEventByCode(): ", err.Error())
+			mutex.Lock()
+			codesOfTheEventsNotRegistered = append(codesOfTheEventsNotRegistered, eventCode)
+			mutex.Unlock()
 		}
+	})
+
+	// The items that were not processed due to the cancellation are registered in the next runs
+	if ctx.Err() != nil {
+		return errors.New(fmt.Sprint("The registration of the events was interrupted: ", ctx.Err().Error()))
 	}
 
-	return
+	if codesOfTheEventsNotRegistered != nil {
+		return errors.New(fmt.Sprintf("%d of %d events could not be registered: %v",
+			len(codesOfTheEventsNotRegistered), len(codesOfTheNewEvents), codesOfTheEventsNotRegistered))
+	}
+
+	return nil
 }
 
-func (instance Event) getCodesOfTheMostRecentEventsRegisteredInTheChamber() ([]int, error) {
+func (instance Event) getCodesOfTheMostRecentEventsRegisteredInTheChamber(ctx context.Context) ([]int, error) {
 	log.Info("Starting the search for the most recent events")
 
-	mostRecentEvents, err := instance.chamberApi.GetMostRecentEvents()
+	mostRecentEvents, err := instance.chamberApi.GetMostRecentEvents(ctx)
 	if err != nil {
 		log.Error("chamberApi.GetMostRecentEvents(): ", err.Error())
 		return nil, err
 	}
 
-	eventCodes, err := extractCodesFromEvents(mostRecentEvents)
-	if err != nil {
-		log.Error("extractCodesFromEvents(): ", err.Error())
-		return nil, err
-	}
+	eventCodes := extractCodesFromEvents(mostRecentEvents)
 
 	log.Info("Successful search for the most recent events: ", eventCodes)
 	return eventCodes, nil
 }
 
-func extractCodesFromEvents(events []map[string]interface{}) ([]int, error) {
+func extractCodesFromEvents(events []chamber.Event) []int {
 	var eventCodes []int
 	for _, eventData := range events {
-		eventCode, err := converters.ToInt(eventData["id"])
-		if err != nil {
-			log.Error("converters.ToInt(): ", err.Error())
-			return nil, err
-		}
-		eventCodes = append(eventCodes, eventCode)
+		eventCodes = append(eventCodes, eventData.Id)
 	}
 
-	return eventCodes, nil
+	return eventCodes
 }
 
 func getCodesOfTheNewEvents(returnedEventCodes []int, registeredEvents []event.Event) []int {
@@ -149,8 +220,34 @@ func getCodesOfTheNewEvents(returnedEventCodes []int, registeredEvents []event.E
 	return eventCodesToRegister
 }
 
-func (instance Event) RegisterNewEventByCode(code int) (*uuid.UUID, error) {
-	eventData, err := instance.getEventDataToRegister(code)
+// RegisterNewEventByCode registers the event unless it is already registered. The registration of the same event is
+// never executed concurrently.
+func (instance Event) RegisterNewEventByCode(ctx context.Context, code int) (*uuid.UUID, error) {
+	defer locks.Lock(fmt.Sprint(eventItemType, ":", code))()
+
+	registeredEvents, err := instance.eventRepository.GetEventsByCodes(ctx, []int{code})
+	if err != nil {
+		log.Error("eventRepository.GetEventsByCodes(): ", err.Error())
+		return nil, err
+	} else if len(registeredEvents) > 0 {
+		eventId := registeredEvents[0].Id()
+		log.Infof("Event %d is already registered", code)
+		return &eventId, nil
+	}
+
+	var eventId *uuid.UUID
+	registerEvent := func(ctx context.Context) error {
+		var err error
+		eventId, err = instance.registerNewEventByCode(ctx, code)
+		return err
+	}
+	err = instance.processingLedgerService.ProcessItem(ctx, eventItemType, strconv.Itoa(code), registerEvent)
+
+	return eventId, err
+}
+
+func (instance Event) registerNewEventByCode(ctx context.Context, code int) (*uuid.UUID, error) {
+	eventData, generationData, err := instance.getEventDataToRegister(ctx, code)
 	if err != nil {
 		log.Errorf("Error retrieving data for event %d: %s", code, err.Error())
 		return nil, err
@@ -158,7 +255,7 @@ func (instance Event) RegisterNewEventByCode(code int) (*uuid.UUID, error) {
 		return nil, nil
 	}
 
-	eventId, err := instance.eventRepository.CreateEvent(*eventData)
+	eventId, err := instance.eventRepository.CreateEvent(ctx, *eventData, *generationData)
 	if err != nil {
 		log.Error("eventRepository.CreateEvent(): ", err.Error())
 		return nil, err
@@ -167,120 +264,107 @@ func (instance Event) RegisterNewEventByCode(code int) (*uuid.UUID, error) {
 	return eventId, nil
 }
 
-func (instance Event) getEventDataToRegister(code int) (*event.Event, error) {
+func (instance Event) getEventDataToRegister(ctx context.Context, code int) (*event.Event, *generation.Generation,
+	error) {
 	log.Info("Starting data search for event ", code)
 
-	eventData, err := instance.chamberApi.GetEventByCode(code)
+	eventData, err := instance.chamberApi.GetEventByCode(ctx, code)
 	if err != nil {
 		log.Error("chamberApi.GetEventByCode(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	startsAt, err := time.Parse("2006-01-02T15:04", fmt.Sprint(eventData["dataHoraInicio"]))
+	startsAt, err := time.Parse(chamber.EventDateTimeLayout, eventData.StartsAt)
 	if err != nil {
 		log.Errorf("Error converting date and time of start of event %d: %s", code, err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	var endsAt time.Time
-	endsAtAsString := fmt.Sprint(eventData["dataHoraFim"])
-	if endsAtAsString != "<nil>" {
-		endsAt, err = time.Parse("2006-01-02T15:04", endsAtAsString)
+	if eventData.EndsAt != nil {
+		endsAt, err = time.Parse(chamber.EventDateTimeLayout, *eventData.EndsAt)
 		if err != nil {
 			log.Errorf("Error converting date and time of end of event %d: %s", code, err.Error())
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
-	location, isInternal, err := getEventLocation(eventData)
-	if err != nil {
-		log.Error("getEventLocation(): ", err.Error())
-		return nil, err
-	}
+	location, isInternal := getEventLocation(*eventData)
 
-	eventTypeDescription := fmt.Sprint(eventData["descricaoTipo"])
-	eventType, specificType, err := instance.getEventTypeByDescription(eventTypeDescription)
+	eventType, specificType, err := instance.getEventTypeByDescription(ctx, eventData.TypeDescription)
 	if err != nil {
 		log.Error("getEventTypeByDescription(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	eventSituationDescription := fmt.Sprint(eventData["situacao"])
-	eventSituation, specificSituation, err := instance.getEventSituationByDescription(eventSituationDescription)
+	eventSituation, specificSituation, err := instance.getEventSituationByDescription(ctx, eventData.Situation)
 	if err != nil {
 		log.Error("getEventSituationByDescription(): ", err.Error())
-		return nil, err
-	}
-
-	legislativeBodyData, err := converters.ToMapSlice(eventData["orgaos"])
-	if err != nil {
-		log.Error("converters.ToMapSlice(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	legislativeBodies, err := instance.getLegislativeBodies(legislativeBodyData)
+	legislativeBodies, err := instance.getLegislativeBodies(ctx, eventData.LegislativeBodies)
 	if err != nil {
 		log.Error("getLegislativeBodies(): ", err.Error())
-		return nil, err
-	}
-
-	requirementData, err := converters.ToMapSlice(eventData["requerimentos"])
-	if err != nil {
-		log.Error("converters.ToMapSlice(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	requirements, err := instance.getEventRequirements(requirementData)
+	requirements, err := instance.getEventRequirements(ctx, eventData.Requirements)
 	if err != nil {
 		log.Error("getEventRequirements(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	agendaItemUrl := fmt.Sprint(eventData["urlDocumentoPauta"])
-	agendaItems, err := instance.getEventAgendaItems(agendaItemUrl)
+	agendaItems, err := instance.getEventAgendaItems(ctx, code)
 	if err != nil {
 		log.Error("getEventAgendaItems(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	eventTopics, err := instance.getEventTopics(requirements, agendaItems)
+	eventTopics, err := instance.getEventTopics(ctx, requirements, agendaItems)
 	if err != nil {
 		log.Error("getEventTopics(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	if eventTopics == "" {
 		log.Warnf("Event %d not registered as there were no propositions related to it", code)
-		return nil, nil
+		return nil, nil, nil
+	}
+
+	titlePrompt, err := instance.promptRegistry.GetPrompt("event_title", "",
+		prompt.Variables{EventType: specificType, ReferenceDate: startsAt})
+	if err != nil {
+		log.Error("promptRegistry.GetPrompt(): ", err.Error())
+		return nil, nil, err
 	}
 
-	chatGptCommand := "Gere um título que usando uma linguagem simples e direta seja chamativo para uma matéria " +
-		"jornalistica sobre um evento que tratou dos seguintes temas:"
 	purpose := fmt.Sprint("Generating the title of event ", code)
-	title, err := instance.chatGptApi.MakeRequest(chatGptCommand, eventTopics, purpose)
+	titleData, titleUsage, err := instance.llmApi.MakeStructuredRequest(ctx, titlePrompt.Text(), eventTopics, purpose,
+		eventTitleSchema)
 	if err != nil {
-		log.Error("chatGptApi.MakeRequest(): ", err.Error())
-		return nil, err
+		log.Error("llmApi.MakeStructuredRequest(): ", err.Error())
+		return nil, nil, err
 	}
-	title = strings.Trim(title, "*\"")
+	title := fmt.Sprint(titleData["title"])
 
 	articleTypeCode := "event"
-	articleType, err := instance.articleTypeRepository.GetArticleTypeByCode(articleTypeCode)
+	articleType, err := instance.articleTypeRepository.GetArticleTypeByCode(ctx, articleTypeCode)
 	if err != nil {
 		log.Error("articleTypeRepository.GetArticleTypeByCode(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	articleData, err := article.NewBuilder().Type(*articleType).ReferenceDateTime(startsAt).Build()
 	if err != nil {
 		log.Errorf("Error validating article data for event %d: %s", code, err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	eventBuilder := event.NewBuilder().
 		Code(code).
 		Title(title).
-		Description(fmt.Sprint(eventData["descricao"])).
+		Description(eventData.Description).
 		StartsAt(startsAt).
 		Location(location).
 		IsInternal(isInternal).
@@ -297,10 +381,9 @@ func (instance Event) getEventDataToRegister(code int) (*event.Event, error) {
 		eventBuilder.EndsAt(endsAt)
 	}
 
-	videoUrl := fmt.Sprint(eventData["urlRegistro"])
-	if videoUrl != "<nil>" {
-		if validators.IsUrlValid(videoUrl) {
-			eventBuilder.VideoUrl(videoUrl)
+	if eventData.VideoUrl != nil {
+		if validators.IsUrlValid(*eventData.VideoUrl) {
+			eventBuilder.VideoUrl(*eventData.VideoUrl)
 		} else {
 			log.Infof("Event %d video URL is invalid", code)
 		}
@@ -309,46 +392,49 @@ func (instance Event) getEventDataToRegister(code int) (*event.Event, error) {
 	eventDomain, err := eventBuilder.Build()
 	if err != nil {
 		log.Errorf("Error validating data for event %d: %s", code, err.Error())
-		return nil, err
+		return nil, nil, err
+	}
+
+	generationData, err := generation.NewBuilder().Prompts(*titlePrompt).Usages(*titleUsage).Build()
+	if err != nil {
+		log.Errorf("Error validating generation data for event %d: %s", code, err.Error())
+		return nil, nil, err
 	}
 
 	log.Infof("Data search for event %d successful", code)
-	return eventDomain, err
+	return eventDomain, generationData, err
 }
 
-func getEventLocation(eventData map[string]interface{}) (string, bool, error) {
-	locationInTheChamber, err := converters.ToMap(eventData["localCamara"])
-	if err != nil {
-		log.Error("converters.ToMap(): ", err.Error())
-		return "", false, err
+func getEventLocation(eventData chamber.Event) (string, bool) {
+	if eventData.LocationInChamber != nil && eventData.LocationInChamber.Name != nil {
+		return *eventData.LocationInChamber.Name, true
 	}
 
-	location := fmt.Sprint(locationInTheChamber["nome"])
-	isInternal := true
-	if location == "<nil>" {
-		location = fmt.Sprint(eventData["localExterno"])
-		isInternal = false
+	var location string
+	if eventData.ExternalLocation != nil {
+		location = *eventData.ExternalLocation
 	}
 
-	return location, isInternal, nil
+	return location, false
 }
 
-func (instance Event) getEventTypeByDescription(eventTypeDescription string) (*eventtype.EventType, string, error) {
-	eventTypes, err := instance.chamberApi.GetEventTypes()
+func (instance Event) getEventTypeByDescription(ctx context.Context, eventTypeDescription string) (*eventtype.EventType,
+	string, error) {
+	eventTypes, err := instance.chamberApi.GetEventTypes(ctx)
 	if err != nil {
 		log.Error("chamberApi.GetEventTypes(): ", err.Error())
 		return nil, "", err
 	}
 
 	var eventTypeCode string
-	for _, eventTypeMap := range eventTypes {
-		if fmt.Sprint(eventTypeMap["nome"]) == eventTypeDescription {
-			eventTypeCode = fmt.Sprint(eventTypeMap["cod"])
+	for _, eventTypeData := range eventTypes {
+		if eventTypeData.Name == eventTypeDescription {
+			eventTypeCode = eventTypeData.Code
 			break
 		}
 	}
 
-	eventType, err := instance.eventTypeRepository.GetEventTypeByCodeOrDefaultType(eventTypeCode)
+	eventType, err := instance.eventTypeRepository.GetEventTypeByCodeOrDefaultType(ctx, eventTypeCode)
 	if err != nil {
 		log.Error("eventTypeRepository.GetEventTypeByCodeOrDefaultType(): ", err.Error())
 		return nil, "", err
@@ -359,23 +445,24 @@ func (instance Event) getEventTypeByDescription(eventTypeDescription string) (*e
 	return eventType, eventSpecificType, nil
 }
 
-func (instance Event) getEventSituationByDescription(eventSituationDescription string) (*eventsituation.EventSituation,
-	string, error) {
-	eventSituations, err := instance.chamberApi.GetEventSituations()
+func (instance Event) getEventSituationByDescription(ctx context.Context, eventSituationDescription string) (
+	*eventsituation.EventSituation, string, error) {
+	eventSituations, err := instance.chamberApi.GetEventSituations(ctx)
 	if err != nil {
 		log.Error("chamberApi.GetEventSituations(): ", err.Error())
 		return nil, "", err
 	}
 
 	var eventSituationCode string
-	for _, eventSituationMap := range eventSituations {
-		if strings.TrimSpace(fmt.Sprint(eventSituationMap["nome"])) == eventSituationDescription {
-			eventSituationCode = fmt.Sprint(eventSituationMap["cod"])
+	for _, eventSituationData := range eventSituations {
+		if strings.TrimSpace(eventSituationData.Name) == eventSituationDescription {
+			eventSituationCode = eventSituationData.Code
 			break
 		}
 	}
 
-	eventSituation, err := instance.eventSituationRepository.GetEventSituationByCodeOrDefaultSituation(eventSituationCode)
+	eventSituation, err := instance.eventSituationRepository.GetEventSituationByCodeOrDefaultSituation(ctx,
+		eventSituationCode)
 	if err != nil {
 		log.Error("eventSituationRepository.GetEventSituationByCodeOrDefaultSituation(): ", err.Error())
 		return nil, "", err
@@ -386,17 +473,14 @@ func (instance Event) getEventSituationByDescription(eventSituationDescription s
 	return eventSituation, eventSpecificSituation, nil
 }
 
-func (instance Event) getLegislativeBodies(legislativeBodyData []map[string]interface{}) (
-	[]legislativebody.LegislativeBody, error) {
-	codesOfTheReturnedLegislativeBodies, err := extractCodesFromLegislativeBodies(legislativeBodyData)
-	if err != nil {
-		log.Error("extractCodesFromLegislativeBodies(): ", err.Error())
-		return nil, err
-	}
+func (instance Event) getLegislativeBodies(ctx context.Context,
+	legislativeBodyReferences []chamber.LegislativeBodyReference) ([]legislativebody.LegislativeBody, error) {
+	codesOfTheReturnedLegislativeBodies := extractCodesFromLegislativeBodies(legislativeBodyReferences)
 
 	var registeredLegislativeBodies []legislativebody.LegislativeBody
 	if codesOfTheReturnedLegislativeBodies != nil {
-		registeredLegislativeBodies, err = instance.legislativeBodyService.GetLegislativeBodiesByCodes(
+		var err error
+		registeredLegislativeBodies, err = instance.legislativeBodyService.GetLegislativeBodiesByCodes(ctx,
 			codesOfTheReturnedLegislativeBodies)
 		if err != nil {
 			log.Error("legislativeBodyService.GetLegislativeBodiesByCodes(): ", err.Error())
@@ -406,7 +490,8 @@ func (instance Event) getLegislativeBodies(legislativeBodyData []map[string]inte
 		codesOfTheLegislativeBodiesToRegister := getCodesOfTheNewLegislativeBodies(codesOfTheReturnedLegislativeBodies,
 			registeredLegislativeBodies)
 		for _, legislativeBodyCode := range codesOfTheLegislativeBodiesToRegister {
-			legislativeBodyId, err := instance.legislativeBodyService.RegisterNewLegislativeBodyByCode(legislativeBodyCode)
+			legislativeBodyId, err := instance.legislativeBodyService.RegisterNewLegislativeBodyByCode(ctx,
+				legislativeBodyCode)
 			if err != nil {
 				log.Error("legislativeBodyService.RegisterNewLegislativeBodyByCode(): ", err.Error())
 				return nil, err
@@ -427,21 +512,17 @@ func (instance Event) getLegislativeBodies(legislativeBodyData []map[string]inte
 	return registeredLegislativeBodies, nil
 }
 
-func extractCodesFromLegislativeBodies(legislativeBodyData []map[string]interface{}) ([]int, error) {
+func extractCodesFromLegislativeBodies(legislativeBodyReferences []chamber.LegislativeBodyReference) []int {
 	var legislativeBodyCodes []int
-	for _, legislativeBody := range legislativeBodyData {
-		legislativeBodyCode, err := converters.ToInt(legislativeBody["id"])
-		if err != nil {
-			log.Error("converters.ToInt(): ", err.Error())
-			return nil, err
-		}
-		legislativeBodyCodes = append(legislativeBodyCodes, legislativeBodyCode)
+	for _, legislativeBody := range legislativeBodyReferences {
+		legislativeBodyCodes = append(legislativeBodyCodes, legislativeBody.Id)
 	}
 
-	return legislativeBodyCodes, nil
+	return legislativeBodyCodes
 }
 
-func (instance Event) getEventRequirements(requirements []map[string]interface{}) ([]proposition.Proposition, error) {
+func (instance Event) getEventRequirements(ctx context.Context, requirements []chamber.PropositionReference) (
+	[]proposition.Proposition, error) {
 	propositionCodes, err := getPropositionCodesFromEventRequirements(requirements)
 	if err != nil {
 		log.Error("getPropositionCodesFromEventRequirements(): ", err.Error())
@@ -450,7 +531,7 @@ func (instance Event) getEventRequirements(requirements []map[string]interface{}
 
 	var propositions []proposition.Proposition
 	if propositionCodes != nil {
-		propositions, err = instance.propositionService.GetPropositionsByCodes(propositionCodes)
+		propositions, err = instance.propositionService.GetPropositionsByCodes(ctx, propositionCodes)
 		if err != nil {
 			log.Error("propositionService.GetPropositionsByCodes(): ", err.Error())
 			return nil, err
@@ -458,7 +539,7 @@ func (instance Event) getEventRequirements(requirements []map[string]interface{}
 
 		codesOfThePropositionsToRegister := getCodesOfTheNewPropositions(propositionCodes, propositions)
 		for _, propositionCode := range codesOfThePropositionsToRegister {
-			propositionId, err := instance.propositionService.RegisterNewPropositionByCode(propositionCode)
+			propositionId, err := instance.propositionService.RegisterNewPropositionByCode(ctx, propositionCode)
 			if err != nil {
 				if strings.Contains(err.Error(), "no content") {
 					continue
@@ -479,13 +560,12 @@ func (instance Event) getEventRequirements(requirements []map[string]interface{}
 	return propositions, nil
 }
 
-func getPropositionCodesFromEventRequirements(requirements []map[string]interface{}) ([]int, error) {
+func getPropositionCodesFromEventRequirements(requirements []chamber.PropositionReference) ([]int, error) {
 	var propositionCodes []int
 	for _, requirement := range requirements {
-		propositionCode := path.Base(fmt.Sprint(requirement["uri"]))
-		code, err := converters.ToInt(propositionCode)
+		code, err := strconv.Atoi(path.Base(requirement.Uri))
 		if err != nil {
-			log.Error("converters.ToInt(): ", err.Error())
+			log.Errorf("Error converting the code of requirement %s to integer: %s", requirement.Uri, err.Error())
 			return nil, err
 		}
 		propositionCodes = append(propositionCodes, code)
@@ -494,24 +574,24 @@ func getPropositionCodesFromEventRequirements(requirements []map[string]interfac
 	return propositionCodes, nil
 }
 
-func (instance Event) getEventAgendaItems(agendaItemUrl string) ([]eventagendaitem.EventAgendaItem,
+func (instance Event) getEventAgendaItems(ctx context.Context, eventCode int) ([]eventagendaitem.EventAgendaItem,
 	error) {
-	agendaItemData, err := requesters.GetDataSliceFromUrl(agendaItemUrl)
+	agendaItemData, err := instance.chamberApi.GetEventAgendaItems(ctx, eventCode)
 	if err != nil {
-		log.Error("requests.GetDataSliceFromUrl(): ", err.Error())
+		log.Error("chamberApi.GetEventAgendaItems(): ", err.Error())
 		return nil, err
-	} else if fmt.Sprint(agendaItemData) == "[]" {
+	} else if len(agendaItemData) == 0 {
 		return nil, nil
 	}
 
-	propositionsRelatedToTheEventAgendaItems, err := instance.getPropositionsRelatedToTheEventAgendaItems(
+	propositionsRelatedToTheEventAgendaItems, err := instance.getPropositionsRelatedToTheEventAgendaItems(ctx,
 		agendaItemData)
 	if err != nil {
 		log.Error("getPropositionsRelatedToTheEventAgendaItems(): ", err.Error())
 		return nil, err
 	}
 
-	votesRelatedToTheEventAgendaItems, err := instance.getVotingRelatedToTheEventAgendaItem(agendaItemData)
+	votesRelatedToTheEventAgendaItems, err := instance.getVotingRelatedToTheEventAgendaItem(ctx, agendaItemData)
 	if err != nil {
 		log.Error("instance.getVotingRelatedToTheEventAgendaItem(): ", err.Error())
 		return nil, err
@@ -519,85 +599,49 @@ func (instance Event) getEventAgendaItems(agendaItemUrl string) ([]eventagendait
 
 	var agendaItems []eventagendaitem.EventAgendaItem
 	for _, agendaItem := range agendaItemData {
-		agendaItemRegimeCode, err := converters.ToInt(agendaItem["codRegime"])
-		if err != nil {
-			log.Error("converters.ToInt(): ", err.Error())
-			return nil, err
-		}
-
-		agendaItemRegime, err := instance.getAgendaItemRegime(agendaItemRegimeCode, fmt.Sprint(agendaItem["regime"]))
+		agendaItemRegime, err := instance.getAgendaItemRegime(ctx, agendaItem.RegimeCode, agendaItem.Regime)
 		if err != nil {
 			log.Error("instance.getAgendaItemRegime(): ", err.Error())
 			return nil, err
 		}
 
 		var rapporteur deputy.Deputy
-		if fmt.Sprint(agendaItem["relator"]) != "<nil>" {
-			rapporteurData, err := converters.ToMap(agendaItem["relator"])
-			if err != nil {
-				log.Error("converters.ToMap(): ", err.Error())
-				return nil, err
-			}
-
-			agendaItemRapporteur, err := instance.deputyService.GetDeputyFromDeputyData(rapporteurData)
+		if agendaItem.Rapporteur != nil && agendaItem.Rapporteur.Id != 0 {
+			agendaItemRapporteur, err := instance.deputyService.GetDeputyByCode(ctx, agendaItem.Rapporteur.Id)
 			if err != nil {
-				log.Error("deputyService.GetDeputyFromDeputyData(): ", err.Error())
+				log.Error("deputyService.GetDeputyByCode(): ", err.Error())
 				return nil, err
 			}
 
 			rapporteur = *agendaItemRapporteur
 		}
 
-		propositionData, err := converters.ToMap(agendaItem["proposicao_"])
-		if err != nil {
-			log.Error("converters.ToMap(): ", err.Error())
-			return nil, err
-		}
-
-		relatedPropositionData, err := converters.ToMap(agendaItem["proposicaoRelacionada_"])
-		if err != nil {
-			log.Error("converters.ToMap(): ", err.Error())
-			return nil, err
-		}
-
 		var agendaItemVoting voting.Voting
-		if votesRelatedToTheEventAgendaItems != nil {
-			agendaItemVoting = votesRelatedToTheEventAgendaItems[path.Base(fmt.Sprint(agendaItem["uriVotacao"]))]
+		if votingCode := getAgendaItemVotingCode(agendaItem); votingCode != "" {
+			agendaItemVoting = votesRelatedToTheEventAgendaItems[votingCode]
 		}
 
-		mainPropositionId, err := converters.ToInt(propositionData["id"])
-		if err != nil {
-			log.Error("converters.ToInt(): ", err.Error())
-			return nil, err
-		}
-
-		var relatedPropositionId int
-		if fmt.Sprint(relatedPropositionData["id"]) != "<nil>" {
-			relatedPropositionId, err = converters.ToInt(relatedPropositionData["id"])
-			if err != nil {
-				log.Error("converters.ToInt(): ", err.Error())
-				return nil, err
-			}
+		var relatedPropositionCode int
+		if agendaItem.RelatedProposition != nil {
+			relatedPropositionCode = agendaItem.RelatedProposition.Id
 		}
 
 		agendaItemBuilder := eventagendaitem.NewBuilder().
-			Title(fmt.Sprint(agendaItem["titulo"])).
-			Topic(fmt.Sprint(agendaItem["topico"])).
+			Title(agendaItem.Title).
+			Topic(agendaItem.Topic).
 			Regime(*agendaItemRegime).
 			Rapporteur(rapporteur).
-			Proposition(propositionsRelatedToTheEventAgendaItems[mainPropositionId]).
-			RelatedProposition(propositionsRelatedToTheEventAgendaItems[relatedPropositionId]).
+			Proposition(propositionsRelatedToTheEventAgendaItems[agendaItem.Proposition.Id]).
+			RelatedProposition(propositionsRelatedToTheEventAgendaItems[relatedPropositionCode]).
 			Voting(agendaItemVoting)
 
-		situation := fmt.Sprint(agendaItem["situacaoItem"])
-		if situation != "<nil>" {
-			agendaItemBuilder.Situation(situation)
+		if agendaItem.Situation != nil {
+			agendaItemBuilder.Situation(*agendaItem.Situation)
 		}
 
 		agendaItemDomain, err := agendaItemBuilder.Build()
 		if err != nil {
-			log.Errorf("Error validating data for agenda item %s: %s", fmt.Sprint(agendaItem["title"]),
-				err.Error())
+			log.Errorf("Error validating data for agenda item %s: %s", agendaItem.Title, err.Error())
 			return nil, err
 		}
 		agendaItems = append(agendaItems, *agendaItemDomain)
@@ -606,8 +650,19 @@ func (instance Event) getEventAgendaItems(agendaItemUrl string) ([]eventagendait
 	return agendaItems, nil
 }
 
-func (instance Event) getAgendaItemRegime(code int, description string) (*agendaitemregime.AgendaItemRegime, error) {
-	agendaItemRegime, err := instance.agendaItemRegimeRepository.GetAgendaItemRegimeByCode(code)
+// getAgendaItemVotingCode returns the code of the voting of the agenda item, which is referenced only by its URI, or
+// an empty string when the item was not voted
+func getAgendaItemVotingCode(agendaItem chamber.EventAgendaItem) string {
+	if agendaItem.VotingUri == nil || strings.TrimSpace(*agendaItem.VotingUri) == "" {
+		return ""
+	}
+
+	return path.Base(*agendaItem.VotingUri)
+}
+
+func (instance Event) getAgendaItemRegime(ctx context.Context, code int, description string) (
+	*agendaitemregime.AgendaItemRegime, error) {
+	agendaItemRegime, err := instance.agendaItemRegimeRepository.GetAgendaItemRegimeByCode(ctx, code)
 	if err != nil {
 		log.Error("agendaItemRegimeRepository.GetAgendaItemRegimeByCode(): ", err.Error())
 		return nil, err
@@ -620,7 +675,7 @@ func (instance Event) getAgendaItemRegime(code int, description string) (*agenda
 			return nil, err
 		}
 
-		agendaItemRegimeId, err := instance.agendaItemRegimeRepository.CreateAgendaItemRegime(*agendaItemRegime)
+		agendaItemRegimeId, err := instance.agendaItemRegimeRepository.CreateAgendaItemRegime(ctx, *agendaItemRegime)
 		if err != nil {
 			log.Error("agendaItemRegimeRepository.CreateAgendaItemRegime(): ", err.Error())
 			return nil, err
@@ -636,40 +691,17 @@ func (instance Event) getAgendaItemRegime(code int, description string) (*agenda
 	return agendaItemRegime, nil
 }
 
-func (instance Event) getPropositionsRelatedToTheEventAgendaItems(agendaItems []map[string]interface{}) (
-	map[int]proposition.Proposition, error) {
+func (instance Event) getPropositionsRelatedToTheEventAgendaItems(ctx context.Context,
+	agendaItems []chamber.EventAgendaItem) (map[int]proposition.Proposition, error) {
 	var propositionCodes []int
 	for _, agendaItemData := range agendaItems {
-		propositionData, err := converters.ToMap(agendaItemData["proposicao_"])
-		if err != nil {
-			log.Error("converters.ToMap(): ", err.Error())
-			return nil, err
-		}
-
-		propositionCode, err := converters.ToInt(propositionData["id"])
-		if err != nil {
-			log.Error("converters.ToInt(): ", err.Error())
-			return nil, err
-		}
-		propositionCodes = append(propositionCodes, propositionCode)
-
-		if fmt.Sprint(agendaItemData["proposicaoRelacionada_"]) != "<nil>" {
-			relatedPropositionData, err := converters.ToMap(agendaItemData["proposicaoRelacionada_"])
-			if err != nil {
-				log.Error("converters.ToMap(): ", err.Error())
-				return nil, err
-			}
-
-			propositionCode, err = converters.ToInt(relatedPropositionData["id"])
-			if err != nil {
-				log.Error("converters.ToInt(): ", err.Error())
-				return nil, err
-			}
-			propositionCodes = append(propositionCodes, propositionCode)
+		propositionCodes = append(propositionCodes, agendaItemData.Proposition.Id)
+		if agendaItemData.RelatedProposition != nil && agendaItemData.RelatedProposition.Id != 0 {
+			propositionCodes = append(propositionCodes, agendaItemData.RelatedProposition.Id)
 		}
 	}
 
-	propositions, err := instance.propositionService.GetPropositionsByCodes(propositionCodes)
+	propositions, err := instance.propositionService.GetPropositionsByCodes(ctx, propositionCodes)
 	if err != nil {
 		log.Error("propositionService.GetPropositionsByCodes(): ", err.Error())
 		return nil, err
@@ -677,7 +709,7 @@ func (instance Event) getPropositionsRelatedToTheEventAgendaItems(agendaItems []
 
 	codesOfThePropositionsToRegister := getCodesOfTheNewPropositions(propositionCodes, propositions)
 	for _, propositionCode := range codesOfThePropositionsToRegister {
-		propositionId, err := instance.propositionService.RegisterNewPropositionByCode(propositionCode)
+		propositionId, err := instance.propositionService.RegisterNewPropositionByCode(ctx, propositionCode)
 		if err != nil {
 			if strings.Contains(err.Error(), "no content") {
 				continue
@@ -702,12 +734,12 @@ func (instance Event) getPropositionsRelatedToTheEventAgendaItems(agendaItems []
 	return propositionsRelatedToTheEventAgendaItems, nil
 }
 
-func (instance Event) getVotingRelatedToTheEventAgendaItem(agendaItems []map[string]interface{}) (
-	map[string]voting.Voting, error) {
+func (instance Event) getVotingRelatedToTheEventAgendaItem(ctx context.Context,
+	agendaItems []chamber.EventAgendaItem) (map[string]voting.Voting, error) {
 	var returnedVotingCodes []string
 	for _, agendaItemData := range agendaItems {
-		votingCode := path.Base(fmt.Sprint(agendaItemData["uriVotacao"]))
-		if votingCode == "<nil>" {
+		votingCode := getAgendaItemVotingCode(agendaItemData)
+		if votingCode == "" {
 			continue
 		}
 		returnedVotingCodes = append(returnedVotingCodes, votingCode)
@@ -717,7 +749,7 @@ func (instance Event) getVotingRelatedToTheEventAgendaItem(agendaItems []map[str
 		return nil, nil
 	}
 
-	registeredVotes, err := instance.votingService.GetVotesByCodes(returnedVotingCodes)
+	registeredVotes, err := instance.votingService.GetVotesByCodes(ctx, returnedVotingCodes)
 	if err != nil {
 		log.Error("votingService.GetVotesByCodes(): ", err.Error())
 		return nil, err
@@ -725,7 +757,7 @@ func (instance Event) getVotingRelatedToTheEventAgendaItem(agendaItems []map[str
 
 	votesToRegister := getCodesOfTheNewVotes(returnedVotingCodes, registeredVotes)
 	for _, votingCode := range votesToRegister {
-		votingId, err := instance.votingService.RegisterNewVotingByCode(votingCode)
+		votingId, err := instance.votingService.RegisterNewVotingByCode(ctx, votingCode)
 		if err != nil {
 			log.Error("votingService.RegisterNewVotingByCode(): ", err.Error())
 			return nil, err
@@ -747,7 +779,7 @@ func (instance Event) getVotingRelatedToTheEventAgendaItem(agendaItems []map[str
 	return votesRelatedToTheEventAgendaItems, nil
 }
 
-func (instance Event) getEventTopics(requirements []proposition.Proposition,
+func (instance Event) getEventTopics(ctx context.Context, requirements []proposition.Proposition,
 	agendaItems []eventagendaitem.EventAgendaItem) (string, error) {
 	var propositionCodes []int
 	for _, requirement := range requirements {
@@ -760,7 +792,7 @@ func (instance Event) getEventTopics(requirements []proposition.Proposition,
 
 	var eventTopics string
 	if propositionCodes != nil {
-		propositions, err := instance.propositionService.GetPropositionsByCodes(propositionCodes)
+		propositions, err := instance.propositionService.GetPropositionsByCodes(ctx, propositionCodes)
 		if err != nil {
 			log.Error("propositionService.GetPropositionsByCodes(): ", err.Error())
 			return "", err
@@ -774,33 +806,33 @@ func (instance Event) getEventTopics(requirements []proposition.Proposition,
 	return eventTopics, nil
 }
 
-func (instance Event) UpdateEventsOccurringToday() {
-	events, err := instance.eventRepository.GetEventsOccurringToday()
+func (instance Event) UpdateEventsOccurringToday(ctx context.Context) {
+	events, err := instance.eventRepository.GetEventsOccurringToday(ctx)
 	if err != nil {
 		log.Error("eventRepository.GetEventsOccurringToday(): ", err.Error())
 		return
 	}
 
-	instance.updateEvents(events)
+	instance.updateEvents(ctx, events)
 }
 
-func (instance Event) UpdateEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished() {
-	events, err := instance.eventRepository.GetEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished()
+func (instance Event) UpdateEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished(ctx context.Context) {
+	events, err := instance.eventRepository.GetEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished(ctx)
 	if err != nil {
 		log.Error("eventRepository.GetEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished(): ", err.Error())
 		return
 	}
 
-	instance.updateEvents(events)
+	instance.updateEvents(ctx, events)
 }
 
-func (instance Event) updateEvents(events []event.Event) {
+func (instance Event) updateEvents(ctx context.Context, events []event.Event) {
 	if len(events) == 0 {
 		log.Info("No events found for update")
 		return
 	}
 
-	eventsToUpdate, err := instance.getEventsToUpdate(events)
+	eventsToUpdate, err := instance.getEventsToUpdate(ctx, events)
 	if err != nil {
 		log.Error("getEventsToUpdate(): ", err.Error())
 		return
@@ -812,14 +844,14 @@ func (instance Event) updateEvents(events []event.Event) {
 	}
 
 	for _, eventData := range eventsToUpdate {
-		err = instance.eventRepository.UpdateEvent(eventData)
+		err = instance.eventRepository.UpdateEvent(ctx, eventData)
 		if err != nil {
 			log.Error("eventRepository.UpdateEvent(): ", err.Error())
 		}
 	}
 }
 
-func (instance Event) getEventsToUpdate(events []event.Event) ([]event.Event, error) {
+func (instance Event) getEventsToUpdate(ctx context.Context, events []event.Event) ([]event.Event, error) {
 	eventCodesAsString := getEventCodesAsString(events)
 
 	log.Info("Starting data search to update events: ", eventCodesAsString)
@@ -829,14 +861,14 @@ func (instance Event) getEventsToUpdate(events []event.Event) ([]event.Event, er
 
 	var updatedEvents []event.Event
 	for _, eventCodeChunk := range eventCodeChunks {
-		eventsToUpdate, err := instance.chamberApi.GetEventsByCodes(eventCodeChunk)
+		eventsToUpdate, err := instance.chamberApi.GetEventsByCodes(ctx, eventCodeChunk)
 		if err != nil {
 			log.Error("chamberApi.GetEventsByCodes(): ", err.Error())
 			return nil, nil
 		}
 
 		for _, eventData := range eventsToUpdate {
-			eventDomain, err := instance.getEventDataToUpdate(eventData)
+			eventDomain, err := instance.getEventDataToUpdate(ctx, eventData)
 			if err != nil {
 				log.Error("getEventDataToUpdate(): ", err.Error())
 				return nil, err
@@ -882,37 +914,27 @@ func removeEventsThatHaveNotBeenUpdated(updatedEvents []event.Event, registeredE
 	return eventsWithUpdates
 }
 
-func (instance Event) getEventDataToUpdate(eventData map[string]interface{}) (*event.Event, error) {
-	code, err := converters.ToInt(eventData["id"])
-	if err != nil {
-		log.Error("converters.ToInt(): ", err.Error())
-		return nil, err
-	}
+func (instance Event) getEventDataToUpdate(ctx context.Context, eventData chamber.Event) (*event.Event, error) {
+	code := eventData.Id
 
-	startsAt, err := time.Parse("2006-01-02T15:04", fmt.Sprint(eventData["dataHoraInicio"]))
+	startsAt, err := time.Parse(chamber.EventDateTimeLayout, eventData.StartsAt)
 	if err != nil {
 		log.Errorf("Error converting date and time of start of event %d: %s", code, err.Error())
 		return nil, err
 	}
 
 	var endsAt time.Time
-	endsAtAsString := fmt.Sprint(eventData["dataHoraFim"])
-	if endsAtAsString != "<nil>" {
-		endsAt, err = time.Parse("2006-01-02T15:04", endsAtAsString)
+	if eventData.EndsAt != nil {
+		endsAt, err = time.Parse(chamber.EventDateTimeLayout, *eventData.EndsAt)
 		if err != nil {
 			log.Errorf("Error converting date and time of end of event %d: %s", code, err.Error())
 			return nil, err
 		}
 	}
 
-	location, isInternal, err := getEventLocation(eventData)
-	if err != nil {
-		log.Error("getEventLocation(): ", err.Error())
-		return nil, err
-	}
+	location, isInternal := getEventLocation(eventData)
 
-	eventSituationDescription := fmt.Sprint(eventData["situacao"])
-	eventSituation, specificSituation, err := instance.getEventSituationByDescription(eventSituationDescription)
+	eventSituation, specificSituation, err := instance.getEventSituationByDescription(ctx, eventData.Situation)
 	if err != nil {
 		log.Error("getEventSituationByDescription(): ", err.Error())
 		return nil, err
@@ -920,7 +942,7 @@ func (instance Event) getEventDataToUpdate(eventData map[string]interface{}) (*e
 
 	eventBuilder := event.NewBuilder().
 		Code(code).
-		Description(fmt.Sprint(eventData["descricao"])).
+		Description(eventData.Description).
 		StartsAt(startsAt).
 		Location(location).
 		IsInternal(isInternal).
@@ -931,10 +953,9 @@ func (instance Event) getEventDataToUpdate(eventData map[string]interface{}) (*e
 		eventBuilder.EndsAt(endsAt)
 	}
 
-	videoUrl := fmt.Sprint(eventData["urlRegistro"])
-	if videoUrl != "<nil>" {
-		if validators.IsUrlValid(videoUrl) {
-			eventBuilder.VideoUrl(videoUrl)
+	if eventData.VideoUrl != nil {
+		if validators.IsUrlValid(*eventData.VideoUrl) {
+			eventBuilder.VideoUrl(*eventData.VideoUrl)
 		} else {
 			log.Infof("Event %d video URL is invalid", code)
 		}
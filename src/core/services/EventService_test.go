@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/devlucassantos/vnc-domains/src/domains/event"
+	"strconv"
+	"testing"
+	"time"
+	"vnc-summarizer/core/interfaces/chamber"
+)
+
+func TestRegisterNewEvents(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		registeredCodes          []int
+		mostRecentCodes          []int
+		codesWithoutRequirements []int
+		expectedRegisteredCodes  []int
+		expectedNumberOfSearches int
+	}{
+		{
+			name:                     "registers the new events",
+			mostRecentCodes:          []int{75001, 75002},
+			expectedRegisteredCodes:  []int{75001, 75002},
+			expectedNumberOfSearches: 2,
+		},
+		{
+			name:                     "registers only the events that are not registered yet",
+			registeredCodes:          []int{75001},
+			mostRecentCodes:          []int{75001, 75002, 75002},
+			expectedRegisteredCodes:  []int{75001, 75002},
+			expectedNumberOfSearches: 1,
+		},
+		{
+			name:                     "does not register the events without related propositions",
+			mostRecentCodes:          []int{75001, 75002},
+			codesWithoutRequirements: []int{75002},
+			expectedRegisteredCodes:  []int{75001},
+			expectedNumberOfSearches: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			ctx := context.Background()
+			startsAt := getCurrentDateTime(t)
+
+			environment.chamberApi.addProposition(1001)
+			for _, code := range testCase.registeredCodes {
+				environment.chamberApi.addEvent(code, startsAt, fakeOpenSituationName, 1001)
+				_, err := environment.eventService.RegisterNewEventByCode(ctx, code)
+				if err != nil {
+					t.Fatalf("RegisterNewEventByCode(): %s", err.Error())
+				}
+			}
+			numberOfPreviousSearches := environment.chamberApi.getNumberOfCalls("GetEventByCode")
+
+			for _, code := range testCase.mostRecentCodes {
+				environment.chamberApi.addEvent(code, startsAt, fakeOpenSituationName, 1001)
+			}
+			for _, code := range testCase.codesWithoutRequirements {
+				environment.chamberApi.addEvent(code, startsAt, fakeOpenSituationName)
+			}
+			environment.chamberApi.mostRecentEventCodes = testCase.mostRecentCodes
+
+			environment.eventService.RegisterNewEvents(ctx)
+
+			numberOfSearches := environment.chamberApi.getNumberOfCalls("GetEventByCode") - numberOfPreviousSearches
+			if numberOfSearches != testCase.expectedNumberOfSearches {
+				t.Errorf("%d searches of events were expected, but %d were made", testCase.expectedNumberOfSearches,
+					numberOfSearches)
+			}
+
+			events, err := environment.eventRepository.GetEventsByCodes(ctx, testCase.mostRecentCodes)
+			if err != nil {
+				t.Fatalf("GetEventsByCodes(): %s", err.Error())
+			}
+			if len(events) != len(testCase.expectedRegisteredCodes) {
+				t.Fatalf("%d events were expected to be registered, but %d were registered",
+					len(testCase.expectedRegisteredCodes), len(events))
+			}
+			for _, code := range testCase.expectedRegisteredCodes {
+				if getEventByCode(events, code) == nil {
+					t.Errorf("Event %d was not registered", code)
+				}
+			}
+
+			for _, code := range testCase.codesWithoutRequirements {
+				status, _ := environment.database.GetProcessingItemStatus(eventItemType, strconv.Itoa(code))
+				if status != processingItemSucceeded {
+					t.Errorf("The processing of event %d was expected to be succeeded, but it was %s", code, status)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterNewEventByCode(t *testing.T) {
+	environment := newTestEnvironment(t)
+	ctx := context.Background()
+
+	environment.chamberApi.addProposition(1001)
+	environment.chamberApi.addProposition(1002)
+	environment.chamberApi.addVoting("2438516-54", 1002)
+	environment.chamberApi.addEvent(75001, getCurrentDateTime(t), fakeOpenSituationName, 1001)
+
+	agendaItemSituation := "Aprovado o Projeto de Lei"
+	votingUri := "https://dadosabertos.camara.leg.br/api/v2/votacoes/2438516-54"
+	environment.chamberApi.setEventAgendaItems(75001, []chamber.EventAgendaItem{
+		{
+			Title:       "PL 1002/2025",
+			Topic:       "Projeto de Lei",
+			RegimeCode:  99,
+			Regime:      "Urgência (Art. 155, RICD)",
+			Situation:   &agendaItemSituation,
+			Rapporteur:  &chamber.DeputyReference{Id: fakeDeputyCode, Name: "Maria Silva"},
+			Proposition: chamber.PropositionReference{Id: 1002},
+			VotingUri:   &votingUri,
+		},
+	})
+
+	eventId, err := environment.eventService.RegisterNewEventByCode(ctx, 75001)
+	if err != nil {
+		t.Fatalf("RegisterNewEventByCode(): %s", err.Error())
+	}
+
+	events, err := environment.eventRepository.GetEventsByCodes(ctx, []int{75001})
+	if err != nil {
+		t.Fatalf("GetEventsByCodes(): %s", err.Error())
+	}
+	registeredEvent := getEventByCode(events, 75001)
+	if registeredEvent == nil || registeredEvent.Id() != *eventId {
+		t.Fatalf("Event %s was not registered", eventId)
+	}
+
+	eventType := registeredEvent.Type()
+	if eventType.Codes() != "110" || registeredEvent.SpecificType() != "Reunião Deliberativa (110)" {
+		t.Errorf("The type of the event was expected to be 110, but it was %s (%s)", eventType.Codes(),
+			registeredEvent.SpecificType())
+	}
+	eventSituation := registeredEvent.Situation()
+	if eventSituation.Codes() != "2" || eventSituation.IsFinished() {
+		t.Errorf("The situation of the event was expected to be 2, but it was %s", eventSituation.Codes())
+	}
+	if len(registeredEvent.LegislativeBodies()) != 1 || len(registeredEvent.Requirements()) != 1 {
+		t.Errorf("The event was expected to have 1 legislative body and 1 requirement, but it has %d and %d",
+			len(registeredEvent.LegislativeBodies()), len(registeredEvent.Requirements()))
+	}
+	if len(registeredEvent.AgendaItems()) != 1 {
+		t.Fatalf("The event was expected to have 1 agenda item, but it has %d", len(registeredEvent.AgendaItems()))
+	}
+
+	agendaItem := registeredEvent.AgendaItems()[0]
+	agendaItemProposition, agendaItemVoting := agendaItem.Proposition(), agendaItem.Voting()
+	agendaItemRapporteur, agendaItemRegime := agendaItem.Rapporteur(), agendaItem.Regime()
+	if agendaItemProposition.Code() != 1002 || agendaItemVoting.Code() != "2438516-54" {
+		t.Errorf("The agenda item was expected to reference proposition 1002 and voting 2438516-54, but it "+
+			"references %d and %s", agendaItemProposition.Code(), agendaItemVoting.Code())
+	}
+	if agendaItemRapporteur.Code() != fakeDeputyCode || agendaItemRegime.Code() != 99 {
+		t.Errorf("The agenda item was expected to have rapporteur %d and regime 99, but it has %d and %d",
+			fakeDeputyCode, agendaItemRapporteur.Code(), agendaItemRegime.Code())
+	}
+
+	votes, err := environment.votingRepository.GetVotesByCodes(ctx, []string{"2438516-54"})
+	if err != nil {
+		t.Fatalf("GetVotesByCodes(): %s", err.Error())
+	}
+	if len(votes) != 1 {
+		t.Error("The voting of the agenda item was not registered")
+	}
+}
+
+func TestRegisterNewEventByCodeErrors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configure func(environment *testEnvironment)
+	}{
+		{
+			name: "returns the error of the Chamber of Deputies API",
+			configure: func(environment *testEnvironment) {
+				environment.chamberApi.failOperation("GetEventByCode", errors.New("Service unavailable"), -1)
+			},
+		},
+		{
+			name: "returns the error of the registration of the requirements",
+			configure: func(environment *testEnvironment) {
+				environment.database.FailOperation("CreateProposition", errors.New("The connection was closed"))
+			},
+		},
+		{
+			name: "returns the error of the registration of the legislative bodies",
+			configure: func(environment *testEnvironment) {
+				environment.chamberApi.failOperation("GetLegislativeBodyByCode", errors.New("Service unavailable"),
+					-1)
+			},
+		},
+		{
+			name: "returns the error of the repository",
+			configure: func(environment *testEnvironment) {
+				environment.database.FailOperation("CreateEvent", errors.New("The connection was closed"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			ctx := context.Background()
+
+			environment.chamberApi.addProposition(1001)
+			environment.chamberApi.addEvent(75001, getCurrentDateTime(t), fakeOpenSituationName, 1001)
+			testCase.configure(environment)
+
+			eventId, err := environment.eventService.RegisterNewEventByCode(ctx, 75001)
+			if err == nil {
+				t.Fatalf("An error was expected, but event %s was registered", eventId)
+			}
+
+			events, err := environment.eventRepository.GetEventsByCodes(ctx, []int{75001})
+			if err != nil {
+				t.Fatalf("GetEventsByCodes(): %s", err.Error())
+			}
+			if len(events) > 0 {
+				t.Error("The event was registered despite the error")
+			}
+
+			status, _ := environment.database.GetProcessingItemStatus(eventItemType, "75001")
+			if status != processingItemFailed {
+				t.Errorf("The processing of the event was expected to be failed, but it was %s", status)
+			}
+		})
+	}
+}
+
+func TestUpdateEvents(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		startsAt              func(currentDateTime time.Time) time.Time
+		update                func(eventService *Event, ctx context.Context)
+		situationInTheChamber string
+		chamberError          error
+		expectedSituation     string
+	}{
+		{
+			name:                  "updates the situation of the events occurring today",
+			startsAt:              func(currentDateTime time.Time) time.Time { return currentDateTime },
+			update:                (*Event).UpdateEventsOccurringToday,
+			situationInTheChamber: fakeClosedSituationName,
+			expectedSituation:     "3",
+		},
+		{
+			name: "does not update the events that are not occurring today",
+			startsAt: func(currentDateTime time.Time) time.Time {
+				return currentDateTime.AddDate(0, 0, -10)
+			},
+			update:                (*Event).UpdateEventsOccurringToday,
+			situationInTheChamber: fakeClosedSituationName,
+			expectedSituation:     "2",
+		},
+		{
+			name: "updates the situation of the events that started in the last three months",
+			startsAt: func(currentDateTime time.Time) time.Time {
+				return currentDateTime.AddDate(0, 0, -10)
+			},
+			update:                (*Event).UpdateEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished,
+			situationInTheChamber: fakeClosedSituationName,
+			expectedSituation:     "3",
+		},
+		{
+			name:                  "keeps the events when the Chamber of Deputies API fails",
+			startsAt:              func(currentDateTime time.Time) time.Time { return currentDateTime },
+			update:                (*Event).UpdateEventsOccurringToday,
+			situationInTheChamber: fakeClosedSituationName,
+			chamberError:          errors.New("Service unavailable"),
+			expectedSituation:     "2",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			ctx := context.Background()
+
+			environment.chamberApi.addProposition(1001)
+			startsAt := testCase.startsAt(getCurrentDateTime(t))
+			environment.chamberApi.addEvent(75001, startsAt, fakeOpenSituationName, 1001)
+			_, err := environment.eventService.RegisterNewEventByCode(ctx, 75001)
+			if err != nil {
+				t.Fatalf("RegisterNewEventByCode(): %s", err.Error())
+			}
+
+			environment.chamberApi.setEventSituation(75001, testCase.situationInTheChamber)
+			if testCase.chamberError != nil {
+				environment.chamberApi.failOperation("GetEventsByCodes", testCase.chamberError, -1)
+			}
+			testCase.update(environment.eventService, ctx)
+
+			events, err := environment.eventRepository.GetEventsByCodes(ctx, []int{75001})
+			if err != nil {
+				t.Fatalf("GetEventsByCodes(): %s", err.Error())
+			}
+			if len(events) != 1 {
+				t.Fatalf("1 event was expected to be registered, but %d were registered", len(events))
+			}
+
+			eventSituation := events[0].Situation()
+			if eventSituation.Codes() != testCase.expectedSituation {
+				t.Errorf("The situation of the event was expected to be %s, but it was %s",
+					testCase.expectedSituation, eventSituation.Codes())
+			}
+			if events[0].Title() == "" || len(events[0].Requirements()) != 1 {
+				t.Error("The data of the event that is not returned by the update was lost")
+			}
+		})
+	}
+}
+
+func getEventByCode(events []event.Event, code int) *event.Event {
+	for _, eventData := range events {
+		if eventData.Code() == code {
+			return &eventData
+		}
+	}
+
+	return nil
+}
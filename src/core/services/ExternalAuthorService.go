@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"github.com/devlucassantos/vnc-domains/src/domains/externalauthor"
 	"github.com/devlucassantos/vnc-domains/src/domains/externalauthortype"
 	"github.com/labstack/gommon/log"
@@ -23,8 +24,8 @@ func NewExternalAuthorService(externalAuthorRepository postgres.ExternalAuthor,
 	}
 }
 
-func (instance ExternalAuthor) GetExternalAuthorFromAuthorData(authorName string, authorTypeCode int,
-	authorType string) (*externalauthor.ExternalAuthor, error) {
+func (instance ExternalAuthor) GetExternalAuthorFromAuthorData(ctx context.Context, authorName string,
+	authorTypeCode int, authorType string) (*externalauthor.ExternalAuthor, error) {
 	caser := cases.Title(language.BrazilianPortuguese)
 	externalAuthorTypeData, err := externalauthortype.NewBuilder().
 		Code(authorTypeCode).
@@ -44,15 +45,15 @@ func (instance ExternalAuthor) GetExternalAuthorFromAuthorData(authorName string
 		return nil, err
 	}
 
-	updatedExternalAuthor, err := instance.getExternalAuthorFromDatabase(externalAuthorData)
+	updatedExternalAuthor, err := instance.getExternalAuthorFromDatabase(ctx, externalAuthorData)
 
 	return updatedExternalAuthor, nil
 }
 
-func (instance ExternalAuthor) getExternalAuthorFromDatabase(
+func (instance ExternalAuthor) getExternalAuthorFromDatabase(ctx context.Context,
 	externalAuthorDomain *externalauthor.ExternalAuthor) (*externalauthor.ExternalAuthor, error) {
 	externalAuthorType := externalAuthorDomain.Type()
-	registeredExternalAuthor, err := instance.externalAuthorRepository.GetExternalAuthorByNameAndTypeCode(
+	registeredExternalAuthor, err := instance.externalAuthorRepository.GetExternalAuthorByNameAndTypeCode(ctx,
 		externalAuthorDomain.Name(), externalAuthorType.Code())
 	if err != nil {
 		log.Error("externalAuthorRepository.GetExternalAuthorByNameAndTypeCode(): ", err.Error())
@@ -61,7 +62,7 @@ func (instance ExternalAuthor) getExternalAuthorFromDatabase(
 		return registeredExternalAuthor, nil
 	}
 
-	registeredExternalAuthorType, err := instance.externalAuthorTypeRepository.GetExternalAuthorTypeByCode(
+	registeredExternalAuthorType, err := instance.externalAuthorTypeRepository.GetExternalAuthorTypeByCode(ctx,
 		externalAuthorType.Code())
 	if err != nil {
 		log.Error("externalAuthorTypeRepository.GetExternalAuthorTypeByCode(): ", err.Error())
@@ -72,7 +73,7 @@ func (instance ExternalAuthor) getExternalAuthorFromDatabase(
 	if registeredExternalAuthorType != nil {
 		updatedExternalAuthor, err = externalAuthorDomain.NewUpdater().Type(*registeredExternalAuthorType).Build()
 	} else {
-		externalAuthorTypeId, err := instance.externalAuthorTypeRepository.CreateExternalAuthorType(
+		externalAuthorTypeId, err := instance.externalAuthorTypeRepository.CreateExternalAuthorType(ctx,
 			externalAuthorType)
 		if err != nil {
 			log.Error("externalAuthorTypeRepository.CreateExternalAuthorType(): ", err.Error())
@@ -93,7 +94,7 @@ func (instance ExternalAuthor) getExternalAuthorFromDatabase(
 		return nil, err
 	}
 
-	externalAuthorId, err := instance.externalAuthorRepository.CreateExternalAuthor(*externalAuthorDomain)
+	externalAuthorId, err := instance.externalAuthorRepository.CreateExternalAuthor(ctx, *externalAuthorDomain)
 	if err != nil {
 		log.Error("externalAuthorRepository.CreateExternalAuthor(): ", err.Error())
 		return nil, err
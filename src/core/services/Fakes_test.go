@@ -0,0 +1,958 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devlucassantos/vnc-domains/src/domains/articletype"
+	"github.com/devlucassantos/vnc-domains/src/domains/eventsituation"
+	"github.com/devlucassantos/vnc-domains/src/domains/eventtype"
+	"github.com/devlucassantos/vnc-domains/src/domains/propositiontype"
+	"github.com/google/uuid"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+	"vnc-summarizer/adapters/databases/memory"
+	"vnc-summarizer/core/domains/articleimage"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/core/domains/imagevariant"
+	"vnc-summarizer/core/domains/prompt"
+	"vnc-summarizer/core/interfaces/chamber"
+	"vnc-summarizer/core/interfaces/imagegeneration"
+	"vnc-summarizer/utils/datetime"
+)
+
+// Codes of the data registered in the fake of the Chamber of Deputies API by default
+const (
+	fakeDeputyCode          = 204379
+	fakePartyAcronym        = "PT"
+	fakeLegislativeBodyCode = 180
+	fakeEventTypeName       = "Reunião Deliberativa"
+	fakeOpenSituationName   = "Em Andamento"
+	fakeClosedSituationName = "Encerrada"
+	fakeSubmissionDateTime  = "2025-03-10T16:12"
+)
+
+// fakeOperations counts the calls of the operations of a fake and makes them fail the number of times configured
+type fakeOperations struct {
+	mutex          sync.Mutex
+	numberOfCalls  map[string]int
+	failures       map[string]error
+	numberOfErrors map[string]int
+	isInitialized  bool
+}
+
+// failOperation makes the next calls of the operation return the error. A negative number of times makes the
+// operation fail in all the next calls.
+func (instance *fakeOperations) failOperation(operation string, err error, numberOfTimes int) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	instance.initialize()
+	instance.failures[operation] = err
+	instance.numberOfErrors[operation] = numberOfTimes
+}
+
+func (instance *fakeOperations) getNumberOfCalls(operation string) int {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	instance.initialize()
+	return instance.numberOfCalls[operation]
+}
+
+// call registers the call of the operation and returns the error configured for it. The goroutine yields after the
+// call, so concurrent workers interleave as they would while waiting for the response of a real service.
+func (instance *fakeOperations) call(operation string) error {
+	defer runtime.Gosched()
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	instance.initialize()
+	instance.numberOfCalls[operation]++
+
+	numberOfErrors := instance.numberOfErrors[operation]
+	if numberOfErrors == 0 {
+		return nil
+	} else if numberOfErrors > 0 {
+		instance.numberOfErrors[operation]--
+	}
+
+	return instance.failures[operation]
+}
+
+func (instance *fakeOperations) initialize() {
+	if instance.isInitialized {
+		return
+	}
+
+	instance.numberOfCalls = map[string]int{}
+	instance.failures = map[string]error{}
+	instance.numberOfErrors = map[string]int{}
+	instance.isInitialized = true
+}
+
+// fakeChamberApi is a fake of the Chamber of Deputies API that returns the data registered in its fields. The most
+// recent items are the ones listed in the fields of the most recent codes, while the searches by date range return all
+// the items registered.
+type fakeChamberApi struct {
+	fakeOperations
+	dataMutex                  sync.Mutex
+	propositions               map[int]chamber.Proposition
+	propositionAuthors         map[int][]chamber.Author
+	propositionTypes           []chamber.PropositionType
+	deputies                   map[int]chamber.Deputy
+	parties                    map[string]chamber.Party
+	legislativeBodies          map[int]chamber.LegislativeBody
+	legislativeBodyTypes       []chamber.LegislativeBodyType
+	votes                      map[string]chamber.Voting
+	events                     map[int]chamber.Event
+	eventAgendaItems           map[int][]chamber.EventAgendaItem
+	eventTypes                 []chamber.EventType
+	eventSituations            []chamber.EventSituation
+	mostRecentPropositionCodes []int
+	mostRecentVotingCodes      []string
+	mostRecentEventCodes       []int
+}
+
+func newFakeChamberApi() *fakeChamberApi {
+	return &fakeChamberApi{
+		propositions:       map[int]chamber.Proposition{},
+		propositionAuthors: map[int][]chamber.Author{},
+		propositionTypes: []chamber.PropositionType{
+			{Code: "139", Name: "Projeto de Lei"},
+			{Code: "390", Name: "Requerimento"},
+		},
+		deputies: map[int]chamber.Deputy{
+			fakeDeputyCode: {
+				Id:        fakeDeputyCode,
+				Cpf:       "12345678900",
+				CivilName: "MARIA DA SILVA",
+				LastStatus: chamber.DeputyStatus{
+					ElectoralName: "MARIA SILVA",
+					PartyAcronym:  fakePartyAcronym,
+					FederatedUnit: "SP",
+					ImageUrl:      "https://www.camara.leg.br/internet/deputado/bandep/204379.jpg",
+				},
+			},
+		},
+		parties: map[string]chamber.Party{
+			fakePartyAcronym: {
+				Id:       36844,
+				Name:     "Partido dos Trabalhadores",
+				Acronym:  fakePartyAcronym,
+				ImageUrl: "https://www.camara.leg.br/internet/Deputado/img/partidos/PT.gif",
+			},
+		},
+		legislativeBodies: map[int]chamber.LegislativeBody{
+			fakeLegislativeBodyCode: {
+				Id:       fakeLegislativeBodyCode,
+				TypeCode: 26,
+				Name:     "Plenário",
+				Acronym:  "PLEN",
+			},
+		},
+		legislativeBodyTypes: []chamber.LegislativeBodyType{
+			{Code: "26", Name: "Plenário Virtual"},
+		},
+		votes:            map[string]chamber.Voting{},
+		events:           map[int]chamber.Event{},
+		eventAgendaItems: map[int][]chamber.EventAgendaItem{},
+		eventTypes: []chamber.EventType{
+			{Code: "110", Name: fakeEventTypeName},
+		},
+		eventSituations: []chamber.EventSituation{
+			{Code: "2", Name: fakeOpenSituationName},
+			{Code: "3", Name: fakeClosedSituationName},
+		},
+	}
+}
+
+// addProposition registers a bill in the fake, written by a deputy and by an external author
+func (instance *fakeChamberApi) addProposition(code int) {
+	originalTextUrl := fmt.Sprint("https://www.camara.leg.br/proposicoesWeb/prop_mostrarintegra?codteor=", code)
+	instance.setProposition(chamber.Proposition{
+		Id:              code,
+		TypeCode:        139,
+		TypeAcronym:     "PL",
+		SubmittedAt:     fakeSubmissionDateTime,
+		OriginalTextUrl: &originalTextUrl,
+	})
+}
+
+func (instance *fakeChamberApi) setProposition(propositionData chamber.Proposition) {
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	deputyUri := fmt.Sprint("https://dadosabertos.camara.leg.br/api/v2/deputados/", fakeDeputyCode)
+	instance.propositions[propositionData.Id] = propositionData
+	instance.propositionAuthors[propositionData.Id] = []chamber.Author{
+		{
+			Uri:            &deputyUri,
+			Name:           "Maria Silva",
+			Type:           "Deputado(a)",
+			TypeCode:       chamber.DeputyAuthorTypeCode,
+			SignatureOrder: 1,
+		},
+		{
+			Name:           "SENADO FEDERAL",
+			Type:           "ÓRGÃO DO PODER LEGISLATIVO",
+			TypeCode:       40000,
+			SignatureOrder: 2,
+		},
+	}
+}
+
+// addVoting registers a voting of the plenary in the fake whose main proposition is the informed proposition
+func (instance *fakeChamberApi) addVoting(code string, mainPropositionCode int) {
+	approval := 1
+	mainPropositionUri := fmt.Sprint("https://dadosabertos.camara.leg.br/api/v2/proposicoes/", mainPropositionCode)
+	instance.setVoting(chamber.Voting{
+		Id:                  code,
+		Date:                "2025-03-11",
+		Result:              "Aprovado o Projeto de Lei.",
+		Approval:            &approval,
+		LegislativeBodyCode: fakeLegislativeBodyCode,
+		LastPresentation:    &chamber.PropositionPresentation{CitedPropositionUri: &mainPropositionUri},
+	})
+}
+
+func (instance *fakeChamberApi) setVoting(votingData chamber.Voting) {
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	instance.votes[votingData.Id] = votingData
+}
+
+// addEvent registers an event in the fake whose requirements are the informed propositions
+func (instance *fakeChamberApi) addEvent(code int, startsAt time.Time, situation string,
+	requirementCodes ...int) {
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	var requirements []chamber.PropositionReference
+	for _, requirementCode := range requirementCodes {
+		requirements = append(requirements, chamber.PropositionReference{
+			Id:  requirementCode,
+			Uri: fmt.Sprint("https://dadosabertos.camara.leg.br/api/v2/proposicoes/", requirementCode),
+		})
+	}
+
+	locationName := "Anexo II, Plenário 01"
+	instance.events[code] = chamber.Event{
+		Id:                code,
+		StartsAt:          startsAt.Format(chamber.EventDateTimeLayout),
+		Description:       "Discussão e votação de requerimentos",
+		TypeDescription:   fakeEventTypeName,
+		Situation:         situation,
+		LocationInChamber: &chamber.EventLocation{Name: &locationName},
+		LegislativeBodies: []chamber.LegislativeBodyReference{{Id: fakeLegislativeBodyCode}},
+		Requirements:      requirements,
+	}
+}
+
+func (instance *fakeChamberApi) setEventAgendaItems(eventCode int, agendaItems []chamber.EventAgendaItem) {
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	instance.eventAgendaItems[eventCode] = agendaItems
+}
+
+func (instance *fakeChamberApi) setEventSituation(code int, situation string) {
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	eventData := instance.events[code]
+	eventData.Situation = situation
+	instance.events[code] = eventData
+}
+
+func (instance *fakeChamberApi) GetMostRecentPropositions(_ context.Context) ([]chamber.Proposition, error) {
+	err := instance.call("GetMostRecentPropositions")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	var propositions []chamber.Proposition
+	for _, code := range instance.mostRecentPropositionCodes {
+		propositions = append(propositions, chamber.Proposition{Id: code})
+	}
+
+	return propositions, nil
+}
+
+func (instance *fakeChamberApi) GetPropositionsByDateRange(_ context.Context, _, _ time.Time) (
+	[]chamber.Proposition, error) {
+	err := instance.call("GetPropositionsByDateRange")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	var propositions []chamber.Proposition
+	for _, code := range getSortedKeys(instance.propositions) {
+		propositions = append(propositions, chamber.Proposition{Id: code})
+	}
+
+	return propositions, nil
+}
+
+func (instance *fakeChamberApi) GetPropositionByCode(_ context.Context, code int) (*chamber.Proposition, error) {
+	err := instance.call("GetPropositionByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	propositionData, exists := instance.propositions[code]
+	if !exists {
+		return nil, errors.New(fmt.Sprintf("Proposition %d not found", code))
+	}
+
+	return &propositionData, nil
+}
+
+func (instance *fakeChamberApi) GetPropositionAuthors(_ context.Context, propositionCode int) ([]chamber.Author,
+	error) {
+	err := instance.call("GetPropositionAuthors")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	return slices.Clone(instance.propositionAuthors[propositionCode]), nil
+}
+
+func (instance *fakeChamberApi) GetPropositionContentDirectly(_ context.Context, propositionUrl string) (string,
+	string, error) {
+	err := instance.call("GetPropositionContentDirectly")
+	if err != nil {
+		return "", "", err
+	}
+
+	return fmt.Sprint(propositionUrl, "&formato=html"), "Conteúdo em HTML da proposição", nil
+}
+
+func (instance *fakeChamberApi) GetPropositionTypes(_ context.Context) ([]chamber.PropositionType, error) {
+	err := instance.call("GetPropositionTypes")
+	if err != nil {
+		return nil, err
+	}
+
+	return instance.propositionTypes, nil
+}
+
+func (instance *fakeChamberApi) GetDeputyByCode(_ context.Context, code int) (*chamber.Deputy, error) {
+	err := instance.call("GetDeputyByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	deputyData, exists := instance.deputies[code]
+	if !exists {
+		return nil, errors.New(fmt.Sprintf("Deputy %d not found", code))
+	}
+
+	return &deputyData, nil
+}
+
+func (instance *fakeChamberApi) GetPartyByAcronym(_ context.Context, acronym string) (*chamber.Party, error) {
+	err := instance.call("GetPartyByAcronym")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	partyData, exists := instance.parties[acronym]
+	if !exists {
+		return nil, errors.New(fmt.Sprintf("Party %s not found", acronym))
+	}
+
+	return &partyData, nil
+}
+
+func (instance *fakeChamberApi) GetLegislativeBodyByCode(_ context.Context, code int) (*chamber.LegislativeBody,
+	error) {
+	err := instance.call("GetLegislativeBodyByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	legislativeBodyData, exists := instance.legislativeBodies[code]
+	if !exists {
+		return nil, errors.New(fmt.Sprintf("Legislative body %d not found", code))
+	}
+
+	return &legislativeBodyData, nil
+}
+
+func (instance *fakeChamberApi) GetLegislativeBodyTypes(_ context.Context) ([]chamber.LegislativeBodyType, error) {
+	err := instance.call("GetLegislativeBodyTypes")
+	if err != nil {
+		return nil, err
+	}
+
+	return instance.legislativeBodyTypes, nil
+}
+
+func (instance *fakeChamberApi) GetMostRecentVotes(_ context.Context) ([]chamber.Voting, error) {
+	err := instance.call("GetMostRecentVotes")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	var votes []chamber.Voting
+	for _, code := range instance.mostRecentVotingCodes {
+		votes = append(votes, chamber.Voting{Id: code})
+	}
+
+	return votes, nil
+}
+
+func (instance *fakeChamberApi) GetVotesByDateRange(_ context.Context, _, _ time.Time) ([]chamber.Voting, error) {
+	err := instance.call("GetVotesByDateRange")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	var votes []chamber.Voting
+	for _, code := range getSortedKeys(instance.votes) {
+		votes = append(votes, chamber.Voting{Id: code})
+	}
+
+	return votes, nil
+}
+
+func (instance *fakeChamberApi) GetVotingByCode(_ context.Context, code string) (*chamber.Voting, error) {
+	err := instance.call("GetVotingByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	votingData, exists := instance.votes[code]
+	if !exists {
+		return nil, errors.New(fmt.Sprintf("Voting %s not found", code))
+	}
+
+	return &votingData, nil
+}
+
+func (instance *fakeChamberApi) GetMostRecentEvents(_ context.Context) ([]chamber.Event, error) {
+	err := instance.call("GetMostRecentEvents")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	var events []chamber.Event
+	for _, code := range instance.mostRecentEventCodes {
+		events = append(events, chamber.Event{Id: code})
+	}
+
+	return events, nil
+}
+
+func (instance *fakeChamberApi) GetEventsByDateRange(_ context.Context, _, _ time.Time) ([]chamber.Event, error) {
+	err := instance.call("GetEventsByDateRange")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	var events []chamber.Event
+	for _, code := range getSortedKeys(instance.events) {
+		events = append(events, chamber.Event{Id: code})
+	}
+
+	return events, nil
+}
+
+func (instance *fakeChamberApi) GetEventByCode(_ context.Context, code int) (*chamber.Event, error) {
+	err := instance.call("GetEventByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	eventData, exists := instance.events[code]
+	if !exists {
+		return nil, errors.New(fmt.Sprintf("Event %d not found", code))
+	}
+
+	return &eventData, nil
+}
+
+func (instance *fakeChamberApi) GetEventsByCodes(_ context.Context, eventCodes []string) ([]chamber.Event, error) {
+	err := instance.call("GetEventsByCodes")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	var events []chamber.Event
+	for _, code := range getSortedKeys(instance.events) {
+		if slices.Contains(eventCodes, fmt.Sprint(code)) {
+			events = append(events, instance.events[code])
+		}
+	}
+
+	return events, nil
+}
+
+func (instance *fakeChamberApi) GetEventAgendaItems(_ context.Context, eventCode int) ([]chamber.EventAgendaItem,
+	error) {
+	err := instance.call("GetEventAgendaItems")
+	if err != nil {
+		return nil, err
+	}
+
+	instance.dataMutex.Lock()
+	defer instance.dataMutex.Unlock()
+
+	return slices.Clone(instance.eventAgendaItems[eventCode]), nil
+}
+
+func (instance *fakeChamberApi) GetEventTypes(_ context.Context) ([]chamber.EventType, error) {
+	err := instance.call("GetEventTypes")
+	if err != nil {
+		return nil, err
+	}
+
+	return instance.eventTypes, nil
+}
+
+func (instance *fakeChamberApi) GetEventSituations(_ context.Context) ([]chamber.EventSituation, error) {
+	err := instance.call("GetEventSituations")
+	if err != nil {
+		return nil, err
+	}
+
+	return instance.eventSituations, nil
+}
+
+// fakeLlm is a fake of the language models that answers the structured requests with the properties of the schema
+// and the requests to the vision model with the image reviews queued in the fake, approving the image when there is
+// no review queued
+type fakeLlm struct {
+	fakeOperations
+	costPerRequest float64
+	imageReviews   []map[string]interface{}
+}
+
+func (instance *fakeLlm) MakeRequest(_ context.Context, _, _, purpose string) (string,
+	*generationusage.GenerationUsage, error) {
+	err := instance.call("MakeRequest")
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprint("Resposta: ", purpose), instance.getUsage(), nil
+}
+
+func (instance *fakeLlm) MakeRequestUsingMapReduce(_ context.Context, _, _, purpose string) (string,
+	*generationusage.GenerationUsage, error) {
+	err := instance.call("MakeRequestUsingMapReduce")
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprint("Resposta: ", purpose), instance.getUsage(), nil
+}
+
+func (instance *fakeLlm) MakeStructuredRequest(_ context.Context, _, _, _ string,
+	schema map[string]interface{}) (map[string]interface{}, *generationusage.GenerationUsage, error) {
+	err := instance.call("MakeStructuredRequest")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := map[string]interface{}{}
+	properties, _ := schema["properties"].(map[string]interface{})
+	for property, propertySchema := range properties {
+		switch propertySchema.(map[string]interface{})["type"] {
+		case "array":
+			response[property] = []interface{}{fmt.Sprint("Item de ", property)}
+		case "boolean":
+			response[property] = false
+		case "integer":
+			response[property] = 10
+		default:
+			response[property] = fmt.Sprint("Texto de ", property)
+		}
+	}
+
+	return response, instance.getUsage(), nil
+}
+
+func (instance *fakeLlm) MakeRequestToVision(_ context.Context, _, imageUrl string) (string,
+	*generationusage.GenerationUsage, error) {
+	err := instance.call("MakeRequestToVision")
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprint("Descrição da imagem ", imageUrl), instance.getUsage(), nil
+}
+
+func (instance *fakeLlm) MakeStructuredRequestToVision(_ context.Context, _, _, _, _ string,
+	_ map[string]interface{}) (map[string]interface{}, *generationusage.GenerationUsage, error) {
+	err := instance.call("MakeStructuredRequestToVision")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	imageReview := getImageReviewResponse(true)
+	if len(instance.imageReviews) > 0 {
+		imageReview = instance.imageReviews[0]
+		instance.imageReviews = instance.imageReviews[1:]
+	}
+
+	return imageReview, instance.getUsage(), nil
+}
+
+func (instance *fakeLlm) getUsage() *generationusage.GenerationUsage {
+	usage, _ := generationusage.NewBuilder().
+		Provider("OpenAI").
+		Model("fake-model").
+		PromptTokens(100).
+		CompletionTokens(50).
+		EstimatedCost(instance.costPerRequest).
+		Build()
+	return usage
+}
+
+// getImageReviewResponse returns the answer of the vision model to the review of an image that is approved or that
+// contains text
+func getImageReviewResponse(approved bool) map[string]interface{} {
+	return map[string]interface{}{
+		"contains_text":                !approved,
+		"contains_politician_likeness": false,
+		"contains_party_logo":          false,
+		"contains_unsafe_content":      false,
+		"relevance_score":              10,
+		"justification":                "Revisão da imagem",
+	}
+}
+
+type fakePromptRegistry struct {
+	fakeOperations
+}
+
+func (instance *fakePromptRegistry) GetPrompt(code, variant string, _ prompt.Variables) (*prompt.Prompt, error) {
+	err := instance.call("GetPrompt")
+	if err != nil {
+		return nil, err
+	}
+
+	return prompt.NewBuilder().Code(code).Variant(variant).Version(1).Text(fmt.Sprint("Prompt ", code)).Build()
+}
+
+// fakeImageGenerator is a fake of the image generators whose usages are registered with the provider of the fake,
+// which is imagegeneration.PlaceholderProvider for the fake of the placeholder images
+type fakeImageGenerator struct {
+	fakeOperations
+	provider string
+}
+
+func (instance *fakeImageGenerator) GenerateImage(_ context.Context, _, _, _ string) ([]byte,
+	*generationusage.GenerationUsage, error) {
+	err := instance.call("GenerateImage")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var estimatedCost float64
+	if instance.provider != imagegeneration.PlaceholderProvider {
+		estimatedCost = 0.04
+	}
+
+	usage, err := generationusage.NewBuilder().
+		Provider(instance.provider).
+		Model("fake-image-model").
+		NumberOfImages(1).
+		EstimatedCost(estimatedCost).
+		Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []byte(fmt.Sprint("Imagem de ", instance.provider)), usage, nil
+}
+
+type fakePdfContentExtractor struct {
+	fakeOperations
+}
+
+func (instance *fakePdfContentExtractor) MakeRequest(_ context.Context, pdfUrl string) (string, error) {
+	err := instance.call("MakeRequest")
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprint("Conteúdo do PDF ", pdfUrl), nil
+}
+
+type fakeImageProcessor struct {
+	fakeOperations
+}
+
+func (instance *fakeImageProcessor) ProcessImage(_ context.Context, image []byte) (*articleimage.ArticleImage,
+	error) {
+	err := instance.call("ProcessImage")
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := imagevariant.NewBuilder().Kind("card").Format("webp").Width(640).Height(360).Content(image).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return articleimage.NewBuilder().BlurHash("LEHV6nWB2yk8pyo0adR*.7kCMdnj").DominantColor("#336699").
+		Variants(*variant).Build()
+}
+
+// fakeObjectStorage is a fake of the object storage that keeps the keys of the objects saved
+type fakeObjectStorage struct {
+	fakeOperations
+	keys []string
+}
+
+func (instance *fakeObjectStorage) SaveObject(_ context.Context, key string, _ []byte) (string, error) {
+	err := instance.call("SaveObject")
+	if err != nil {
+		return "", err
+	}
+
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	instance.keys = append(instance.keys, key)
+	return fmt.Sprint("https://storage.vnc.test/", key), nil
+}
+
+// testEnvironment is the set of services used by the tests, which share the fakes and the in-memory database
+type testEnvironment struct {
+	database                      *memory.Database
+	chamberApi                    *fakeChamberApi
+	llmApi                        *fakeLlm
+	promptRegistry                *fakePromptRegistry
+	imageGenerator                *fakeImageGenerator
+	placeholderImageGenerator     *fakeImageGenerator
+	pdfContentExtractor           *fakePdfContentExtractor
+	imageProcessor                *fakeImageProcessor
+	objectStorage                 *fakeObjectStorage
+	processingLedgerService       *ProcessingLedger
+	deputyService                 *Deputy
+	legislativeBodyService        *LegislativeBody
+	propositionService            *Proposition
+	votingService                 *Voting
+	eventService                  *Event
+	newsletterService             *Newsletter
+	propositionRepository         *memory.Proposition
+	votingRepository              *memory.Voting
+	eventRepository               *memory.Event
+	newsletterRepository          *memory.Newsletter
+	articleRepository             *memory.Article
+	deputyRepository              *memory.Deputy
+	partyRepository               *memory.Party
+	legislativeBodyRepository     *memory.LegislativeBody
+	legislativeBodyTypeRepository *memory.LegislativeBodyType
+}
+
+// newTestEnvironment creates the services with the fakes and the in-memory repositories. The settings read from the
+// environment are cleared, the workers are sequential and the waiting time between the attempts is shortened.
+func newTestEnvironment(t *testing.T) *testEnvironment {
+	t.Helper()
+
+	for _, environmentVariable := range []string{"ECONOMY_MODE_ACTIVE", "DAILY_BUDGET_CEILING",
+		"IMAGE_REVIEW_ACTIVE", "IMAGE_REVIEW_MAXIMUM_ATTEMPTS", "IMAGE_REVIEW_MINIMUM_RELEVANCE",
+		"PROCESSING_ITEM_MAXIMUM_ATTEMPTS", "ITEM_PROCESSING_TIMEOUT", "PROPOSITION_REGISTRATION_CONCURRENCY",
+		"VOTING_REGISTRATION_CONCURRENCY", "EVENT_REGISTRATION_CONCURRENCY"} {
+		t.Setenv(environmentVariable, "")
+	}
+
+	defaultRetryWaitingTimeUnit := retryWaitingTimeUnit
+	retryWaitingTimeUnit = time.Millisecond
+	t.Cleanup(func() {
+		retryWaitingTimeUnit = defaultRetryWaitingTimeUnit
+	})
+
+	database := memory.NewDatabase()
+	environment := &testEnvironment{
+		database:                      database,
+		chamberApi:                    newFakeChamberApi(),
+		llmApi:                        &fakeLlm{costPerRequest: 0.01},
+		promptRegistry:                &fakePromptRegistry{},
+		imageGenerator:                &fakeImageGenerator{provider: "OpenAI"},
+		placeholderImageGenerator:     &fakeImageGenerator{provider: imagegeneration.PlaceholderProvider},
+		pdfContentExtractor:           &fakePdfContentExtractor{},
+		imageProcessor:                &fakeImageProcessor{},
+		objectStorage:                 &fakeObjectStorage{},
+		propositionRepository:         memory.NewPropositionRepository(database),
+		votingRepository:              memory.NewVotingRepository(database),
+		eventRepository:               memory.NewEventRepository(database),
+		newsletterRepository:          memory.NewNewsletterRepository(database),
+		articleRepository:             memory.NewArticleRepository(database),
+		deputyRepository:              memory.NewDeputyRepository(database),
+		partyRepository:               memory.NewPartyRepository(database),
+		legislativeBodyRepository:     memory.NewLegislativeBodyRepository(database),
+		legislativeBodyTypeRepository: memory.NewLegislativeBodyTypeRepository(database),
+	}
+
+	articleTypeRepository := memory.NewArticleTypeRepository(database,
+		newArticleType(t, "proposition", "Proposição"),
+		newArticleType(t, "voting", "Votação"),
+		newArticleType(t, "event", "Evento"),
+		newArticleType(t, "newsletter", "Boletim"))
+	propositionTypeRepository := memory.NewPropositionTypeRepository(database,
+		newPropositionType(t, "139,140", "Projeto de Lei"),
+		newPropositionType(t, "default_option", "Outras Proposições"))
+	eventTypeRepository := memory.NewEventTypeRepository(database,
+		newEventType(t, "110", "Reunião Deliberativa"),
+		newEventType(t, "default_option", "Outros Eventos"))
+	eventSituationRepository := memory.NewEventSituationRepository(database,
+		newEventSituation(t, "2", "Em Andamento", false),
+		newEventSituation(t, "3", "Encerrada", true),
+		newEventSituation(t, "default_option", "Outras Situações", false))
+
+	environment.processingLedgerService = NewProcessingLedgerService(memory.NewProcessingRunRepository(database),
+		memory.NewProcessingItemRepository(database))
+	environment.deputyService = NewDeputyService(environment.chamberApi, environment.deputyRepository,
+		environment.partyRepository)
+	environment.legislativeBodyService = NewLegislativeBodyService(environment.chamberApi,
+		environment.legislativeBodyRepository, environment.legislativeBodyTypeRepository)
+	externalAuthorService := NewExternalAuthorService(memory.NewExternalAuthorRepository(database),
+		memory.NewExternalAuthorTypeRepository(database))
+	authorService := NewAuthorService(environment.chamberApi, environment.deputyService, externalAuthorService)
+	environment.propositionService = NewPropositionService(authorService, environment.chamberApi,
+		environment.llmApi, environment.promptRegistry, environment.imageGenerator,
+		environment.placeholderImageGenerator, environment.pdfContentExtractor, environment.imageProcessor,
+		environment.objectStorage, NewImageLibraryService(nil, memory.NewLibraryImageRepository(database)),
+		NewImageReviewService(environment.llmApi, environment.promptRegistry),
+		NewBudgetService(memory.NewGenerationUsageRepository(database)), environment.processingLedgerService,
+		environment.propositionRepository, propositionTypeRepository, articleTypeRepository)
+	environment.votingService = NewVotingService(environment.chamberApi, environment.llmApi,
+		environment.promptRegistry, environment.votingRepository, articleTypeRepository,
+		memory.NewUnitOfWork(database), environment.legislativeBodyService, environment.propositionService,
+		environment.processingLedgerService)
+	environment.eventService = NewEventService(environment.deputyService, environment.legislativeBodyService,
+		environment.propositionService, environment.votingService, environment.processingLedgerService,
+		environment.chamberApi, environment.llmApi, environment.promptRegistry, environment.eventRepository,
+		articleTypeRepository, eventTypeRepository, eventSituationRepository,
+		memory.NewAgendaItemRegimeRepository(database))
+	environment.newsletterService = NewNewsletterService(environment.llmApi, environment.promptRegistry,
+		environment.newsletterRepository, articleTypeRepository, environment.articleRepository)
+
+	return environment
+}
+
+func newArticleType(t *testing.T, codes, description string) articletype.ArticleType {
+	t.Helper()
+
+	articleType, err := articletype.NewBuilder().Id(uuid.New()).Codes(codes).Description(description).
+		Color("#0000FF").Build()
+	if err != nil {
+		t.Fatalf("articletype.NewBuilder(): %s", err.Error())
+	}
+
+	return *articleType
+}
+
+func newPropositionType(t *testing.T, codes, description string) propositiontype.PropositionType {
+	t.Helper()
+
+	propositionType, err := propositiontype.NewBuilder().Id(uuid.New()).Codes(codes).Description(description).
+		Color("#00FF00").Build()
+	if err != nil {
+		t.Fatalf("propositiontype.NewBuilder(): %s", err.Error())
+	}
+
+	return *propositionType
+}
+
+func newEventType(t *testing.T, codes, description string) eventtype.EventType {
+	t.Helper()
+
+	eventType, err := eventtype.NewBuilder().Id(uuid.New()).Codes(codes).Description(description).
+		Color("#FF0000").Build()
+	if err != nil {
+		t.Fatalf("eventtype.NewBuilder(): %s", err.Error())
+	}
+
+	return *eventType
+}
+
+func newEventSituation(t *testing.T, codes, description string, isFinished bool) eventsituation.EventSituation {
+	t.Helper()
+
+	eventSituation, err := eventsituation.NewBuilder().Id(uuid.New()).Codes(codes).Description(description).
+		Color("#FFFF00").IsFinished(isFinished).Build()
+	if err != nil {
+		t.Fatalf("eventsituation.NewBuilder(): %s", err.Error())
+	}
+
+	return *eventSituation
+}
+
+// getCurrentDateTime returns the current date and time in Brazil without the seconds, as the dates and times are
+// returned by the Chamber of Deputies API
+func getCurrentDateTime(t *testing.T) time.Time {
+	t.Helper()
+
+	currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+	if err != nil {
+		t.Fatalf("datetime.GetCurrentDateTimeInBrazil(): %s", err.Error())
+	}
+
+	return currentDateTime.Truncate(time.Minute)
+}
+
+func getSortedKeys[K int | string, V any](items map[K]V) []K {
+	var keys []K
+	for key := range items {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"github.com/labstack/gommon/log"
+	"math"
+	"os"
+	"strconv"
+	"vnc-summarizer/core/domains/articleimage"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/core/domains/libraryimage"
+	"vnc-summarizer/core/interfaces/embeddings"
+	"vnc-summarizer/core/interfaces/postgres"
+)
+
+const defaultImageLibrarySimilarityThreshold = 0.85
+
+type ImageLibrary struct {
+	embeddingApi           embeddings.Embedding
+	libraryImageRepository postgres.LibraryImage
+}
+
+// NewImageLibraryService creates the service of the library of images, which is disabled when there is no embedding
+// provider configured in EMBEDDING_PROVIDER
+func NewImageLibraryService(embeddingApi embeddings.Embedding,
+	libraryImageRepository postgres.LibraryImage) *ImageLibrary {
+	return &ImageLibrary{
+		embeddingApi:           embeddingApi,
+		libraryImageRepository: libraryImageRepository,
+	}
+}
+
+// GetSimilarImage searches the active images of the library that share at least one subject tag with the article and
+// returns the one whose description is the most similar to the prompt of the image, as long as the similarity reaches
+// the threshold configured in IMAGE_LIBRARY_SIMILARITY_THRESHOLD. When no image is similar enough, the returned image
+// is nil and the generation usage refers to the embedding of the prompt.
+func (instance ImageLibrary) GetSimilarImage(ctx context.Context, imagePrompt string, subjectTags []string) (
+	*articleimage.ArticleImage, *generationusage.GenerationUsage, error) {
+	if instance.embeddingApi == nil || len(subjectTags) == 0 {
+		return nil, nil, nil
+	}
+
+	promptEmbedding, embeddingUsage, err := instance.embeddingApi.MakeRequest(ctx, imagePrompt,
+		"Embedding of the image prompt")
+	if err != nil {
+		log.Error("embeddingApi.MakeRequest(): ", err.Error())
+		return nil, nil, err
+	}
+
+	libraryImages, err := instance.libraryImageRepository.GetLibraryImagesBySubjectTags(ctx, subjectTags,
+		embeddingUsage.Model())
+	if err != nil {
+		log.Error("libraryImageRepository.GetLibraryImagesBySubjectTags(): ", err.Error())
+		return nil, embeddingUsage, err
+	}
+
+	var mostSimilarImage *libraryimage.LibraryImage
+	highestSimilarity := getImageLibrarySimilarityThreshold()
+	for index, libraryImage := range libraryImages {
+		similarity := getCosineSimilarity(promptEmbedding, libraryImage.Embedding())
+		if similarity >= highestSimilarity {
+			mostSimilarImage = &libraryImages[index]
+			highestSimilarity = similarity
+		}
+	}
+
+	if mostSimilarImage == nil {
+		log.Info("No image of the library is similar enough to the prompt of the image")
+		return nil, embeddingUsage, nil
+	}
+
+	err = instance.libraryImageRepository.RegisterLibraryImageUse(ctx, mostSimilarImage.Id())
+	if err != nil {
+		log.Warn("libraryImageRepository.RegisterLibraryImageUse(): ", err.Error())
+	}
+
+	log.Infof("Reusing image %s of the library, whose similarity to the prompt of the image is %.3f",
+		mostSimilarImage.Id(), highestSimilarity)
+	image := mostSimilarImage.Image()
+	return &image, embeddingUsage, nil
+}
+
+// AddImage registers a generated image in the library, indexed by the subject tags of the article and by the
+// embedding of its description, so it can be reused by the next articles on the same subject
+func (instance ImageLibrary) AddImage(ctx context.Context, image articleimage.ArticleImage, subjectTags []string) (
+	*generationusage.GenerationUsage, error) {
+	if instance.embeddingApi == nil || len(subjectTags) == 0 {
+		return nil, nil
+	}
+
+	descriptionEmbedding, embeddingUsage, err := instance.embeddingApi.MakeRequest(ctx, image.Description(),
+		"Embedding of the image description")
+	if err != nil {
+		log.Error("embeddingApi.MakeRequest(): ", err.Error())
+		return nil, err
+	}
+
+	libraryImage, err := libraryimage.NewBuilder().
+		Image(image).
+		SubjectTags(subjectTags).
+		Embedding(descriptionEmbedding).
+		EmbeddingModel(embeddingUsage.Model()).
+		Build()
+	if err != nil {
+		log.Error("Error validating data of the library image: ", err.Error())
+		return embeddingUsage, err
+	}
+
+	_, err = instance.libraryImageRepository.CreateLibraryImage(ctx, *libraryImage)
+	if err != nil {
+		log.Error("libraryImageRepository.CreateLibraryImage(): ", err.Error())
+		return embeddingUsage, err
+	}
+
+	return embeddingUsage, nil
+}
+
+func getImageLibrarySimilarityThreshold() float64 {
+	similarityThreshold, err := strconv.ParseFloat(os.Getenv("IMAGE_LIBRARY_SIMILARITY_THRESHOLD"), 64)
+	if err != nil || similarityThreshold <= 0 || similarityThreshold > 1 {
+		return defaultImageLibrarySimilarityThreshold
+	}
+
+	return similarityThreshold
+}
+
+func getCosineSimilarity(firstVector, secondVector []float64) float64 {
+	if len(firstVector) == 0 || len(firstVector) != len(secondVector) {
+		return 0
+	}
+
+	var dotProduct, firstNorm, secondNorm float64
+	for index := range firstVector {
+		dotProduct += firstVector[index] * secondVector[index]
+		firstNorm += firstVector[index] * firstVector[index]
+		secondNorm += secondVector[index] * secondVector[index]
+	}
+
+	if firstNorm == 0 || secondNorm == 0 {
+		return 0
+	}
+
+	return dotProduct / (math.Sqrt(firstNorm) * math.Sqrt(secondNorm))
+}
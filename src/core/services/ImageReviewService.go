@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"os"
+	"strconv"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/core/domains/imagereview"
+	"vnc-summarizer/core/domains/prompt"
+	"vnc-summarizer/core/interfaces/llm"
+	"vnc-summarizer/core/interfaces/prompts"
+	"vnc-summarizer/utils/converters"
+)
+
+const (
+	defaultImageReviewMinimumRelevance = 0.6
+	maximumImageReviewRelevanceScore   = 10
+)
+
+var imageReviewSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"contains_text":                map[string]interface{}{"type": "boolean"},
+		"contains_politician_likeness": map[string]interface{}{"type": "boolean"},
+		"contains_party_logo":          map[string]interface{}{"type": "boolean"},
+		"contains_unsafe_content":      map[string]interface{}{"type": "boolean"},
+		"relevance_score":              map[string]interface{}{"type": "integer"},
+		"justification":                map[string]interface{}{"type": "string"},
+	},
+	"required": []string{"contains_text", "contains_politician_likeness", "contains_party_logo",
+		"contains_unsafe_content", "relevance_score", "justification"},
+	"additionalProperties": false,
+}
+
+type ImageReview struct {
+	llmApi         llm.Llm
+	promptRegistry prompts.Prompt
+}
+
+func NewImageReviewService(llmApi llm.Llm, promptRegistry prompts.Prompt) *ImageReview {
+	return &ImageReview{
+		llmApi:         llmApi,
+		promptRegistry: promptRegistry,
+	}
+}
+
+// ReviewImage asks the vision model whether the image contains rendered text, likenesses of real politicians, party
+// logos or unsafe content and how relevant it is to the content of the article. The image is approved when none of
+// these elements is found and the relevance reaches IMAGE_REVIEW_MINIMUM_RELEVANCE. When the review is disabled in
+// IMAGE_REVIEW_ACTIVE, the returned review is nil.
+func (instance ImageReview) ReviewImage(ctx context.Context, imageUrl, imageProvider, articleContent,
+	promptVariant string, promptVariables prompt.Variables) (*imagereview.ImageReview, *prompt.Prompt,
+	*generationusage.GenerationUsage, error) {
+	if !isImageReviewActive() {
+		return nil, nil, nil, nil
+	}
+
+	imageReviewPrompt, err := instance.promptRegistry.GetPrompt("proposition_image_review", promptVariant,
+		promptVariables)
+	if err != nil {
+		log.Error("promptRegistry.GetPrompt(): ", err.Error())
+		return nil, nil, nil, err
+	}
+
+	purpose := fmt.Sprint("Review of the image available at ", imageUrl)
+	reviewData, reviewUsage, err := instance.llmApi.MakeStructuredRequestToVision(ctx, imageReviewPrompt.Text(),
+		articleContent, imageUrl, purpose, imageReviewSchema)
+	if err != nil {
+		log.Error("llmApi.MakeStructuredRequestToVision(): ", err.Error())
+		return nil, nil, nil, err
+	}
+
+	relevanceScore, err := converters.ToInt(reviewData["relevance_score"])
+	if err != nil {
+		log.Error("converters.ToInt(): ", err.Error())
+		return nil, nil, nil, err
+	}
+	normalizedRelevanceScore := float64(relevanceScore) / maximumImageReviewRelevanceScore
+
+	containsText := reviewData["contains_text"] == true
+	containsPoliticianLikeness := reviewData["contains_politician_likeness"] == true
+	containsPartyLogo := reviewData["contains_party_logo"] == true
+	containsUnsafeContent := reviewData["contains_unsafe_content"] == true
+	approved := !containsText && !containsPoliticianLikeness && !containsPartyLogo && !containsUnsafeContent &&
+		normalizedRelevanceScore >= getImageReviewMinimumRelevance()
+
+	imageReviewData, err := imagereview.NewBuilder().
+		ImageUrl(imageUrl).
+		ImageProvider(imageProvider).
+		ContainsText(containsText).
+		ContainsPoliticianLikeness(containsPoliticianLikeness).
+		ContainsPartyLogo(containsPartyLogo).
+		ContainsUnsafeContent(containsUnsafeContent).
+		RelevanceScore(normalizedRelevanceScore).
+		Justification(fmt.Sprint(reviewData["justification"])).
+		Approved(approved).
+		Build()
+	if err != nil {
+		log.Errorf("Error validating the review data of the image %s: %s", imageUrl, err.Error())
+		return nil, nil, nil, err
+	}
+
+	return imageReviewData, imageReviewPrompt, reviewUsage, nil
+}
+
+func isImageReviewActive() bool {
+	imageReviewActive, err := strconv.ParseBool(os.Getenv("IMAGE_REVIEW_ACTIVE"))
+	if err != nil {
+		return false
+	}
+
+	return imageReviewActive
+}
+
+func getImageReviewMinimumRelevance() float64 {
+	minimumRelevance, err := strconv.ParseFloat(os.Getenv("IMAGE_REVIEW_MINIMUM_RELEVANCE"), 64)
+	if err != nil || minimumRelevance < 0 || minimumRelevance > 1 {
+		return defaultImageReviewMinimumRelevance
+	}
+
+	return minimumRelevance
+}
@@ -1,14 +1,17 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"github.com/devlucassantos/vnc-domains/src/domains/legislativebody"
 	"github.com/devlucassantos/vnc-domains/src/domains/legislativebodytype"
 	"github.com/google/uuid"
 	"github.com/labstack/gommon/log"
+	"strconv"
 	"vnc-summarizer/core/interfaces/chamber"
 	"vnc-summarizer/core/interfaces/postgres"
 	"vnc-summarizer/utils/converters"
+	"vnc-summarizer/utils/locks"
 )
 
 type LegislativeBody struct {
@@ -26,20 +29,29 @@ func NewLegislativeBodyService(chamberApi chamber.Chamber, legislativeBodyReposi
 	}
 }
 
-func (instance LegislativeBody) RegisterNewLegislativeBodyByCode(code int) (*uuid.UUID, error) {
-	legislativeBodyData, err := instance.chamberApi.GetLegislativeBodyByCode(code)
+// RegisterNewLegislativeBodyByCode registers the legislative body unless it is already registered. The registration of
+// the same legislative body is never executed concurrently, so votes and events of the same legislative body registered
+// by different workers reuse the legislative body registered by the first of them.
+func (instance LegislativeBody) RegisterNewLegislativeBodyByCode(ctx context.Context, code int) (*uuid.UUID, error) {
+	defer locks.Lock(fmt.Sprint("legislative_body:", code))()
+
+	registeredLegislativeBody, err := instance.legislativeBodyRepository.GetLegislativeBodyByCode(ctx, code)
 	if err != nil {
-		log.Error("chamberApi.GetLegislativeBodyByCode(): ", err.Error())
+		log.Error("legislativeBodyRepository.GetLegislativeBodyByCode(): ", err.Error())
 		return nil, err
+	} else if registeredLegislativeBody != nil {
+		legislativeBodyId := registeredLegislativeBody.Id()
+		log.Infof("Legislative body %d is already registered", code)
+		return &legislativeBodyId, nil
 	}
 
-	typeCode, err := converters.ToInt(legislativeBodyData["codTipoOrgao"])
+	legislativeBodyData, err := instance.chamberApi.GetLegislativeBodyByCode(ctx, code)
 	if err != nil {
-		log.Error("converters.ToInt(): ", err.Error())
+		log.Error("chamberApi.GetLegislativeBodyByCode(): ", err.Error())
 		return nil, err
 	}
 
-	legislativeBodyType, err := instance.getLegislativeBodyTypeDataByCode(typeCode)
+	legislativeBodyType, err := instance.getLegislativeBodyTypeDataByCode(ctx, legislativeBodyData.TypeCode)
 	if err != nil {
 		log.Error("getLegislativeBodyTypeDataByCode(): ", err.Error())
 		return nil, err
@@ -47,8 +59,8 @@ func (instance LegislativeBody) RegisterNewLegislativeBodyByCode(code int) (*uui
 
 	legislativeBody, err := legislativebody.NewBuilder().
 		Code(code).
-		Name(fmt.Sprint(legislativeBodyData["nome"])).
-		Acronym(fmt.Sprint(legislativeBodyData["sigla"])).
+		Name(legislativeBodyData.Name).
+		Acronym(legislativeBodyData.Acronym).
 		Type(*legislativeBodyType).
 		Build()
 	if err != nil {
@@ -56,7 +68,7 @@ func (instance LegislativeBody) RegisterNewLegislativeBodyByCode(code int) (*uui
 		return nil, err
 	}
 
-	legislativeBodyId, err := instance.legislativeBodyRepository.CreateLegislativeBody(*legislativeBody)
+	legislativeBodyId, err := instance.legislativeBodyRepository.CreateLegislativeBody(ctx, *legislativeBody)
 	if err != nil {
 		log.Error("legislativeBodyRepository.CreateLegislativeBody(): ", err.Error())
 		return nil, err
@@ -65,31 +77,31 @@ func (instance LegislativeBody) RegisterNewLegislativeBodyByCode(code int) (*uui
 	return legislativeBodyId, nil
 }
 
-func (instance LegislativeBody) getLegislativeBodyTypeDataByCode(code int) (*legislativebodytype.LegislativeBodyType,
-	error) {
-	legislativeBodyType, err := instance.legislativeBodyTypeRepository.GetLegislativeBodyTypeByCode(code)
+func (instance LegislativeBody) getLegislativeBodyTypeDataByCode(ctx context.Context, code int) (
+	*legislativebodytype.LegislativeBodyType, error) {
+	defer locks.Lock(fmt.Sprint("legislative_body_type:", code))()
+
+	legislativeBodyType, err := instance.legislativeBodyTypeRepository.GetLegislativeBodyTypeByCode(ctx, code)
 	if err != nil {
 		log.Error("legislativeBodyTypeRepository.GetLegislativeBodyTypeByCode(): ", err.Error())
 		return nil, err
 	}
 
 	if legislativeBodyType == nil {
-		legislativeBodyTypes, err := instance.chamberApi.GetLegislativeBodyTypes()
+		legislativeBodyTypes, err := instance.chamberApi.GetLegislativeBodyTypes(ctx)
 		if err != nil {
 			log.Error("chamberApi.GetLegislativeBodyTypes(): ", err.Error())
 			return nil, err
 		}
 
-		var legislativeBodyTypeData map[string]interface{}
-		for _, legislativeBodyTypeMap := range legislativeBodyTypes {
-			if fmt.Sprint(legislativeBodyTypeMap["cod"]) == fmt.Sprint(code) {
-				legislativeBodyTypeData = legislativeBodyTypeMap
+		var description string
+		for _, legislativeBodyTypeData := range legislativeBodyTypes {
+			if legislativeBodyTypeData.Code == strconv.Itoa(code) {
+				description = legislativeBodyTypeData.Name
 				break
 			}
 		}
 
-		description := fmt.Sprint(legislativeBodyTypeData["nome"])
-
 		legislativeBodyType, err = legislativebodytype.NewBuilder().
 			Code(code).
 			Description(description).
@@ -99,7 +111,7 @@ func (instance LegislativeBody) getLegislativeBodyTypeDataByCode(code int) (*leg
 			return nil, err
 		}
 
-		legislativeBodyTypeId, err := instance.legislativeBodyTypeRepository.CreateLegislativeBodyType(
+		legislativeBodyTypeId, err := instance.legislativeBodyTypeRepository.CreateLegislativeBodyType(ctx,
 			*legislativeBodyType)
 		if err != nil {
 			log.Error("legislativeBodyTypeRepository.CreateLegislativeBodyType(): ", err.Error())
@@ -116,8 +128,9 @@ func (instance LegislativeBody) getLegislativeBodyTypeDataByCode(code int) (*leg
 	return legislativeBodyType, nil
 }
 
-func (instance LegislativeBody) GetLegislativeBodyByCode(code int) (*legislativebody.LegislativeBody, error) {
-	legislativeBody, err := instance.legislativeBodyRepository.GetLegislativeBodyByCode(code)
+func (instance LegislativeBody) GetLegislativeBodyByCode(ctx context.Context, code int) (
+	*legislativebody.LegislativeBody, error) {
+	legislativeBody, err := instance.legislativeBodyRepository.GetLegislativeBodyByCode(ctx, code)
 	if err != nil {
 		log.Error("legislativeBodyRepository.GetLegislativeBodyByCode(): ", err.Error())
 		return nil, err
@@ -126,8 +139,9 @@ func (instance LegislativeBody) GetLegislativeBodyByCode(code int) (*legislative
 	return legislativeBody, nil
 }
 
-func (instance LegislativeBody) GetLegislativeBodiesByCodes(codes []int) ([]legislativebody.LegislativeBody, error) {
-	legislativeBodies, err := instance.legislativeBodyRepository.GetLegislativeBodiesByCodes(codes)
+func (instance LegislativeBody) GetLegislativeBodiesByCodes(ctx context.Context, codes []int) (
+	[]legislativebody.LegislativeBody, error) {
+	legislativeBodies, err := instance.legislativeBodyRepository.GetLegislativeBodiesByCodes(ctx, codes)
 	if err != nil {
 		log.Error("legislativeBodyRepository.GetLegislativeBodiesByCodes(): ", err.Error())
 		return nil, err
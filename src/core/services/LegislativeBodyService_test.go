@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/devlucassantos/vnc-domains/src/domains/legislativebodytype"
+	"github.com/google/uuid"
+	"sync"
+	"testing"
+)
+
+func TestRegisterNewLegislativeBodyByCode(t *testing.T) {
+	testCases := []struct {
+		name                         string
+		registeredTypeDescription    string
+		expectedTypeDescription      string
+		expectedNumberOfTypeSearches int
+	}{
+		{
+			name:                         "registers the type of the legislative body returned by the Chamber",
+			expectedTypeDescription:      "Plenário Virtual",
+			expectedNumberOfTypeSearches: 1,
+		},
+		{
+			name:                      "uses the type of the legislative body already registered",
+			registeredTypeDescription: "Plenário",
+			expectedTypeDescription:   "Plenário",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			ctx := context.Background()
+
+			if testCase.registeredTypeDescription != "" {
+				legislativeBodyType, err := legislativebodytype.NewBuilder().Code(26).
+					Description(testCase.registeredTypeDescription).Build()
+				if err != nil {
+					t.Fatalf("legislativebodytype.NewBuilder(): %s", err.Error())
+				}
+
+				_, err = environment.legislativeBodyTypeRepository.CreateLegislativeBodyType(ctx, *legislativeBodyType)
+				if err != nil {
+					t.Fatalf("CreateLegislativeBodyType(): %s", err.Error())
+				}
+			}
+
+			legislativeBodyId, err := environment.legislativeBodyService.RegisterNewLegislativeBodyByCode(ctx,
+				fakeLegislativeBodyCode)
+			if err != nil {
+				t.Fatalf("RegisterNewLegislativeBodyByCode(): %s", err.Error())
+			}
+
+			legislativeBody, err := environment.legislativeBodyService.GetLegislativeBodyByCode(ctx,
+				fakeLegislativeBodyCode)
+			if err != nil {
+				t.Fatalf("GetLegislativeBodyByCode(): %s", err.Error())
+			}
+			if legislativeBody == nil || legislativeBody.Id() != *legislativeBodyId {
+				t.Fatalf("Legislative body %s was not registered", legislativeBodyId)
+			}
+			if legislativeBody.Name() != "Plenário" || legislativeBody.Acronym() != "PLEN" {
+				t.Errorf("The legislative body was registered as %s (%s)", legislativeBody.Name(),
+					legislativeBody.Acronym())
+			}
+
+			legislativeBodyType := legislativeBody.Type()
+			if legislativeBodyType.Description() != testCase.expectedTypeDescription {
+				t.Errorf("The type of the legislative body was expected to be %s, but it was %s",
+					testCase.expectedTypeDescription, legislativeBodyType.Description())
+			}
+
+			numberOfTypeSearches := environment.chamberApi.getNumberOfCalls("GetLegislativeBodyTypes")
+			if numberOfTypeSearches != testCase.expectedNumberOfTypeSearches {
+				t.Errorf("%d searches of legislative body types were expected, but %d were made",
+					testCase.expectedNumberOfTypeSearches, numberOfTypeSearches)
+			}
+		})
+	}
+}
+
+func TestRegisterNewLegislativeBodyByCodeErrors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configure func(t *testing.T, environment *testEnvironment)
+	}{
+		{
+			name: "returns the error of the search for the legislative body",
+			configure: func(_ *testing.T, environment *testEnvironment) {
+				environment.chamberApi.failOperation("GetLegislativeBodyByCode", errors.New("Service unavailable"),
+					-1)
+			},
+		},
+		{
+			name: "returns the error of the search for the legislative body types",
+			configure: func(_ *testing.T, environment *testEnvironment) {
+				environment.chamberApi.failOperation("GetLegislativeBodyTypes", errors.New("Service unavailable"),
+					-1)
+			},
+		},
+		{
+			name: "returns the error of the registration of the legislative body type",
+			configure: func(_ *testing.T, environment *testEnvironment) {
+				environment.database.FailOperation("CreateLegislativeBodyType",
+					errors.New("The connection was closed"))
+			},
+		},
+		{
+			name: "returns the error of the registration of the legislative body",
+			configure: func(_ *testing.T, environment *testEnvironment) {
+				environment.database.FailOperation("CreateLegislativeBody", errors.New("The connection was closed"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			ctx := context.Background()
+			testCase.configure(t, environment)
+
+			legislativeBodyId, err := environment.legislativeBodyService.RegisterNewLegislativeBodyByCode(ctx,
+				fakeLegislativeBodyCode)
+			if err == nil {
+				t.Errorf("An error was expected, but legislative body %s was registered", legislativeBodyId)
+			}
+		})
+	}
+}
+
+func TestRegisterNewLegislativeBodyByCodeConcurrently(t *testing.T) {
+	environment := newTestEnvironment(t)
+	ctx := context.Background()
+
+	const numberOfWorkers = 5
+	legislativeBodyIds := make([]*uuid.UUID, numberOfWorkers)
+	errs := make([]error, numberOfWorkers)
+	start := make(chan struct{})
+	var waitGroup sync.WaitGroup
+	for index := range numberOfWorkers {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			<-start
+			legislativeBodyIds[index], errs[index] = environment.legislativeBodyService.
+				RegisterNewLegislativeBodyByCode(ctx, fakeLegislativeBodyCode)
+		}()
+	}
+	close(start)
+	waitGroup.Wait()
+
+	for index, err := range errs {
+		if err != nil {
+			t.Fatalf("RegisterNewLegislativeBodyByCode(): %s", err.Error())
+		} else if *legislativeBodyIds[index] != *legislativeBodyIds[0] {
+			t.Errorf("The workers were expected to share legislative body %s, but %s was returned",
+				legislativeBodyIds[0], legislativeBodyIds[index])
+		}
+	}
+
+	numberOfSearches := environment.chamberApi.getNumberOfCalls("GetLegislativeBodyByCode")
+	if numberOfSearches != 1 {
+		t.Errorf("The legislative body was expected to be searched once, but %d searches were made", numberOfSearches)
+	}
+}
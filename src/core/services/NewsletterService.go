@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"github.com/devlucassantos/vnc-domains/src/domains/article"
 	"github.com/devlucassantos/vnc-domains/src/domains/newsletter"
@@ -8,31 +9,37 @@ import (
 	"math"
 	"strings"
 	"time"
-	"vnc-summarizer/core/interfaces/chatgpt"
+	"vnc-summarizer/core/domains/generation"
+	"vnc-summarizer/core/domains/prompt"
+	"vnc-summarizer/core/interfaces/llm"
 	"vnc-summarizer/core/interfaces/postgres"
+	"vnc-summarizer/core/interfaces/prompts"
+	"vnc-summarizer/utils/contexts"
 )
 
 type Newsletter struct {
-	chatGptApi            chatgpt.ChatGpt
+	llmApi                llm.Llm
+	promptRegistry        prompts.Prompt
 	newsletterRepository  postgres.Newsletter
 	articleTypeRepository postgres.ArticleType
 	articleRepository     postgres.Article
 }
 
-func NewNewsletterService(chatGptApi chatgpt.ChatGpt, newsletterRepository postgres.Newsletter,
+func NewNewsletterService(llmApi llm.Llm, promptRegistry prompts.Prompt, newsletterRepository postgres.Newsletter,
 	articleTypeRepository postgres.ArticleType, articleRepository postgres.Article) *Newsletter {
 	return &Newsletter{
-		chatGptApi:            chatGptApi,
+		llmApi:                llmApi,
+		promptRegistry:        promptRegistry,
 		newsletterRepository:  newsletterRepository,
 		articleTypeRepository: articleTypeRepository,
 		articleRepository:     articleRepository,
 	}
 }
 
-func (instance Newsletter) RegisterNewNewsletter(referenceDate time.Time) {
+func (instance Newsletter) RegisterNewNewsletter(ctx context.Context, referenceDate time.Time) {
 	formattedReferenceDate := referenceDate.Format("02/01/2006")
 
-	articles, err := instance.articleRepository.GetArticlesByReferenceDate(referenceDate)
+	articles, err := instance.articleRepository.GetArticlesByReferenceDate(ctx, referenceDate)
 	if err != nil {
 		log.Error("articleRepository.GetArticlesByReferenceDate(): ", err.Error())
 		return
@@ -42,12 +49,12 @@ func (instance Newsletter) RegisterNewNewsletter(referenceDate time.Time) {
 	}
 
 	var articlesOutsideTheNewsletter []article.Article
-	registeredNewsletter, err := instance.newsletterRepository.GetNewsletterByReferenceDate(referenceDate)
+	registeredNewsletter, err := instance.newsletterRepository.GetNewsletterByReferenceDate(ctx, referenceDate)
 	if err != nil {
 		log.Error("newsletterRepository.GetNewsletterByReferenceDate(): ", err.Error())
 		return
 	} else if registeredNewsletter != nil {
-		newsletterArticles, err := instance.articleRepository.GetNewsletterArticlesByNewsletterId(
+		newsletterArticles, err := instance.articleRepository.GetNewsletterArticlesByNewsletterId(ctx,
 			registeredNewsletter.Id())
 		if err != nil {
 			log.Error("articleRepository.GetNewsletterArticlesByNewsletterId(): ", err.Error())
@@ -84,14 +91,16 @@ func (instance Newsletter) RegisterNewNewsletter(referenceDate time.Time) {
 			formattedReferenceDate)
 	}
 
-	newsletterData, err := instance.generateNewsletter(articles, referenceDate)
+	newsletterData, generationData, err := instance.generateNewsletter(ctx, articles, referenceDate)
 	if err != nil {
 		for attempt := 1; attempt <= 3; attempt++ {
-			waitingTimeInSeconds := int(math.Pow(4, float64(attempt)))
-			log.Warnf("It was not possible to register newsletter of %s on the %dth attempt, trying again in "+
-				"%d seconds", formattedReferenceDate, attempt, waitingTimeInSeconds)
-			time.Sleep(time.Duration(waitingTimeInSeconds) * time.Second)
-			newsletterData, err = instance.generateNewsletter(articles, referenceDate)
+			waitingTime := time.Duration(math.Pow(4, float64(attempt))) * retryWaitingTimeUnit
+			log.Warnf("It was not possible to register newsletter of %s on the %dth attempt, trying again in %s",
+				formattedReferenceDate, attempt, waitingTime)
+			if contexts.Sleep(ctx, waitingTime) != nil {
+				break
+			}
+			newsletterData, generationData, err = instance.generateNewsletter(ctx, articles, referenceDate)
 			if err == nil {
 				break
 			}
@@ -112,12 +121,13 @@ func (instance Newsletter) RegisterNewNewsletter(referenceDate time.Time) {
 	}
 
 	if registeredNewsletter == nil {
-		_, err = instance.newsletterRepository.CreateNewsletter(*newsletterData)
+		_, err = instance.newsletterRepository.CreateNewsletter(ctx, *newsletterData, *generationData)
 		if err != nil {
 			log.Error("newsletterRepository.CreateNewsletter(): ", err.Error())
 		}
 	} else {
-		err = instance.newsletterRepository.UpdateNewsletter(*newsletterData, articlesOutsideTheNewsletter)
+		err = instance.newsletterRepository.UpdateNewsletter(ctx, *newsletterData, articlesOutsideTheNewsletter,
+			*generationData)
 		if err != nil {
 			log.Error("newsletterRepository.UpdateNewsletter(): ", err.Error())
 		}
@@ -127,8 +137,8 @@ func (instance Newsletter) RegisterNewNewsletter(referenceDate time.Time) {
 	return
 }
 
-func (instance Newsletter) generateNewsletter(articles []article.Article, referenceDate time.Time) (
-	*newsletter.Newsletter, error) {
+func (instance Newsletter) generateNewsletter(ctx context.Context, articles []article.Article,
+	referenceDate time.Time) (*newsletter.Newsletter, *generation.Generation, error) {
 	formattedReferenceDate := referenceDate.Format("02/01/2006")
 
 	maximumNumberOfRelevantArticles := 10
@@ -140,28 +150,32 @@ func (instance Newsletter) generateNewsletter(articles []article.Article, refere
 			articleData.Title(), articleData.Content())
 	}
 
-	chatGptCommand := "Gere uma descrição para ser usada em um boletim sobre o conjunto de matérias políticas abaixo. " +
-		"É importante que a descrição seja curta e chamativa, falando sobre o máximo de matérias possíveis, " +
-		"correlacionando os temas, utilizando uma linguagem simples e direta, não possuindo mais do que 500 " +
-		"caracteres e sem referenciar a frequência em que o boletim é disponibilizado. Matérias:\n\n"
+	descriptionPrompt, err := instance.promptRegistry.GetPrompt("newsletter_description", "",
+		prompt.Variables{ReferenceDate: referenceDate})
+	if err != nil {
+		log.Error("promptRegistry.GetPrompt(): ", err.Error())
+		return nil, nil, err
+	}
+
 	purpose := fmt.Sprint("Generating the newsletter description of ", formattedReferenceDate)
-	description, err := instance.chatGptApi.MakeRequest(chatGptCommand, contentOfArticles, purpose)
+	description, descriptionUsage, err := instance.llmApi.MakeRequest(ctx, descriptionPrompt.Text(), contentOfArticles,
+		purpose)
 	if err != nil {
-		log.Error("chatGptApi.MakeRequest(): ", err.Error())
-		return nil, err
+		log.Error("llmApi.MakeRequest(): ", err.Error())
+		return nil, nil, err
 	}
 
 	articleTypeCode := "newsletter"
-	articleType, err := instance.articleTypeRepository.GetArticleTypeByCode(articleTypeCode)
+	articleType, err := instance.articleTypeRepository.GetArticleTypeByCode(ctx, articleTypeCode)
 	if err != nil {
 		log.Error("articleTypeRepository.GetArticleTypeByCode(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	articleData, err := article.NewBuilder().Type(*articleType).Build()
 	if err != nil {
 		log.Errorf("Error validating article data for newsletter of %s: %s", formattedReferenceDate, err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	newsletterData, err := newsletter.NewBuilder().
@@ -172,10 +186,17 @@ func (instance Newsletter) generateNewsletter(articles []article.Article, refere
 		Build()
 	if err != nil {
 		log.Errorf("Error validating newsletter data of %s: %s", formattedReferenceDate, err.Error())
-		return nil, err
+		return nil, nil, err
+	}
+
+	generationData, err := generation.NewBuilder().Prompts(*descriptionPrompt).Usages(*descriptionUsage).Build()
+	if err != nil {
+		log.Errorf("Error validating generation data for newsletter of %s: %s", formattedReferenceDate,
+			err.Error())
+		return nil, nil, err
 	}
 
-	return newsletterData, nil
+	return newsletterData, generationData, nil
 }
 
 func getMostRelevantArticles(articles []article.Article, maximumNumberOfRelevantArticles int) []article.Article {
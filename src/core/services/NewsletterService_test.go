@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRegisterNewNewsletter(t *testing.T) {
+	testCases := []struct {
+		name                               string
+		codesOfThePreviousRun              []int
+		codesOfTheCurrentRun               []int
+		numberOfFailures                   int
+		expectedNewsletter                 bool
+		expectedNumberOfArticles           int
+		expectedNumberOfGenerationRequests int
+	}{
+		{
+			name:                               "creates the newsletter with the articles of the day",
+			codesOfTheCurrentRun:               []int{1001, 1002},
+			expectedNewsletter:                 true,
+			expectedNumberOfArticles:           2,
+			expectedNumberOfGenerationRequests: 1,
+		},
+		{
+			name:                               "updates the newsletter with the new articles of the day",
+			codesOfThePreviousRun:              []int{1001},
+			codesOfTheCurrentRun:               []int{1002},
+			expectedNewsletter:                 true,
+			expectedNumberOfArticles:           2,
+			expectedNumberOfGenerationRequests: 1,
+		},
+		{
+			name:                     "does not update the newsletter when there are no new articles",
+			codesOfThePreviousRun:    []int{1001, 1002},
+			expectedNewsletter:       true,
+			expectedNumberOfArticles: 2,
+		},
+		{
+			name: "does not create the newsletter when there are no articles",
+		},
+		{
+			name:                               "retries the generation of the newsletter that fails temporarily",
+			codesOfTheCurrentRun:               []int{1001},
+			numberOfFailures:                   2,
+			expectedNewsletter:                 true,
+			expectedNumberOfArticles:           1,
+			expectedNumberOfGenerationRequests: 3,
+		},
+		{
+			name:                               "does not create the newsletter when all the attempts fail",
+			codesOfTheCurrentRun:               []int{1001},
+			numberOfFailures:                   -1,
+			expectedNumberOfGenerationRequests: 4,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			ctx := context.Background()
+			referenceDate := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+			registerPropositions := func(codes []int) {
+				for _, code := range codes {
+					environment.chamberApi.addProposition(code)
+					_, err := environment.propositionService.RegisterNewPropositionByCode(ctx, code)
+					if err != nil {
+						t.Fatalf("RegisterNewPropositionByCode(): %s", err.Error())
+					}
+				}
+			}
+
+			registerPropositions(testCase.codesOfThePreviousRun)
+			if testCase.codesOfThePreviousRun != nil {
+				environment.newsletterService.RegisterNewNewsletter(ctx, referenceDate)
+			}
+			registerPropositions(testCase.codesOfTheCurrentRun)
+
+			numberOfPreviousRequests := environment.llmApi.getNumberOfCalls("MakeRequest")
+			environment.llmApi.failOperation("MakeRequest", errors.New("The model is overloaded"),
+				testCase.numberOfFailures)
+			environment.newsletterService.RegisterNewNewsletter(ctx, referenceDate)
+
+			numberOfGenerationRequests := environment.llmApi.getNumberOfCalls("MakeRequest") - numberOfPreviousRequests
+			if numberOfGenerationRequests != testCase.expectedNumberOfGenerationRequests {
+				t.Errorf("%d requests to generate the newsletter were expected, but %d were made",
+					testCase.expectedNumberOfGenerationRequests, numberOfGenerationRequests)
+			}
+
+			registeredNewsletter, err := environment.newsletterRepository.GetNewsletterByReferenceDate(ctx,
+				referenceDate)
+			if err != nil {
+				t.Fatalf("GetNewsletterByReferenceDate(): %s", err.Error())
+			}
+			if testCase.expectedNewsletter != (registeredNewsletter != nil) {
+				t.Fatalf("The registration of the newsletter was expected to be %t", testCase.expectedNewsletter)
+			} else if registeredNewsletter == nil {
+				return
+			}
+
+			newsletterArticles, err := environment.articleRepository.GetNewsletterArticlesByNewsletterId(ctx,
+				registeredNewsletter.Id())
+			if err != nil {
+				t.Fatalf("GetNewsletterArticlesByNewsletterId(): %s", err.Error())
+			}
+			if len(newsletterArticles) != testCase.expectedNumberOfArticles {
+				t.Errorf("The newsletter was expected to have %d articles, but it has %d",
+					testCase.expectedNumberOfArticles, len(newsletterArticles))
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"vnc-summarizer/core/interfaces/postgres"
+	"vnc-summarizer/utils/contexts"
+	"vnc-summarizer/utils/runs"
+)
+
+const (
+	processingItemSucceeded = "succeeded"
+	processingItemFailed    = "failed"
+	processingItemAbandoned = "abandoned"
+	processingItemSkipped   = "skipped"
+)
+
+const defaultMaximumNumberOfProcessingAttempts = 5
+
+type ProcessingLedger struct {
+	processingRunRepository  postgres.ProcessingRun
+	processingItemRepository postgres.ProcessingItem
+}
+
+func NewProcessingLedgerService(processingRunRepository postgres.ProcessingRun,
+	processingItemRepository postgres.ProcessingItem) *ProcessingLedger {
+	return &ProcessingLedger{
+		processingRunRepository:  processingRunRepository,
+		processingItemRepository: processingItemRepository,
+	}
+}
+
+// StartRun registers a new processing run of the job and returns a copy of the context that links the items processed
+// and the articles generated with it to the run. If the run cannot be registered, the informed context is returned.
+func (instance ProcessingLedger) StartRun(ctx context.Context, jobName string) (context.Context, error) {
+	runId, err := instance.processingRunRepository.CreateProcessingRun(ctx, jobName)
+	if err != nil {
+		log.Error("processingRunRepository.CreateProcessingRun(): ", err.Error())
+		return ctx, err
+	}
+
+	log.Infof("Starting processing run %s of job %s", runId, jobName)
+	return runs.WithRunId(ctx, *runId), nil
+}
+
+// FinishRun registers the end of the processing run of the context, even if the context has already been canceled
+func (instance ProcessingLedger) FinishRun(ctx context.Context) {
+	runId := runs.GetRunId(ctx)
+	if runId == uuid.Nil {
+		return
+	}
+
+	err := instance.processingRunRepository.FinishProcessingRun(context.WithoutCancel(ctx), runId)
+	if err != nil {
+		log.Error("processingRunRepository.FinishProcessingRun(): ", err.Error())
+	}
+}
+
+// ProcessItem executes the processing of the item, recording its status, error, number of attempts and duration.
+// Failures of the ledger itself are only logged, so they never prevent the processing of the item. Items that fail
+// are retried in later runs until the maximum number of attempts is reached, when they are abandoned. Items without
+// content are skipped, since retrying them would not change the result. The processing is canceled when the timeout
+// configured in ITEM_PROCESSING_TIMEOUT expires.
+func (instance ProcessingLedger) ProcessItem(ctx context.Context, itemType, code string,
+	process func(ctx context.Context) error) error {
+	processingCtx, cancel := contexts.WithTimeout(ctx, "ITEM_PROCESSING_TIMEOUT", 30*time.Minute)
+	defer cancel()
+
+	numberOfAttempts, err := instance.processingItemRepository.StartProcessingItem(ctx, runs.GetRunId(ctx), itemType,
+		code)
+	if err != nil {
+		log.Error("processingItemRepository.StartProcessingItem(): ", err.Error())
+		return process(processingCtx)
+	}
+
+	processingErr := process(processingCtx)
+
+	status, errorMessage := processingItemSucceeded, ""
+	if processingErr != nil && strings.Contains(processingErr.Error(), "no content") {
+		status, errorMessage = processingItemSkipped, processingErr.Error()
+	} else if processingErr != nil {
+		status, errorMessage = processingItemFailed, processingErr.Error()
+		if numberOfAttempts >= getMaximumNumberOfProcessingAttempts() {
+			log.Warnf("The processing of %s %s was abandoned after %d attempts", itemType, code, numberOfAttempts)
+			status = processingItemAbandoned
+		}
+	}
+
+	// The result is recorded even if the processing was canceled, so the item is retried in the next runs
+	err = instance.processingItemRepository.FinishProcessingItem(context.WithoutCancel(ctx), itemType, code, status,
+		errorMessage)
+	if err != nil {
+		log.Error("processingItemRepository.FinishProcessingItem(): ", err.Error())
+	}
+
+	return processingErr
+}
+
+func (instance ProcessingLedger) GetCodesOfTheItemsToRetry(ctx context.Context, itemType string) []string {
+	codes, err := instance.processingItemRepository.GetCodesOfTheItemsToRetry(ctx, itemType,
+		getMaximumNumberOfProcessingAttempts())
+	if err != nil {
+		log.Error("processingItemRepository.GetCodesOfTheItemsToRetry(): ", err.Error())
+		return nil
+	}
+
+	if codes != nil {
+		log.Infof("%d items of type %s that failed in previous runs will be retried: %v", len(codes), itemType,
+			codes)
+	}
+
+	return codes
+}
+
+func getMaximumNumberOfProcessingAttempts() int {
+	maximumNumberOfAttempts, err := strconv.Atoi(os.Getenv("PROCESSING_ITEM_MAXIMUM_ATTEMPTS"))
+	if err != nil || maximumNumberOfAttempts <= 0 {
+		return defaultMaximumNumberOfProcessingAttempts
+	}
+
+	return maximumNumberOfAttempts
+}
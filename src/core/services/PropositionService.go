@@ -1,127 +1,245 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/devlucassantos/vnc-domains/src/domains/article"
 	"github.com/devlucassantos/vnc-domains/src/domains/proposition"
 	"github.com/google/uuid"
 	"github.com/labstack/gommon/log"
-	"io"
 	"math"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"vnc-summarizer/core/domains/articleimage"
+	"vnc-summarizer/core/domains/generation"
+	"vnc-summarizer/core/domains/generationusage"
+	"vnc-summarizer/core/domains/imagereview"
+	"vnc-summarizer/core/domains/imagevariant"
+	"vnc-summarizer/core/domains/prompt"
+	"vnc-summarizer/core/domains/summary"
 	"vnc-summarizer/core/interfaces/chamber"
-	"vnc-summarizer/core/interfaces/chatgpt"
-	"vnc-summarizer/core/interfaces/dalle"
+	"vnc-summarizer/core/interfaces/imagegeneration"
+	"vnc-summarizer/core/interfaces/images"
+	"vnc-summarizer/core/interfaces/llm"
 	"vnc-summarizer/core/interfaces/pdfcontentextractor"
 	"vnc-summarizer/core/interfaces/postgres"
-	"vnc-summarizer/core/interfaces/s3"
+	"vnc-summarizer/core/interfaces/prompts"
 	"vnc-summarizer/core/interfaces/services"
+	"vnc-summarizer/core/interfaces/storage"
+	"vnc-summarizer/utils/contexts"
 	"vnc-summarizer/utils/converters"
 	"vnc-summarizer/utils/datetime"
-	"vnc-summarizer/utils/requesters"
+	"vnc-summarizer/utils/locks"
+	"vnc-summarizer/utils/workerpools"
 )
 
+var propositionSummarySchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"title": map[string]interface{}{
+			"type": "string",
+		},
+		"paragraphs": map[string]interface{}{
+			"type":     "array",
+			"items":    map[string]interface{}{"type": "string"},
+			"minItems": 1,
+			"maxItems": 3,
+		},
+		"key_points": map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"type": "string"},
+		},
+		"affected_groups": map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"type": "string"},
+		},
+		"subject_tags": map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"type": "string"},
+		},
+	},
+	"required":             []string{"title", "paragraphs", "key_points", "affected_groups", "subject_tags"},
+	"additionalProperties": false,
+}
+
+const (
+	propositionItemType                           = "proposition"
+	defaultMaximumNumberOfImageGenerationAttempts = 2
+	maximumNumberOfImageReviewAttempts            = 2
+)
+
+// retryWaitingTimeUnit is the unit of the waiting time between the attempts to generate an article, which is
+// multiplied by 4 raised to the number of the attempt
+var retryWaitingTimeUnit = time.Second
+
 type Proposition struct {
 	authorService             services.Author
 	chamberApi                chamber.Chamber
-	chatGptApi                chatgpt.ChatGpt
-	dallEApi                  dalle.DallE
+	llmApi                    llm.Llm
+	promptRegistry            prompts.Prompt
+	imageGenerator            imagegeneration.ImageGenerator
+	placeholderImageGenerator imagegeneration.ImageGenerator
 	vncPdfContentExtractor    pdfcontentextractor.VncPdfContentExtractor
-	awsS3Api                  s3.AwsS3
+	imageProcessor            images.ImageProcessor
+	objectStorage             storage.ObjectStorage
+	imageLibraryService       services.ImageLibrary
+	imageReviewService        services.ImageReview
+	budgetService             services.Budget
+	processingLedgerService   services.ProcessingLedger
 	propositionRepository     postgres.Proposition
 	propositionTypeRepository postgres.PropositionType
 	articleTypeRepository     postgres.ArticleType
 }
 
 func NewPropositionService(authorService services.Author, chamberApi chamber.Chamber,
-	chatGptApi chatgpt.ChatGpt, dallEApi dalle.DallE, vncPdfContentExtractor pdfcontentextractor.VncPdfContentExtractor,
-	awsS3Api s3.AwsS3, propositionRepository postgres.Proposition, propositionTypeRepository postgres.PropositionType,
+	llmApi llm.Llm, promptRegistry prompts.Prompt, imageGenerator imagegeneration.ImageGenerator,
+	placeholderImageGenerator imagegeneration.ImageGenerator,
+	vncPdfContentExtractor pdfcontentextractor.VncPdfContentExtractor, imageProcessor images.ImageProcessor,
+	objectStorage storage.ObjectStorage, imageLibraryService services.ImageLibrary,
+	imageReviewService services.ImageReview, budgetService services.Budget,
+	processingLedgerService services.ProcessingLedger,
+	propositionRepository postgres.Proposition, propositionTypeRepository postgres.PropositionType,
 	articleTypeRepository postgres.ArticleType) *Proposition {
 	return &Proposition{
 		authorService:             authorService,
 		chamberApi:                chamberApi,
-		chatGptApi:                chatGptApi,
-		dallEApi:                  dallEApi,
+		llmApi:                    llmApi,
+		promptRegistry:            promptRegistry,
+		imageGenerator:            imageGenerator,
+		placeholderImageGenerator: placeholderImageGenerator,
 		vncPdfContentExtractor:    vncPdfContentExtractor,
-		awsS3Api:                  awsS3Api,
+		imageProcessor:            imageProcessor,
+		objectStorage:             objectStorage,
+		imageLibraryService:       imageLibraryService,
+		imageReviewService:        imageReviewService,
+		budgetService:             budgetService,
+		processingLedgerService:   processingLedgerService,
 		propositionRepository:     propositionRepository,
 		propositionTypeRepository: propositionTypeRepository,
 		articleTypeRepository:     articleTypeRepository,
 	}
 }
 
-func (instance Proposition) RegisterNewPropositions() {
-	codesOfTheMostRecentPropositionsReturned, err := instance.getCodesOfTheMostRecentPropositionsRegisteredInTheChamber()
+func (instance Proposition) RegisterNewPropositions(ctx context.Context) {
+	codesOfTheMostRecentPropositionsReturned,
+		err := instance.getCodesOfTheMostRecentPropositionsRegisteredInTheChamber(ctx)
 	if err != nil {
 		log.Error("getCodesOfTheMostRecentPropositionsRegisteredInTheChamber(): ", err.Error())
 		return
-	} else if codesOfTheMostRecentPropositionsReturned == nil {
+	}
+
+	for _, code := range instance.processingLedgerService.GetCodesOfTheItemsToRetry(ctx, propositionItemType) {
+		propositionCode, err := strconv.Atoi(code)
+		if err != nil {
+			log.Errorf("Error converting the code of proposition %s to integer: %s", code, err.Error())
+			continue
+		}
+		codesOfTheMostRecentPropositionsReturned = append(codesOfTheMostRecentPropositionsReturned, propositionCode)
+	}
+
+	err = instance.registerNewPropositions(ctx, codesOfTheMostRecentPropositionsReturned)
+	if err != nil {
+		log.Error("registerNewPropositions(): ", err.Error())
+	}
+}
+
+func (instance Proposition) RegisterNewPropositionsByDateRange(ctx context.Context, startDate,
+	endDate time.Time) error {
+	formattedStartDate := startDate.Format("02/01/2006")
+	formattedEndDate := endDate.Format("02/01/2006")
+	log.Infof("Starting the search for the propositions submitted between %s and %s", formattedStartDate,
+		formattedEndDate)
+
+	propositions, err := instance.chamberApi.GetPropositionsByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		log.Error("chamberApi.GetPropositionsByDateRange(): ", err.Error())
+		return err
+	}
+
+	propositionCodes := extractPropositionCodes(propositions)
+
+	log.Infof("Successful search for the propositions submitted between %s and %s: %v", formattedStartDate,
+		formattedEndDate, propositionCodes)
+	return instance.registerNewPropositions(ctx, propositionCodes)
+}
+
+// registerNewPropositions registers the propositions that are not yet registered and returns an error if any of them
+// could not be registered. Propositions without content are not considered failures, since they cannot be summarized.
+func (instance Proposition) registerNewPropositions(ctx context.Context, propositionCodes []int) error {
+	if propositionCodes == nil {
 		log.Info("No new propositions were identified for registration")
-		return
+		return nil
 	}
 
-	registeredPropositions, err := instance.propositionRepository.
-		GetPropositionsByCodes(codesOfTheMostRecentPropositionsReturned)
+	registeredPropositions, err := instance.propositionRepository.GetPropositionsByCodes(ctx, propositionCodes)
 	if err != nil {
 		log.Error("propositionRepository.GetCodesOfTheMostRecentPropositions(): ", err.Error())
-		return
+		return err
 	}
 
-	codesOfTheNewPropositions := getCodesOfTheNewPropositions(codesOfTheMostRecentPropositionsReturned,
-		registeredPropositions)
+	codesOfTheNewPropositions := getCodesOfTheNewPropositions(propositionCodes, registeredPropositions)
 	if codesOfTheNewPropositions != nil {
 		log.Infof("%d new propositions were identified for registration: %v", len(codesOfTheNewPropositions),
 			codesOfTheNewPropositions)
 	} else {
 		log.Info("No new propositions were identified for registration")
-		return
+		return nil
 	}
 
-	for _, propositionCode := range codesOfTheNewPropositions {
-		_, err = instance.RegisterNewPropositionByCode(propositionCode)
-		if err != nil {
+	var codesOfThePropositionsNotRegistered []int
+	var mutex sync.Mutex
+	numberOfWorkers := workerpools.GetNumberOfWorkers("PROPOSITION_REGISTRATION_CONCURRENCY")
+	workerpools.Process(ctx, codesOfTheNewPropositions, numberOfWorkers, func(ctx context.Context, code int) {
+		_, err := instance.RegisterNewPropositionByCode(ctx, code)
+		if err != nil && !strings.Contains(err.Error(), "no content") {
 			log.Error("RegisterNewPropositionByCode(): ", err.Error())
+			mutex.Lock()
+			codesOfThePropositionsNotRegistered = append(codesOfThePropositionsNotRegistered, code)
+			mutex.Unlock()
 		}
+	})
+
+	// The items that were not processed due to the cancellation are registered in the next runs
+	if ctx.Err() != nil {
+		return errors.New(fmt.Sprint("The registration of the propositions was interrupted: ", ctx.Err().Error()))
 	}
 
-	return
+	if codesOfThePropositionsNotRegistered != nil {
+		return errors.New(fmt.Sprintf("%d of %d propositions could not be registered: %v",
+			len(codesOfThePropositionsNotRegistered), len(codesOfTheNewPropositions),
+			codesOfThePropositionsNotRegistered))
+	}
+
+	return nil
 }
 
-func (instance Proposition) getCodesOfTheMostRecentPropositionsRegisteredInTheChamber() ([]int, error) {
+func (instance Proposition) getCodesOfTheMostRecentPropositionsRegisteredInTheChamber(ctx context.Context) ([]int,
+	error) {
 	log.Info("Starting the search for the most recent propositions")
 
-	mostRecentPropositions, err := instance.chamberApi.GetMostRecentPropositions()
+	mostRecentPropositions, err := instance.chamberApi.GetMostRecentPropositions(ctx)
 	if err != nil {
 		log.Error("chamberApi.GetMostRecentPropositions(): ", err.Error())
 		return nil, err
 	}
 
-	propositionCodes, err := extractPropositionCodes(mostRecentPropositions)
-	if err != nil {
-		log.Error("extractPropositionCodes(): ", err.Error())
-		return nil, err
-	}
+	propositionCodes := extractPropositionCodes(mostRecentPropositions)
 
 	log.Info("Successful search for the latest propositions: ", propositionCodes)
 	return propositionCodes, nil
 }
 
-func extractPropositionCodes(propositions []map[string]interface{}) ([]int, error) {
+func extractPropositionCodes(propositions []chamber.Proposition) []int {
 	var propositionCodes []int
 	for _, propositionData := range propositions {
-		code, err := converters.ToInt(propositionData["id"])
-		if err != nil {
-			log.Error("converters.ToInt(): ", err.Error())
-			return nil, err
-		}
-		propositionCodes = append(propositionCodes, code)
+		propositionCodes = append(propositionCodes, propositionData.Id)
 	}
 
-	return propositionCodes, nil
+	return propositionCodes
 }
 
 func getCodesOfTheNewPropositions(returnedPropositionCodes []int, registeredPropositions []proposition.Proposition) []int {
@@ -144,8 +262,36 @@ func getCodesOfTheNewPropositions(returnedPropositionCodes []int, registeredProp
 	return propositionCodesToRegister
 }
 
-func (instance Proposition) RegisterNewPropositionByCode(code int) (*uuid.UUID, error) {
-	propositionData, err := instance.getProposition(code)
+// RegisterNewPropositionByCode registers the proposition unless it is already registered. The registration of the
+// same proposition is never executed concurrently, so votes and events that reference a proposition being registered
+// by another worker wait for it and reuse the registered proposition.
+func (instance Proposition) RegisterNewPropositionByCode(ctx context.Context, code int) (*uuid.UUID, error) {
+	defer locks.Lock(fmt.Sprint(propositionItemType, ":", code))()
+
+	registeredPropositions, err := instance.propositionRepository.GetPropositionsByCodes(ctx, []int{code})
+	if err != nil {
+		log.Error("propositionRepository.GetPropositionsByCodes(): ", err.Error())
+		return nil, err
+	} else if len(registeredPropositions) > 0 {
+		propositionId := registeredPropositions[0].Id()
+		log.Infof("Proposition %d is already registered", code)
+		return &propositionId, nil
+	}
+
+	var propositionId *uuid.UUID
+	registerProposition := func(ctx context.Context) error {
+		var err error
+		propositionId, err = instance.registerNewPropositionByCode(ctx, code)
+		return err
+	}
+	err = instance.processingLedgerService.ProcessItem(ctx, propositionItemType, strconv.Itoa(code),
+		registerProposition)
+
+	return propositionId, err
+}
+
+func (instance Proposition) registerNewPropositionByCode(ctx context.Context, code int) (*uuid.UUID, error) {
+	propositionData, generationData, err := instance.getProposition(ctx, code)
 	if err != nil {
 		if !strings.Contains(err.Error(), "no content") {
 			log.Error("getProposition(): ", err.Error())
@@ -153,7 +299,7 @@ func (instance Proposition) RegisterNewPropositionByCode(code int) (*uuid.UUID,
 		return nil, err
 	}
 
-	propositionId, err := instance.propositionRepository.CreateProposition(*propositionData)
+	propositionId, err := instance.propositionRepository.CreateProposition(ctx, *propositionData, *generationData)
 	if err != nil {
 		log.Error("propositionRepository.CreateProposition(): ", err.Error())
 		return nil, err
@@ -162,16 +308,19 @@ func (instance Proposition) RegisterNewPropositionByCode(code int) (*uuid.UUID,
 	return propositionId, err
 }
 
-func (instance Proposition) getProposition(code int) (*proposition.Proposition, error) {
+func (instance Proposition) getProposition(ctx context.Context, code int) (*proposition.Proposition,
+	*generation.Generation, error) {
 	log.Info("Starting summary of proposition ", code)
-	propositionData, err := instance.getPropositionDataToRegister(code)
+	propositionData, generationData, err := instance.getPropositionDataToRegister(ctx, code)
 	if err != nil && !strings.Contains(err.Error(), "no content") {
 		for attempt := 1; attempt <= 3; attempt++ {
-			waitingTimeInSeconds := int(math.Pow(4, float64(attempt)))
-			log.Warnf("It was not possible to register proposition %d on the %dth attempt, trying again in %d seconds",
-				code, attempt, waitingTimeInSeconds)
-			time.Sleep(time.Duration(waitingTimeInSeconds) * time.Second)
-			propositionData, err = instance.getPropositionDataToRegister(code)
+			waitingTime := time.Duration(math.Pow(4, float64(attempt))) * retryWaitingTimeUnit
+			log.Warnf("It was not possible to register proposition %d on the %dth attempt, trying again in %s",
+				code, attempt, waitingTime)
+			if contexts.Sleep(ctx, waitingTime) != nil {
+				break
+			}
+			propositionData, generationData, err = instance.getPropositionDataToRegister(ctx, code)
 			if err == nil {
 				break
 			}
@@ -182,79 +331,80 @@ func (instance Proposition) getProposition(code int) (*proposition.Proposition,
 		if !strings.Contains(err.Error(), "no content") {
 			log.Errorf("Error summarizing proposition %d: %s", code, err.Error())
 		}
-		return nil, err
+		return nil, nil, err
 	}
 
 	log.Infof("Proposition %d successfully summarized", code)
-	return propositionData, nil
+	return propositionData, generationData, nil
 }
 
-func (instance Proposition) getPropositionDataToRegister(propositionCode int) (*proposition.Proposition, error) {
-	propositionData, err := instance.chamberApi.GetPropositionByCode(propositionCode)
+func (instance Proposition) getPropositionDataToRegister(ctx context.Context, propositionCode int) (
+	*proposition.Proposition, *generation.Generation, error) {
+	propositionData, err := instance.chamberApi.GetPropositionByCode(ctx, propositionCode)
 	if err != nil {
 		log.Error("chamberApi.GetPropositionByCode(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	originalTextUrl := fmt.Sprint(propositionData["urlInteiroTeor"])
-	if originalTextUrl == "<nil>" {
+	if propositionData.OriginalTextUrl == nil {
 		errorMessage := fmt.Sprintf("Proposition %d can not be registered because it has no content",
 			propositionCode)
 		log.Warn(errorMessage)
 		err = errors.New(errorMessage)
-		return nil, err
+		return nil, nil, err
 	}
 
-	authorsUrl := fmt.Sprint(propositionData["uriAutores"])
-	deputies, externalAuthors, err := instance.authorService.GetAuthorsFromAuthorsUrl(authorsUrl)
+	deputies, externalAuthors, err := instance.authorService.GetAuthorsByPropositionCode(ctx, propositionCode)
 	if err != nil {
-		log.Error("authorService.GetAuthorsFromAuthorsUrl(): ", err.Error())
-		return nil, err
+		log.Error("authorService.GetAuthorsByPropositionCode(): ", err.Error())
+		return nil, nil, err
 	}
 
-	propositionTypeCode := fmt.Sprint(propositionData["codTipo"])
-	propositionType, err := instance.propositionTypeRepository.GetPropositionTypeByCodeOrDefaultType(propositionTypeCode)
+	propositionTypeCode := strconv.Itoa(propositionData.TypeCode)
+	propositionType, err := instance.propositionTypeRepository.GetPropositionTypeByCodeOrDefaultType(ctx,
+		propositionTypeCode)
 	if err != nil {
 		log.Error("propositionTypeRepository.GetPropositionTypeByCodeOrDefaultType(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	specificType, err := instance.getPropositionSpecificType(propositionTypeCode)
+	specificType, err := instance.getPropositionSpecificType(ctx, propositionTypeCode)
 	if err != nil {
 		log.Error("getPropositionSpecificType(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	originalTextUrl, propositionText, originalTextMimeType, err := instance.getPropositionContent(propositionCode,
-		originalTextUrl)
+	originalTextUrl, propositionText, originalTextMimeType, err := instance.getPropositionContent(ctx, propositionCode,
+		*propositionData.OriginalTextUrl)
 	if err != nil {
 		log.Error("getPropositionContent(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	chatGptCommand := "Resuma a seguinte proposição política de forma simples e direta, como se estivesse escrevendo " +
-		"para uma revista. O texto produzido deve conter no máximo três parágrafos:"
-	purpose := fmt.Sprint("Summary of the content of proposition ", propositionCode)
-	propositionContentSummary, err := instance.chatGptApi.MakeRequest(chatGptCommand, propositionText, purpose)
+	promptVariables := prompt.Variables{
+		PropositionType:         propositionData.TypeAcronym,
+		PropositionSpecificType: specificType,
+	}
+	promptVariant := promptVariables.PropositionType
+
+	summaryPrompt, err := instance.promptRegistry.GetPrompt("proposition_summary", promptVariant, promptVariables)
 	if err != nil {
-		log.Error("chatGptApi.MakeRequest(): ", err.Error())
-		return nil, err
+		log.Error("promptRegistry.GetPrompt(): ", err.Error())
+		return nil, nil, err
 	}
 
-	chatGptCommand = "Gere um título chamativo utilizando uma linguagem simples e direta para a seguinte matéria para " +
-		"uma revista sobre uma proposição política: "
-	purpose = fmt.Sprint("Generating the title of proposition ", propositionCode)
-	propositionTitle, err := instance.chatGptApi.MakeRequest(chatGptCommand, propositionContentSummary, purpose)
+	propositionSummary, summaryUsage, err := instance.getPropositionSummary(ctx, propositionCode, summaryPrompt.Text(),
+		propositionText)
 	if err != nil {
-		log.Error("chatGptApi.MakeRequest(): ", err.Error())
-		return nil, err
+		log.Error("getPropositionSummary(): ", err.Error())
+		return nil, nil, err
 	}
-	propositionTitle = strings.Trim(propositionTitle, "*\"")
+	propositionContentSummary := propositionSummary.Content()
 
-	submittedAt, err := time.Parse("2006-01-02T15:04", fmt.Sprint(propositionData["dataApresentacao"]))
+	submittedAt, err := time.Parse(chamber.PropositionDateTimeLayout, propositionData.SubmittedAt)
 	if err != nil {
 		log.Errorf("Error converting submission date and time of proposition %d: %s", propositionCode, err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	economyModeActive, err := strconv.ParseBool(os.Getenv("ECONOMY_MODE_ACTIVE"))
@@ -263,22 +413,32 @@ func (instance Proposition) getPropositionDataToRegister(propositionCode int) (*
 			err.Error())
 	}
 
-	var propositionImageUrl, propositionImageDescription string
-	if !economyModeActive || !strings.Contains(propositionType.Codes(), "default_option") {
-		propositionImageUrl, propositionImageDescription, err = instance.getPropositionImage(propositionCode,
-			propositionContentSummary)
+	generationPrompts := []prompt.Prompt{*summaryPrompt}
+	generationUsages := []generationusage.GenerationUsage{*summaryUsage}
+	var propositionImage *articleimage.ArticleImage
+	var imageReviews []imagereview.ImageReview
+	if economyModeActive && strings.Contains(propositionType.Codes(), "default_option") {
+		log.Infof("Active economy mode: Image generation for proposition %d was skipped", propositionCode)
+	} else if instance.budgetService.IsDailyBudgetExceeded(ctx) {
+		log.Infof("Daily budget exceeded: Image generation for proposition %d was skipped", propositionCode)
+	} else {
+		var imagePrompts []prompt.Prompt
+		var imageUsages []generationusage.GenerationUsage
+		propositionImage, imageReviews, imagePrompts, imageUsages, err = instance.getPropositionImage(ctx,
+			propositionCode, propositionContentSummary, propositionSummary.SubjectTags(), promptVariant,
+			promptVariables)
 		if err != nil {
 			log.Error("getPropositionImage(): ", err.Error())
-			return nil, err
+			return nil, nil, err
 		}
-	} else {
-		log.Infof("Active economy mode: Image generation for proposition %d was skipped", propositionCode)
+		generationPrompts = append(generationPrompts, imagePrompts...)
+		generationUsages = append(generationUsages, imageUsages...)
 	}
 
 	referenceDateTime, err := datetime.GetCurrentDateTimeInBrazil()
 	if err != nil {
 		log.Error("datetime.GetCurrentDateTimeInBrazil(): ", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	if referenceDateTime.Sub(submittedAt).Hours() > 24 {
@@ -286,24 +446,24 @@ func (instance Proposition) getPropositionDataToRegister(propositionCode int) (*
 	}
 
 	articleTypeCode := "proposition"
-	articleType, err := instance.articleTypeRepository.GetArticleTypeByCode(articleTypeCode)
+	articleType, err := instance.articleTypeRepository.GetArticleTypeByCode(ctx, articleTypeCode)
 	if err != nil {
 		log.Error("articleTypeRepository.GetArticleTypeByCode(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	articleData, err := article.NewBuilder().Type(*articleType).ReferenceDateTime(*referenceDateTime).Build()
 	if err != nil {
 		log.Errorf("Error validating article data for proposition %d: %s", propositionCode,
 			err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	propositionBuilder := proposition.NewBuilder().
 		Code(propositionCode).
 		OriginalTextUrl(originalTextUrl).
 		OriginalTextMimeType(originalTextMimeType).
-		Title(propositionTitle).
+		Title(propositionSummary.Title()).
 		Content(propositionContentSummary).
 		SubmittedAt(submittedAt).
 		SpecificType(specificType).
@@ -312,23 +472,88 @@ func (instance Proposition) getPropositionDataToRegister(propositionCode int) (*
 		ExternalAuthors(externalAuthors).
 		Article(*articleData)
 
-	if propositionImageUrl != "" {
-		propositionBuilder.ImageUrl(propositionImageUrl).ImageDescription(propositionImageDescription)
+	if propositionImage != nil {
+		propositionBuilder.ImageUrl(propositionImage.Url()).ImageDescription(propositionImage.Description())
 	}
 
 	propositionDataToRegister, err := propositionBuilder.Build()
 	if err != nil {
 		log.Errorf("Error validating data for proposition %d: %s", propositionCode, err.Error())
-		return nil, err
+		return nil, nil, err
+	}
+
+	generationBuilder := generation.NewBuilder().
+		Prompts(generationPrompts...).
+		Summary(*propositionSummary).
+		Usages(generationUsages...)
+
+	if propositionImage != nil {
+		generationBuilder.Image(*propositionImage).ImageReviews(imageReviews...)
 	}
 
-	return propositionDataToRegister, err
+	generationData, err := generationBuilder.Build()
+	if err != nil {
+		log.Errorf("Error validating generation data for proposition %d: %s", propositionCode, err.Error())
+		return nil, nil, err
+	}
+
+	return propositionDataToRegister, generationData, err
 }
 
-func (instance Proposition) getPropositionContent(propositionCode int, propositionUrl string) (string, string, string, error) {
+func (instance Proposition) getPropositionSummary(ctx context.Context, propositionCode int, command,
+	propositionText string) (*summary.Summary, *generationusage.GenerationUsage, error) {
+	purpose := fmt.Sprint("Summary of the content of proposition ", propositionCode)
+	summaryData, summaryUsage, err := instance.llmApi.MakeStructuredRequest(ctx, command, propositionText, purpose,
+		propositionSummarySchema)
+	if err != nil {
+		log.Error("llmApi.MakeStructuredRequest(): ", err.Error())
+		return nil, nil, err
+	}
+
+	paragraphs, err := converters.ToStringSlice(summaryData["paragraphs"])
+	if err != nil {
+		log.Error("converters.ToStringSlice(): ", err.Error())
+		return nil, nil, err
+	}
+
+	keyPoints, err := converters.ToStringSlice(summaryData["key_points"])
+	if err != nil {
+		log.Error("converters.ToStringSlice(): ", err.Error())
+		return nil, nil, err
+	}
+
+	affectedGroups, err := converters.ToStringSlice(summaryData["affected_groups"])
+	if err != nil {
+		log.Error("converters.ToStringSlice(): ", err.Error())
+		return nil, nil, err
+	}
+
+	subjectTags, err := converters.ToStringSlice(summaryData["subject_tags"])
+	if err != nil {
+		log.Error("converters.ToStringSlice(): ", err.Error())
+		return nil, nil, err
+	}
+
+	propositionSummary, err := summary.NewBuilder().
+		Title(fmt.Sprint(summaryData["title"])).
+		Paragraphs(paragraphs).
+		KeyPoints(keyPoints).
+		AffectedGroups(affectedGroups).
+		SubjectTags(subjectTags).
+		Build()
+	if err != nil {
+		log.Errorf("Error validating summary data for proposition %d: %s", propositionCode, err.Error())
+		return nil, nil, err
+	}
+
+	return propositionSummary, summaryUsage, nil
+}
+
+func (instance Proposition) getPropositionContent(ctx context.Context, propositionCode int, propositionUrl string) (
+	string, string, string, error) {
 	log.Info("Extracting content from proposition ", propositionCode)
 
-	propositionContent, err := instance.vncPdfContentExtractor.MakeRequest(propositionUrl)
+	propositionContent, err := instance.vncPdfContentExtractor.MakeRequest(ctx, propositionUrl)
 	if err != nil && !strings.Contains(err.Error(), "Is this really a PDF?") {
 		log.Error("vncPdfContentExtractor.MakeRequest(): ", err.Error())
 		return "", "", "", err
@@ -342,7 +567,7 @@ func (instance Proposition) getPropositionContent(propositionCode int, propositi
 		return propositionUrl, propositionContent, propositionMimeType, nil
 	}
 
-	propositionUrl, propositionContent, err = instance.chamberApi.GetPropositionContentDirectly(propositionUrl)
+	propositionUrl, propositionContent, err = instance.chamberApi.GetPropositionContentDirectly(ctx, propositionUrl)
 	if err != nil {
 		log.Error("chamberApi.GetPropositionContentDirectly(): ", err.Error())
 		return "", "", "", err
@@ -352,8 +577,9 @@ func (instance Proposition) getPropositionContent(propositionCode int, propositi
 	return propositionUrl, propositionContent, propositionMimeType, nil
 }
 
-func (instance Proposition) getPropositionSpecificType(propositionTypeCode string) (string, error) {
-	propositionTypes, err := instance.chamberApi.GetPropositionTypes()
+func (instance Proposition) getPropositionSpecificType(ctx context.Context, propositionTypeCode string) (string,
+	error) {
+	propositionTypes, err := instance.chamberApi.GetPropositionTypes(ctx)
 	if err != nil {
 		log.Error("chamberApi.GetPropositionTypes(): ", err.Error())
 		return "", err
@@ -361,8 +587,8 @@ func (instance Proposition) getPropositionSpecificType(propositionTypeCode strin
 
 	var propositionSpecificType string
 	for _, propositionType := range propositionTypes {
-		if fmt.Sprint(propositionType["cod"]) == propositionTypeCode {
-			propositionSpecificType = fmt.Sprintf("%s (%s)", propositionType["nome"], propositionType["cod"])
+		if propositionType.Code == propositionTypeCode {
+			propositionSpecificType = fmt.Sprintf("%s (%s)", propositionType.Name, propositionType.Code)
 			break
 		}
 	}
@@ -370,53 +596,238 @@ func (instance Proposition) getPropositionSpecificType(propositionTypeCode strin
 	return propositionSpecificType, nil
 }
 
-func (instance Proposition) getPropositionImage(propositionCode int, propositionContent string) (string, string, error) {
-	chatGptCommand := "Gere um prompt para o DALL·E gerar uma imagem para um site jornalistico sobre a seguinte " +
-		"proposição política brasileira. É importante que o prompt esteja de acordo com as políticas do DALL·E e que " +
-		"seja especificado a necessidade de evitar usar textos nessas imagens: "
+func (instance Proposition) getPropositionImage(ctx context.Context, propositionCode int, propositionContent string,
+	subjectTags []string, promptVariant string, promptVariables prompt.Variables) (*articleimage.ArticleImage,
+	[]imagereview.ImageReview, []prompt.Prompt, []generationusage.GenerationUsage, error) {
+	imageGenerationPrompt, err := instance.promptRegistry.GetPrompt("proposition_image_prompt", promptVariant,
+		promptVariables)
+	if err != nil {
+		log.Error("promptRegistry.GetPrompt(): ", err.Error())
+		return nil, nil, nil, nil, err
+	}
+
 	purpose := fmt.Sprint("Generating the prompt for the image of proposition ", propositionCode)
-	prompt, err := instance.chatGptApi.MakeRequest(chatGptCommand, propositionContent, purpose)
+	imageGenerationPromptText, imageGenerationPromptUsage, err := instance.llmApi.MakeRequest(ctx,
+		imageGenerationPrompt.Text(), propositionContent, purpose)
+	if err != nil {
+		log.Error("llmApi.MakeRequest(): ", err.Error())
+		return nil, nil, nil, nil, err
+	}
+
+	imageUsages := []generationusage.GenerationUsage{*imageGenerationPromptUsage}
+	libraryImage, libraryImageUsage, err := instance.imageLibraryService.GetSimilarImage(ctx,
+		imageGenerationPromptText, subjectTags)
+	if err != nil {
+		log.Warnf("The image library could not be searched for an image for proposition %d, a new image will be "+
+			"generated: %s", propositionCode, err.Error())
+	}
+	if libraryImageUsage != nil {
+		imageUsages = append(imageUsages, *libraryImageUsage)
+	}
+	if libraryImage != nil {
+		log.Infof("The image of proposition %d was taken from the image library: %s", propositionCode,
+			libraryImage.Url())
+		return libraryImage, nil, []prompt.Prompt{*imageGenerationPrompt}, imageUsages, nil
+	}
+
+	currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+	if err != nil {
+		log.Error("datetime.GetCurrentDateTimeInBrazil(): ", err)
+		return nil, nil, nil, nil, err
+	}
+
+	// Each attempt saves its image with a different key, so the rejected images remain available for auditing. After
+	// the last attempt is rejected, a placeholder image, which does not need to be reviewed, is used instead.
+	imagePrompts := []prompt.Prompt{*imageGenerationPrompt}
+	var imageReviews []imagereview.ImageReview
+	var image []byte
+	var imageKey, imageUrl string
+	var imageGenerationUsage *generationusage.GenerationUsage
+	maximumNumberOfAttempts := getMaximumNumberOfImageGenerationAttempts()
+	for attempt := 1; ; attempt++ {
+		imageGenerator := instance.imageGenerator
+		if attempt > maximumNumberOfAttempts {
+			log.Warnf("No image generated for proposition %d was approved by the review, a placeholder image will "+
+				"be used", propositionCode)
+			imageGenerator = instance.placeholderImageGenerator
+		}
+
+		imageKey = fmt.Sprintf("propositions/%d_%s", propositionCode, currentDateTime.Format("150405_02012006"))
+		if attempt > 1 {
+			imageKey = fmt.Sprint(imageKey, "_", attempt)
+		}
+
+		image, imageUrl, imageGenerationUsage, err = instance.generatePropositionImage(ctx, propositionCode,
+			imageGenerator, imageKey, imageGenerationPromptText, promptVariables.PropositionType)
+		if err != nil {
+			log.Error("generatePropositionImage(): ", err.Error())
+			return nil, nil, nil, nil, err
+		}
+		imageUsages = append(imageUsages, *imageGenerationUsage)
+
+		if imageGenerationUsage.Provider() == imagegeneration.PlaceholderProvider {
+			break
+		}
+
+		// Only the images rejected by the review are replaced, so an image that could not be reviewed is used
+		// without review
+		imageReview, imageReviewPrompt, imageReviewUsage, err := instance.reviewPropositionImage(ctx,
+			propositionCode, imageUrl, imageGenerationUsage.Provider(), propositionContent, promptVariant,
+			promptVariables)
+		if err != nil {
+			log.Warnf("The image of proposition %d could not be reviewed and will be used without review: %s",
+				propositionCode, err.Error())
+			break
+		} else if imageReview == nil {
+			break
+		}
+
+		if attempt == 1 {
+			imagePrompts = append(imagePrompts, *imageReviewPrompt)
+		}
+		imageReviews = append(imageReviews, *imageReview)
+		imageUsages = append(imageUsages, *imageReviewUsage)
+		if imageReview.Approved() {
+			break
+		}
+
+		log.Warnf("The image of proposition %d was rejected by the review on the %dth attempt: %s",
+			propositionCode, attempt, imageReview.Justification())
+	}
+
+	propositionImage, err := instance.processPropositionImage(ctx, propositionCode, imageKey, image)
 	if err != nil {
-		log.Error("chatGptApi.MakeRequest(): ", err.Error())
-		return "", "", err
+		log.Warnf("The variants of the image of proposition %d could not be generated, only the original image "+
+			"will be registered: %s", propositionCode, err.Error())
+		propositionImage = &articleimage.ArticleImage{}
 	}
 
-	purpose = fmt.Sprint("Generating the image of proposition ", propositionCode)
-	dallEImageUrl, err := instance.dallEApi.MakeRequest(prompt, purpose)
+	imageDescriptionPrompt, err := instance.promptRegistry.GetPrompt("proposition_image_description", promptVariant,
+		promptVariables)
 	if err != nil {
-		log.Error("dallEApi.MakeRequest(): ", err.Error())
-		return "", "", err
+		log.Error("promptRegistry.GetPrompt(): ", err.Error())
+		return nil, nil, nil, nil, err
 	}
 
-	response, err := requesters.GetRequest(dallEImageUrl)
+	imageDescription, imageDescriptionUsage, err := instance.llmApi.MakeRequestToVision(ctx,
+		imageDescriptionPrompt.Text(), imageUrl)
 	if err != nil {
-		log.Error("requests.GetRequest(): ", err.Error())
-		return "", "", err
+		log.Error("llmApi.MakeRequestToVision(): ", err.Error())
+		return nil, nil, nil, nil, err
 	}
 
-	image, err := io.ReadAll(response.Body)
+	propositionImage, err = propositionImage.NewUpdater().Url(imageUrl).Description(imageDescription).Build()
 	if err != nil {
-		log.Errorf("Error interpreting the image of proposition %d: %s", propositionCode, err.Error())
-		return "", "", err
+		log.Errorf("Error validating the image data of proposition %d: %s", propositionCode, err.Error())
+		return nil, nil, nil, nil, err
 	}
 
-	imageUrl, err := instance.awsS3Api.SavePropositionImage(propositionCode, image)
+	imageUsages = append(imageUsages, *imageDescriptionUsage)
+
+	// The placeholder images only identify the type of the proposition, so they are not worth reusing
+	if imageGenerationUsage.Provider() != imagegeneration.PlaceholderProvider {
+		libraryImageUsage, err = instance.imageLibraryService.AddImage(ctx, *propositionImage, subjectTags)
+		if err != nil {
+			log.Warnf("The image of proposition %d could not be added to the image library: %s", propositionCode,
+				err.Error())
+		}
+		if libraryImageUsage != nil {
+			imageUsages = append(imageUsages, *libraryImageUsage)
+		}
+	}
+
+	imagePrompts = append(imagePrompts, *imageDescriptionPrompt)
+	return propositionImage, imageReviews, imagePrompts, imageUsages, nil
+}
+
+// generatePropositionImage generates the image of the proposition and saves it in the object storage with the key
+func (instance Proposition) generatePropositionImage(ctx context.Context, propositionCode int,
+	imageGenerator imagegeneration.ImageGenerator, imageKey, imagePrompt, theme string) ([]byte, string,
+	*generationusage.GenerationUsage, error) {
+	purpose := fmt.Sprint("Generating the image of proposition ", propositionCode)
+	image, imageGenerationUsage, err := imageGenerator.GenerateImage(ctx, imagePrompt, theme, purpose)
 	if err != nil {
-		log.Error("awsS3Api.SavePropositionImage(): ", err.Error())
-		return "", "", err
+		log.Error("imageGenerator.GenerateImage(): ", err.Error())
+		return nil, "", nil, err
 	}
 
-	imageDescription, err := instance.chatGptApi.MakeRequestToVision(imageUrl)
+	imageUrl, err := instance.objectStorage.SaveObject(ctx, fmt.Sprint(imageKey, ".png"), image)
 	if err != nil {
-		log.Error("chatGptApi.MakeRequestToVision(): ", err.Error())
-		return "", "", err
+		log.Error("objectStorage.SaveObject(): ", err.Error())
+		return nil, "", nil, err
+	}
+	log.Infof("The image of proposition %d was successfully registered: %s", propositionCode, imageUrl)
+
+	return image, imageUrl, imageGenerationUsage, nil
+}
+
+// reviewPropositionImage reviews the image of the proposition, trying again when the review itself fails
+func (instance Proposition) reviewPropositionImage(ctx context.Context, propositionCode int, imageUrl, imageProvider,
+	propositionContent, promptVariant string, promptVariables prompt.Variables) (*imagereview.ImageReview,
+	*prompt.Prompt, *generationusage.GenerationUsage, error) {
+	var err error
+	for attempt := 1; attempt <= maximumNumberOfImageReviewAttempts; attempt++ {
+		var imageReview *imagereview.ImageReview
+		var imageReviewPrompt *prompt.Prompt
+		var imageReviewUsage *generationusage.GenerationUsage
+		imageReview, imageReviewPrompt, imageReviewUsage, err = instance.imageReviewService.ReviewImage(ctx,
+			imageUrl, imageProvider, propositionContent, promptVariant, promptVariables)
+		if err == nil {
+			return imageReview, imageReviewPrompt, imageReviewUsage, nil
+		}
+
+		log.Warnf("The review of the image of proposition %d failed on the %dth attempt: %s", propositionCode,
+			attempt, err.Error())
+	}
+
+	return nil, nil, nil, err
+}
+
+func getMaximumNumberOfImageGenerationAttempts() int {
+	maximumNumberOfAttempts, err := strconv.Atoi(os.Getenv("IMAGE_REVIEW_MAXIMUM_ATTEMPTS"))
+	if err != nil || maximumNumberOfAttempts <= 0 {
+		return defaultMaximumNumberOfImageGenerationAttempts
+	}
+
+	return maximumNumberOfAttempts
+}
+
+// processPropositionImage generates the variants of the image of the proposition and saves them next to the original
+// image, which remains the image of the proposition for the clients that do not use the variants
+func (instance Proposition) processPropositionImage(ctx context.Context, propositionCode int, imageKey string,
+	image []byte) (*articleimage.ArticleImage, error) {
+	propositionImage, err := instance.imageProcessor.ProcessImage(ctx, image)
+	if err != nil {
+		log.Error("imageProcessor.ProcessImage(): ", err.Error())
+		return nil, err
+	}
+
+	var savedVariants []imagevariant.ImageVariant
+	for _, variant := range propositionImage.Variants() {
+		variantKey := fmt.Sprintf("%s_%dx%d.%s", imageKey, variant.Width(), variant.Height(), variant.Format())
+		variantUrl, err := instance.objectStorage.SaveObject(ctx, variantKey, variant.Content())
+		if err != nil {
+			log.Error("objectStorage.SaveObject(): ", err.Error())
+			return nil, err
+		}
+
+		savedVariant, err := variant.NewUpdater().Url(variantUrl).Build()
+		if err != nil {
+			log.Errorf("Error validating the image variant data of proposition %d: %s", propositionCode,
+				err.Error())
+			return nil, err
+		}
+		savedVariants = append(savedVariants, *savedVariant)
 	}
+	log.Infof("%d variants of the image of proposition %d were successfully registered", len(savedVariants),
+		propositionCode)
 
-	return imageUrl, imageDescription, nil
+	return propositionImage.NewUpdater().Variants(savedVariants...).Build()
 }
 
-func (instance Proposition) GetPropositionsByCodes(codes []int) ([]proposition.Proposition, error) {
-	propositions, err := instance.propositionRepository.GetPropositionsByCodes(codes)
+func (instance Proposition) GetPropositionsByCodes(ctx context.Context, codes []int) ([]proposition.Proposition,
+	error) {
+	propositions, err := instance.propositionRepository.GetPropositionsByCodes(ctx, codes)
 	if err != nil {
 		log.Error("propositionRepository.GetPropositionsByCodes(): ", err.Error())
 		return nil, err
@@ -0,0 +1,386 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/devlucassantos/vnc-domains/src/domains/proposition"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestRegisterNewPropositions(t *testing.T) {
+	testCases := []struct {
+		name                       string
+		registeredCodes            []int
+		mostRecentCodes            []int
+		codesWithoutContent        []int
+		expectedRegisteredCodes    []int
+		expectedNumberOfSearches   int
+		expectedProcessingStatus   map[int]string
+		numberOfStructuredFailures int
+	}{
+		{
+			name:                     "registers only the propositions that are not registered yet",
+			registeredCodes:          []int{1001},
+			mostRecentCodes:          []int{1001, 1002, 1002},
+			expectedRegisteredCodes:  []int{1001, 1002},
+			expectedNumberOfSearches: 1,
+			expectedProcessingStatus: map[int]string{1002: processingItemSucceeded},
+		},
+		{
+			name:                     "does not register the propositions without content",
+			mostRecentCodes:          []int{1001, 1002},
+			codesWithoutContent:      []int{1002},
+			expectedRegisteredCodes:  []int{1001},
+			expectedNumberOfSearches: 2,
+			expectedProcessingStatus: map[int]string{1001: processingItemSucceeded, 1002: processingItemSkipped},
+		},
+		{
+			name:                       "retries the summary of the propositions that fail temporarily",
+			mostRecentCodes:            []int{1001},
+			expectedRegisteredCodes:    []int{1001},
+			expectedNumberOfSearches:   3,
+			expectedProcessingStatus:   map[int]string{1001: processingItemSucceeded},
+			numberOfStructuredFailures: 2,
+		},
+		{
+			name:                       "marks the propositions that fail in all attempts as failed",
+			mostRecentCodes:            []int{1001},
+			expectedNumberOfSearches:   4,
+			expectedProcessingStatus:   map[int]string{1001: processingItemFailed},
+			numberOfStructuredFailures: -1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			ctx := context.Background()
+
+			for _, code := range testCase.registeredCodes {
+				environment.chamberApi.addProposition(code)
+				_, err := environment.propositionService.RegisterNewPropositionByCode(ctx, code)
+				if err != nil {
+					t.Fatalf("RegisterNewPropositionByCode(): %s", err.Error())
+				}
+			}
+			numberOfPreviousSearches := environment.chamberApi.getNumberOfCalls("GetPropositionByCode")
+
+			for _, code := range testCase.mostRecentCodes {
+				environment.chamberApi.addProposition(code)
+			}
+			for _, code := range testCase.codesWithoutContent {
+				propositionData := environment.chamberApi.propositions[code]
+				propositionData.OriginalTextUrl = nil
+				environment.chamberApi.setProposition(propositionData)
+			}
+			environment.chamberApi.mostRecentPropositionCodes = testCase.mostRecentCodes
+			environment.llmApi.failOperation("MakeStructuredRequest", errors.New("The model is overloaded"),
+				testCase.numberOfStructuredFailures)
+
+			environment.propositionService.RegisterNewPropositions(ctx)
+
+			numberOfSearches := environment.chamberApi.getNumberOfCalls("GetPropositionByCode") -
+				numberOfPreviousSearches
+			if numberOfSearches != testCase.expectedNumberOfSearches {
+				t.Errorf("%d searches of propositions were expected, but %d were made",
+					testCase.expectedNumberOfSearches, numberOfSearches)
+			}
+
+			propositions, err := environment.propositionRepository.GetPropositionsByCodes(ctx,
+				testCase.mostRecentCodes)
+			if err != nil {
+				t.Fatalf("GetPropositionsByCodes(): %s", err.Error())
+			}
+			if len(propositions) != len(testCase.expectedRegisteredCodes) {
+				t.Fatalf("%d propositions were expected to be registered, but %d were registered",
+					len(testCase.expectedRegisteredCodes), len(propositions))
+			}
+			for _, code := range testCase.expectedRegisteredCodes {
+				if getPropositionByCode(propositions, code) == nil {
+					t.Errorf("Proposition %d was not registered", code)
+				}
+			}
+
+			for code, expectedStatus := range testCase.expectedProcessingStatus {
+				status, _ := environment.database.GetProcessingItemStatus(propositionItemType, strconv.Itoa(code))
+				if status != expectedStatus {
+					t.Errorf("The processing of proposition %d was expected to be %s, but it was %s", code,
+						expectedStatus, status)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterNewPropositionsRetriesThePropositionsThatFailedInPreviousRuns(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		maximumNumberOfAttempts string
+		expectedStatus          string
+		expectedAttemptStatuses []string
+		expectedRegistration    bool
+	}{
+		{
+			name:                    "retries the proposition while the maximum number of attempts is not reached",
+			maximumNumberOfAttempts: "5",
+			expectedStatus:          processingItemSucceeded,
+			expectedAttemptStatuses: []string{processingItemFailed, processingItemSucceeded},
+			expectedRegistration:    true,
+		},
+		{
+			name:                    "abandons the proposition when the maximum number of attempts is reached",
+			maximumNumberOfAttempts: "1",
+			expectedStatus:          processingItemAbandoned,
+			expectedAttemptStatuses: []string{processingItemAbandoned},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			t.Setenv("PROCESSING_ITEM_MAXIMUM_ATTEMPTS", testCase.maximumNumberOfAttempts)
+			ctx := context.Background()
+
+			environment.chamberApi.addProposition(1001)
+			environment.chamberApi.mostRecentPropositionCodes = []int{1001}
+			environment.database.FailOperation("CreateProposition", errors.New("The connection was closed"))
+			environment.propositionService.RegisterNewPropositions(ctx)
+
+			environment.database.FailOperation("CreateProposition", nil)
+			environment.chamberApi.mostRecentPropositionCodes = nil
+			environment.propositionService.RegisterNewPropositions(ctx)
+
+			status, _ := environment.database.GetProcessingItemStatus(propositionItemType, "1001")
+			if status != testCase.expectedStatus {
+				t.Errorf("The processing of the proposition was expected to be %s, but it was %s",
+					testCase.expectedStatus, status)
+			}
+
+			attemptStatuses := environment.database.GetProcessingAttemptStatuses(propositionItemType, "1001")
+			if !slices.Equal(attemptStatuses, testCase.expectedAttemptStatuses) {
+				t.Errorf("The attempts of processing the proposition were expected to be %v, but they were %v",
+					testCase.expectedAttemptStatuses, attemptStatuses)
+			}
+
+			propositions, err := environment.propositionRepository.GetPropositionsByCodes(ctx, []int{1001})
+			if err != nil {
+				t.Fatalf("GetPropositionsByCodes(): %s", err.Error())
+			}
+			if testCase.expectedRegistration != (len(propositions) == 1) {
+				t.Errorf("The registration of the proposition was expected to be %t, but %d propositions were "+
+					"registered", testCase.expectedRegistration, len(propositions))
+			}
+		})
+	}
+}
+
+func TestRegisterNewPropositionByCode(t *testing.T) {
+	testCases := []struct {
+		name                              string
+		propositionTypeCode               int
+		environmentVariables              map[string]string
+		imageReviews                      []map[string]interface{}
+		imageReviewFailures               int
+		expectedImage                     bool
+		expectedNumberOfGeneratedImages   int
+		expectedNumberOfPlaceholderImages int
+		expectedNumberOfImageReviews      int
+	}{
+		{
+			name:                            "generates the image of the proposition",
+			propositionTypeCode:             139,
+			expectedImage:                   true,
+			expectedNumberOfGeneratedImages: 1,
+		},
+		{
+			name:                 "skips the image of the propositions of the default type in the economy mode",
+			propositionTypeCode:  390,
+			environmentVariables: map[string]string{"ECONOMY_MODE_ACTIVE": "true"},
+		},
+		{
+			name:                            "generates the image of the other propositions in the economy mode",
+			propositionTypeCode:             139,
+			environmentVariables:            map[string]string{"ECONOMY_MODE_ACTIVE": "true"},
+			expectedImage:                   true,
+			expectedNumberOfGeneratedImages: 1,
+		},
+		{
+			name:                 "skips the image of the propositions when the daily budget is exceeded",
+			propositionTypeCode:  139,
+			environmentVariables: map[string]string{"DAILY_BUDGET_CEILING": "0"},
+		},
+		{
+			name:                            "generates a new image when the image is rejected by the review",
+			propositionTypeCode:             139,
+			environmentVariables:            map[string]string{"IMAGE_REVIEW_ACTIVE": "true"},
+			imageReviews:                    []map[string]interface{}{getImageReviewResponse(false)},
+			expectedImage:                   true,
+			expectedNumberOfGeneratedImages: 2,
+			expectedNumberOfImageReviews:    2,
+		},
+		{
+			name:                 "uses a placeholder image when all the images are rejected by the review",
+			propositionTypeCode:  139,
+			environmentVariables: map[string]string{"IMAGE_REVIEW_ACTIVE": "true"},
+			imageReviews: []map[string]interface{}{getImageReviewResponse(false),
+				getImageReviewResponse(false)},
+			expectedImage:                     true,
+			expectedNumberOfGeneratedImages:   2,
+			expectedNumberOfPlaceholderImages: 1,
+			expectedNumberOfImageReviews:      2,
+		},
+		{
+			name:                            "reviews the image again when the review fails",
+			propositionTypeCode:             139,
+			environmentVariables:            map[string]string{"IMAGE_REVIEW_ACTIVE": "true"},
+			imageReviewFailures:             1,
+			expectedImage:                   true,
+			expectedNumberOfGeneratedImages: 1,
+			expectedNumberOfImageReviews:    1,
+		},
+		{
+			name:                            "uses the image without review when the review keeps failing",
+			propositionTypeCode:             139,
+			environmentVariables:            map[string]string{"IMAGE_REVIEW_ACTIVE": "true"},
+			imageReviewFailures:             -1,
+			expectedImage:                   true,
+			expectedNumberOfGeneratedImages: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			for environmentVariable, value := range testCase.environmentVariables {
+				t.Setenv(environmentVariable, value)
+			}
+			environment.llmApi.imageReviews = testCase.imageReviews
+			if testCase.imageReviewFailures != 0 {
+				environment.llmApi.failOperation("MakeStructuredRequestToVision",
+					errors.New("The review could not be parsed"), testCase.imageReviewFailures)
+			}
+			ctx := context.Background()
+
+			environment.chamberApi.addProposition(1001)
+			propositionData := environment.chamberApi.propositions[1001]
+			propositionData.TypeCode = testCase.propositionTypeCode
+			environment.chamberApi.setProposition(propositionData)
+
+			propositionId, err := environment.propositionService.RegisterNewPropositionByCode(ctx, 1001)
+			if err != nil {
+				t.Fatalf("RegisterNewPropositionByCode(): %s", err.Error())
+			}
+
+			propositions, err := environment.propositionRepository.GetPropositionsByCodes(ctx, []int{1001})
+			if err != nil {
+				t.Fatalf("GetPropositionsByCodes(): %s", err.Error())
+			}
+			registeredProposition := getPropositionByCode(propositions, 1001)
+			if registeredProposition == nil || registeredProposition.Id() != *propositionId {
+				t.Fatalf("Proposition %s was not registered", propositionId)
+			}
+			if len(registeredProposition.Deputies()) != 1 || len(registeredProposition.ExternalAuthors()) != 1 {
+				t.Errorf("The proposition was expected to have 1 deputy and 1 external author, but it has %d and %d",
+					len(registeredProposition.Deputies()), len(registeredProposition.ExternalAuthors()))
+			}
+			if testCase.expectedImage != (registeredProposition.ImageUrl() != "") {
+				t.Errorf("The image of the proposition was expected to be %t, but its URL is %q",
+					testCase.expectedImage, registeredProposition.ImageUrl())
+			}
+
+			numberOfGeneratedImages := environment.imageGenerator.getNumberOfCalls("GenerateImage")
+			if numberOfGeneratedImages != testCase.expectedNumberOfGeneratedImages {
+				t.Errorf("%d images were expected to be generated, but %d were generated",
+					testCase.expectedNumberOfGeneratedImages, numberOfGeneratedImages)
+			}
+			numberOfPlaceholderImages := environment.placeholderImageGenerator.getNumberOfCalls("GenerateImage")
+			if numberOfPlaceholderImages != testCase.expectedNumberOfPlaceholderImages {
+				t.Errorf("%d placeholder images were expected to be used, but %d were used",
+					testCase.expectedNumberOfPlaceholderImages, numberOfPlaceholderImages)
+			}
+
+			articleData := registeredProposition.Article()
+			generations := environment.database.GetArticleGenerations(articleData.Id())
+			if len(generations) != 1 {
+				t.Fatalf("1 generation was expected to be registered, but %d were registered", len(generations))
+			}
+			if len(generations[0].ImageReviews()) != testCase.expectedNumberOfImageReviews {
+				t.Errorf("%d image reviews were expected to be registered, but %d were registered",
+					testCase.expectedNumberOfImageReviews, len(generations[0].ImageReviews()))
+			}
+		})
+	}
+}
+
+func TestRegisterNewPropositionByCodeErrors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configure func(environment *testEnvironment)
+	}{
+		{
+			name: "returns the error of the Chamber of Deputies API",
+			configure: func(environment *testEnvironment) {
+				environment.chamberApi.failOperation("GetPropositionByCode", errors.New("Service unavailable"), -1)
+			},
+		},
+		{
+			name: "returns the error of the prompt registry",
+			configure: func(environment *testEnvironment) {
+				environment.promptRegistry.failOperation("GetPrompt", errors.New("Prompt not found"), -1)
+			},
+		},
+		{
+			name: "returns the error of the image generator",
+			configure: func(environment *testEnvironment) {
+				environment.imageGenerator.failOperation("GenerateImage", errors.New("Content policy violation"),
+					-1)
+			},
+		},
+		{
+			name: "returns the error of the repository",
+			configure: func(environment *testEnvironment) {
+				environment.database.FailOperation("CreateProposition", errors.New("The connection was closed"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			ctx := context.Background()
+
+			environment.chamberApi.addProposition(1001)
+			testCase.configure(environment)
+
+			propositionId, err := environment.propositionService.RegisterNewPropositionByCode(ctx, 1001)
+			if err == nil {
+				t.Fatalf("An error was expected, but proposition %s was registered", propositionId)
+			}
+
+			propositions, err := environment.propositionRepository.GetPropositionsByCodes(ctx, []int{1001})
+			if err != nil {
+				t.Fatalf("GetPropositionsByCodes(): %s", err.Error())
+			}
+			if len(propositions) > 0 {
+				t.Error("The proposition was registered despite the error")
+			}
+
+			status, _ := environment.database.GetProcessingItemStatus(propositionItemType, "1001")
+			if status != processingItemFailed {
+				t.Errorf("The processing of the proposition was expected to be failed, but it was %s", status)
+			}
+		})
+	}
+}
+
+func getPropositionByCode(propositions []proposition.Proposition, code int) *proposition.Proposition {
+	for _, propositionData := range propositions {
+		if propositionData.Code() == code {
+			return &propositionData
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"os"
+	"sync"
+	"time"
+	"vnc-summarizer/core/interfaces/postgres"
+	"vnc-summarizer/core/interfaces/services"
+	"vnc-summarizer/utils/contexts"
+	"vnc-summarizer/utils/crons"
+	"vnc-summarizer/utils/datetime"
+)
+
+type scheduledJob struct {
+	name                        string
+	scheduleEnvironmentVariable string
+	execute                     func(ctx context.Context, executionTime time.Time)
+}
+
+type Scheduler struct {
+	propositionService      services.Proposition
+	votingService           services.Voting
+	eventService            services.Event
+	newsletterService       services.Newsletter
+	processingLedgerService services.ProcessingLedger
+	scheduledJobRepository  postgres.ScheduledJob
+}
+
+func NewSchedulerService(propositionService services.Proposition, votingService services.Voting,
+	eventService services.Event, newsletterService services.Newsletter,
+	processingLedgerService services.ProcessingLedger, scheduledJobRepository postgres.ScheduledJob) *Scheduler {
+	return &Scheduler{
+		propositionService:      propositionService,
+		votingService:           votingService,
+		eventService:            eventService,
+		newsletterService:       newsletterService,
+		processingLedgerService: processingLedgerService,
+		scheduledJobRepository:  scheduledJobRepository,
+	}
+}
+
+func (instance Scheduler) getJobs() []scheduledJob {
+	return []scheduledJob{
+		{
+			name:                        "new_propositions",
+			scheduleEnvironmentVariable: "NEW_PROPOSITIONS_SCHEDULE",
+			execute: func(ctx context.Context, _ time.Time) {
+				instance.propositionService.RegisterNewPropositions(ctx)
+			},
+		},
+		{
+			name:                        "new_votes",
+			scheduleEnvironmentVariable: "NEW_VOTES_SCHEDULE",
+			execute: func(ctx context.Context, _ time.Time) {
+				instance.votingService.RegisterNewVotes(ctx)
+			},
+		},
+		{
+			name:                        "events_occurring_today_update",
+			scheduleEnvironmentVariable: "EVENTS_OCCURRING_TODAY_UPDATE_SCHEDULE",
+			execute: func(ctx context.Context, _ time.Time) {
+				instance.eventService.UpdateEventsOccurringToday(ctx)
+			},
+		},
+		{
+			name:                        "new_events",
+			scheduleEnvironmentVariable: "NEW_EVENTS_SCHEDULE",
+			execute: func(ctx context.Context, _ time.Time) {
+				instance.eventService.RegisterNewEvents(ctx)
+			},
+		},
+		{
+			name:                        "newsletter",
+			scheduleEnvironmentVariable: "NEWSLETTER_SCHEDULE",
+			execute: func(ctx context.Context, executionTime time.Time) {
+				// Executions in the early morning complete the newsletter of the previous day
+				if executionTime.Hour() < 6 {
+					executionTime = executionTime.AddDate(0, 0, -1)
+				}
+				instance.newsletterService.RegisterNewNewsletter(ctx, executionTime)
+			},
+		},
+		{
+			name:                        "unfinished_events_update",
+			scheduleEnvironmentVariable: "UNFINISHED_EVENTS_UPDATE_SCHEDULE",
+			execute: func(ctx context.Context, _ time.Time) {
+				instance.eventService.UpdateEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished(ctx)
+			},
+		},
+	}
+}
+
+// Start executes each job independently according to the cron expression configured in its environment variable,
+// which is evaluated in the Brazil time zone. Jobs with an empty expression are disabled. When the context is
+// canceled, no new executions are started and the method returns after the executions in progress finish.
+func (instance Scheduler) Start(ctx context.Context) error {
+	jobs, schedules, err := instance.getEnabledJobs()
+	if err != nil {
+		log.Error("getEnabledJobs(): ", err.Error())
+		return err
+	}
+
+	var waitGroup sync.WaitGroup
+	for index, job := range jobs {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			instance.runJob(ctx, job, schedules[index])
+		}()
+	}
+	waitGroup.Wait()
+
+	if ctx.Err() != nil {
+		log.Info("All scheduled jobs have been stopped")
+		return nil
+	}
+
+	return errors.New("All scheduled jobs have stopped")
+}
+
+// RunJobsOnce executes each enabled job a single time, sequentially, regardless of its schedule
+func (instance Scheduler) RunJobsOnce(ctx context.Context) error {
+	jobs, _, err := instance.getEnabledJobs()
+	if err != nil {
+		log.Error("getEnabledJobs(): ", err.Error())
+		return err
+	}
+
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			return errors.New(fmt.Sprint("The execution of the jobs was interrupted: ", ctx.Err().Error()))
+		}
+
+		currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+		if err != nil {
+			log.Error("datetime.GetCurrentDateTimeInBrazil(): ", err.Error())
+			return err
+		}
+
+		instance.executeJob(ctx, job, *currentDateTime)
+	}
+
+	return nil
+}
+
+func (instance Scheduler) getEnabledJobs() ([]scheduledJob, []*crons.Schedule, error) {
+	var enabledJobs []scheduledJob
+	var schedules []*crons.Schedule
+	for _, job := range instance.getJobs() {
+		cronExpression := os.Getenv(job.scheduleEnvironmentVariable)
+		if cronExpression == "" {
+			log.Infof("Job %s is disabled because environment variable %s is empty", job.name,
+				job.scheduleEnvironmentVariable)
+			continue
+		}
+
+		schedule, err := crons.Parse(cronExpression)
+		if err != nil {
+			return nil, nil, errors.New(fmt.Sprintf("Error converting environment variable %s to cron expression: %s",
+				job.scheduleEnvironmentVariable, err.Error()))
+		}
+
+		enabledJobs = append(enabledJobs, job)
+		schedules = append(schedules, schedule)
+	}
+
+	if enabledJobs == nil {
+		return nil, nil, errors.New("No job is scheduled")
+	}
+
+	return enabledJobs, schedules, nil
+}
+
+// runJob executes the job whenever its schedule is reached. Since the job runs in its own goroutine and the next
+// execution is only calculated after the previous one finishes, executions of the same job never overlap. Executions
+// missed while the job was running or while the service was stopped are caught up with a single execution.
+func (instance Scheduler) runJob(ctx context.Context, job scheduledJob, schedule *crons.Schedule) {
+	lastExecutionTime, err := instance.getLastExecutionTime(ctx, job.name)
+	if err != nil {
+		log.Error("getLastExecutionTime(): ", err.Error())
+		return
+	}
+
+	for {
+		nextExecutionTime := schedule.Next(lastExecutionTime)
+		if nextExecutionTime.IsZero() {
+			log.Errorf("The schedule of job %s has no upcoming executions", job.name)
+			return
+		}
+
+		currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+		if err != nil {
+			log.Error("datetime.GetCurrentDateTimeInBrazil(): ", err.Error())
+			return
+		}
+
+		executionTime := nextExecutionTime
+		if nextExecutionTime.After(*currentDateTime) {
+			log.Infof("Next execution of job %s scheduled for %s", job.name,
+				nextExecutionTime.Format("02/01/2006 15:04"))
+			err = contexts.Sleep(ctx, nextExecutionTime.Sub(*currentDateTime))
+			if err != nil {
+				log.Infof("Job %s has been stopped", job.name)
+				return
+			}
+		} else {
+			log.Warnf("The execution of job %s scheduled for %s was missed, executing it now", job.name,
+				nextExecutionTime.Format("02/01/2006 15:04"))
+			executionTime = *currentDateTime
+		}
+
+		instance.executeJob(ctx, job, executionTime)
+		lastExecutionTime = executionTime
+
+		// Executions interrupted by the shutdown of the service are not recorded, so they are caught up when the
+		// service starts again
+		if ctx.Err() != nil {
+			log.Infof("Job %s has been stopped", job.name)
+			return
+		}
+
+		err = instance.scheduledJobRepository.SaveLastExecutionTime(ctx, job.name, executionTime)
+		if err != nil {
+			log.Error("scheduledJobRepository.SaveLastExecutionTime(): ", err.Error())
+		}
+	}
+}
+
+func (instance Scheduler) executeJob(ctx context.Context, job scheduledJob, executionTime time.Time) {
+	log.Infof("Starting job %s", job.name)
+	ctx, err := instance.processingLedgerService.StartRun(ctx, job.name)
+	if err != nil {
+		log.Error("processingLedgerService.StartRun(): ", err.Error())
+	}
+
+	startTime := time.Now()
+	job.execute(ctx, executionTime)
+	log.Infof("Job %s finished in %s", job.name, time.Since(startTime).Round(time.Second))
+
+	instance.processingLedgerService.FinishRun(ctx)
+}
+
+// getLastExecutionTime returns the last execution time of the job in the Brazil time zone. Jobs that have never been
+// executed are considered executed now, so they only run at the next scheduled time.
+func (instance Scheduler) getLastExecutionTime(ctx context.Context, jobName string) (time.Time, error) {
+	currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+	if err != nil {
+		log.Error("datetime.GetCurrentDateTimeInBrazil(): ", err.Error())
+		return time.Time{}, err
+	}
+
+	lastExecutionTime, err := instance.scheduledJobRepository.GetLastExecutionTime(ctx, jobName)
+	if err != nil {
+		log.Error("scheduledJobRepository.GetLastExecutionTime(): ", err.Error())
+		return time.Time{}, err
+	} else if lastExecutionTime == nil {
+		return *currentDateTime, nil
+	}
+
+	// The execution time is stored with its time zone, but it is returned in the time zone of the database session
+	return lastExecutionTime.In(currentDateTime.Location()), nil
+}
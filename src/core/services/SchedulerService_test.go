@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+	"vnc-summarizer/adapters/databases/memory"
+	"vnc-summarizer/utils/datetime"
+)
+
+func TestGetLastExecutionTime(t *testing.T) {
+	currentDateTime, err := datetime.GetCurrentDateTimeInBrazil()
+	if err != nil {
+		t.Fatalf("datetime.GetCurrentDateTimeInBrazil(): %s", err.Error())
+	}
+	executionTime := time.Date(2025, 3, 10, 21, 30, 0, 0, currentDateTime.Location())
+	// The database driver returns the time in the time zone of the session, which is usually UTC
+	executionTimeInUtc := executionTime.UTC()
+
+	testCases := []struct {
+		name                  string
+		storedExecutionTime   *time.Time
+		expectedExecutionTime *time.Time
+	}{
+		{
+			name:                  "returns the execution time saved in the Brazil time zone",
+			storedExecutionTime:   &executionTime,
+			expectedExecutionTime: &executionTime,
+		},
+		{
+			name:                  "returns the execution time read in another time zone in the Brazil time zone",
+			storedExecutionTime:   &executionTimeInUtc,
+			expectedExecutionTime: &executionTime,
+		},
+		{
+			name: "returns the current time for jobs that have never been executed",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+			scheduledJobRepository := memory.NewScheduledJobRepository(memory.NewDatabase())
+			schedulerService := NewSchedulerService(nil, nil, nil, nil, nil, scheduledJobRepository)
+
+			if testCase.storedExecutionTime != nil {
+				err := scheduledJobRepository.SaveLastExecutionTime(ctx, "new_propositions",
+					*testCase.storedExecutionTime)
+				if err != nil {
+					t.Fatalf("SaveLastExecutionTime(): %s", err.Error())
+				}
+			}
+
+			startTime := time.Now()
+			lastExecutionTime, err := schedulerService.getLastExecutionTime(ctx, "new_propositions")
+			if err != nil {
+				t.Fatalf("getLastExecutionTime(): %s", err.Error())
+			}
+
+			if lastExecutionTime.Location().String() != currentDateTime.Location().String() {
+				t.Errorf("The last execution time was expected to be in the %s time zone, but it was in %s",
+					currentDateTime.Location(), lastExecutionTime.Location())
+			}
+			if testCase.expectedExecutionTime == nil {
+				if lastExecutionTime.Before(startTime.Add(-time.Second)) || lastExecutionTime.After(time.Now()) {
+					t.Errorf("The last execution time was expected to be the current time, but it was %s",
+						lastExecutionTime)
+				}
+			} else if lastExecutionTime.Format(time.RFC3339) != testCase.expectedExecutionTime.Format(time.RFC3339) {
+				t.Errorf("The last execution time was expected to be %s, but it was %s",
+					testCase.expectedExecutionTime.Format(time.RFC3339), lastExecutionTime.Format(time.RFC3339))
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/devlucassantos/vnc-domains/src/domains/article"
 	"github.com/devlucassantos/vnc-domains/src/domains/legislativebody"
@@ -8,100 +10,159 @@ import (
 	"github.com/devlucassantos/vnc-domains/src/domains/voting"
 	"github.com/google/uuid"
 	"github.com/labstack/gommon/log"
+	"path"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"vnc-summarizer/core/domains/generation"
+	"vnc-summarizer/core/domains/prompt"
 	"vnc-summarizer/core/interfaces/chamber"
-	"vnc-summarizer/core/interfaces/chatgpt"
+	"vnc-summarizer/core/interfaces/llm"
 	"vnc-summarizer/core/interfaces/postgres"
+	"vnc-summarizer/core/interfaces/prompts"
 	"vnc-summarizer/core/interfaces/services"
 	"vnc-summarizer/utils/converters"
-	"vnc-summarizer/utils/requesters"
+	"vnc-summarizer/utils/locks"
+	"vnc-summarizer/utils/workerpools"
 )
 
+const votingItemType = "voting"
+
 type Voting struct {
-	chamberApi             chamber.Chamber
-	chatGptApi             chatgpt.ChatGpt
-	votingRepository       postgres.Voting
-	articleTypeRepository  postgres.ArticleType
-	legislativeBodyService services.LegislativeBody
-	propositionService     services.Proposition
+	chamberApi              chamber.Chamber
+	llmApi                  llm.Llm
+	promptRegistry          prompts.Prompt
+	votingRepository        postgres.Voting
+	articleTypeRepository   postgres.ArticleType
+	unitOfWork              postgres.UnitOfWork
+	legislativeBodyService  services.LegislativeBody
+	propositionService      services.Proposition
+	processingLedgerService services.ProcessingLedger
 }
 
-func NewVotingService(chamberApi chamber.Chamber, chatGptApi chatgpt.ChatGpt, votingRepository postgres.Voting,
-	articleTypeRepository postgres.ArticleType, legislativeBodyService services.LegislativeBody,
-	propositionService services.Proposition) *Voting {
+func NewVotingService(chamberApi chamber.Chamber, llmApi llm.Llm, promptRegistry prompts.Prompt,
+	votingRepository postgres.Voting, articleTypeRepository postgres.ArticleType, unitOfWork postgres.UnitOfWork,
+	legislativeBodyService services.LegislativeBody, propositionService services.Proposition,
+	processingLedgerService services.ProcessingLedger) *Voting {
 	return &Voting{
-		chamberApi:             chamberApi,
-		chatGptApi:             chatGptApi,
-		votingRepository:       votingRepository,
-		articleTypeRepository:  articleTypeRepository,
-		legislativeBodyService: legislativeBodyService,
-		propositionService:     propositionService,
+		chamberApi:              chamberApi,
+		llmApi:                  llmApi,
+		promptRegistry:          promptRegistry,
+		votingRepository:        votingRepository,
+		articleTypeRepository:   articleTypeRepository,
+		unitOfWork:              unitOfWork,
+		legislativeBodyService:  legislativeBodyService,
+		propositionService:      propositionService,
+		processingLedgerService: processingLedgerService,
 	}
 }
 
-func (instance Voting) RegisterNewVotes() {
-	codesOfTheMostRecentVotesReturned, err := instance.getCodesOfTheMostRecentVotesRegisteredInTheChamber()
+func (instance Voting) RegisterNewVotes(ctx context.Context) {
+	codesOfTheMostRecentVotesReturned, err := instance.getCodesOfTheMostRecentVotesRegisteredInTheChamber(ctx)
 	if err != nil {
 		log.Error("getCodesOfTheMostRecentVotesRegisteredInTheChamber(): ", err.Error())
 		return
-	} else if codesOfTheMostRecentVotesReturned == nil {
+	}
+
+	codesOfTheMostRecentVotesReturned = append(codesOfTheMostRecentVotesReturned,
+		instance.processingLedgerService.GetCodesOfTheItemsToRetry(ctx, votingItemType)...)
+
+	err = instance.registerNewVotes(ctx, codesOfTheMostRecentVotesReturned)
+	if err != nil {
+		log.Error("registerNewVotes(): ", err.Error())
+	}
+}
+
+func (instance Voting) RegisterNewVotesByDateRange(ctx context.Context, startDate, endDate time.Time) error {
+	formattedStartDate := startDate.Format("02/01/2006")
+	formattedEndDate := endDate.Format("02/01/2006")
+	log.Infof("Starting the search for the votes held between %s and %s", formattedStartDate, formattedEndDate)
+
+	votes, err := instance.chamberApi.GetVotesByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		log.Error("chamberApi.GetVotesByDateRange(): ", err.Error())
+		return err
+	}
+
+	votingCodes := extractVotingCodes(votes)
+
+	log.Infof("Successful search for the votes held between %s and %s: %v", formattedStartDate, formattedEndDate,
+		votingCodes)
+	return instance.registerNewVotes(ctx, votingCodes)
+}
+
+// registerNewVotes registers the votes that are not yet registered and returns an error if any of them could not be
+// registered
+func (instance Voting) registerNewVotes(ctx context.Context, votingCodes []string) error {
+	if votingCodes == nil {
 		log.Info("No new votes were identified for registration")
-		return
+		return nil
 	}
 
-	votesRegistered, err := instance.votingRepository.GetVotesByCodes(codesOfTheMostRecentVotesReturned)
+	votesRegistered, err := instance.votingRepository.GetVotesByCodes(ctx, votingCodes)
 	if err != nil {
 		log.Error("votingRepository.GetVotesByCodes(): ", err.Error())
-		return
+		return err
 	}
 
-	codesOfTheNewVotes := getCodesOfTheNewVotes(codesOfTheMostRecentVotesReturned, votesRegistered)
+	codesOfTheNewVotes := getCodesOfTheNewVotes(votingCodes, votesRegistered)
 	if codesOfTheNewVotes != nil {
 		log.Infof("%d new votes were identified for registration: %v", len(codesOfTheNewVotes),
 			codesOfTheNewVotes)
 	} else {
 		log.Info("No new votes were identified for registration")
-		return
+		return nil
 	}
 
-	for _, votingCode := range codesOfTheNewVotes {
-		_, err = instance.RegisterNewVotingByCode(votingCode)
+	var codesOfTheVotesNotRegistered []string
+	var mutex sync.Mutex
+	numberOfWorkers := workerpools.GetNumberOfWorkers("VOTING_REGISTRATION_CONCURRENCY")
+	workerpools.Process(ctx, codesOfTheNewVotes, numberOfWorkers, func(ctx context.Context, votingCode string) {
+		_, err := instance.RegisterNewVotingByCode(ctx, votingCode)
 		if err != nil {
 			log.Error("RegisterNewVotingByCode(): ", err.Error())
+			mutex.Lock()
+			codesOfTheVotesNotRegistered = append(codesOfTheVotesNotRegistered, votingCode)
+			mutex.Unlock()
 		}
+	})
+
+	// The items that were not processed due to the cancellation are registered in the next runs
+	if ctx.Err() != nil {
+		return errors.New(fmt.Sprint("The registration of the votes was interrupted: ", ctx.Err().Error()))
 	}
 
-	return
+	if codesOfTheVotesNotRegistered != nil {
+		return errors.New(fmt.Sprintf("%d of %d votes could not be registered: %v",
+			len(codesOfTheVotesNotRegistered), len(codesOfTheNewVotes), codesOfTheVotesNotRegistered))
+	}
+
+	return nil
 }
 
-func (instance Voting) getCodesOfTheMostRecentVotesRegisteredInTheChamber() ([]string, error) {
+func (instance Voting) getCodesOfTheMostRecentVotesRegisteredInTheChamber(ctx context.Context) ([]string, error) {
 	log.Info("Starting the search for the most recent votes")
 
-	mostRecentVotes, err := instance.chamberApi.GetMostRecentVotes()
+	mostRecentVotes, err := instance.chamberApi.GetMostRecentVotes(ctx)
 	if err != nil {
 		log.Error("chamberApi.GetMostRecentVotes(): ", err.Error())
 		return nil, err
 	}
 
-	votingCodes, err := extractVotingCodes(mostRecentVotes)
-	if err != nil {
-		log.Error("extractVotingCodes(): ", err.Error())
-		return nil, err
-	}
+	votingCodes := extractVotingCodes(mostRecentVotes)
 
 	log.Info("Successful search for the latest votes: ", votingCodes)
 	return votingCodes, nil
 }
 
-func extractVotingCodes(votes []map[string]interface{}) ([]string, error) {
+func extractVotingCodes(votes []chamber.Voting) []string {
 	var votingCodes []string
 	for _, votingData := range votes {
-		votingCodes = append(votingCodes, fmt.Sprint(votingData["id"]))
+		votingCodes = append(votingCodes, votingData.Id)
 	}
 
-	return votingCodes, nil
+	return votingCodes
 }
 
 func getCodesOfTheNewVotes(returnedVotingCodes []string, registeredVotes []voting.Voting) []string {
@@ -124,90 +185,132 @@ func getCodesOfTheNewVotes(returnedVotingCodes []string, registeredVotes []votin
 	return votingCodesToRegister
 }
 
-func (instance Voting) RegisterNewVotingByCode(code string) (*uuid.UUID, error) {
-	votingData, err := instance.getVotingDataToRegister(code)
+// RegisterNewVotingByCode registers the voting unless it is already registered. The registration of the same voting is
+// never executed concurrently, so events that reference a voting being registered by another worker wait for it.
+func (instance Voting) RegisterNewVotingByCode(ctx context.Context, code string) (*uuid.UUID, error) {
+	defer locks.Lock(fmt.Sprint(votingItemType, ":", code))()
+
+	registeredVotes, err := instance.votingRepository.GetVotesByCodes(ctx, []string{code})
+	if err != nil {
+		log.Error("votingRepository.GetVotesByCodes(): ", err.Error())
+		return nil, err
+	} else if len(registeredVotes) > 0 {
+		votingId := registeredVotes[0].Id()
+		log.Infof("Voting %s is already registered", code)
+		return &votingId, nil
+	}
+
+	var votingId *uuid.UUID
+	registerVoting := func(ctx context.Context) error {
+		var err error
+		votingId, err = instance.registerNewVotingByCode(ctx, code)
+		return err
+	}
+	err = instance.processingLedgerService.ProcessItem(ctx, votingItemType, code, registerVoting)
+
+	return votingId, err
+}
+
+func (instance Voting) registerNewVotingByCode(ctx context.Context, code string) (*uuid.UUID, error) {
+	votingData, generationData, err := instance.getVotingDataToRegister(ctx, code)
 	if err != nil {
 		log.Errorf("Error retrieving data for voting %s: %s", code, err.Error())
 		return nil, err
 	}
 
-	votingId, err := instance.votingRepository.CreateVoting(*votingData)
+	// The legislative body of the voting is registered in the same transaction as the voting, so it is not left
+	// registered when the registration of the voting fails
+	var votingId *uuid.UUID
+	createVoting := func(ctx context.Context) error {
+		legislativeBody := votingData.LegislativeBody()
+		if legislativeBody.Id() == uuid.Nil {
+			legislativeBodyId, err := instance.legislativeBodyService.RegisterNewLegislativeBodyByCode(ctx,
+				legislativeBody.Code())
+			if err != nil {
+				log.Error("legislativeBodyService.RegisterNewLegislativeBodyByCode(): ", err.Error())
+				return err
+			}
+
+			legislativeBodyRegistered, err := legislativebody.NewBuilder().Id(*legislativeBodyId).Build()
+			if err != nil {
+				log.Errorf("Error updating legislative body %s: %s", legislativeBodyId, err.Error())
+				return err
+			}
+
+			votingData, err = votingData.NewUpdater().LegislativeBody(*legislativeBodyRegistered).Build()
+			if err != nil {
+				log.Errorf("Error updating data for voting %s: %s", code, err.Error())
+				return err
+			}
+		}
+
+		votingId, err = instance.votingRepository.CreateVoting(ctx, *votingData, *generationData)
+		if err != nil {
+			log.Error("votingRepository.CreateVoting(): ", err.Error())
+			return err
+		}
+
+		return nil
+	}
+	err = instance.unitOfWork.Execute(ctx, createVoting)
 	if err != nil {
-		log.Error("votingRepository.CreateVoting(): ", err.Error())
+		log.Error("unitOfWork.Execute(): ", err.Error())
 		return nil, err
 	}
 
 	return votingId, nil
 }
 
-func (instance Voting) getVotingDataToRegister(code string) (*voting.Voting, error) {
+func (instance Voting) getVotingDataToRegister(ctx context.Context, code string) (*voting.Voting,
+	*generation.Generation, error) {
 	log.Info("Starting data search for voting ", code)
 
-	votingData, err := instance.chamberApi.GetVotingByCode(code)
+	votingData, err := instance.chamberApi.GetVotingByCode(ctx, code)
 	if err != nil {
 		log.Error("chamberApi.GetVotingByCode(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	result := fmt.Sprint(votingData["descricao"])
-
 	var resultAnnouncedAt time.Time
-	resultAnnouncedAtAsString := fmt.Sprint(votingData["dataHoraRegistro"])
-	if resultAnnouncedAtAsString != "<nil>" {
-		resultAnnouncedAt, err = time.Parse("2006-01-02T15:04:05", resultAnnouncedAtAsString)
+	if votingData.ResultAnnouncedAt != nil {
+		resultAnnouncedAt, err = time.Parse(chamber.VotingDateTimeLayout, *votingData.ResultAnnouncedAt)
 	} else {
-		resultAnnouncedAtAsString = fmt.Sprint(votingData["data"])
-		resultAnnouncedAt, err = time.Parse("2006-01-02", resultAnnouncedAtAsString)
+		resultAnnouncedAt, err = time.Parse(chamber.VotingDateLayout, votingData.Date)
 	}
 	if err != nil {
 		log.Errorf("Error converting date and time of result announcement of voting %s: %s", code,
 			err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	var isApproved *bool
-	isApprovedAsString := fmt.Sprint(votingData["aprovacao"])
-	if resultAnnouncedAtAsString != "<nil>" {
-		isVotingApproved, err := strconv.ParseBool(isApprovedAsString)
-		if err != nil {
-			log.Errorf("Error converting result of voting %s to boolean: %s", code, err.Error())
-			return nil, err
-		}
-
+	if votingData.Approval != nil {
+		isVotingApproved := *votingData.Approval == 1
 		isApproved = &isVotingApproved
 	}
 
-	legislativeBodyCode, err := converters.ToInt(votingData["idOrgao"])
-	if err != nil {
-		log.Error("converters.ToInt(): ", err.Error())
-		return nil, err
-	}
+	legislativeBodyCode := votingData.LegislativeBodyCode
 
-	legislativeBody, err := instance.legislativeBodyService.GetLegislativeBodyByCode(legislativeBodyCode)
+	legislativeBody, err := instance.legislativeBodyService.GetLegislativeBodyByCode(ctx, legislativeBodyCode)
 	if err != nil {
 		log.Error("legislativeBodyService.GetLegislativeBodyDataByCode(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
+	// The legislative bodies not yet registered are only registered alongside the voting
 	if legislativeBody == nil {
-		legislativeBodyId, err := instance.legislativeBodyService.RegisterNewLegislativeBodyByCode(legislativeBodyCode)
+		legislativeBody, err = legislativebody.NewBuilder().Code(legislativeBodyCode).Build()
 		if err != nil {
-			log.Error("legislativeBodyService.RegisterNewLegislativeBodyByCode(): ", err.Error())
-			return nil, err
-		}
-
-		legislativeBodyRegistered, err := legislativebody.NewBuilder().Id(*legislativeBodyId).Build()
-		if err != nil {
-			log.Errorf("Error updating legislative body %s: %s", legislativeBodyId, err.Error())
-			return nil, err
+			log.Errorf("Error validating data for legislative body %d: %s", legislativeBodyCode, err.Error())
+			return nil, nil, err
 		}
-		legislativeBody = legislativeBodyRegistered
 	}
 
-	mainProposition, relatedPropositions, affectedPropositions, err := instance.getVotingRelatedPropositions(votingData)
+	mainProposition, relatedPropositions, affectedPropositions, err := instance.getVotingRelatedPropositions(ctx,
+		*votingData)
 	if err != nil {
 		log.Error("getVotingRelatedPropositions(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	numberOfArticles := 1
@@ -225,36 +328,38 @@ func (instance Voting) getVotingDataToRegister(code string) (*voting.Voting, err
 			affectedPropositions[index].Title(), affectedPropositions[index].Content())
 	}
 
-	chatGptCommand := fmt.Sprintf("Gere uma descrição para ser usada em uma matéria jornalistica sobre uma "+
-		"votação legislativa que teve como resultado '%s'. Essa votação foi relacionada ao conjunto de matérias abaixo, "+
-		"que são sobre proposições legislativas. É importante que a descrição seja curta e chamativa, falando sobre o "+
-		"máximo de matérias possíveis, correlacionando os temas e o resultado, utilizando uma linguagem simples e "+
-		"direta, não possuindo mais do que 500 caracteres e sem referenciar quando ocorreu a votação. Matérias:\n\n",
-		result)
+	descriptionPrompt, err := instance.promptRegistry.GetPrompt("voting_description", "",
+		prompt.Variables{VotingResult: votingData.Result})
+	if err != nil {
+		log.Error("promptRegistry.GetPrompt(): ", err.Error())
+		return nil, nil, err
+	}
+
 	purpose := fmt.Sprint("Generating the description for voting ", code)
-	description, err := instance.chatGptApi.MakeRequest(chatGptCommand, contentOfArticles, purpose)
+	description, descriptionUsage, err := instance.llmApi.MakeRequest(ctx, descriptionPrompt.Text(), contentOfArticles,
+		purpose)
 	if err != nil {
-		log.Error("chatGptApi.MakeRequest(): ", err.Error())
-		return nil, err
+		log.Error("llmApi.MakeRequest(): ", err.Error())
+		return nil, nil, err
 	}
 
 	articleTypeCode := "voting"
-	articleType, err := instance.articleTypeRepository.GetArticleTypeByCode(articleTypeCode)
+	articleType, err := instance.articleTypeRepository.GetArticleTypeByCode(ctx, articleTypeCode)
 	if err != nil {
 		log.Error("articleTypeRepository.GetArticleTypeByCode(): ", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	articleData, err := article.NewBuilder().Type(*articleType).Build()
 	if err != nil {
 		log.Errorf("Error validating article data for voting %s: %s", code, err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	votingBuilder := voting.NewBuilder().
 		Code(code).
 		Description(description).
-		Result(fmt.Sprint(votingData["descricao"])).
+		Result(votingData.Result).
 		ResultAnnouncedAt(resultAnnouncedAt).
 		IsApproved(isApproved).
 		LegislativeBody(*legislativeBody).
@@ -269,43 +374,31 @@ func (instance Voting) getVotingDataToRegister(code string) (*voting.Voting, err
 	votingDomain, err := votingBuilder.Build()
 	if err != nil {
 		log.Errorf("Error validating data for voting %s: %s", code, err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	log.Infof("Data search for voting %s successful", code)
-	return votingDomain, err
-}
-
-func (instance Voting) getVotingRelatedPropositions(votingData map[string]interface{}) (*proposition.Proposition,
-	[]proposition.Proposition, []proposition.Proposition, error) {
-	var mainPropositionData map[string]interface{}
-	var err error
-	mainPropositionData, err = getMainPropositionData(votingData["ultimaApresentacaoProposicao"])
+	generationData, err := generation.NewBuilder().Prompts(*descriptionPrompt).Usages(*descriptionUsage).Build()
 	if err != nil {
-		log.Error("getMainPropositionData(): ", err.Error())
-		return nil, nil, nil, err
+		log.Errorf("Error validating generation data for voting %s: %s", code, err.Error())
+		return nil, nil, err
 	}
 
-	relatedPropositionsMapSlice, err := converters.ToMapSlice(votingData["objetosPossiveis"])
-	if err != nil {
-		log.Error("converters.ToMapSlice(): ", err.Error())
-		return nil, nil, nil, err
-	}
+	log.Infof("Data search for voting %s successful", code)
+	return votingDomain, generationData, err
+}
 
-	affectedPropositionsMapSlice, err := converters.ToMapSlice(votingData["proposicoesAfetadas"])
+func (instance Voting) getVotingRelatedPropositions(ctx context.Context, votingData chamber.Voting) (
+	*proposition.Proposition, []proposition.Proposition, []proposition.Proposition, error) {
+	mainPropositionCode, err := getMainPropositionCode(votingData.LastPresentation)
 	if err != nil {
-		log.Error("converters.ToMapSlice(): ", err.Error())
+		log.Error("getMainPropositionCode(): ", err.Error())
 		return nil, nil, nil, err
 	}
 
-	votingRelatedPropositionCodes, err := getVotingRelatedPropositionCodes(mainPropositionData,
-		relatedPropositionsMapSlice, affectedPropositionsMapSlice)
-	if err != nil {
-		log.Error("getVotingRelatedPropositionCodes(): ", err.Error())
-		return nil, nil, nil, err
-	}
+	votingRelatedPropositionCodes := getVotingRelatedPropositionCodes(mainPropositionCode,
+		votingData.RelatedPropositions, votingData.AffectedPropositions)
 
-	relatedPropositionsAlreadyRegistered, err := instance.propositionService.GetPropositionsByCodes(
+	relatedPropositionsAlreadyRegistered, err := instance.propositionService.GetPropositionsByCodes(ctx,
 		votingRelatedPropositionCodes)
 	if err != nil {
 		log.Error("propositionRepository.GetPropositionsByCodes(): ", err.Error())
@@ -315,7 +408,7 @@ func (instance Voting) getVotingRelatedPropositions(votingData map[string]interf
 	codesOfThePropositionsToRegister := getCodesOfTheNewPropositions(votingRelatedPropositionCodes,
 		relatedPropositionsAlreadyRegistered)
 
-	registeredPropositions, err := instance.registerNewVotingRelatedPropositions(codesOfThePropositionsToRegister)
+	registeredPropositions, err := instance.registerNewVotingRelatedPropositions(ctx, codesOfThePropositionsToRegister)
 	if err != nil {
 		log.Error("registerNewVotingRelatedPropositions(): ", err.Error())
 		return nil, nil, nil, err
@@ -323,71 +416,53 @@ func (instance Voting) getVotingRelatedPropositions(votingData map[string]interf
 
 	votingRelatedPropositions := append(relatedPropositionsAlreadyRegistered, registeredPropositions...)
 
-	mainProposition, relatedPropositions, affectedPropositions := extractVotingRelatedPropositions(mainPropositionData,
-		relatedPropositionsMapSlice, affectedPropositionsMapSlice, votingRelatedPropositions)
+	mainProposition, relatedPropositions, affectedPropositions := extractVotingRelatedPropositions(mainPropositionCode,
+		votingData.RelatedPropositions, votingData.AffectedPropositions, votingRelatedPropositions)
 
 	return &mainProposition, relatedPropositions, affectedPropositions, nil
 }
 
-func getMainPropositionData(mainProposition interface{}) (map[string]interface{}, error) {
-	mainPropositionMap, err := converters.ToMap(mainProposition)
-	if err != nil {
-		log.Error("converters.ToMap(): ", err.Error())
-		return nil, err
+// getMainPropositionCode returns the code of the proposition cited by the last presentation before the voting, or
+// zero when the voting has no main proposition
+func getMainPropositionCode(lastPresentation *chamber.PropositionPresentation) (int, error) {
+	if lastPresentation == nil || lastPresentation.CitedPropositionUri == nil {
+		return 0, nil
 	}
 
-	var mainPropositionData map[string]interface{}
-	UrlOfTheMainProposition := fmt.Sprint(mainPropositionMap["uriProposicaoCitada"])
-	if UrlOfTheMainProposition != "<nil>" {
-		mainPropositionData, err = requesters.GetDataObjectFromUrl(UrlOfTheMainProposition)
-		if err != nil {
-			log.Error("requests.GetDataObjectFromUrl(): ", err.Error())
-			return nil, err
-		}
+	mainPropositionCode, err := strconv.Atoi(path.Base(*lastPresentation.CitedPropositionUri))
+	if err != nil {
+		log.Errorf("Error converting the code of the main proposition %s to integer: %s",
+			*lastPresentation.CitedPropositionUri, err.Error())
+		return 0, err
 	}
 
-	return mainPropositionData, nil
+	return mainPropositionCode, nil
 }
 
-func getVotingRelatedPropositionCodes(mainProposition map[string]interface{},
-	relatedPropositions, affectedPropositions []map[string]interface{}) ([]int, error) {
+func getVotingRelatedPropositionCodes(mainPropositionCode int,
+	relatedPropositions, affectedPropositions []chamber.PropositionReference) []int {
 	var votingRelatedPropositionCodes []int
 
-	if mainProposition != nil {
-		mainPropositionCode, err := converters.ToInt(mainProposition["id"])
-		if err != nil {
-			log.Error("converters.ToInt(): ", err.Error())
-			return nil, err
-		}
+	if mainPropositionCode != 0 {
 		votingRelatedPropositionCodes = append(votingRelatedPropositionCodes, mainPropositionCode)
 	}
 
 	for _, relatedProposition := range relatedPropositions {
-		relatedPropositionCode, err := converters.ToInt(relatedProposition["id"])
-		if err != nil {
-			log.Error("converters.ToInt(): ", err.Error())
-			return nil, err
-		}
-		votingRelatedPropositionCodes = append(votingRelatedPropositionCodes, relatedPropositionCode)
+		votingRelatedPropositionCodes = append(votingRelatedPropositionCodes, relatedProposition.Id)
 	}
 
 	for _, affectedProposition := range affectedPropositions {
-		affectedPropositionCode, err := converters.ToInt(affectedProposition["id"])
-		if err != nil {
-			log.Error("converters.ToInt(): ", err.Error())
-			return nil, err
-		}
-		votingRelatedPropositionCodes = append(votingRelatedPropositionCodes, affectedPropositionCode)
+		votingRelatedPropositionCodes = append(votingRelatedPropositionCodes, affectedProposition.Id)
 	}
 
-	return votingRelatedPropositionCodes, nil
+	return votingRelatedPropositionCodes
 }
 
-func (instance Voting) registerNewVotingRelatedPropositions(codesOfThePropositionsToRegister []int) (
-	[]proposition.Proposition, error) {
+func (instance Voting) registerNewVotingRelatedPropositions(ctx context.Context,
+	codesOfThePropositionsToRegister []int) ([]proposition.Proposition, error) {
 	var registeredPropositions []proposition.Proposition
 	for _, propositionCode := range codesOfThePropositionsToRegister {
-		propositionId, err := instance.propositionService.RegisterNewPropositionByCode(propositionCode)
+		propositionId, err := instance.propositionService.RegisterNewPropositionByCode(ctx, propositionCode)
 		if err != nil {
 			if strings.Contains(err.Error(), "no content") {
 				continue
@@ -407,27 +482,25 @@ func (instance Voting) registerNewVotingRelatedPropositions(codesOfThePropositio
 	return registeredPropositions, nil
 }
 
-func extractVotingRelatedPropositions(mainPropositionMap map[string]interface{},
-	relatedPropositionsMapSlice, affectedPropositionsMapSlice []map[string]interface{},
+func extractVotingRelatedPropositions(mainPropositionCode int,
+	relatedPropositionReferences, affectedPropositionReferences []chamber.PropositionReference,
 	propositionsAlreadyRegistered []proposition.Proposition) (proposition.Proposition, []proposition.Proposition,
 	[]proposition.Proposition) {
 	var mainProposition proposition.Proposition
 	var relatedPropositions, affectedPropositions []proposition.Proposition
 	for _, propositionData := range propositionsAlreadyRegistered {
-		if mainPropositionMap != nil {
-			if propositionData.Code() == int(mainPropositionMap["id"].(float64)) {
-				mainProposition = propositionData
-			}
+		if mainPropositionCode != 0 && propositionData.Code() == mainPropositionCode {
+			mainProposition = propositionData
 		}
 
-		for _, relatedPropositionData := range relatedPropositionsMapSlice {
-			if propositionData.Code() == int(relatedPropositionData["id"].(float64)) {
+		for _, relatedPropositionData := range relatedPropositionReferences {
+			if propositionData.Code() == relatedPropositionData.Id {
 				relatedPropositions = append(relatedPropositions, propositionData)
 			}
 		}
 
-		for _, affectedPropositionData := range affectedPropositionsMapSlice {
-			if propositionData.Code() == int(affectedPropositionData["id"].(float64)) {
+		for _, affectedPropositionData := range affectedPropositionReferences {
+			if propositionData.Code() == affectedPropositionData.Id {
 				affectedPropositions = append(affectedPropositions, propositionData)
 			}
 		}
@@ -436,8 +509,8 @@ func extractVotingRelatedPropositions(mainPropositionMap map[string]interface{},
 	return mainProposition, relatedPropositions, affectedPropositions
 }
 
-func (instance Voting) GetVotesByCodes(codes []string) ([]voting.Voting, error) {
-	votes, err := instance.votingRepository.GetVotesByCodes(codes)
+func (instance Voting) GetVotesByCodes(ctx context.Context, codes []string) ([]voting.Voting, error) {
+	votes, err := instance.votingRepository.GetVotesByCodes(ctx, codes)
 	if err != nil {
 		log.Error("votingRepository.GetVotesByCodes(): ", err.Error())
 		return nil, err
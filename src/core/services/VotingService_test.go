@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/devlucassantos/vnc-domains/src/domains/voting"
+	"testing"
+	"vnc-summarizer/core/interfaces/chamber"
+)
+
+func TestRegisterNewVotes(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		registeredCodes          []string
+		mostRecentCodes          []string
+		expectedRegisteredCodes  []string
+		expectedNumberOfSearches int
+	}{
+		{
+			name:                     "registers the new votes",
+			mostRecentCodes:          []string{"2438516-54", "2438516-60"},
+			expectedRegisteredCodes:  []string{"2438516-54", "2438516-60"},
+			expectedNumberOfSearches: 2,
+		},
+		{
+			name:                     "registers only the votes that are not registered yet",
+			registeredCodes:          []string{"2438516-54"},
+			mostRecentCodes:          []string{"2438516-54", "2438516-60", "2438516-60"},
+			expectedRegisteredCodes:  []string{"2438516-54", "2438516-60"},
+			expectedNumberOfSearches: 1,
+		},
+		{
+			name:                     "does nothing when there are no new votes",
+			registeredCodes:          []string{"2438516-54"},
+			mostRecentCodes:          []string{"2438516-54"},
+			expectedRegisteredCodes:  []string{"2438516-54"},
+			expectedNumberOfSearches: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			ctx := context.Background()
+
+			environment.chamberApi.addProposition(1001)
+			for _, code := range testCase.registeredCodes {
+				environment.chamberApi.addVoting(code, 1001)
+				_, err := environment.votingService.RegisterNewVotingByCode(ctx, code)
+				if err != nil {
+					t.Fatalf("RegisterNewVotingByCode(): %s", err.Error())
+				}
+			}
+			numberOfPreviousSearches := environment.chamberApi.getNumberOfCalls("GetVotingByCode")
+
+			for _, code := range testCase.mostRecentCodes {
+				environment.chamberApi.addVoting(code, 1001)
+			}
+			environment.chamberApi.mostRecentVotingCodes = testCase.mostRecentCodes
+
+			environment.votingService.RegisterNewVotes(ctx)
+
+			numberOfSearches := environment.chamberApi.getNumberOfCalls("GetVotingByCode") - numberOfPreviousSearches
+			if numberOfSearches != testCase.expectedNumberOfSearches {
+				t.Errorf("%d searches of votes were expected, but %d were made", testCase.expectedNumberOfSearches,
+					numberOfSearches)
+			}
+
+			votes, err := environment.votingRepository.GetVotesByCodes(ctx, testCase.mostRecentCodes)
+			if err != nil {
+				t.Fatalf("GetVotesByCodes(): %s", err.Error())
+			}
+			if len(votes) != len(testCase.expectedRegisteredCodes) {
+				t.Fatalf("%d votes were expected to be registered, but %d were registered",
+					len(testCase.expectedRegisteredCodes), len(votes))
+			}
+			for _, code := range testCase.expectedRegisteredCodes {
+				if getVotingByCode(votes, code) == nil {
+					t.Errorf("Voting %s was not registered", code)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterNewVotingByCode(t *testing.T) {
+	environment := newTestEnvironment(t)
+	ctx := context.Background()
+
+	for _, propositionCode := range []int{1001, 1002, 1003, 1004} {
+		environment.chamberApi.addProposition(propositionCode)
+	}
+	propositionWithoutContent := environment.chamberApi.propositions[1004]
+	propositionWithoutContent.OriginalTextUrl = nil
+	environment.chamberApi.setProposition(propositionWithoutContent)
+
+	_, err := environment.propositionService.RegisterNewPropositionByCode(ctx, 1002)
+	if err != nil {
+		t.Fatalf("RegisterNewPropositionByCode(): %s", err.Error())
+	}
+
+	environment.chamberApi.addVoting("2438516-54", 1001)
+	votingData := environment.chamberApi.votes["2438516-54"]
+	votingData.RelatedPropositions = []chamber.PropositionReference{{Id: 1002}, {Id: 1004}}
+	votingData.AffectedPropositions = []chamber.PropositionReference{{Id: 1003}}
+	environment.chamberApi.setVoting(votingData)
+
+	votingId, err := environment.votingService.RegisterNewVotingByCode(ctx, "2438516-54")
+	if err != nil {
+		t.Fatalf("RegisterNewVotingByCode(): %s", err.Error())
+	}
+
+	votes, err := environment.votingRepository.GetVotesByCodes(ctx, []string{"2438516-54"})
+	if err != nil {
+		t.Fatalf("GetVotesByCodes(): %s", err.Error())
+	}
+	registeredVoting := getVotingByCode(votes, "2438516-54")
+	if registeredVoting == nil || registeredVoting.Id() != *votingId {
+		t.Fatalf("Voting %s was not registered", votingId)
+	}
+
+	mainProposition := registeredVoting.MainProposition()
+	if mainProposition.Code() != 1001 {
+		t.Errorf("The main proposition of the voting was expected to be 1001, but it was %d", mainProposition.Code())
+	}
+	if len(registeredVoting.RelatedPropositions()) != 1 || len(registeredVoting.AffectedPropositions()) != 1 {
+		t.Errorf("The voting was expected to have 1 related and 1 affected proposition, but it has %d and %d",
+			len(registeredVoting.RelatedPropositions()), len(registeredVoting.AffectedPropositions()))
+	}
+	if approved := registeredVoting.IsApproved(); approved == nil || !*approved {
+		t.Error("The voting was expected to be approved")
+	}
+
+	propositions, err := environment.propositionRepository.GetPropositionsByCodes(ctx, []int{1001, 1002, 1003, 1004})
+	if err != nil {
+		t.Fatalf("GetPropositionsByCodes(): %s", err.Error())
+	}
+	if len(propositions) != 3 {
+		t.Errorf("3 propositions were expected to be registered, but %d were registered", len(propositions))
+	}
+	if numberOfSearches := environment.chamberApi.getNumberOfCalls("GetPropositionByCode"); numberOfSearches != 4 {
+		t.Errorf("The propositions registered before the voting were expected not to be searched again, but %d "+
+			"searches were made", numberOfSearches)
+	}
+
+	legislativeBody, err := environment.legislativeBodyRepository.GetLegislativeBodyByCode(ctx,
+		fakeLegislativeBodyCode)
+	if err != nil {
+		t.Fatalf("GetLegislativeBodyByCode(): %s", err.Error())
+	}
+	legislativeBodyOfTheVoting := registeredVoting.LegislativeBody()
+	if legislativeBody == nil || legislativeBody.Id() != legislativeBodyOfTheVoting.Id() {
+		t.Error("The legislative body of the voting was not registered alongside the voting")
+	}
+}
+
+func TestRegisterNewVotingByCodeErrors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configure func(t *testing.T, environment *testEnvironment)
+	}{
+		{
+			name: "returns the error of the Chamber of Deputies API",
+			configure: func(t *testing.T, environment *testEnvironment) {
+				environment.chamberApi.failOperation("GetVotingByCode", errors.New("Service unavailable"), -1)
+			},
+		},
+		{
+			name: "returns the error of the registration of the main proposition",
+			configure: func(t *testing.T, environment *testEnvironment) {
+				environment.database.FailOperation("CreateProposition", errors.New("The connection was closed"))
+			},
+		},
+		{
+			name: "returns the error of the language model",
+			configure: func(t *testing.T, environment *testEnvironment) {
+				// The image of the main proposition is skipped so only the description of the voting uses the model
+				t.Setenv("DAILY_BUDGET_CEILING", "0")
+				environment.llmApi.failOperation("MakeRequest", errors.New("The model is overloaded"), -1)
+			},
+		},
+		{
+			name: "undoes the registration of the legislative body when the voting is not registered",
+			configure: func(t *testing.T, environment *testEnvironment) {
+				environment.database.FailOperation("CreateVoting", errors.New("The connection was closed"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := newTestEnvironment(t)
+			ctx := context.Background()
+
+			environment.chamberApi.addProposition(1001)
+			environment.chamberApi.addVoting("2438516-54", 1001)
+			testCase.configure(t, environment)
+
+			votingId, err := environment.votingService.RegisterNewVotingByCode(ctx, "2438516-54")
+			if err == nil {
+				t.Fatalf("An error was expected, but voting %s was registered", votingId)
+			}
+
+			votes, err := environment.votingRepository.GetVotesByCodes(ctx, []string{"2438516-54"})
+			if err != nil {
+				t.Fatalf("GetVotesByCodes(): %s", err.Error())
+			}
+			if len(votes) > 0 {
+				t.Error("The voting was registered despite the error")
+			}
+
+			legislativeBody, err := environment.legislativeBodyRepository.GetLegislativeBodyByCode(ctx,
+				fakeLegislativeBodyCode)
+			if err != nil {
+				t.Fatalf("GetLegislativeBodyByCode(): %s", err.Error())
+			}
+			if legislativeBody != nil {
+				t.Error("The legislative body was registered despite the error in the registration of the voting")
+			}
+
+			status, _ := environment.database.GetProcessingItemStatus(votingItemType, "2438516-54")
+			if status != processingItemFailed {
+				t.Errorf("The processing of the voting was expected to be failed, but it was %s", status)
+			}
+		})
+	}
+}
+
+func getVotingByCode(votes []voting.Voting, code string) *voting.Voting {
+	for _, votingData := range votes {
+		if votingData.Code() == code {
+			return &votingData
+		}
+	}
+
+	return nil
+}
@@ -1,12 +1,13 @@
 package main
 
 import (
+	"context"
 	"github.com/joho/godotenv"
 	"github.com/labstack/gommon/log"
 	"os"
-	"time"
-	"vnc-summarizer/config/dicontainer"
-	"vnc-summarizer/utils/datetime"
+	"os/signal"
+	"syscall"
+	"vnc-summarizer/commands"
 )
 
 func main() {
@@ -17,37 +18,13 @@ func main() {
 		}
 	}
 
-	propositionService := dicontainer.GetPropositionService()
-	newsletterService := dicontainer.GetNewsletterService()
-	votingService := dicontainer.GetVotingService()
-	eventService := dicontainer.GetEventService()
+	// SIGTERM is sent by the container runtime on restarts, so the service stops starting new work and the items in
+	// progress are finished or aborted without leaving partial records
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	for {
-		startTime, err := datetime.GetCurrentDateTimeInBrazil()
-		if err != nil {
-			log.Fatal("datetime.GetCurrentDateTimeInBrazil(): ", err)
-			return
-		}
-
-		propositionService.RegisterNewPropositions()
-		votingService.RegisterNewVotes()
-		eventService.UpdateEventsOccurringToday()
-		eventService.RegisterNewEvents()
-
-		if startTime.Hour() >= 18 {
-			newsletterService.RegisterNewNewsletter(*startTime)
-		} else if startTime.Hour() < 6 {
-			newsletterService.RegisterNewNewsletter(startTime.AddDate(0, 0, -1))
-		}
-
-		if startTime.Hour() == 7 {
-			eventService.UpdateEventsThatStartedInTheLastThreeMonthsAndHaveNotFinished()
-		}
-
-		elapsedTime := time.Since(*startTime)
-		sleepDuration := time.Hour - elapsedTime
-		if sleepDuration > 0 {
-			time.Sleep(sleepDuration)
-		}
+	err := commands.Execute(ctx, os.Args[1:])
+	if err != nil {
+		log.Fatal(err.Error())
 	}
 }
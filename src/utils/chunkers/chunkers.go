@@ -0,0 +1,130 @@
+package chunkers
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var legislativeStructureRegex = regexp.MustCompile(`(?i)^\s*(art\.|artigo\s|§|par[áa]grafo\s+[úu]nico|inciso\s|` +
+	`al[íi]nea\s|cap[íi]tulo\s|se[çc][ãa]o\s|t[íi]tulo\s|livro\s|anexo\b|justifica[çc][ãa]o\b|` +
+	`[IVXLCDM]+\s*[-–—]|[a-z]\)\s)`)
+var sentenceEndingRegex = regexp.MustCompile(`[.!?;:]["')\]]*\s+`)
+
+type segmenter func(text string) []string
+
+// Split divides the content into chunks with no more than tokenLimit tokens. The content is divided preferably at
+// paragraph boundaries and at the structural units of Brazilian legislative texts (articles, paragraphs, items,
+// chapters...). Units that do not fit in a chunk are divided into sentences, then words and, as a last resort, runes.
+// The tokens are counted by the tokenizer of the model that receives the chunks.
+func Split(content string, tokenLimit int, countTokens func(text string) int) []string {
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+
+	return split(content, tokenLimit, countTokens, []segmenter{splitIntoStructuralUnits, splitIntoSentences,
+		splitIntoWords, splitIntoRunes})
+}
+
+func split(text string, tokenLimit int, countTokens func(text string) int, segmenters []segmenter) []string {
+	if countTokens(text) <= tokenLimit || len(segmenters) == 0 {
+		return []string{text}
+	}
+
+	var chunks []string
+	var currentChunk string
+	var currentChunkTokens int
+	for _, segment := range segmenters[0](text) {
+		segmentTokens := countTokens(segment)
+		if currentChunkTokens+segmentTokens <= tokenLimit {
+			currentChunk += segment
+			currentChunkTokens += segmentTokens
+			continue
+		}
+
+		if strings.TrimSpace(currentChunk) != "" {
+			chunks = append(chunks, currentChunk)
+		}
+		currentChunk, currentChunkTokens = "", 0
+
+		if segmentTokens > tokenLimit {
+			segmentChunks := split(segment, tokenLimit, countTokens, segmenters[1:])
+			lastSegmentChunk := segmentChunks[len(segmentChunks)-1]
+			chunks = append(chunks, segmentChunks[:len(segmentChunks)-1]...)
+			currentChunk, currentChunkTokens = lastSegmentChunk, countTokens(lastSegmentChunk)
+		} else {
+			currentChunk, currentChunkTokens = segment, segmentTokens
+		}
+	}
+
+	if strings.TrimSpace(currentChunk) != "" {
+		chunks = append(chunks, currentChunk)
+	}
+
+	return chunks
+}
+
+func splitIntoStructuralUnits(text string) []string {
+	var units []string
+	var currentUnit strings.Builder
+	var previousLineIsBlank bool
+	for _, line := range strings.SplitAfter(text, "\n") {
+		lineIsBlank := strings.TrimSpace(line) == ""
+		startsNewUnit := !lineIsBlank && (previousLineIsBlank || legislativeStructureRegex.MatchString(line))
+		if startsNewUnit && currentUnit.Len() > 0 {
+			units = append(units, currentUnit.String())
+			currentUnit.Reset()
+		}
+		currentUnit.WriteString(line)
+		previousLineIsBlank = lineIsBlank
+	}
+
+	if currentUnit.Len() > 0 {
+		units = append(units, currentUnit.String())
+	}
+
+	return units
+}
+
+func splitIntoSentences(text string) []string {
+	var sentences []string
+	start := 0
+	for _, sentenceEnding := range sentenceEndingRegex.FindAllStringIndex(text, -1) {
+		sentences = append(sentences, text[start:sentenceEnding[1]])
+		start = sentenceEnding[1]
+	}
+
+	if start < len(text) {
+		sentences = append(sentences, text[start:])
+	}
+
+	return sentences
+}
+
+func splitIntoWords(text string) []string {
+	var words []string
+	start := 0
+	for index, character := range text {
+		if unicode.IsSpace(character) {
+			end := index + utf8.RuneLen(character)
+			words = append(words, text[start:end])
+			start = end
+		}
+	}
+
+	if start < len(text) {
+		words = append(words, text[start:])
+	}
+
+	return words
+}
+
+func splitIntoRunes(text string) []string {
+	var runes []string
+	for _, character := range text {
+		runes = append(runes, string(character))
+	}
+
+	return runes
+}
@@ -0,0 +1,69 @@
+package chunkers
+
+import (
+	"slices"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplit(t *testing.T) {
+	testCases := []struct {
+		name           string
+		content        string
+		tokenLimit     int
+		expectedChunks []string
+	}{
+		{
+			name:           "returns no chunks for empty content",
+			content:        " \n ",
+			tokenLimit:     10,
+			expectedChunks: nil,
+		},
+		{
+			name:           "keeps the content that fits in the limit in a single chunk",
+			content:        "Art. 1º Texto.",
+			tokenLimit:     100,
+			expectedChunks: []string{"Art. 1º Texto."},
+		},
+		{
+			name:           "divides the content at the structural units of legislative texts",
+			content:        "Art. 1º Primeiro.\nArt. 2º Segundo.\n",
+			tokenLimit:     20,
+			expectedChunks: []string{"Art. 1º Primeiro.\n", "Art. 2º Segundo.\n"},
+		},
+		{
+			name:           "divides the content at paragraph boundaries",
+			content:        "Um.\n\nDois.\n",
+			tokenLimit:     6,
+			expectedChunks: []string{"Um.\n\n", "Dois.\n"},
+		},
+		{
+			name:           "divides the units that do not fit in the limit into sentences",
+			content:        "Primeira frase. Segunda frase.",
+			tokenLimit:     16,
+			expectedChunks: []string{"Primeira frase. ", "Segunda frase."},
+		},
+		{
+			name:           "divides the sentences that do not fit in the limit into words",
+			content:        "palavra palavra palavra",
+			tokenLimit:     10,
+			expectedChunks: []string{"palavra ", "palavra ", "palavra"},
+		},
+		{
+			name:           "divides the words that do not fit in the limit into runes",
+			content:        "açãoéf",
+			tokenLimit:     4,
+			expectedChunks: []string{"ação", "éf"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Each rune is counted as a token so the size of the chunks is predictable
+			chunks := Split(testCase.content, testCase.tokenLimit, utf8.RuneCountInString)
+			if !slices.Equal(chunks, testCase.expectedChunks) {
+				t.Fatalf("Split() returned %q instead of %q", chunks, testCase.expectedChunks)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package contexts
+
+import (
+	"context"
+	"github.com/labstack/gommon/log"
+	"os"
+	"time"
+)
+
+// GetTimeout returns the duration configured in the environment variable, which is the default timeout when the
+// variable is empty or invalid
+func GetTimeout(environmentVariable string, defaultTimeout time.Duration) time.Duration {
+	timeoutAsString := os.Getenv(environmentVariable)
+	if timeoutAsString == "" {
+		return defaultTimeout
+	}
+
+	timeout, err := time.ParseDuration(timeoutAsString)
+	if err != nil || timeout <= 0 {
+		log.Warnf("The value of environment variable %s must be a positive duration, using the default timeout "+
+			"of %s", environmentVariable, defaultTimeout)
+		return defaultTimeout
+	}
+
+	return timeout
+}
+
+// WithTimeout returns a copy of the context that is canceled when the timeout configured in the environment variable
+// expires
+func WithTimeout(ctx context.Context, environmentVariable string, defaultTimeout time.Duration) (context.Context,
+	context.CancelFunc) {
+	return context.WithTimeout(ctx, GetTimeout(environmentVariable, defaultTimeout))
+}
+
+// WithGracePeriod returns a context that is only canceled when the grace period expires after the cancellation of the
+// parent context. It allows operations already started to finish when the service is shutting down, while the parent
+// context prevents new operations from being started.
+func WithGracePeriod(ctx context.Context, gracePeriod time.Duration) (context.Context, context.CancelFunc) {
+	gracefulCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		time.AfterFunc(gracePeriod, cancel)
+	})
+
+	return gracefulCtx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// Sleep pauses the execution for the informed duration, returning the error of the context if it is canceled first
+func Sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -61,6 +61,23 @@ func ToMapSlice(data interface{}) ([]map[string]interface{}, error) {
 	return resultMap, nil
 }
 
+func ToStringSlice(data interface{}) ([]string, error) {
+	jsonData, err := ToJson(data)
+	if err != nil {
+		log.Error("ToJson(): ", err.Error())
+		return nil, err
+	}
+
+	var resultSlice []string
+	err = json.Unmarshal(jsonData, &resultSlice)
+	if err != nil {
+		log.Error("Error converting data to []string: ", err.Error())
+		return nil, err
+	}
+
+	return resultSlice, nil
+}
+
 func IntSliceToUniqueIntSlice(data []int) []int {
 	var uniqueIntSlice []int
 	uniqueIntMap := map[int]struct{}{}
@@ -0,0 +1,178 @@
+package crons
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule represents a cron expression with the standard five fields (minute, hour, day of month, month and day of
+// week). Each field accepts *, numbers, ranges (1-5), lists (1,3,5) and steps (*/15 or 0-30/10).
+type Schedule struct {
+	minutes                uint64
+	hours                  uint64
+	daysOfMonth            uint64
+	months                 uint64
+	daysOfWeek             uint64
+	isDayOfMonthRestricted bool
+	isDayOfWeekRestricted  bool
+}
+
+type field struct {
+	name    string
+	minimum int
+	maximum int
+}
+
+var fields = []field{
+	{name: "minute", minimum: 0, maximum: 59},
+	{name: "hour", minimum: 0, maximum: 23},
+	{name: "day of month", minimum: 1, maximum: 31},
+	{name: "month", minimum: 1, maximum: 12},
+	{name: "day of week", minimum: 0, maximum: 7},
+}
+
+// Maximum period searched for the next execution, which avoids an infinite search for expressions that never match
+// (e.g. 0 0 31 2 *)
+const maximumNumberOfYearsSearched = 5
+
+func Parse(expression string) (*Schedule, error) {
+	expressionFields := strings.Fields(expression)
+	if len(expressionFields) != len(fields) {
+		return nil, errors.New(fmt.Sprintf("The cron expression %q must have %d fields", expression,
+			len(fields)))
+	}
+
+	values := make([]uint64, len(fields))
+	for index, expressionField := range expressionFields {
+		value, err := parseField(expressionField, fields[index])
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Invalid cron expression %q: %s", expression, err.Error()))
+		}
+		values[index] = value
+	}
+
+	// Sunday can be represented by both 0 and 7
+	daysOfWeek := values[4]
+	if daysOfWeek&(1<<7) != 0 {
+		daysOfWeek = (daysOfWeek | 1) &^ (1 << 7)
+	}
+
+	return &Schedule{
+		minutes:                values[0],
+		hours:                  values[1],
+		daysOfMonth:            values[2],
+		months:                 values[3],
+		daysOfWeek:             daysOfWeek,
+		isDayOfMonthRestricted: expressionFields[2] != "*",
+		isDayOfWeekRestricted:  expressionFields[4] != "*",
+	}, nil
+}
+
+func parseField(expressionField string, fieldData field) (uint64, error) {
+	var value uint64
+	for _, part := range strings.Split(expressionField, ",") {
+		rangeExpression, stepExpression, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepExpression)
+			if err != nil || step <= 0 {
+				return 0, errors.New(fmt.Sprintf("the step %q of the %s field is invalid", stepExpression,
+					fieldData.name))
+			}
+		}
+
+		start, end := fieldData.minimum, fieldData.maximum
+		if rangeExpression != "*" {
+			startExpression, endExpression, isRange := strings.Cut(rangeExpression, "-")
+
+			var err error
+			start, err = parseFieldNumber(startExpression, fieldData)
+			if err != nil {
+				return 0, err
+			}
+
+			if isRange {
+				end, err = parseFieldNumber(endExpression, fieldData)
+				if err != nil {
+					return 0, err
+				}
+			} else if !hasStep {
+				end = start
+			}
+
+			if start > end {
+				return 0, errors.New(fmt.Sprintf("the range %q of the %s field is invalid", rangeExpression,
+					fieldData.name))
+			}
+		}
+
+		for number := start; number <= end; number += step {
+			value |= 1 << number
+		}
+	}
+
+	return value, nil
+}
+
+func parseFieldNumber(numberExpression string, fieldData field) (int, error) {
+	number, err := strconv.Atoi(numberExpression)
+	if err != nil || number < fieldData.minimum || number > fieldData.maximum {
+		return 0, errors.New(fmt.Sprintf("the value %q of the %s field must be between %d and %d",
+			numberExpression, fieldData.name, fieldData.minimum, fieldData.maximum))
+	}
+
+	return number, nil
+}
+
+// Next returns the first time after the informed time that matches the schedule, considering the time zone of the
+// informed time. If there is no such time within the next years, the zero time is returned.
+func (instance Schedule) Next(after time.Time) time.Time {
+	location := after.Location()
+	nextTime := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute()+1, 0, 0, location)
+	searchLimit := nextTime.AddDate(maximumNumberOfYearsSearched, 0, 0)
+
+	for nextTime.Before(searchLimit) {
+		if instance.months&(1<<int(nextTime.Month())) == 0 {
+			nextTime = time.Date(nextTime.Year(), nextTime.Month()+1, 1, 0, 0, 0, 0, location)
+			continue
+		}
+
+		if !instance.matchesDay(nextTime) {
+			nextTime = time.Date(nextTime.Year(), nextTime.Month(), nextTime.Day()+1, 0, 0, 0, 0, location)
+			continue
+		}
+
+		if instance.hours&(1<<nextTime.Hour()) == 0 {
+			nextTime = time.Date(nextTime.Year(), nextTime.Month(), nextTime.Day(), nextTime.Hour()+1, 0, 0, 0,
+				location)
+			continue
+		}
+
+		if instance.minutes&(1<<nextTime.Minute()) == 0 {
+			nextTime = nextTime.Add(time.Minute)
+			continue
+		}
+
+		return nextTime
+	}
+
+	return time.Time{}
+}
+
+// matchesDay follows the cron convention, in which a day matches either field when both the day of month and the
+// day of week are restricted
+func (instance Schedule) matchesDay(date time.Time) bool {
+	matchesDayOfMonth := instance.daysOfMonth&(1<<date.Day()) != 0
+	matchesDayOfWeek := instance.daysOfWeek&(1<<int(date.Weekday())) != 0
+
+	if instance.isDayOfMonthRestricted && instance.isDayOfWeekRestricted {
+		return matchesDayOfMonth || matchesDayOfWeek
+	}
+
+	return matchesDayOfMonth && matchesDayOfWeek
+}
@@ -0,0 +1,121 @@
+package crons
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expression    string
+		expectedError bool
+	}{
+		{name: "accepts every minute", expression: "* * * * *"},
+		{name: "accepts numbers, ranges, lists and steps", expression: "0,30 0-5,18-23 */2 1-12/3 1-5"},
+		{name: "accepts 7 as Sunday", expression: "0 0 * * 7"},
+		{name: "rejects expressions with less than five fields", expression: "0 * * *", expectedError: true},
+		{name: "rejects expressions with more than five fields", expression: "0 0 * * * *", expectedError: true},
+		{name: "rejects values out of the range of the field", expression: "60 * * * *", expectedError: true},
+		{name: "rejects days of month equal to zero", expression: "0 0 0 * *", expectedError: true},
+		{name: "rejects inverted ranges", expression: "0 10-5 * * *", expectedError: true},
+		{name: "rejects invalid steps", expression: "*/0 * * * *", expectedError: true},
+		{name: "rejects values that are not numbers", expression: "0 0 * JAN *", expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := Parse(testCase.expression)
+			if testCase.expectedError && err == nil {
+				t.Errorf("An error was expected for the expression %q", testCase.expression)
+			} else if !testCase.expectedError && err != nil {
+				t.Errorf("Parse(): %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatalf("time.LoadLocation(): %s", err.Error())
+	}
+
+	// 10/03/2025 was a Monday
+	testCases := []struct {
+		name         string
+		expression   string
+		after        time.Time
+		expectedNext time.Time
+	}{
+		{
+			name:         "returns the next minute",
+			expression:   "* * * * *",
+			after:        time.Date(2025, 3, 10, 10, 15, 30, 0, location),
+			expectedNext: time.Date(2025, 3, 10, 10, 16, 0, 0, location),
+		},
+		{
+			name:         "never returns the informed time",
+			expression:   "0 * * * *",
+			after:        time.Date(2025, 3, 10, 10, 0, 0, 0, location),
+			expectedNext: time.Date(2025, 3, 10, 11, 0, 0, 0, location),
+		},
+		{
+			name:         "moves to the next day after the last hour of the range",
+			expression:   "0 0-5,18-23 * * *",
+			after:        time.Date(2025, 3, 10, 23, 0, 0, 0, location),
+			expectedNext: time.Date(2025, 3, 11, 0, 0, 0, 0, location),
+		},
+		{
+			name:         "moves to the first hour of the range",
+			expression:   "0 0-5,18-23 * * *",
+			after:        time.Date(2025, 3, 10, 6, 0, 0, 0, location),
+			expectedNext: time.Date(2025, 3, 10, 18, 0, 0, 0, location),
+		},
+		{
+			name:         "uses the steps of the field",
+			expression:   "*/20 * * * *",
+			after:        time.Date(2025, 3, 10, 10, 41, 0, 0, location),
+			expectedNext: time.Date(2025, 3, 10, 11, 0, 0, 0, location),
+		},
+		{
+			name:         "moves to the next day of week",
+			expression:   "0 7 * * 0",
+			after:        time.Date(2025, 3, 10, 7, 0, 0, 0, location),
+			expectedNext: time.Date(2025, 3, 16, 7, 0, 0, 0, location),
+		},
+		{
+			name:         "matches either the day of month or the day of week when both are restricted",
+			expression:   "0 7 20 * 3",
+			after:        time.Date(2025, 3, 10, 7, 0, 0, 0, location),
+			expectedNext: time.Date(2025, 3, 12, 7, 0, 0, 0, location),
+		},
+		{
+			name:         "moves to the next year",
+			expression:   "0 0 1 1 *",
+			after:        time.Date(2025, 3, 10, 7, 0, 0, 0, location),
+			expectedNext: time.Date(2026, 1, 1, 0, 0, 0, 0, location),
+		},
+		{
+			name:         "returns the zero time for expressions that never match",
+			expression:   "0 0 31 2 *",
+			after:        time.Date(2025, 3, 10, 7, 0, 0, 0, location),
+			expectedNext: time.Time{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			schedule, err := Parse(testCase.expression)
+			if err != nil {
+				t.Fatalf("Parse(): %s", err.Error())
+			}
+
+			next := schedule.Next(testCase.after)
+			if !next.Equal(testCase.expectedNext) {
+				t.Errorf("The next execution of %q after %s was expected to be %s, but it was %s",
+					testCase.expression, testCase.after, testCase.expectedNext, next)
+			}
+		})
+	}
+}
@@ -15,3 +15,18 @@ func GetCurrentDateTimeInBrazil() (*time.Time, error) {
 	currentDateTimeInBrazil := time.Now().In(saoPauloLocation)
 	return &currentDateTimeInBrazil, err
 }
+
+func ParseDateInBrazil(date string) (*time.Time, error) {
+	saoPauloLocation, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		fmt.Println("Error loading Brazil time zone (Based on São Paulo time zone): ", err)
+		return nil, err
+	}
+
+	dateInBrazil, err := time.ParseInLocation("2006-01-02", date, saoPauloLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dateInBrazil, nil
+}
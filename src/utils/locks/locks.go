@@ -0,0 +1,40 @@
+package locks
+
+import "sync"
+
+type keyedMutex struct {
+	mutex           sync.Mutex
+	numberOfHolders int
+}
+
+var (
+	registryMutex sync.Mutex
+	mutexes       = map[string]*keyedMutex{}
+)
+
+// Lock blocks until the lock identified by the key is acquired and returns the function that releases it. Locks of
+// different keys are independent, which allows different items to be processed concurrently while the same item is
+// never processed twice at the same time.
+func Lock(key string) func() {
+	registryMutex.Lock()
+	mutex, exists := mutexes[key]
+	if !exists {
+		mutex = &keyedMutex{}
+		mutexes[key] = mutex
+	}
+	mutex.numberOfHolders++
+	registryMutex.Unlock()
+
+	mutex.mutex.Lock()
+
+	return func() {
+		mutex.mutex.Unlock()
+
+		registryMutex.Lock()
+		defer registryMutex.Unlock()
+		mutex.numberOfHolders--
+		if mutex.numberOfHolders == 0 {
+			delete(mutexes, key)
+		}
+	}
+}
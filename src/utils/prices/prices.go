@@ -0,0 +1,35 @@
+package prices
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// GetModelPrices returns the prices of the model configured in the environment variable, whose value must follow the
+// format model=price[:price],model=price[:price] (e.g. gpt-4o=2.5:10,gpt-4o-mini=0.15:0.6). If the model is not
+// configured, no prices are returned.
+func GetModelPrices(environmentVariable, model string) ([]float64, error) {
+	for _, modelPrices := range strings.Split(os.Getenv(environmentVariable), ",") {
+		modelName, pricesAsString, found := strings.Cut(strings.TrimSpace(modelPrices), "=")
+		if !found || modelName != model {
+			continue
+		}
+
+		var prices []float64
+		for _, priceAsString := range strings.Split(pricesAsString, ":") {
+			price, err := strconv.ParseFloat(strings.TrimSpace(priceAsString), 64)
+			if err != nil {
+				return nil, errors.New(fmt.Sprintf("Error converting the price of model %s in environment "+
+					"variable %s to number: %s", model, environmentVariable, err.Error()))
+			}
+			prices = append(prices, price)
+		}
+
+		return prices, nil
+	}
+
+	return nil, nil
+}
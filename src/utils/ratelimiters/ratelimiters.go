@@ -0,0 +1,140 @@
+package ratelimiters
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+	"vnc-summarizer/utils/contexts"
+)
+
+var limiters = map[string]*Limiter{}
+var limitersMutex sync.Mutex
+
+// Limiter is a token bucket limiter that controls both the number of requests and the number of tokens sent per
+// minute. A limit equal to zero disables the control of the respective bucket.
+type Limiter struct {
+	mutex        sync.Mutex
+	requests     bucket
+	tokens       bucket
+	blockedUntil time.Time
+}
+
+type bucket struct {
+	capacity  float64
+	available float64
+	updatedAt time.Time
+}
+
+// Get returns the limiter identified by the key, creating it on the first call, so that every client of the same
+// quota (e.g. the same model) shares the limiter.
+func Get(key string, requestsPerMinute, tokensPerMinute int) *Limiter {
+	limitersMutex.Lock()
+	defer limitersMutex.Unlock()
+
+	limiter, exists := limiters[key]
+	if !exists {
+		currentTime := time.Now()
+		limiter = &Limiter{
+			requests: bucket{capacity: float64(requestsPerMinute), available: float64(requestsPerMinute),
+				updatedAt: currentTime},
+			tokens: bucket{capacity: float64(tokensPerMinute), available: float64(tokensPerMinute),
+				updatedAt: currentTime},
+		}
+		limiters[key] = limiter
+	}
+
+	return limiter
+}
+
+// Wait blocks until a request with the informed number of tokens can be sent and then consumes the quota. If the
+// context is canceled first, the quota is not consumed and the error of the context is returned.
+func (instance *Limiter) Wait(ctx context.Context, tokens int) error {
+	for {
+		waitingTime := instance.reserve(float64(tokens))
+		if waitingTime <= 0 {
+			return nil
+		}
+
+		err := contexts.Sleep(ctx, waitingTime)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Update adjusts the available quota to the values informed by the server. Negative values are ignored.
+func (instance *Limiter) Update(remainingRequests, remainingTokens int) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	currentTime := time.Now()
+	instance.requests.refill(currentTime)
+	instance.tokens.refill(currentTime)
+	if remainingRequests >= 0 {
+		instance.requests.available = math.Min(instance.requests.available, float64(remainingRequests))
+	}
+	if remainingTokens >= 0 {
+		instance.tokens.available = math.Min(instance.tokens.available, float64(remainingTokens))
+	}
+}
+
+// BlockFor prevents new requests from being sent during the informed duration
+func (instance *Limiter) BlockFor(duration time.Duration) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	blockedUntil := time.Now().Add(duration)
+	if blockedUntil.After(instance.blockedUntil) {
+		instance.blockedUntil = blockedUntil
+	}
+}
+
+func (instance *Limiter) reserve(tokens float64) time.Duration {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	currentTime := time.Now()
+	if currentTime.Before(instance.blockedUntil) {
+		return instance.blockedUntil.Sub(currentTime)
+	}
+
+	instance.requests.refill(currentTime)
+	instance.tokens.refill(currentTime)
+
+	// Requests larger than the bucket capacity would wait forever, so they only wait for the bucket to be full
+	tokens = math.Min(tokens, instance.tokens.capacity)
+	waitingTime := max(instance.requests.getWaitingTime(1), instance.tokens.getWaitingTime(tokens))
+	if waitingTime > 0 {
+		return waitingTime
+	}
+
+	instance.requests.consume(1)
+	instance.tokens.consume(tokens)
+	return 0
+}
+
+func (instance *bucket) refill(currentTime time.Time) {
+	if instance.capacity <= 0 {
+		return
+	}
+
+	elapsedMinutes := currentTime.Sub(instance.updatedAt).Minutes()
+	instance.available = math.Min(instance.capacity, instance.available+elapsedMinutes*instance.capacity)
+	instance.updatedAt = currentTime
+}
+
+func (instance *bucket) getWaitingTime(amount float64) time.Duration {
+	if instance.capacity <= 0 || instance.available >= amount {
+		return 0
+	}
+
+	missingMinutes := (amount - instance.available) / instance.capacity
+	return time.Duration(missingMinutes * float64(time.Minute))
+}
+
+func (instance *bucket) consume(amount float64) {
+	if instance.capacity > 0 {
+		instance.available -= amount
+	}
+}
@@ -1,54 +1,176 @@
 package requesters
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/labstack/gommon/log"
-	"vnc-summarizer/utils/converters"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+	"vnc-summarizer/utils/contexts"
+	"vnc-summarizer/utils/ratelimiters"
 )
 
-func getResponseFromUrl(url string) (map[string]interface{}, error) {
-	response, err := GetRequest(url)
+const (
+	defaultMaximumNumberOfAttemptsPerChamberRequest = 5
+	maximumChamberBackoffTime                       = time.Minute
+)
+
+// The data requests are sent to the Chamber of Deputies API, so every worker shares the same limiter to keep the
+// number of requests within the configured politeness limit
+func waitForChamberApiQuota(ctx context.Context) error {
+	requestsPerMinute, err := strconv.Atoi(os.Getenv("CHAMBER_API_REQUESTS_PER_MINUTE"))
 	if err != nil {
-		log.Error("GetRequest(): ", err.Error())
-		return nil, err
+		requestsPerMinute = 0
 	}
 
-	responseBody, err := DecodeResponseBody(response)
-	if err != nil {
-		log.Error("DecodeResponseBody(): ", err.Error())
-		return nil, err
+	return ratelimiters.Get("chamber_api", requestsPerMinute, 0).Wait(ctx, 0)
+}
+
+// SendChamberRequest sends a GET request to the Chamber of Deputies API within the politeness limit configured in
+// CHAMBER_API_REQUESTS_PER_MINUTE. Requests that fail due to network errors, rate limits (429) or server errors (5xx)
+// are sent again after the time indicated by the API or an exponential backoff with jitter, up to the number of
+// attempts configured in CHAMBER_API_MAXIMUM_ATTEMPTS. The body of the response is returned already read, and the
+// responses of conditional requests whose content was not modified (304) are not considered failures.
+func SendChamberRequest(ctx context.Context, url string, headers map[string]string) (*http.Response, []byte,
+	error) {
+	maximumNumberOfAttempts := getMaximumNumberOfAttemptsPerChamberRequest()
+
+	var lastError error
+	for attempt := 1; attempt <= maximumNumberOfAttempts; attempt++ {
+		err := waitForChamberApiQuota(ctx)
+		if err != nil {
+			log.Error("waitForChamberApiQuota(): ", err.Error())
+			return nil, nil, err
+		}
+
+		response, err := getRequestWithHeaders(ctx, url, headers)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Errorf("The request to %s was canceled: %s", url, ctx.Err().Error())
+				return nil, nil, ctx.Err()
+			}
+
+			lastError = err
+			err = waitBeforeRetryingChamberRequest(ctx, url, attempt, maximumNumberOfAttempts,
+				GetBackoffTime(attempt, maximumChamberBackoffTime), err.Error())
+			if err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		responseBody, err := io.ReadAll(response.Body)
+		CloseResponseBody(response.Request, response)
+		if err != nil {
+			log.Errorf("Error reading the response to request %s: %s", url, err.Error())
+			return nil, nil, err
+		}
+
+		if response.StatusCode == http.StatusOK || response.StatusCode == http.StatusNotModified {
+			return response, responseBody, nil
+		}
+
+		lastError = errors.New(fmt.Sprintf("Error making request to %s: [Status: %s; Body: %s]", url,
+			response.Status, string(responseBody)))
+		if response.StatusCode != http.StatusTooManyRequests && response.StatusCode < http.StatusInternalServerError {
+			log.Error(lastError.Error())
+			return nil, nil, lastError
+		}
+
+		waitingTime, informedByTheApi := GetRetryAfterTime(response.Header)
+		if !informedByTheApi {
+			waitingTime = GetBackoffTime(attempt, maximumChamberBackoffTime)
+		}
+		err = waitBeforeRetryingChamberRequest(ctx, url, attempt, maximumNumberOfAttempts, waitingTime,
+			fmt.Sprint("Status ", response.Status))
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
-	return responseBody, nil
+	log.Errorf("It was not possible to make the request to %s after %d attempts: %s", url, maximumNumberOfAttempts,
+		lastError.Error())
+	return nil, nil, lastError
 }
 
-func GetDataObjectFromUrl(url string) (map[string]interface{}, error) {
-	responseBody, err := getResponseFromUrl(url)
+func waitBeforeRetryingChamberRequest(ctx context.Context, url string, attempt, maximumNumberOfAttempts int,
+	waitingTime time.Duration, reason string) error {
+	if attempt == maximumNumberOfAttempts {
+		return nil
+	}
+
+	log.Warnf("The request to %s failed on the %dth attempt, trying again in %s: %s", url, attempt, waitingTime,
+		reason)
+	err := contexts.Sleep(ctx, waitingTime)
 	if err != nil {
-		log.Error("getResponseFromUrl(): ", err.Error())
-		return nil, err
+		log.Errorf("The request to %s was canceled: %s", url, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func getMaximumNumberOfAttemptsPerChamberRequest() int {
+	maximumNumberOfAttempts, err := strconv.Atoi(os.Getenv("CHAMBER_API_MAXIMUM_ATTEMPTS"))
+	if err != nil || maximumNumberOfAttempts < 1 {
+		return defaultMaximumNumberOfAttemptsPerChamberRequest
 	}
 
-	resultMap, err := converters.ToMap(responseBody["dados"])
+	return maximumNumberOfAttempts
+}
+
+// GetDataFromUrl searches the URL in the Chamber of Deputies API and decodes the content of the "dados" field of the
+// response into the informed data, which must be a pointer
+func GetDataFromUrl(ctx context.Context, url string, data interface{}) error {
+	_, responseBody, err := SendChamberRequest(ctx, url, nil)
 	if err != nil {
-		log.Error("converters.ToMap(): ", err.Error())
-		return nil, err
+		log.Error("SendChamberRequest(): ", err.Error())
+		return err
 	}
 
-	return resultMap, nil
+	return DecodeData(url, responseBody, data)
 }
 
-func GetDataSliceFromUrl(url string) ([]map[string]interface{}, error) {
-	responseBody, err := getResponseFromUrl(url)
+// DecodeData decodes the content of the "dados" field of a response of the Chamber of Deputies API into the informed
+// data, which must be a pointer. The response is rejected when the field is missing or when any of its fields has a
+// type different from the expected, and the error names the field.
+func DecodeData(url string, responseBody []byte, data interface{}) error {
+	var content struct {
+		Data json.RawMessage `json:"dados"`
+	}
+	err := json.Unmarshal(responseBody, &content)
 	if err != nil {
-		log.Error("getResponseFromUrl(): ", err.Error())
-		return nil, err
+		log.Errorf("Error decoding the response to request %s: %s", url, err.Error())
+		return err
+	} else if len(content.Data) == 0 || string(content.Data) == "null" {
+		errorMessage := fmt.Sprintf("The field dados is missing in the response to request %s", url)
+		log.Error(errorMessage)
+		return errors.New(errorMessage)
 	}
 
-	resultMapSlice, err := converters.ToMapSlice(responseBody["dados"])
+	err = json.Unmarshal(content.Data, data)
 	if err != nil {
-		log.Error("converters.ToMapSlice(): ", err.Error())
-		return nil, err
+		var typeError *json.UnmarshalTypeError
+		if errors.As(err, &typeError) {
+			err = errors.New(fmt.Sprintf("The field %s has the type %s instead of %s in the response to request %s",
+				getFieldPath(typeError.Field), typeError.Value, typeError.Type, url))
+		}
+		log.Errorf("Error decoding the data of the response to request %s: %s", url, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func getFieldPath(field string) string {
+	if field == "" {
+		return "dados"
 	}
 
-	return resultMapSlice, nil
+	return fmt.Sprint("dados.", field)
 }
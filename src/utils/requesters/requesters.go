@@ -1,13 +1,58 @@
 package requesters
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/labstack/gommon/log"
+	"math"
+	"math/rand/v2"
 	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+	"vnc-summarizer/utils/contexts"
 )
 
-func GetRequest(url string) (*http.Response, error) {
-	response, err := http.Get(url)
+const defaultUserAgent = "vnc-summarizer (+https://github.com/devlucassantos/vnc-summarizer)"
+
+var sharedClient *http.Client
+var sharedClientOnce sync.Once
+
+// getClient returns the client shared by all the GET requests, so the connections to the same host are reused. The
+// client is created on the first request, after the environment variables are loaded.
+func getClient() *http.Client {
+	sharedClientOnce.Do(func() {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = 10
+		sharedClient = &http.Client{
+			Timeout:   contexts.GetTimeout("HTTP_REQUEST_TIMEOUT", time.Minute),
+			Transport: transport,
+		}
+	})
+
+	return sharedClient
+}
+
+// GetRequest sends a GET request that is canceled along with the context or when the timeout configured in
+// HTTP_REQUEST_TIMEOUT expires
+func GetRequest(ctx context.Context, url string) (*http.Response, error) {
+	return getRequestWithHeaders(ctx, url, nil)
+}
+
+func getRequestWithHeaders(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		log.Errorf("Error building the request to %s: %s", url, err.Error())
+		return nil, err
+	}
+
+	request.Header.Set("User-Agent", getUserAgent())
+	for header, value := range headers {
+		request.Header.Set(header, value)
+	}
+
+	response, err := getClient().Do(request)
 	if err != nil {
 		log.Errorf("Error making request to %s: %s", url, err.Error())
 		return nil, err
@@ -16,6 +61,15 @@ func GetRequest(url string) (*http.Response, error) {
 	return response, err
 }
 
+func getUserAgent() string {
+	userAgent := os.Getenv("HTTP_USER_AGENT")
+	if userAgent == "" {
+		return defaultUserAgent
+	}
+
+	return userAgent
+}
+
 func DecodeResponseBody(response *http.Response) (map[string]interface{}, error) {
 	content := make(map[string]interface{})
 	err := json.NewDecoder(response.Body).Decode(&content)
@@ -33,3 +87,28 @@ func CloseResponseBody(request *http.Request, response *http.Response) {
 		log.Warn("Error closing the request response body: ", request)
 	}
 }
+
+// GetRetryAfterTime returns the time to wait before sending the request again informed by the server in the
+// Retry-After header, in seconds or as a date, or in the retry-after-ms header used by some LLM providers
+func GetRetryAfterTime(header http.Header) (time.Duration, bool) {
+	if milliseconds, err := strconv.Atoi(header.Get("retry-after-ms")); err == nil {
+		return time.Duration(milliseconds) * time.Millisecond, true
+	}
+
+	retryAfter := header.Get("retry-after")
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if retryDate, err := http.ParseTime(retryAfter); err == nil {
+		return time.Until(retryDate), true
+	}
+
+	return 0, false
+}
+
+// GetBackoffTime returns an exponential backoff with jitter for the attempt, limited to the maximum informed
+func GetBackoffTime(attempt int, maximumBackoffTime time.Duration) time.Duration {
+	backoffTime := time.Duration(math.Pow(2, float64(attempt))) * time.Second
+	jitter := time.Duration(rand.Int64N(int64(backoffTime)))
+	return min(backoffTime+jitter, maximumBackoffTime)
+}
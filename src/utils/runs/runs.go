@@ -0,0 +1,23 @@
+package runs
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+type runIdKey struct{}
+
+// WithRunId returns a copy of the context that links the records created with it to the processing run
+func WithRunId(ctx context.Context, runId uuid.UUID) context.Context {
+	return context.WithValue(ctx, runIdKey{}, runId)
+}
+
+// GetRunId returns the identifier of the processing run of the context or uuid.Nil if no run has been started
+func GetRunId(ctx context.Context) uuid.UUID {
+	runId, ok := ctx.Value(runIdKey{}).(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return runId
+}
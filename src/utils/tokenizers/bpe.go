@@ -0,0 +1,169 @@
+package tokenizers
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"github.com/pkoukk/tiktoken-go"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The OpenAI models released after the encodings known by the tokenizer library use the encoding of GPT-4o
+var recentOpenAiModelRegex = regexp.MustCompile(`^(gpt-[4-9]|o[1-9])`)
+
+const bpeFileDownloadTimeout = 30 * time.Second
+
+var (
+	bpeLoaderOnce sync.Once
+	encodings     sync.Map
+)
+
+// bpeEncoding is the encoding of a model, which is loaded only once even when it cannot be loaded
+type bpeEncoding struct {
+	once     sync.Once
+	tiktoken *tiktoken.Tiktoken
+}
+
+// GetTokenCounter returns the function that counts the tokens of the texts sent to the model. The OpenAI models are
+// counted by their BPE encoding, whose file is downloaded on first use and saved in TIKTOKEN_CACHE_DIR, while the other
+// models and the OpenAI models whose encoding cannot be loaded are counted by the estimate of CountTokens.
+func GetTokenCounter(model string) func(text string) int {
+	encodingName := getEncodingName(model)
+	if encodingName == "" {
+		return CountTokens
+	}
+
+	return func(text string) int {
+		encoding := getEncoding(encodingName)
+		if encoding == nil {
+			return CountTokens(text)
+		}
+
+		return len(encoding.EncodeOrdinary(text))
+	}
+}
+
+func getEncodingName(model string) string {
+	encodingName, found := tiktoken.MODEL_TO_ENCODING[model]
+	if found {
+		return encodingName
+	}
+
+	for prefix, encodingName := range tiktoken.MODEL_PREFIX_TO_ENCODING {
+		if strings.HasPrefix(model, prefix) {
+			return encodingName
+		}
+	}
+
+	if recentOpenAiModelRegex.MatchString(model) {
+		return tiktoken.MODEL_O200K_BASE
+	}
+
+	return ""
+}
+
+func getEncoding(encodingName string) *tiktoken.Tiktoken {
+	bpeLoaderOnce.Do(func() {
+		tiktoken.SetBpeLoader(bpeLoader{client: &http.Client{Timeout: bpeFileDownloadTimeout}})
+	})
+
+	value, _ := encodings.LoadOrStore(encodingName, &bpeEncoding{})
+	encoding := value.(*bpeEncoding)
+	encoding.once.Do(func() {
+		var err error
+		encoding.tiktoken, err = tiktoken.GetEncoding(encodingName)
+		if err != nil {
+			log.Warnf("The BPE encoding %s could not be loaded, the tokens will be estimated: %s", encodingName,
+				err.Error())
+		}
+	})
+
+	return encoding.tiktoken
+}
+
+// bpeLoader loads the files of the BPE encodings from the cache directory, downloading them when they are not cached
+// with a timeout, since the library downloads them without one
+type bpeLoader struct {
+	client *http.Client
+}
+
+func (instance bpeLoader) LoadTiktokenBpe(bpeFileUrl string) (map[string]int, error) {
+	cacheDirectory := os.Getenv("TIKTOKEN_CACHE_DIR")
+	if cacheDirectory == "" {
+		cacheDirectory = filepath.Join(os.TempDir(), "tiktoken")
+	}
+	fileName := sha256.Sum256([]byte(bpeFileUrl))
+	filePath := filepath.Join(cacheDirectory, hex.EncodeToString(fileName[:]))
+
+	fileContent, err := os.ReadFile(filePath)
+	if err != nil {
+		fileContent, err = instance.downloadBpeFile(bpeFileUrl, filePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bpeRanks := map[string]int{}
+	for _, line := range strings.Split(string(fileContent), "\n") {
+		if line == "" {
+			continue
+		}
+
+		encodedToken, rankAsString, _ := strings.Cut(line, " ")
+		token, err := base64.StdEncoding.DecodeString(encodedToken)
+		if err != nil {
+			return nil, err
+		}
+		rank, err := strconv.Atoi(rankAsString)
+		if err != nil {
+			return nil, err
+		}
+		bpeRanks[string(token)] = rank
+	}
+
+	return bpeRanks, nil
+}
+
+func (instance bpeLoader) downloadBpeFile(bpeFileUrl, filePath string) ([]byte, error) {
+	response, err := instance.client.Get(bpeFileUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New(fmt.Sprintf("Error downloading the BPE file %s: [Status: %s]", bpeFileUrl,
+			response.Status))
+	}
+
+	fileContent, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// The file is only cached to avoid downloading it again, so the errors of the cache are not returned
+	err = os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err == nil {
+		temporaryFilePath := filePath + ".tmp"
+		err = os.WriteFile(temporaryFilePath, fileContent, 0644)
+		if err == nil {
+			err = os.Rename(temporaryFilePath, filePath)
+		}
+	}
+	if err != nil {
+		log.Warnf("The BPE file %s could not be cached: %s", bpeFileUrl, err.Error())
+	}
+
+	return fileContent, nil
+}
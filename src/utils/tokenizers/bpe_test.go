@@ -0,0 +1,37 @@
+package tokenizers
+
+import (
+	"github.com/pkoukk/tiktoken-go"
+	"testing"
+)
+
+func TestGetEncodingName(t *testing.T) {
+	testCases := []struct {
+		model                string
+		expectedEncodingName string
+	}{
+		{model: "gpt-4o-mini", expectedEncodingName: tiktoken.MODEL_O200K_BASE},
+		{model: "gpt-4", expectedEncodingName: tiktoken.MODEL_CL100K_BASE},
+		{model: "text-embedding-3-small", expectedEncodingName: tiktoken.MODEL_CL100K_BASE},
+		{model: "gpt-4.1-nano", expectedEncodingName: tiktoken.MODEL_O200K_BASE},
+		{model: "o3-mini", expectedEncodingName: tiktoken.MODEL_O200K_BASE},
+		{model: "claude-3-5-haiku-latest", expectedEncodingName: ""},
+		{model: "llama3.1:8b", expectedEncodingName: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.model, func(t *testing.T) {
+			encodingName := getEncodingName(testCase.model)
+			if encodingName != testCase.expectedEncodingName {
+				t.Fatalf("getEncodingName() returned %q instead of %q", encodingName, testCase.expectedEncodingName)
+			}
+		})
+	}
+}
+
+func TestGetTokenCounterWithoutEncoding(t *testing.T) {
+	countTokens := GetTokenCounter("llama3.1:8b")
+	if countTokens("Projeto de lei.") != CountTokens("Projeto de lei.") {
+		t.Fatal("The tokens of the models without a known encoding were not estimated")
+	}
+}
@@ -0,0 +1,31 @@
+package tokenizers
+
+import "unicode"
+
+// Estimated number of characters of a word represented by a single token. The value is lower than the usual four
+// characters per token of English texts because Portuguese words with accents are split into more tokens.
+const charactersPerToken = 3
+
+// CountTokens estimates the number of tokens of the text, which is used for the models whose tokenizer is not known
+func CountTokens(text string) int {
+	var numberOfTokens, wordLength int
+	for _, character := range text {
+		if unicode.IsLetter(character) || unicode.IsDigit(character) {
+			wordLength++
+			continue
+		}
+
+		numberOfTokens += countWordTokens(wordLength)
+		wordLength = 0
+		if !unicode.IsSpace(character) {
+			numberOfTokens++
+		}
+	}
+	numberOfTokens += countWordTokens(wordLength)
+
+	return numberOfTokens
+}
+
+func countWordTokens(wordLength int) int {
+	return (wordLength + charactersPerToken - 1) / charactersPerToken
+}
@@ -0,0 +1,145 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateJsonSchema checks the decoded JSON data against the subset of the JSON Schema specification used in the
+// structured requests to the LLMs: type, properties, required, additionalProperties, items, enum, minItems, maxItems,
+// minLength and maxLength.
+func ValidateJsonSchema(data interface{}, schema map[string]interface{}) error {
+	var problems []string
+	validateJsonSchemaNode(data, schema, "$", &problems)
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func validateJsonSchemaNode(data interface{}, schema map[string]interface{}, path string, problems *[]string) {
+	if schemaType, exists := schema["type"]; exists && !hasJsonSchemaType(data, fmt.Sprint(schemaType)) {
+		*problems = append(*problems, fmt.Sprintf("%s must be of type %s", path, schemaType))
+		return
+	}
+
+	if allowedValues, ok := getJsonSchemaList(schema, "enum"); ok {
+		var isAllowed bool
+		for _, allowedValue := range allowedValues {
+			if allowedValue == fmt.Sprint(data) {
+				isAllowed = true
+				break
+			}
+		}
+		if !isAllowed {
+			*problems = append(*problems, fmt.Sprintf("%s must be one of %v", path, allowedValues))
+		}
+	}
+
+	switch value := data.(type) {
+	case map[string]interface{}:
+		validateJsonSchemaObject(value, schema, path, problems)
+	case []interface{}:
+		if minItems, ok := getJsonSchemaLimit(schema, "minItems"); ok && len(value) < minItems {
+			*problems = append(*problems, fmt.Sprintf("%s must have at least %d items", path, minItems))
+		}
+		if maxItems, ok := getJsonSchemaLimit(schema, "maxItems"); ok && len(value) > maxItems {
+			*problems = append(*problems, fmt.Sprintf("%s must have at most %d items", path, maxItems))
+		}
+		if itemSchema, ok := schema["items"].(map[string]interface{}); ok {
+			for index, item := range value {
+				validateJsonSchemaNode(item, itemSchema, fmt.Sprintf("%s[%d]", path, index), problems)
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(value)
+		if minLength, ok := getJsonSchemaLimit(schema, "minLength"); ok && length < minLength {
+			*problems = append(*problems, fmt.Sprintf("%s must have at least %d characters", path, minLength))
+		}
+		if maxLength, ok := getJsonSchemaLimit(schema, "maxLength"); ok && length > maxLength {
+			*problems = append(*problems, fmt.Sprintf("%s must have at most %d characters", path, maxLength))
+		}
+	}
+}
+
+func validateJsonSchemaObject(data map[string]interface{}, schema map[string]interface{}, path string,
+	problems *[]string) {
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	if requiredProperties, ok := getJsonSchemaList(schema, "required"); ok {
+		for _, property := range requiredProperties {
+			if _, exists := data[property]; !exists {
+				*problems = append(*problems, fmt.Sprintf("%s.%s is required", path, property))
+			}
+		}
+	}
+
+	for property, value := range data {
+		propertySchema, exists := properties[property].(map[string]interface{})
+		if !exists {
+			if additionalProperties, ok := schema["additionalProperties"].(bool); ok && !additionalProperties {
+				*problems = append(*problems, fmt.Sprintf("%s.%s is not allowed", path, property))
+			}
+			continue
+		}
+		validateJsonSchemaNode(value, propertySchema, fmt.Sprint(path, ".", property), problems)
+	}
+}
+
+func hasJsonSchemaType(data interface{}, schemaType string) bool {
+	switch schemaType {
+	case "object":
+		_, ok := data.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := data.([]interface{})
+		return ok
+	case "string":
+		_, ok := data.(string)
+		return ok
+	case "number":
+		_, ok := data.(float64)
+		return ok
+	case "integer":
+		number, ok := data.(float64)
+		return ok && number == math.Trunc(number)
+	case "boolean":
+		_, ok := data.(bool)
+		return ok
+	case "null":
+		return data == nil
+	default:
+		return true
+	}
+}
+
+// The schemas can be declared in the code, using Go types, or decoded from JSON
+func getJsonSchemaLimit(schema map[string]interface{}, keyword string) (int, bool) {
+	switch limit := schema[keyword].(type) {
+	case int:
+		return limit, true
+	case float64:
+		return int(limit), true
+	default:
+		return 0, false
+	}
+}
+
+func getJsonSchemaList(schema map[string]interface{}, keyword string) ([]string, bool) {
+	switch list := schema[keyword].(type) {
+	case []string:
+		return list, true
+	case []interface{}:
+		var values []string
+		for _, value := range list {
+			values = append(values, fmt.Sprint(value))
+		}
+		return values, true
+	default:
+		return nil, false
+	}
+}
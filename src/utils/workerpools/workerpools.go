@@ -0,0 +1,70 @@
+package workerpools
+
+import (
+	"context"
+	"github.com/labstack/gommon/log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+	"vnc-summarizer/utils/contexts"
+)
+
+const defaultShutdownGracePeriod = 25 * time.Second
+
+// Process executes the function for each item using at most the informed number of workers and waits until all items
+// are processed. With a single worker, the items are processed sequentially in the order informed. When the context is
+// canceled, the remaining items are no longer processed and the items in progress have the grace period configured in
+// SHUTDOWN_GRACE_PERIOD to finish before their context is also canceled.
+func Process[T any](ctx context.Context, items []T, numberOfWorkers int, process func(ctx context.Context, item T)) {
+	if numberOfWorkers < 1 {
+		numberOfWorkers = 1
+	}
+
+	queue := make(chan T)
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < min(numberOfWorkers, len(items)); worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for item := range queue {
+				itemCtx, cancel := contexts.WithGracePeriod(ctx, contexts.GetTimeout("SHUTDOWN_GRACE_PERIOD",
+					defaultShutdownGracePeriod))
+				process(itemCtx, item)
+				cancel()
+			}
+		}()
+	}
+
+	for index, item := range items {
+		// The context is also watched while all the workers are busy, so no item is sent after it is canceled
+		if ctx.Err() == nil {
+			select {
+			case queue <- item:
+				continue
+			case <-ctx.Done():
+			}
+		}
+
+		log.Warnf("The processing was interrupted and %d of %d items were not processed", len(items)-index,
+			len(items))
+		break
+	}
+	close(queue)
+	waitGroup.Wait()
+}
+
+// GetNumberOfWorkers returns the number of workers configured in the environment variable, which is 1 when the
+// variable is empty or invalid
+func GetNumberOfWorkers(environmentVariable string) int {
+	numberOfWorkers, err := strconv.Atoi(os.Getenv(environmentVariable))
+	if err != nil || numberOfWorkers < 1 {
+		if os.Getenv(environmentVariable) != "" {
+			log.Warnf("The value of environment variable %s must be a positive integer, using a single worker",
+				environmentVariable)
+		}
+		return 1
+	}
+
+	return numberOfWorkers
+}
@@ -0,0 +1,78 @@
+package workerpools
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProcess(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		numberOfItems          int
+		numberOfWorkers        int
+		cancelAfterItem        int
+		expectedProcessedItems int
+		expectsSequentialOrder bool
+	}{
+		{
+			name:                   "processes all the items sequentially with a single worker",
+			numberOfItems:          5,
+			numberOfWorkers:        1,
+			cancelAfterItem:        -1,
+			expectedProcessedItems: 5,
+			expectsSequentialOrder: true,
+		},
+		{
+			name:                   "processes all the items with more workers than items",
+			numberOfItems:          3,
+			numberOfWorkers:        10,
+			cancelAfterItem:        -1,
+			expectedProcessedItems: 3,
+		},
+		{
+			name:                   "does not send items after the context is canceled while the workers are busy",
+			numberOfItems:          5,
+			numberOfWorkers:        1,
+			cancelAfterItem:        0,
+			expectedProcessedItems: 1,
+			expectsSequentialOrder: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			items := make([]int, testCase.numberOfItems)
+			for index := range items {
+				items[index] = index
+			}
+
+			var mutex sync.Mutex
+			var processedItems []int
+			Process(ctx, items, testCase.numberOfWorkers, func(_ context.Context, item int) {
+				mutex.Lock()
+				processedItems = append(processedItems, item)
+				mutex.Unlock()
+
+				if item == testCase.cancelAfterItem {
+					// Waits for the next item to be waiting for a free worker
+					time.Sleep(10 * time.Millisecond)
+					cancel()
+				}
+			})
+
+			if len(processedItems) != testCase.expectedProcessedItems {
+				t.Fatalf("Expected %d processed items, got %v", testCase.expectedProcessedItems, processedItems)
+			}
+			for index, item := range processedItems {
+				if testCase.expectsSequentialOrder && item != index {
+					t.Fatalf("The items were not processed in order: %v", processedItems)
+				}
+			}
+		})
+	}
+}